@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/service"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+func main() {
+	configFile := flag.String("config", "config.yaml", "配置文件路径")
+	// 始终以utf8mb4连接，GBK版本的数据由MySQL转码；驱动不做转码，以gbk连接会把GBK字节原样写入新站
+	source := config.DatabaseConfig{Type: "mysql", Charset: "utf8mb4"}
+	flag.StringVar(&source.Host, "host", "127.0.0.1", "DedeCMS数据库主机")
+	flag.IntVar(&source.Port, "port", 3306, "DedeCMS数据库端口")
+	flag.StringVar(&source.Username, "user", "root", "DedeCMS数据库用户名")
+	flag.StringVar(&source.Password, "password", "", "DedeCMS数据库密码")
+	flag.StringVar(&source.Database, "database", "", "DedeCMS数据库名")
+	flag.StringVar(&source.Prefix, "prefix", "dede_", "DedeCMS数据表前缀")
+	cmsPath := flag.String("cmspath", "", "DedeCMS安装目录，安装在根目录时为空")
+	siteURL := flag.String("site-url", "", "旧站点地址，如 http://www.example.com")
+	mapFile := flag.String("map", "", "旧地址映射文件路径，默认写入 "+service.DefaultImportDir)
+	templates := flag.String("templates", "", "DedeCMS模板目录，如 templets/default")
+	templateOut := flag.String("template-out", "templets/dede", "转换后的模板目录")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "用法: dedeimport [选项] -database DedeCMS数据库名\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if source.Database == "" && *templates == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := service.DedeImportOptions{
+		CMSPath: *cmsPath,
+		SiteURL: *siteURL,
+	}
+	if err := run(*configFile, source, opts, *mapFile, *templates, *templateOut); err != nil {
+		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run 执行DedeCMS导入，source为空时只转换模板
+func run(configFile string, source config.DatabaseConfig, opts service.DedeImportOptions, mapFile, templates, templateOut string) error {
+	if templates != "" {
+		count, err := service.ConvertDedeTemplates(templates, templateOut)
+		if err != nil {
+			return fmt.Errorf("转换模板失败: %v", err)
+		}
+		fmt.Printf("转换模板 %d 个，已写入 %s\n", count, templateOut)
+	}
+	if source.Database == "" {
+		return nil
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %v", err)
+	}
+
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Path); err != nil {
+		return fmt.Errorf("初始化日志失败: %v", err)
+	}
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("数据库连接失败: %v", err)
+	}
+	defer db.Close()
+
+	src, err := database.NewConnection(source)
+	if err != nil {
+		return fmt.Errorf("DedeCMS数据库连接失败: %v", err)
+	}
+	defer src.Close()
+
+	// 使用与服务端相同的缓存，导入后清除已失效的缓存；内存缓存需要重启服务端
+	var cacheProvider cache.Cache
+	switch cfg.Cache.Type {
+	case "memory":
+		cacheProvider = cache.NewMemoryCache()
+	case "redis":
+		cacheProvider, err = cache.NewRedisCache(cfg.Cache)
+		if err != nil {
+			return fmt.Errorf("Redis缓存初始化失败: %v", err)
+		}
+	default:
+		cacheProvider = cache.NewFileCache(cfg.Cache.Path)
+	}
+
+	importService := service.NewImportService(db, cacheProvider, cfg)
+	result, err := importService.ImportDede(src, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("新建栏目 %d 个，导入文档 %d 篇、会员 %d 个、评论 %d 条、友情链接 %d 个，跳过 %d 条\n",
+		result.Categories, result.Articles, result.Members, result.Comments, result.Links, result.Skipped)
+	for _, e := range result.Errors {
+		fmt.Printf("失败: %s\n", e)
+	}
+
+	if mapFile == "" {
+		mapFile = filepath.Join(service.DefaultImportDir, "dede_"+time.Now().Format("20060102150405")+".json")
+	}
+	if err := result.WriteURLMap(mapFile); err != nil {
+		return fmt.Errorf("写入地址映射失败: %v", err)
+	}
+	fmt.Printf("地址映射已写入 %s\n", mapFile)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/service"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+func main() {
+	configFile := flag.String("config", "config.yaml", "配置文件路径")
+	output := flag.String("o", "", "导出包路径，默认写入 "+service.DefaultExportDir)
+	files := flag.Bool("files", true, "将内容中引用的上传文件打包到导出包中")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "用法: export [选项]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*configFile, *output, service.ExportOptions{IncludeFiles: *files}); err != nil {
+		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run 执行导出命令
+func run(configFile, output string, opts service.ExportOptions) error {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %v", err)
+	}
+
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Path); err != nil {
+		return fmt.Errorf("初始化日志失败: %v", err)
+	}
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("数据库连接失败: %v", err)
+	}
+	defer db.Close()
+
+	if output == "" {
+		output = filepath.Join(service.DefaultExportDir, "aq3cms_"+time.Now().Format("20060102150405")+".zip")
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// 导出只读取数据库，不需要与服务端共用缓存
+	exportService := service.NewExportService(db, cache.NewMemoryCache(), cfg)
+	manifest, err := exportService.Export(file, opts)
+	if err != nil {
+		os.Remove(output)
+		return err
+	}
+
+	c := manifest.Counts
+	fmt.Printf("导出栏目 %d 个、文章 %d 篇、标签 %d 个、专题 %d 个、评论 %d 条，引用上传文件 %d 个，打包 %d 个\n",
+		c["categories"], c["articles"], c["tags"], c["specials"], c["comments"], c["uploads"], c["files"])
+	fmt.Printf("导出包已写入 %s\n", output)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/service"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+func main() {
+	configFile := flag.String("config", "config.yaml", "配置文件路径")
+	typeID := flag.Int64("typeid", 0, "没有分类的文章导入到的栏目ID")
+	uploadURL := flag.String("upload-url", service.DefaultWXRUploadURL, "附件的新地址前缀")
+	mapFile := flag.String("map", "", "旧地址映射文件路径，默认写入 "+service.DefaultImportDir)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "用法: import [选项] wxr WordPress导出文件.xml\n")
+		fmt.Fprintf(os.Stderr, "      import [选项] bundle aq3cms导出包.zip\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := service.ImportOptions{
+		DefaultTypeID: *typeID,
+		UploadURL:     *uploadURL,
+	}
+	if err := run(*configFile, flag.Arg(0), flag.Arg(1), *mapFile, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run 执行导入命令
+func run(configFile, command, path, mapFile string, opts service.ImportOptions) error {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %v", err)
+	}
+
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Path); err != nil {
+		return fmt.Errorf("初始化日志失败: %v", err)
+	}
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("数据库连接失败: %v", err)
+	}
+	defer db.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// 使用与服务端相同的缓存，导入后清除已失效的缓存；内存缓存需要重启服务端
+	var cacheProvider cache.Cache
+	switch cfg.Cache.Type {
+	case "memory":
+		cacheProvider = cache.NewMemoryCache()
+	case "redis":
+		cacheProvider, err = cache.NewRedisCache(cfg.Cache)
+		if err != nil {
+			return fmt.Errorf("Redis缓存初始化失败: %v", err)
+		}
+	default:
+		cacheProvider = cache.NewFileCache(cfg.Cache.Path)
+	}
+
+	importService := service.NewImportService(db, cacheProvider, cfg)
+
+	var result *service.ImportResult
+	switch command {
+	case "wxr":
+		result, err = importService.ImportWXR(file, opts)
+	case "bundle":
+		var info os.FileInfo
+		if info, err = file.Stat(); err == nil {
+			result, err = importService.ImportBundle(file, info.Size())
+		}
+	default:
+		flag.Usage()
+		return fmt.Errorf("未知命令: %s", command)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("新建栏目 %d 个，导入文章 %d 篇、专题 %d 个、评论 %d 条，改写附件地址 %d 个，还原上传文件 %d 个，跳过 %d 条\n",
+		result.Categories, result.Articles, result.Specials, result.Comments, result.Attachments, result.Files, result.Skipped)
+	for _, e := range result.Errors {
+		fmt.Printf("失败: %s\n", e)
+	}
+
+	if mapFile == "" {
+		mapFile = filepath.Join(service.DefaultImportDir, command+"_"+time.Now().Format("20060102150405")+".json")
+	}
+	if err := result.WriteURLMap(mapFile); err != nil {
+		return fmt.Errorf("写入地址映射失败: %v", err)
+	}
+	fmt.Printf("地址映射已写入 %s\n", mapFile)
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"aq3cms/config"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/sql/migrations"
+)
+
+func main() {
+	configFile := flag.String("config", "config.yaml", "配置文件路径")
+	steps := flag.Int("steps", 1, "down 命令回滚的版本数")
+	to := flag.Int("to", 0, "up 命令执行到的目标版本，0 表示最新")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "用法: migrate [选项] up|down|status|version\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	command := "up"
+	if flag.NArg() > 0 {
+		command = flag.Arg(0)
+	}
+
+	if err := run(*configFile, command, *steps, *to); err != nil {
+		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run 执行迁移命令
+func run(configFile, command string, steps, to int) error {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %v", err)
+	}
+
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Path); err != nil {
+		return fmt.Errorf("初始化日志失败: %v", err)
+	}
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("数据库连接失败: %v", err)
+	}
+	defer db.Close()
+
+	source, err := migrations.For(db.Dialect.Name())
+	if err != nil {
+		return fmt.Errorf("加载数据库迁移失败: %v", err)
+	}
+	migrator, err := database.NewMigrator(db, source)
+	if err != nil {
+		return fmt.Errorf("加载数据库迁移失败: %v", err)
+	}
+
+	switch command {
+	case "up":
+		count, err := migrator.UpTo(to)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("已执行 %d 个迁移\n", count)
+	case "down":
+		count, err := migrator.Down(steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("已回滚 %d 个迁移\n", count)
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-8s %-30s %-20s\n", "版本", "名称", "执行时间")
+		for _, s := range status {
+			appliedAt := "未执行"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d     %-30s %-20s\n", s.Version, s.Name, appliedAt)
+		}
+	case "version":
+		version, err := migrator.Version()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("当前版本: %d\n", version)
+	default:
+		flag.Usage()
+		return fmt.Errorf("未知命令: %s", command)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,9 +15,11 @@ import (
 	"aq3cms/config"
 	"aq3cms/internal/controller"
 	"aq3cms/internal/model"
+	"aq3cms/internal/service"
 	"aq3cms/pkg/cache"
 	"aq3cms/pkg/database"
 	"aq3cms/pkg/logger"
+	"aq3cms/sql/migrations"
 )
 
 func main() {
@@ -48,6 +51,23 @@ func run() error {
 
 	logger.Info("数据库连接成功", "type", cfg.Database.Type, "host", cfg.Database.Host)
 
+	// 执行数据库迁移
+	if cfg.Database.AutoMigrate {
+		source, err := migrations.For(db.Dialect.Name())
+		if err != nil {
+			return fmt.Errorf("加载数据库迁移失败: %v", err)
+		}
+		migrator, err := database.NewMigrator(db, source)
+		if err != nil {
+			return fmt.Errorf("加载数据库迁移失败: %v", err)
+		}
+		count, err := migrator.Up()
+		if err != nil {
+			return fmt.Errorf("数据库迁移失败: %v", err)
+		}
+		logger.Info("数据库迁移完成", "count", count)
+	}
+
 	// 初始化默认管理员
 	adminModel := model.NewAdminModel(db)
 	if err := adminModel.InitDefaultAdmin(); err != nil {
@@ -79,6 +99,22 @@ func run() error {
 	router := mux.NewRouter()
 	controller.RegisterRoutes(router, db, cacheProvider, cfg)
 
+	// 请求的根上下文，关闭超时后取消以中断仍在执行的查询
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	// 定时清理回收站
+	go service.NewRecycleService(db, cacheProvider, cfg).RunAutoPurge(baseCtx)
+
+	// 定时发布和到期下线
+	go service.NewWorkflowService(db, cacheProvider, cfg).RunScheduler(baseCtx)
+
+	// 定时补算重复内容检查的指纹
+	go service.NewDuplicateService(db, cacheProvider, cfg).RunBackfill(baseCtx)
+
+	// 定时计算相关文章
+	go service.NewRelatedService(db, cacheProvider, cfg).RunBuilder(baseCtx)
+
 	// 创建HTTP服务器
 	server := &http.Server{
 		Addr:           fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -86,10 +122,14 @@ func run() error {
 		ReadTimeout:    time.Duration(cfg.Server.ReadTimeout) * time.Second,
 		WriteTimeout:   time.Duration(cfg.Server.WriteTimeout) * time.Second,
 		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+		BaseContext:    func(net.Listener) context.Context { return baseCtx },
 	}
 
 	// 优雅关闭
+	idle := make(chan struct{})
 	go func() {
+		defer close(idle)
+
 		quit := make(chan os.Signal, 1)
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 		<-quit
@@ -101,6 +141,7 @@ func run() error {
 
 		if err := server.Shutdown(ctx); err != nil {
 			logger.Error("服务器关闭出错", "error", err)
+			cancelRequests()
 		}
 	}()
 
@@ -110,6 +151,9 @@ func run() error {
 		return fmt.Errorf("服务器启动失败: %v", err)
 	}
 
+	// 等待正在处理的请求结束
+	<-idle
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"io/ioutil"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -36,16 +37,25 @@ type ServerConfig struct {
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
-	Type     string `yaml:"type"`
-	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	Database string `yaml:"database"`
-	Prefix   string `yaml:"prefix"`
-	Charset  string `yaml:"charset"`
-	MaxIdle  int    `yaml:"maxIdle"`
-	MaxOpen  int    `yaml:"maxOpen"`
+	Type         string `yaml:"type"`
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+	Database     string `yaml:"database"`
+	Prefix       string `yaml:"prefix"`
+	Charset      string `yaml:"charset"`
+	MaxIdle      int    `yaml:"maxIdle"`
+	MaxOpen      int    `yaml:"maxOpen"`
+	SSLMode      string `yaml:"sslMode"`      // PostgreSQL的sslmode，默认disable
+	AutoMigrate  bool   `yaml:"autoMigrate"`  // 启动时自动执行数据库迁移
+	QueryTimeout int    `yaml:"queryTimeout"` // 默认查询超时（秒），0表示不限制
+
+	Replicas             []string `yaml:"replicas"`             // 只读副本DSN，格式与驱动一致
+	ReplicaCheckInterval int      `yaml:"replicaCheckInterval"` // 副本健康检查间隔（秒），默认10
+
+	SlowQueryThreshold int `yaml:"slowQueryThreshold"` // 慢查询阈值（毫秒），超过时记录日志，0表示不记录
+	SlowQueryTop       int `yaml:"slowQueryTop"`       // 保留的最慢SQL条数，默认20
 }
 
 // TemplateConfig 模板配置
@@ -58,6 +68,7 @@ type TemplateConfig struct {
 // UploadConfig 上传配置
 type UploadConfig struct {
 	Dir            string  `yaml:"dir"`
+	URL            string  `yaml:"url"`
 	MaxSize        int     `yaml:"maxSize"`
 	AllowedExts    string  `yaml:"allowedExts"`
 	DenyExts       string  `yaml:"denyExts"`
@@ -71,6 +82,26 @@ type UploadConfig struct {
 	ImageMaxHeight int     `yaml:"imageMaxHeight"`
 }
 
+// URLPrefix 上传文件的访问地址前缀，以/结尾
+func (c UploadConfig) URLPrefix() string {
+	prefix := strings.Trim(c.URL, "/")
+	if prefix == "" {
+		prefix = "uploads"
+	}
+	if strings.Contains(prefix, "://") {
+		return prefix + "/"
+	}
+	return "/" + prefix + "/"
+}
+
+// LocalDir 上传目录，未配置时为uploads
+func (c UploadConfig) LocalDir() string {
+	if c.Dir == "" {
+		return "uploads"
+	}
+	return c.Dir
+}
+
 // LogConfig 日志配置
 type LogConfig struct {
 	Path  string `yaml:"path"`
@@ -127,6 +158,11 @@ type SiteConfig struct {
 	Close            bool   `yaml:"close"`
 	CloseReason      string `yaml:"closeReason"`
 	CommentAutoCheck bool   `yaml:"commentAutoCheck"`
+	RecycleDays      int    `yaml:"recycleDays"`     // 回收站保留天数，0表示不自动清理
+	RelatedInterval  int    `yaml:"relatedInterval"` // 相关文章计算间隔（分钟），0表示默认60分钟，小于0表示不计算
+	PageBreak        string `yaml:"pageBreak"`       // 文章分页符，为空时使用#p#
+	DuplicateDistance int   `yaml:"duplicateDistance"` // 判定内容重复的指纹汉明距离，0表示默认8，小于0表示不检查
+	DuplicateAction  string `yaml:"duplicateAction"` // 发布重复的采集内容时：skip跳过（默认），flag照常发布并标记
 	SessionSecret    string `yaml:"sessionSecret"`
 }
 
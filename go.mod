@@ -5,12 +5,17 @@ go 1.23.0
 toolchain go1.23.3
 
 require (
+	github.com/JohannesKaufmann/html-to-markdown v1.6.0
 	github.com/PuerkitoBio/goquery v1.10.3
 	github.com/go-sql-driver/mysql v1.6.0
 	github.com/gomodule/redigo v1.8.5
 	github.com/gorilla/mux v1.8.0
 	github.com/gorilla/sessions v1.2.1
+	github.com/lib/pq v1.9.0
+	github.com/mattn/go-sqlite3 v1.14.22
+	github.com/yuin/goldmark v1.7.8
 	golang.org/x/crypto v0.37.0
+	golang.org/x/text v0.24.0
 	gopkg.in/yaml.v2 v2.4.0
 )
 
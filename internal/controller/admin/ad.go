@@ -42,6 +42,8 @@ func NewAdController(db *database.DB, cache cache.Cache, config *config.Config)
 
 // List 广告列表
 func (c *AdController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -56,7 +58,7 @@ func (c *AdController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取广告列表
-	ads, err := c.adModel.GetAll(positionID, -1)
+	ads, err := c.adModel.WithContext(ctx).GetAll(positionID, -1)
 	if err != nil {
 		logger.Error("获取广告列表失败", "error", err)
 		http.Error(w, "Failed to get ads", http.StatusInternalServerError)
@@ -64,7 +66,7 @@ func (c *AdController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取广告位列表
-	positions, err := c.adPositionModel.GetAll(-1)
+	positions, err := c.adPositionModel.WithContext(ctx).GetAll(-1)
 	if err != nil {
 		logger.Error("获取广告位列表失败", "error", err)
 		http.Error(w, "Failed to get ad positions", http.StatusInternalServerError)
@@ -93,12 +95,14 @@ func (c *AdController) List(w http.ResponseWriter, r *http.Request) {
 
 // Add 添加广告页面
 func (c *AdController) Add(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取广告位列表
-	positions, err := c.adPositionModel.GetAll(1)
+	positions, err := c.adPositionModel.WithContext(ctx).GetAll(1)
 	if err != nil {
 		logger.Error("获取广告位列表失败", "error", err)
 		http.Error(w, "Failed to get ad positions", http.StatusInternalServerError)
@@ -125,6 +129,8 @@ func (c *AdController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加广告
 func (c *AdController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -193,7 +199,7 @@ func (c *AdController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存广告
-	id, err := c.adModel.Create(ad)
+	id, err := c.adModel.WithContext(ctx).Create(ad)
 	if err != nil {
 		logger.Error("创建广告失败", "error", err)
 		http.Error(w, "Failed to create ad", http.StatusInternalServerError)
@@ -201,7 +207,7 @@ func (c *AdController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 清除缓存
-	c.adService.ClearCache(id)
+	c.adService.WithContext(ctx).ClearCache(id)
 
 	// 返回成功信息
 	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
@@ -220,6 +226,8 @@ func (c *AdController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑广告页面
 func (c *AdController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -234,7 +242,7 @@ func (c *AdController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取广告
-	ad, err := c.adModel.GetByID(id)
+	ad, err := c.adModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取广告失败", "id", id, "error", err)
 		http.Error(w, "Ad not found", http.StatusNotFound)
@@ -242,7 +250,7 @@ func (c *AdController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取广告位列表
-	positions, err := c.adPositionModel.GetAll(1)
+	positions, err := c.adPositionModel.WithContext(ctx).GetAll(1)
 	if err != nil {
 		logger.Error("获取广告位列表失败", "error", err)
 		http.Error(w, "Failed to get ad positions", http.StatusInternalServerError)
@@ -270,6 +278,8 @@ func (c *AdController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑广告
 func (c *AdController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -285,7 +295,7 @@ func (c *AdController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原广告
-	ad, err := c.adModel.GetByID(id)
+	ad, err := c.adModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取广告失败", "id", id, "error", err)
 		http.Error(w, "Ad not found", http.StatusNotFound)
@@ -351,7 +361,7 @@ func (c *AdController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	ad.Height = height
 
 	// 保存广告
-	err = c.adModel.Update(ad)
+	err = c.adModel.WithContext(ctx).Update(ad)
 	if err != nil {
 		logger.Error("更新广告失败", "error", err)
 		http.Error(w, "Failed to update ad", http.StatusInternalServerError)
@@ -359,7 +369,7 @@ func (c *AdController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 清除缓存
-	c.adService.ClearCache(id)
+	c.adService.WithContext(ctx).ClearCache(id)
 
 	// 返回成功信息
 	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
@@ -377,6 +387,8 @@ func (c *AdController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除广告
 func (c *AdController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取广告ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -387,7 +399,7 @@ func (c *AdController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取广告
-	_, err = c.adModel.GetByID(id)
+	_, err = c.adModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取广告失败", "id", id, "error", err)
 		http.Error(w, "Ad not found", http.StatusNotFound)
@@ -395,10 +407,10 @@ func (c *AdController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 清除缓存
-	c.adService.ClearCache(id)
+	c.adService.WithContext(ctx).ClearCache(id)
 
 	// 删除广告
-	err = c.adModel.Delete(id)
+	err = c.adModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除广告失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete ad", http.StatusInternalServerError)
@@ -421,12 +433,14 @@ func (c *AdController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // PositionList 广告位列表
 func (c *AdController) PositionList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取广告位列表
-	positions, err := c.adPositionModel.GetAll(-1)
+	positions, err := c.adPositionModel.WithContext(ctx).GetAll(-1)
 	if err != nil {
 		logger.Error("获取广告位列表失败", "error", err)
 		http.Error(w, "Failed to get ad positions", http.StatusInternalServerError)
@@ -476,6 +490,8 @@ func (c *AdController) PositionAdd(w http.ResponseWriter, r *http.Request) {
 
 // PositionDoAdd 处理添加广告位
 func (c *AdController) PositionDoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -514,7 +530,7 @@ func (c *AdController) PositionDoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存广告位
-	id, err := c.adPositionModel.Create(position)
+	id, err := c.adPositionModel.WithContext(ctx).Create(position)
 	if err != nil {
 		logger.Error("创建广告位失败", "error", err)
 		http.Error(w, "Failed to create ad position", http.StatusInternalServerError)
@@ -522,7 +538,7 @@ func (c *AdController) PositionDoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 清除缓存
-	c.adService.ClearAllCache()
+	c.adService.WithContext(ctx).ClearAllCache()
 
 	// 返回成功信息
 	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
@@ -541,6 +557,8 @@ func (c *AdController) PositionDoAdd(w http.ResponseWriter, r *http.Request) {
 
 // PositionEdit 编辑广告位页面
 func (c *AdController) PositionEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -555,7 +573,7 @@ func (c *AdController) PositionEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取广告位
-	position, err := c.adPositionModel.GetByID(id)
+	position, err := c.adPositionModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取广告位失败", "id", id, "error", err)
 		http.Error(w, "Ad position not found", http.StatusNotFound)
@@ -582,6 +600,8 @@ func (c *AdController) PositionEdit(w http.ResponseWriter, r *http.Request) {
 
 // PositionDoEdit 处理编辑广告位
 func (c *AdController) PositionDoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -597,7 +617,7 @@ func (c *AdController) PositionDoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原广告位
-	position, err := c.adPositionModel.GetByID(id)
+	position, err := c.adPositionModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取广告位失败", "id", id, "error", err)
 		http.Error(w, "Ad position not found", http.StatusNotFound)
@@ -634,7 +654,7 @@ func (c *AdController) PositionDoEdit(w http.ResponseWriter, r *http.Request) {
 	position.Status = status
 
 	// 保存广告位
-	err = c.adPositionModel.Update(position)
+	err = c.adPositionModel.WithContext(ctx).Update(position)
 	if err != nil {
 		logger.Error("更新广告位失败", "error", err)
 		http.Error(w, "Failed to update ad position", http.StatusInternalServerError)
@@ -642,7 +662,7 @@ func (c *AdController) PositionDoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 清除缓存
-	c.adService.ClearAllCache()
+	c.adService.WithContext(ctx).ClearAllCache()
 
 	// 返回成功信息
 	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
@@ -660,6 +680,8 @@ func (c *AdController) PositionDoEdit(w http.ResponseWriter, r *http.Request) {
 
 // PositionDelete 删除广告位
 func (c *AdController) PositionDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取广告位ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -670,7 +692,7 @@ func (c *AdController) PositionDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查广告位是否有广告
-	hasAds, err := c.adPositionModel.HasAds(id)
+	hasAds, err := c.adPositionModel.WithContext(ctx).HasAds(id)
 	if err != nil {
 		logger.Error("检查广告位是否有广告失败", "id", id, "error", err)
 		http.Error(w, "Failed to check if ad position has ads", http.StatusInternalServerError)
@@ -682,7 +704,7 @@ func (c *AdController) PositionDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除广告位
-	err = c.adPositionModel.Delete(id)
+	err = c.adPositionModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除广告位失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete ad position", http.StatusInternalServerError)
@@ -690,7 +712,7 @@ func (c *AdController) PositionDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 清除缓存
-	c.adService.ClearAllCache()
+	c.adService.WithContext(ctx).ClearAllCache()
 
 	// 返回成功信息
 	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
@@ -2,8 +2,10 @@ package admin
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -27,9 +29,15 @@ type ArticleController struct {
 	cache           cache.Cache
 	config          *config.Config
 	articleModel    *model.ArticleModel
+	batchService    *service.ArticleBatchService
 	categoryModel   *model.CategoryModel
 	tagModel        *model.TagModel
 	htmlService     *service.HtmlService
+	imageService    *service.ImageService
+	keywordService  *service.KeywordService
+	recycleService  *service.RecycleService
+	revisionService *service.RevisionService
+	workflowService *service.WorkflowService
 	templateService *service.TemplateService
 }
 
@@ -40,49 +48,57 @@ func NewArticleController(db *database.DB, cache cache.Cache, config *config.Con
 		cache:           cache,
 		config:          config,
 		articleModel:    model.NewArticleModel(db),
+		batchService:    service.NewArticleBatchService(db, cache, config),
 		categoryModel:   model.NewCategoryModel(db),
 		tagModel:        model.NewTagModel(db),
 		htmlService:     service.NewHtmlService(db, cache, config),
+		imageService:    service.NewImageService(db, cache, config),
+		keywordService:  service.NewKeywordService(db, cache, config),
+		recycleService:  service.NewRecycleService(db, cache, config),
+		revisionService: service.NewRevisionService(db, cache, config),
+		workflowService: service.NewWorkflowService(db, cache, config),
 		templateService: service.NewTemplateService(db, cache, config),
 	}
 }
 
 // Index 文章管理主页
 func (c *ArticleController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取文章统计信息
-	totalArticles, err := c.articleModel.GetTotalCount()
+	totalArticles, err := c.articleModel.WithContext(ctx).GetTotalCount()
 	if err != nil {
 		logger.Error("获取文章总数失败", "error", err)
 		totalArticles = 0
 	}
 
 	// 获取今日新增文章数
-	todayArticles, err := c.articleModel.GetTodayCount()
+	todayArticles, err := c.articleModel.WithContext(ctx).GetTodayCount()
 	if err != nil {
 		logger.Error("获取今日文章数失败", "error", err)
 		todayArticles = 0
 	}
 
 	// 获取待审核文章数
-	pendingArticles, err := c.articleModel.GetPendingCount()
+	pendingArticles, err := c.articleModel.WithContext(ctx).GetPendingCount()
 	if err != nil {
 		logger.Error("获取待审核文章数失败", "error", err)
 		pendingArticles = 0
 	}
 
 	// 获取最新文章列表（前10条）
-	latestArticles, _, err := c.articleModel.GetList(0, 1, 10)
+	latestArticles, _, err := c.articleModel.WithContext(ctx).GetList(0, 1, 10)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 		latestArticles = []*model.Article{}
 	}
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		categories = []*model.Category{}
@@ -112,12 +128,15 @@ func (c *ArticleController) Index(w http.ResponseWriter, r *http.Request) {
 
 // List 文章列表
 func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取查询参数
 	typeidStr := r.URL.Query().Get("typeid")
+	stateStr := r.URL.Query().Get("state")
 	keyword := r.URL.Query().Get("keyword")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("pagesize")
@@ -128,6 +147,13 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 		typeid, _ = strconv.ParseInt(typeidStr, 10, 64)
 	}
 
+	state := -1
+	if stateStr != "" {
+		if s, err := strconv.Atoi(stateStr); err == nil {
+			state = s
+		}
+	}
+
 	page := 1
 	if pageStr != "" {
 		page, _ = strconv.Atoi(pageStr)
@@ -144,19 +170,8 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// 获取文章列表
-	var articles []*model.Article
-	var total int
-	var err error
-
-	if keyword != "" {
-		// 搜索文章
-		articles, total, err = c.articleModel.Search(keyword, page, pageSize)
-	} else {
-		// 获取文章列表
-		articles, total, err = c.articleModel.GetList(typeid, page, pageSize)
-	}
-
+	// 获取文章列表，包括未发布的文章
+	articles, total, err := c.articleModel.WithContext(ctx).GetAdminList(typeid, state, keyword, page, pageSize)
 	if err != nil {
 		logger.Error("获取文章列表失败", "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -164,7 +179,7 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 	}
@@ -189,6 +204,8 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 		"Categories":  categories,
 		"Pagination":  pagination,
 		"TypeID":      typeid,
+		"State":       state,
+		"StateNames":  model.ArticleStateNames,
 		"Keyword":     keyword,
 		"CurrentMenu": "article",
 		"PageTitle":   "文章管理",
@@ -205,12 +222,14 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 
 // Add 添加文章页面
 func (c *ArticleController) Add(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -222,6 +241,7 @@ func (c *ArticleController) Add(w http.ResponseWriter, r *http.Request) {
 		"AdminID":     adminID,
 		"AdminName":   adminName,
 		"Categories":  categories,
+		"CanPublish":  middleware.GetAdminRank(r) >= model.AdminRankReviewer,
 		"CurrentMenu": "article",
 		"PageTitle":   "添加文章",
 	}
@@ -237,6 +257,8 @@ func (c *ArticleController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加文章
 func (c *ArticleController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseMultipartForm(32 << 20); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -258,6 +280,7 @@ func (c *ArticleController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	isRecommendStr := r.FormValue("isrecommend")
 	isHotStr := r.FormValue("ishot")
 	arcRankStr := r.FormValue("arcrank")
+	stateStr := r.FormValue("state")
 	filename := r.FormValue("filename")
 	tags := r.FormValue("tags")
 
@@ -294,6 +317,17 @@ func (c *ArticleController) DoAdd(w http.ResponseWriter, r *http.Request) {
 		arcRank, _ = strconv.Atoi(arcRankStr)
 	}
 
+	// 没有发布权限的管理员只能保存草稿或提交审核
+	state := -1
+	if stateStr != "" {
+		state, _ = strconv.Atoi(stateStr)
+	}
+	pubDate := time.Now()
+	if t := parseFormTime(r.FormValue("pubdate")); !t.IsZero() {
+		pubDate = t
+	}
+	state = c.workflowService.InitialState(state, middleware.GetAdminRank(r), pubDate)
+
 	// 处理上传的缩略图
 	file, header, err := r.FormFile("litpic_upload")
 	if err == nil {
@@ -320,8 +354,9 @@ func (c *ArticleController) DoAdd(w http.ResponseWriter, r *http.Request) {
 		Writer:      writer,
 		Source:      source,
 		LitPic:      litpic,
-		PubDate:     time.Now(),
+		PubDate:     pubDate,
 		SendDate:    time.Now(),
+		ExpireDate:  parseFormTime(r.FormValue("expiredate")),
 		Keywords:    keywords,
 		Description: description,
 		Filename:    filename,
@@ -329,12 +364,25 @@ func (c *ArticleController) DoAdd(w http.ResponseWriter, r *http.Request) {
 		IsRecommend: isRecommend,
 		IsHot:       isHot,
 		ArcRank:     arcRank,
+		State:       state,
 		Click:       0,
-		Body:        body,
+	}
+	if err := article.SetBody(r.FormValue("format"), body, c.config.Site.PageBreak); err != nil {
+		logger.Error("转换Markdown内容失败", "error", err)
+		http.Error(w, "Invalid markdown body", http.StatusBadRequest)
+		return
 	}
 
+	// 下载远程图片
+	if r.FormValue("remote_images") == "1" {
+		c.imageService.WithContext(ctx).LocalizeArticle(article)
+	}
+
+	// 没有填写关键词和摘要时自动提取
+	c.keywordService.WithContext(ctx).Fill(article)
+
 	// 保存文章
-	id, err := c.articleModel.Create(article)
+	id, err := c.articleModel.WithContext(ctx).Create(article)
 	if err != nil {
 		logger.Error("创建文章失败", "error", err)
 		http.Error(w, "Failed to create article", http.StatusInternalServerError)
@@ -343,14 +391,22 @@ func (c *ArticleController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 	// 处理标签
 	if tags != "" {
-		err = c.tagModel.UpdateArticleTags(id, tags)
+		err = c.tagModel.WithContext(ctx).UpdateArticleTags(id, tags)
 		if err != nil {
 			logger.Error("更新文章标签失败", "error", err)
 		}
 	}
 
+	// 处理副栏目
+	if crossTypeIDs := parseIDs(strings.Join(r.Form["crosstypeids"], ",")); len(crossTypeIDs) > 0 {
+		err = c.articleModel.WithContext(ctx).SetCrossTypeIDs(id, typeid, crossTypeIDs)
+		if err != nil {
+			logger.Error("更新文章副栏目失败", "error", err)
+		}
+	}
+
 	// 生成静态页面
-	if c.config.Site.StaticArticle {
+	if c.config.Site.StaticArticle && state == model.ArticleStatePublished {
 		go c.htmlService.GenerateArticle(id)
 	}
 
@@ -371,6 +427,8 @@ func (c *ArticleController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑文章页面
 func (c *ArticleController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -385,7 +443,7 @@ func (c *ArticleController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章
-	article, err := c.articleModel.GetByID(id)
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取文章失败", "id", id, "error", err)
 		http.Error(w, "Article not found", http.StatusNotFound)
@@ -393,17 +451,27 @@ func (c *ArticleController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 	}
 
 	// 获取文章标签
-	tags, err := c.tagModel.GetArticleTags(id)
+	tags, err := c.tagModel.WithContext(ctx).GetArticleTags(id)
 	if err != nil {
 		logger.Error("获取文章标签失败", "id", id, "error", err)
 	}
 
+	// 获取文章副栏目
+	crossTypes := make(map[int64]bool)
+	crossTypeIDs, err := c.articleModel.WithContext(ctx).GetCrossTypeIDs(id)
+	if err != nil {
+		logger.Error("获取文章副栏目失败", "id", id, "error", err)
+	}
+	for _, typeid := range crossTypeIDs {
+		crossTypes[typeid] = true
+	}
+
 	// 准备模板数据
 	data := map[string]interface{}{
 		"AdminID":     adminID,
@@ -411,6 +479,7 @@ func (c *ArticleController) Edit(w http.ResponseWriter, r *http.Request) {
 		"Article":     article,
 		"Categories":  categories,
 		"Tags":        strings.Join(tags, ","),
+		"CrossTypes":  crossTypes,
 		"CurrentMenu": "article",
 		"PageTitle":   "编辑文章",
 	}
@@ -426,6 +495,8 @@ func (c *ArticleController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑文章
 func (c *ArticleController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseMultipartForm(32 << 20); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -441,12 +512,16 @@ func (c *ArticleController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原文章
-	article, err := c.articleModel.GetByID(id)
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取文章失败", "id", id, "error", err)
 		http.Error(w, "Article not found", http.StatusNotFound)
 		return
 	}
+	if article.ArcRank == model.ArcRankRecycled {
+		http.Error(w, "Article is in the recycle bin", http.StatusBadRequest)
+		return
+	}
 
 	// 获取表单数据
 	typeidStr := r.FormValue("typeid")
@@ -494,10 +569,14 @@ func (c *ArticleController) DoEdit(w http.ResponseWriter, r *http.Request) {
 		isHot = 1
 	}
 
-	arcRank := 0
+	// 表单没有阅读权限时保持原值，移入回收站只能通过删除操作
+	arcRank := article.ArcRank
 	if arcRankStr != "" {
 		arcRank, _ = strconv.Atoi(arcRankStr)
 	}
+	if arcRank == model.ArcRankRecycled {
+		arcRank = article.ArcRank
+	}
 
 	// 处理上传的缩略图
 	file, header, err := r.FormFile("litpic_upload")
@@ -533,22 +612,49 @@ func (c *ArticleController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	article.IsRecommend = isRecommend
 	article.IsHot = isHot
 	article.ArcRank = arcRank
-	article.Body = body
+	if err := article.SetBody(r.FormValue("format"), body, c.config.Site.PageBreak); err != nil {
+		logger.Error("转换Markdown内容失败", "id", id, "error", err)
+		http.Error(w, "Invalid markdown body", http.StatusBadRequest)
+		return
+	}
+	if r.FormValue("remote_images") == "1" {
+		c.imageService.WithContext(ctx).LocalizeArticle(article)
+	}
+	c.keywordService.WithContext(ctx).Fill(article)
+	if t := parseFormTime(r.FormValue("pubdate")); !t.IsZero() {
+		article.PubDate = t
+	}
+	article.ExpireDate = parseFormTime(r.FormValue("expiredate"))
 
-	// 保存文章
-	err = c.articleModel.Update(article)
+	// 保存文章并记录修订版本
+	err = c.revisionService.WithContext(ctx).UpdateArticle(article, middleware.GetAdminID(r), 0)
 	if err != nil {
 		logger.Error("更新文章失败", "error", err)
 		http.Error(w, "Failed to update article", http.StatusInternalServerError)
 		return
 	}
 
+	// 没有审核权限的管理员修改后，文章需要重新审核；已发布的文章发布时间改到将来时改为定时发布
+	workflowService := c.workflowService.WithContext(ctx)
+	if err := workflowService.Resubmit(article, middleware.GetAdminID(r), middleware.GetAdminRank(r)); err != nil {
+		logger.Error("文章退回待审核失败", "id", id, "error", err)
+	}
+	if err := workflowService.Reschedule(article, middleware.GetAdminID(r)); err != nil {
+		logger.Error("文章改为定时发布失败", "id", id, "error", err)
+	}
+
 	// 处理标签
-	err = c.tagModel.UpdateArticleTags(id, tags)
+	err = c.tagModel.WithContext(ctx).UpdateArticleTags(id, tags)
 	if err != nil {
 		logger.Error("更新文章标签失败", "error", err)
 	}
 
+	// 处理副栏目
+	err = c.articleModel.WithContext(ctx).SetCrossTypeIDs(id, typeid, parseIDs(strings.Join(r.Form["crosstypeids"], ",")))
+	if err != nil {
+		logger.Error("更新文章副栏目失败", "error", err)
+	}
+
 	// 生成静态页面
 	if c.config.Site.StaticArticle {
 		go c.htmlService.GenerateArticle(id)
@@ -568,8 +674,33 @@ func (c *ArticleController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Delete 删除文章
+// Suggest 根据标题和内容生成关键词和摘要建议，供编辑表单确认后使用
+func (c *ArticleController) Suggest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	article := &model.Article{Title: r.FormValue("title")}
+	if err := article.SetBody(r.FormValue("format"), r.FormValue("body"), c.config.Site.PageBreak); err != nil {
+		http.Error(w, "Invalid markdown body", http.StatusBadRequest)
+		return
+	}
+
+	keywords, description, err := c.keywordService.WithContext(ctx).Suggest(article)
+	if err != nil {
+		logger.Error("提取文章关键词失败", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"keywords":    keywords,
+		"description": description,
+	})
+}
+
+// Delete 删除文章，文章移入回收站
 func (c *ArticleController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取文章ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -579,8 +710,8 @@ func (c *ArticleController) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 删除文章
-	err = c.articleModel.Delete(id)
+	// 移入回收站
+	err = c.recycleService.WithContext(ctx).Trash(id)
 	if err != nil {
 		logger.Error("删除文章失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete article", http.StatusInternalServerError)
@@ -593,7 +724,7 @@ func (c *ArticleController) Delete(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": true,
-			"message": "文章删除成功",
+			"message": "文章已移入回收站",
 		})
 	} else {
 		// 普通表单提交
@@ -601,8 +732,119 @@ func (c *ArticleController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// BatchDelete 批量删除文章
+// BatchDelete 批量删除文章，文章移入回收站
 func (c *ArticleController) BatchDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 解析表单
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	// 获取文章ID列表
+	idsStr := r.FormValue("ids")
+	if idsStr == "" {
+		http.Error(w, "No articles selected", http.StatusBadRequest)
+		return
+	}
+
+	// 批量移入回收站
+	result, err := c.batchService.WithContext(ctx).Run(&service.BatchRequest{
+		Action: service.BatchDelete,
+		IDs:    parseIDs(idsStr),
+	})
+	if err != nil {
+		logger.Error("批量删除文章失败", "error", err)
+	}
+
+	// 返回成功信息
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		// AJAX请求
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"message": err.Error(),
+				"result":  result,
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "文章已批量移入回收站",
+			"result":  result,
+		})
+	} else {
+		// 普通表单提交
+		http.Redirect(w, r, "/aq3cms/article_list", http.StatusFound)
+	}
+}
+
+// Batch 文章批量操作，按选中的文章ID或文章列表的筛选条件选择文章，返回每篇文章的处理结果
+func (c *ArticleController) Batch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 批量操作会直接修改已发布的文章，与审核流程一样要求审核权限
+	if !requireReviewer(w, r) {
+		return
+	}
+
+	// 解析表单
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req := &service.BatchRequest{
+		Action: r.FormValue("action"),
+		Flags:  r.FormValue("flags"),
+		Tags:   r.FormValue("tags"),
+	}
+	req.TypeID, _ = strconv.ParseInt(r.FormValue("typeid"), 10, 64)
+	req.ArcRank, _ = strconv.Atoi(r.FormValue("arcrank"))
+
+	// scope=filter时按筛选条件选择文章
+	if r.FormValue("scope") == "filter" {
+		req.Filter = &service.BatchFilter{Keyword: r.FormValue("filter_keyword")}
+		req.Filter.TypeID, _ = strconv.ParseInt(r.FormValue("filter_typeid"), 10, 64)
+		if state, err := strconv.Atoi(r.FormValue("filter_state")); err == nil {
+			req.Filter.State = &state
+		}
+	} else {
+		req.IDs = parseIDs(r.FormValue("ids"))
+	}
+
+	// 执行批量操作
+	result, err := c.batchService.WithContext(ctx).Run(req)
+
+	// 返回结果
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		logger.Error("文章批量操作失败", "action", req.Action, "error", err)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+			"result":  result,
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("共 %d 篇文章，成功 %d 篇，失败 %d 篇", result.Total, result.Succeeded, result.Failed),
+		"result":  result,
+	})
+}
+
+// BatchLocalizeImages 批量下载所选文章中的远程图片
+func (c *ArticleController) BatchLocalizeImages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 会修改并重新生成已发布的文章，要求审核权限
+	if !requireReviewer(w, r) {
+		return
+	}
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -616,23 +858,139 @@ func (c *ArticleController) BatchDelete(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// 分割ID列表
-	idStrs := strings.Split(idsStr, ",")
-	ids := make([]int64, 0, len(idStrs))
-	for _, idStr := range idStrs {
+	articleModel := c.articleModel.WithContext(ctx)
+	imageService := c.imageService.WithContext(ctx)
+	revisionService := c.revisionService.WithContext(ctx)
+	adminID := middleware.GetAdminID(r)
+
+	articles, images := 0, 0
+	for _, idStr := range strings.Split(idsStr, ",") {
 		id, err := strconv.ParseInt(idStr, 10, 64)
 		if err != nil {
 			continue
 		}
-		ids = append(ids, id)
-	}
 
-	// 批量删除文章
-	for _, id := range ids {
-		err := c.articleModel.Delete(id)
+		article, err := articleModel.GetByID(id)
 		if err != nil {
-			logger.Error("删除文章失败", "id", id, "error", err)
+			logger.Error("获取文章失败", "id", id, "error", err)
+			continue
 		}
+
+		litpic := article.LitPic
+		n := imageService.LocalizeArticle(article)
+		if n == 0 && article.LitPic == litpic {
+			continue
+		}
+
+		// 保存文章并记录修订版本
+		if err := revisionService.UpdateArticle(article, adminID, 0); err != nil {
+			logger.Error("更新文章失败", "id", id, "error", err)
+			continue
+		}
+		if c.config.Site.StaticArticle && article.State == model.ArticleStatePublished {
+			go c.htmlService.GenerateArticle(id)
+		}
+
+		articles++
+		images += n
+	}
+
+	// 返回结果
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  fmt.Sprintf("已处理 %d 篇文章，下载远程图片 %d 张", articles, images),
+		"articles": articles,
+		"images":   images,
+	})
+}
+
+// requireReviewer 检查管理员是否有审核权限，没有时返回403和JSON错误信息
+func requireReviewer(w http.ResponseWriter, r *http.Request) bool {
+	if middleware.GetAdminRank(r) >= model.AdminRankReviewer {
+		return true
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"message": "没有审核权限，不能批量修改文章",
+	})
+	return false
+}
+
+// Recycle 回收站文章列表
+func (c *ArticleController) Recycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取管理员信息
+	adminID := middleware.GetAdminID(r)
+	adminName := middleware.GetAdminName(r)
+
+	// 获取分页参数
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize := 20
+
+	// 获取回收站文章
+	articles, total, err := c.recycleService.WithContext(ctx).GetList(page, pageSize)
+	if err != nil {
+		logger.Error("获取回收站文章失败", "error", err)
+		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
+		return
+	}
+
+	// 计算分页信息
+	totalPages := (total + pageSize - 1) / pageSize
+	pagination := map[string]interface{}{
+		"CurrentPage": page,
+		"TotalPages":  totalPages,
+		"TotalItems":  total,
+		"HasPrev":     page > 1,
+		"HasNext":     page < totalPages,
+		"PrevPage":    page - 1,
+		"NextPage":    page + 1,
+	}
+
+	// 准备模板数据
+	data := map[string]interface{}{
+		"AdminID":     adminID,
+		"AdminName":   adminName,
+		"Articles":    articles,
+		"Pagination":  pagination,
+		"RecycleDays": c.config.Site.RecycleDays,
+		"CurrentMenu": "article",
+		"PageTitle":   "文章回收站",
+	}
+
+	// 渲染模板
+	tplFile := "admin/article_recycle.htm"
+	if err := c.templateService.Render(w, tplFile, data); err != nil {
+		logger.Error("渲染回收站模板失败", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Restore 从回收站恢复文章
+func (c *ArticleController) Restore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取文章ID
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	// 恢复文章
+	if err := c.recycleService.WithContext(ctx).Restore(id); err != nil {
+		logger.Error("恢复文章失败", "id", id, "error", err)
+		http.Error(w, "Failed to restore article", http.StatusInternalServerError)
+		return
 	}
 
 	// 返回成功信息
@@ -641,12 +999,354 @@ func (c *ArticleController) BatchDelete(w http.ResponseWriter, r *http.Request)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": true,
-			"message": "文章批量删除成功",
+			"message": "文章恢复成功",
 		})
 	} else {
 		// 普通表单提交
-		http.Redirect(w, r, "/aq3cms/article_list", http.StatusFound)
+		http.Redirect(w, r, "/aq3cms/article_recycle", http.StatusFound)
+	}
+}
+
+// Purge 彻底删除回收站中的文章
+func (c *ArticleController) Purge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取文章ID
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	// 彻底删除文章
+	if err := c.recycleService.WithContext(ctx).Purge(id); err != nil {
+		logger.Error("彻底删除文章失败", "id", id, "error", err)
+		http.Error(w, "Failed to purge article", http.StatusInternalServerError)
+		return
+	}
+
+	// 返回成功信息
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		// AJAX请求
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "文章已彻底删除",
+		})
+	} else {
+		// 普通表单提交
+		http.Redirect(w, r, "/aq3cms/article_recycle", http.StatusFound)
+	}
+}
+
+// Revisions 文章修订历史
+func (c *ArticleController) Revisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取管理员信息
+	adminID := middleware.GetAdminID(r)
+	adminName := middleware.GetAdminName(r)
+
+	// 获取文章ID
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	// 获取文章
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
+	if err != nil {
+		logger.Error("获取文章失败", "id", id, "error", err)
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+
+	// 获取修订版本
+	revisions, err := c.revisionService.WithContext(ctx).GetList(id)
+	if err != nil {
+		logger.Error("获取文章修订版本失败", "id", id, "error", err)
+		http.Error(w, "Failed to get revisions", http.StatusInternalServerError)
+		return
+	}
+
+	// 准备模板数据
+	data := map[string]interface{}{
+		"AdminID":     adminID,
+		"AdminName":   adminName,
+		"Article":     article,
+		"Revisions":   revisions,
+		"CurrentMenu": "article",
+		"PageTitle":   "修订历史",
+	}
+
+	// 渲染模板
+	tplFile := "admin/article_revisions.htm"
+	if err := c.templateService.Render(w, tplFile, data); err != nil {
+		logger.Error("渲染修订历史模板失败", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevisionDiff 比较两个修订版本
+func (c *ArticleController) RevisionDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取管理员信息
+	adminID := middleware.GetAdminID(r)
+	adminName := middleware.GetAdminName(r)
+
+	// 获取版本ID
+	fromID, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+	toID, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+
+	// 比较版本
+	from, to, lines, err := c.revisionService.WithContext(ctx).Diff(fromID, toID)
+	if err != nil {
+		logger.Error("比较修订版本失败", "from", fromID, "to", toID, "error", err)
+		http.Error(w, "Failed to diff revisions", http.StatusBadRequest)
+		return
+	}
+
+	// 准备模板数据
+	data := map[string]interface{}{
+		"AdminID":     adminID,
+		"AdminName":   adminName,
+		"From":        from,
+		"To":          to,
+		"Lines":       lines,
+		"CurrentMenu": "article",
+		"PageTitle":   "版本比较",
+	}
+
+	// 渲染模板
+	tplFile := "admin/article_revision_diff.htm"
+	if err := c.templateService.Render(w, tplFile, data); err != nil {
+		logger.Error("渲染版本比较模板失败", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevisionRestore 将文章恢复到指定修订版本
+func (c *ArticleController) RevisionRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取版本ID
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+
+	// 恢复版本
+	article, err := c.revisionService.WithContext(ctx).Restore(id, middleware.GetAdminID(r))
+	if err != nil {
+		logger.Error("恢复修订版本失败", "id", id, "error", err)
+		http.Error(w, "Failed to restore revision", http.StatusInternalServerError)
+		return
+	}
+
+	// 返回成功信息
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		// AJAX请求
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "文章已恢复到所选版本",
+		})
+	} else {
+		// 普通表单提交
+		http.Redirect(w, r, "/aq3cms/article_revisions/"+strconv.FormatInt(article.ID, 10), http.StatusFound)
+	}
+}
+
+// Workflow 文章审核记录和可执行的操作
+func (c *ArticleController) Workflow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取管理员信息
+	adminID := middleware.GetAdminID(r)
+	adminName := middleware.GetAdminName(r)
+
+	// 获取文章ID
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	// 获取文章
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
+	if err != nil {
+		logger.Error("获取文章失败", "id", id, "error", err)
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+
+	// 获取审核记录
+	workflowService := c.workflowService.WithContext(ctx)
+	logs, err := workflowService.GetLogs(id)
+	if err != nil {
+		logger.Error("获取文章审核记录失败", "id", id, "error", err)
+		http.Error(w, "Failed to get workflow logs", http.StatusInternalServerError)
+		return
+	}
+
+	// 准备模板数据
+	data := map[string]interface{}{
+		"AdminID":     adminID,
+		"AdminName":   adminName,
+		"Article":     article,
+		"Logs":        logs,
+		"Transitions": workflowService.GetTransitions(article, middleware.GetAdminRank(r)),
+		"Error":       r.URL.Query().Get("error"),
+		"CurrentMenu": "article",
+		"PageTitle":   "文章审核",
+	}
+
+	// 渲染模板
+	tplFile := "admin/article_workflow.htm"
+	if err := c.templateService.Render(w, tplFile, data); err != nil {
+		logger.Error("渲染文章审核模板失败", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Transition 变更文章状态
+func (c *ArticleController) Transition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取文章ID
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	// 解析表单
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	to, err := strconv.Atoi(r.FormValue("to"))
+	if err != nil {
+		http.Error(w, "Invalid state", http.StatusBadRequest)
+		return
+	}
+
+	// 变更状态
+	err = c.workflowService.WithContext(ctx).Transition(id, to, middleware.GetAdminID(r), middleware.GetAdminRank(r), r.FormValue("comment"))
+
+	// 返回结果
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		// AJAX请求
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"message": err.Error(),
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "文章状态已更新",
+		})
+	} else {
+		// 普通表单提交
+		target := "/aq3cms/article_workflow/" + strconv.FormatInt(id, 10)
+		if err != nil {
+			target += "?error=" + url.QueryEscape(err.Error())
+		}
+		http.Redirect(w, r, target, http.StatusFound)
+	}
+}
+
+// Schedule 定时发布和到期下线队列
+func (c *ArticleController) Schedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取管理员信息
+	adminID := middleware.GetAdminID(r)
+	adminName := middleware.GetAdminName(r)
+
+	// 获取等待发布的文章
+	scheduled, err := c.articleModel.WithContext(ctx).GetScheduled(100)
+	if err != nil {
+		logger.Error("获取定时发布文章失败", "error", err)
+		http.Error(w, "Failed to get scheduled articles", http.StatusInternalServerError)
+		return
+	}
+
+	// 获取即将下线的文章
+	expiring, err := c.articleModel.WithContext(ctx).GetExpiring(100)
+	if err != nil {
+		logger.Error("获取到期下线文章失败", "error", err)
+		http.Error(w, "Failed to get expiring articles", http.StatusInternalServerError)
+		return
+	}
+
+	// 准备模板数据
+	data := map[string]interface{}{
+		"AdminID":     adminID,
+		"AdminName":   adminName,
+		"Scheduled":   scheduled,
+		"Expiring":    expiring,
+		"Now":         time.Now(),
+		"CurrentMenu": "article",
+		"PageTitle":   "定时发布",
+	}
+
+	// 渲染模板
+	tplFile := "admin/article_schedule.htm"
+	if err := c.templateService.Render(w, tplFile, data); err != nil {
+		logger.Error("渲染定时发布模板失败", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseIDs 解析逗号分隔的ID列表，忽略无效的ID
+func parseIDs(idsStr string) []int64 {
+	ids := make([]int64, 0)
+	for _, idStr := range strings.Split(idsStr, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(idStr), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// parseFormTime 解析表单中的时间，支持datetime-local格式，为空或格式错误时返回零值
+func parseFormTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
 	}
+	return time.Time{}
 }
 
 // saveUploadedFile 保存上传文件
@@ -41,12 +41,14 @@ func NewCategoryController(db *database.DB, cache cache.Cache, config *config.Co
 
 // Index 栏目管理主页
 func (c *CategoryController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		categories = []*model.Category{}
@@ -89,12 +91,14 @@ func (c *CategoryController) Index(w http.ResponseWriter, r *http.Request) {
 
 // List 栏目列表
 func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -103,7 +107,7 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 
 	// 为每个栏目获取文章数量
 	for _, category := range categories {
-		count, err := c.categoryModel.GetArticleCount(category.ID)
+		count, err := c.categoryModel.WithContext(ctx).GetArticleCount(category.ID)
 		if err != nil {
 			logger.Error("获取栏目文章数量失败", "categoryID", category.ID, "error", err)
 			count = 0
@@ -135,12 +139,14 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 
 // Add 添加栏目页面
 func (c *CategoryController) Add(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -174,6 +180,8 @@ func (c *CategoryController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加栏目
 func (c *CategoryController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -193,6 +201,7 @@ func (c *CategoryController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	description := r.FormValue("description")
 	listTemplate := r.FormValue("list_template")
 	articleTemplate := r.FormValue("article_template")
+	crossID := r.FormValue("crossid")
 
 	// 验证必填字段
 	if typeName == "" {
@@ -235,10 +244,11 @@ func (c *CategoryController) DoAdd(w http.ResponseWriter, r *http.Request) {
 		Description: description,
 		ListTpl:     listTemplate,
 		ArticleTpl:  articleTemplate,
+		CrossID:     crossID,
 	}
 
 	// 保存栏目
-	id, err := c.categoryModel.Create(category)
+	id, err := c.categoryModel.WithContext(ctx).Create(category)
 	if err != nil {
 		logger.Error("创建栏目失败", "error", err)
 		http.Error(w, "Failed to create category", http.StatusInternalServerError)
@@ -265,6 +275,8 @@ func (c *CategoryController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑栏目页面
 func (c *CategoryController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -279,7 +291,7 @@ func (c *CategoryController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目
-	category, err := c.categoryModel.GetByID(id)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取栏目失败", "id", id, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -287,7 +299,7 @@ func (c *CategoryController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 	}
@@ -320,6 +332,8 @@ func (c *CategoryController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑栏目
 func (c *CategoryController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -335,7 +349,7 @@ func (c *CategoryController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原栏目
-	category, err := c.categoryModel.GetByID(id)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取栏目失败", "id", id, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -355,6 +369,7 @@ func (c *CategoryController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	description := r.FormValue("description")
 	listTemplate := r.FormValue("list_template")
 	articleTemplate := r.FormValue("article_template")
+	crossID := r.FormValue("crossid")
 
 	// 验证必填字段
 	if typeName == "" {
@@ -375,7 +390,7 @@ func (c *CategoryController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查是否将栏目设置为其子栏目的父栏目
-	children, err := c.categoryModel.GetChildCategories(id)
+	children, err := c.categoryModel.WithContext(ctx).GetChildCategories(id)
 	if err == nil {
 		for _, child := range children {
 			if child.ID == parentID {
@@ -413,9 +428,10 @@ func (c *CategoryController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	category.Description = description
 	category.ListTpl = listTemplate
 	category.ArticleTpl = articleTemplate
+	category.CrossID = crossID
 
 	// 保存栏目
-	err = c.categoryModel.Update(category)
+	err = c.categoryModel.WithContext(ctx).Update(category)
 	if err != nil {
 		logger.Error("更新栏目失败", "error", err)
 		http.Error(w, "Failed to update category", http.StatusInternalServerError)
@@ -441,6 +457,8 @@ func (c *CategoryController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除栏目
 func (c *CategoryController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取栏目ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -451,21 +469,21 @@ func (c *CategoryController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查是否有子栏目
-	children, err := c.categoryModel.GetChildCategories(id)
+	children, err := c.categoryModel.WithContext(ctx).GetChildCategories(id)
 	if err == nil && len(children) > 0 {
 		http.Error(w, "Cannot delete category with child categories", http.StatusBadRequest)
 		return
 	}
 
 	// 检查是否有文章
-	count, err := c.categoryModel.GetArticleCount(id)
+	count, err := c.categoryModel.WithContext(ctx).GetArticleCount(id)
 	if err == nil && count > 0 {
 		http.Error(w, "Cannot delete category with articles", http.StatusBadRequest)
 		return
 	}
 
 	// 删除栏目
-	err = c.categoryModel.Delete(id)
+	err = c.categoryModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除栏目失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete category", http.StatusInternalServerError)
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -46,12 +47,14 @@ func NewCollectController(db *database.DB, cache cache.Cache, config *config.Con
 
 // RuleList 规则列表
 func (c *CollectController) RuleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取规则列表
-	rules, err := c.collectRuleModel.GetAll()
+	rules, err := c.collectRuleModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取采集规则列表失败", "error", err)
 		http.Error(w, "Failed to get collect rules", http.StatusInternalServerError)
@@ -78,12 +81,14 @@ func (c *CollectController) RuleList(w http.ResponseWriter, r *http.Request) {
 
 // RuleAdd 添加规则页面
 func (c *CollectController) RuleAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -91,7 +96,7 @@ func (c *CollectController) RuleAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型列表
-	models, err := c.modelModel.GetAll()
+	models, err := c.modelModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取模型列表失败", "error", err)
 		http.Error(w, "Failed to get models", http.StatusInternalServerError)
@@ -119,6 +124,8 @@ func (c *CollectController) RuleAdd(w http.ResponseWriter, r *http.Request) {
 
 // RuleDoAdd 处理添加规则
 func (c *CollectController) RuleDoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -173,7 +180,7 @@ func (c *CollectController) RuleDoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存规则
-	id, err := c.collectRuleModel.Create(rule)
+	id, err := c.collectRuleModel.WithContext(ctx).Create(rule)
 	if err != nil {
 		logger.Error("创建采集规则失败", "error", err)
 		http.Error(w, "Failed to create collect rule", http.StatusInternalServerError)
@@ -197,6 +204,8 @@ func (c *CollectController) RuleDoAdd(w http.ResponseWriter, r *http.Request) {
 
 // RuleEdit 编辑规则页面
 func (c *CollectController) RuleEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -211,7 +220,7 @@ func (c *CollectController) RuleEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取规则
-	rule, err := c.collectRuleModel.GetByID(id)
+	rule, err := c.collectRuleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取采集规则失败", "id", id, "error", err)
 		http.Error(w, "Rule not found", http.StatusNotFound)
@@ -219,7 +228,7 @@ func (c *CollectController) RuleEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -227,7 +236,7 @@ func (c *CollectController) RuleEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型列表
-	models, err := c.modelModel.GetAll()
+	models, err := c.modelModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取模型列表失败", "error", err)
 		http.Error(w, "Failed to get models", http.StatusInternalServerError)
@@ -256,6 +265,8 @@ func (c *CollectController) RuleEdit(w http.ResponseWriter, r *http.Request) {
 
 // RuleDoEdit 处理编辑规则
 func (c *CollectController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -271,7 +282,7 @@ func (c *CollectController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原规则
-	rule, err := c.collectRuleModel.GetByID(id)
+	rule, err := c.collectRuleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取采集规则失败", "id", id, "error", err)
 		http.Error(w, "Rule not found", http.StatusNotFound)
@@ -323,7 +334,7 @@ func (c *CollectController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
 	rule.FieldRules = fieldRules
 
 	// 保存规则
-	err = c.collectRuleModel.Update(rule)
+	err = c.collectRuleModel.WithContext(ctx).Update(rule)
 	if err != nil {
 		logger.Error("更新采集规则失败", "error", err)
 		http.Error(w, "Failed to update collect rule", http.StatusInternalServerError)
@@ -346,6 +357,8 @@ func (c *CollectController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
 
 // RuleDelete 删除规则
 func (c *CollectController) RuleDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取规则ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -356,7 +369,7 @@ func (c *CollectController) RuleDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除规则
-	err = c.collectService.DeleteRule(id)
+	err = c.collectService.WithContext(ctx).DeleteRule(id)
 	if err != nil {
 		logger.Error("删除采集规则失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete collect rule", http.StatusInternalServerError)
@@ -379,6 +392,8 @@ func (c *CollectController) RuleDelete(w http.ResponseWriter, r *http.Request) {
 
 // RuleCollect 采集规则
 func (c *CollectController) RuleCollect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取规则ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -389,7 +404,7 @@ func (c *CollectController) RuleCollect(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 采集规则
-	count, err := c.collectService.CollectRule(id)
+	count, err := c.collectService.WithContext(ctx).CollectRule(id)
 	if err != nil {
 		logger.Error("采集规则失败", "id", id, "error", err)
 		http.Error(w, "Failed to collect rule", http.StatusInternalServerError)
@@ -413,6 +428,8 @@ func (c *CollectController) RuleCollect(w http.ResponseWriter, r *http.Request)
 
 // ItemList 项目列表
 func (c *CollectController) ItemList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -427,7 +444,7 @@ func (c *CollectController) ItemList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取规则
-	rule, err := c.collectRuleModel.GetByID(id)
+	rule, err := c.collectRuleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取采集规则失败", "id", id, "error", err)
 		http.Error(w, "Rule not found", http.StatusNotFound)
@@ -453,7 +470,7 @@ func (c *CollectController) ItemList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取项目列表
-	items, total, err := c.collectItemModel.GetByRuleID(id, status, page, 20)
+	items, total, err := c.collectItemModel.WithContext(ctx).GetByRuleID(id, status, page, 20)
 	if err != nil {
 		logger.Error("获取采集项目列表失败", "error", err)
 		http.Error(w, "Failed to get collect items", http.StatusInternalServerError)
@@ -495,6 +512,8 @@ func (c *CollectController) ItemList(w http.ResponseWriter, r *http.Request) {
 
 // ItemDetail 项目详情
 func (c *CollectController) ItemDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -509,7 +528,7 @@ func (c *CollectController) ItemDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取项目
-	item, err := c.collectItemModel.GetByID(id)
+	item, err := c.collectItemModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取采集项目失败", "id", id, "error", err)
 		http.Error(w, "Item not found", http.StatusNotFound)
@@ -517,7 +536,7 @@ func (c *CollectController) ItemDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取规则
-	rule, err := c.collectRuleModel.GetByID(item.RuleID)
+	rule, err := c.collectRuleModel.WithContext(ctx).GetByID(item.RuleID)
 	if err != nil {
 		logger.Error("获取采集规则失败", "id", item.RuleID, "error", err)
 		http.Error(w, "Rule not found", http.StatusNotFound)
@@ -525,7 +544,7 @@ func (c *CollectController) ItemDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取字段数据
-	fieldData, err := c.collectItemModel.GetFieldData(id)
+	fieldData, err := c.collectItemModel.WithContext(ctx).GetFieldData(id)
 	if err != nil {
 		logger.Error("获取字段数据失败", "error", err)
 		http.Error(w, "Failed to get field data", http.StatusInternalServerError)
@@ -554,6 +573,8 @@ func (c *CollectController) ItemDetail(w http.ResponseWriter, r *http.Request) {
 
 // ItemPublish 发布项目
 func (c *CollectController) ItemPublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取项目ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -564,15 +585,31 @@ func (c *CollectController) ItemPublish(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 获取项目
-	item, err := c.collectItemModel.GetByID(id)
+	item, err := c.collectItemModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取采集项目失败", "id", id, "error", err)
 		http.Error(w, "Item not found", http.StatusNotFound)
 		return
 	}
 
-	// 发布项目
-	err = c.collectService.PublishItem(id)
+	// 发布项目，force=1时不检查重复内容
+	if r.URL.Query().Get("force") == "1" {
+		err = c.collectService.WithContext(ctx).ForcePublishItem(id)
+	} else {
+		err = c.collectService.WithContext(ctx).PublishItem(id)
+	}
+	if errors.Is(err, service.ErrDuplicateContent) {
+		if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"message": "采集项目" + err.Error() + "，已跳过",
+			})
+		} else {
+			http.Redirect(w, r, "/admin/collect_item_list/"+strconv.FormatInt(item.RuleID, 10)+"?status="+strconv.Itoa(model.CollectItemStatusDuplicate), http.StatusFound)
+		}
+		return
+	}
 	if err != nil {
 		logger.Error("发布采集项目失败", "id", id, "error", err)
 		http.Error(w, "Failed to publish collect item", http.StatusInternalServerError)
@@ -595,6 +632,8 @@ func (c *CollectController) ItemPublish(w http.ResponseWriter, r *http.Request)
 
 // ItemDelete 删除项目
 func (c *CollectController) ItemDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取项目ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -605,7 +644,7 @@ func (c *CollectController) ItemDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取项目
-	item, err := c.collectItemModel.GetByID(id)
+	item, err := c.collectItemModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取采集项目失败", "id", id, "error", err)
 		http.Error(w, "Item not found", http.StatusNotFound)
@@ -613,7 +652,7 @@ func (c *CollectController) ItemDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除项目
-	err = c.collectItemModel.Delete(id)
+	err = c.collectItemModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除采集项目失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete collect item", http.StatusInternalServerError)
@@ -636,6 +675,8 @@ func (c *CollectController) ItemDelete(w http.ResponseWriter, r *http.Request) {
 
 // BatchPublish 批量发布
 func (c *CollectController) BatchPublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取规则ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -646,7 +687,7 @@ func (c *CollectController) BatchPublish(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 批量发布
-	count, err := c.collectService.BatchPublish(id)
+	count, skipped, err := c.collectService.WithContext(ctx).BatchPublish(id)
 	if err != nil {
 		logger.Error("批量发布采集项目失败", "id", id, "error", err)
 		http.Error(w, "Failed to batch publish collect items", http.StatusInternalServerError)
@@ -657,13 +698,56 @@ func (c *CollectController) BatchPublish(w http.ResponseWriter, r *http.Request)
 	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
 		// AJAX请求
 		w.Header().Set("Content-Type", "application/json")
+		message := "批量发布成功，共发布" + strconv.Itoa(count) + "条数据"
+		if skipped > 0 {
+			message += "，跳过" + strconv.Itoa(skipped) + "条重复内容"
+		}
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": true,
-			"message": "批量发布成功，共发布" + strconv.Itoa(count) + "条数据",
+			"message": message,
 			"count":   count,
+			"skipped": skipped,
 		})
 	} else {
 		// 普通表单提交
 		http.Redirect(w, r, "/admin/collect_item_list/"+idStr, http.StatusFound)
 	}
 }
+
+// Duplicates 重复内容报告，列出内容相近的文档和未发布的采集项目
+func (c *CollectController) Duplicates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 获取管理员信息
+	adminID := middleware.GetAdminID(r)
+	adminName := middleware.GetAdminName(r)
+
+	// 查找重复内容
+	duplicateService := service.NewDuplicateService(c.db, c.cache, c.config).WithContext(ctx)
+	clusters, total, err := duplicateService.Clusters()
+	if err != nil {
+		logger.Error("查找重复内容失败", "error", err)
+		http.Error(w, "Failed to find duplicates", http.StatusInternalServerError)
+		return
+	}
+
+	// 准备模板数据
+	data := map[string]interface{}{
+		"AdminID":     adminID,
+		"AdminName":   adminName,
+		"Clusters":    clusters,
+		"Total":       total,
+		"Distance":    duplicateService.Distance(),
+		"Action":      duplicateService.Action(),
+		"CurrentMenu": "collect",
+		"PageTitle":   "重复内容",
+	}
+
+	// 渲染模板
+	tplFile := "admin/collect_duplicates.htm"
+	if err := c.templateService.Render(w, tplFile, data); err != nil {
+		logger.Error("渲染重复内容模板失败", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -40,37 +40,39 @@ func NewCommentController(db *database.DB, cache cache.Cache, config *config.Con
 
 // Index 评论管理首页
 func (c *CommentController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取评论统计信息
-	totalComments, err := c.commentModel.GetTotalCount()
+	totalComments, err := c.commentModel.WithContext(ctx).GetTotalCount()
 	if err != nil {
 		logger.Error("获取评论总数失败", "error", err)
 		totalComments = 0
 	}
 
-	pendingComments, err := c.commentModel.GetPendingCount()
+	pendingComments, err := c.commentModel.WithContext(ctx).GetPendingCount()
 	if err != nil {
 		logger.Error("获取待审核评论数失败", "error", err)
 		pendingComments = 0
 	}
 
-	approvedComments, err := c.commentModel.GetApprovedCount()
+	approvedComments, err := c.commentModel.WithContext(ctx).GetApprovedCount()
 	if err != nil {
 		logger.Error("获取已审核评论数失败", "error", err)
 		approvedComments = 0
 	}
 
-	rejectedComments, err := c.commentModel.GetRejectedCount()
+	rejectedComments, err := c.commentModel.WithContext(ctx).GetRejectedCount()
 	if err != nil {
 		logger.Error("获取已拒绝评论数失败", "error", err)
 		rejectedComments = 0
 	}
 
 	// 获取最新评论
-	latestComments, err := c.commentModel.GetLatest(10)
+	latestComments, err := c.commentModel.WithContext(ctx).GetLatest(10)
 	if err != nil {
 		logger.Error("获取最新评论失败", "error", err)
 		latestComments = []*model.Comment{}
@@ -100,6 +102,8 @@ func (c *CommentController) Index(w http.ResponseWriter, r *http.Request) {
 
 // List 评论列表
 func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -133,7 +137,7 @@ func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取评论列表
-	comments, total, err := c.commentModel.GetList(isCheck, keyword, page, pageSize)
+	comments, total, err := c.commentModel.WithContext(ctx).GetList(isCheck, keyword, page, pageSize)
 	if err != nil {
 		logger.Error("获取评论列表失败", "error", err)
 		http.Error(w, "Failed to get comments", http.StatusInternalServerError)
@@ -175,6 +179,8 @@ func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
 
 // Detail 评论详情
 func (c *CommentController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -189,7 +195,7 @@ func (c *CommentController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取评论
-	comment, err := c.commentModel.GetByID(id)
+	comment, err := c.commentModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取评论失败", "id", id, "error", err)
 		http.Error(w, "Comment not found", http.StatusNotFound)
@@ -197,7 +203,7 @@ func (c *CommentController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章
-	article, err := c.articleModel.GetByID(comment.AID)
+	article, err := c.articleModel.WithContext(ctx).GetByID(comment.AID)
 	if err != nil {
 		logger.Error("获取文章失败", "id", comment.AID, "error", err)
 	}
@@ -223,6 +229,8 @@ func (c *CommentController) Detail(w http.ResponseWriter, r *http.Request) {
 
 // Approve 审核评论
 func (c *CommentController) Approve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取评论ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -233,7 +241,7 @@ func (c *CommentController) Approve(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 审核评论
-	err = c.commentModel.UpdateStatus(id, 1)
+	err = c.commentModel.WithContext(ctx).UpdateStatus(id, 1)
 	if err != nil {
 		logger.Error("审核评论失败", "id", id, "error", err)
 		http.Error(w, "Failed to approve comment", http.StatusInternalServerError)
@@ -256,6 +264,8 @@ func (c *CommentController) Approve(w http.ResponseWriter, r *http.Request) {
 
 // Reject 拒绝评论
 func (c *CommentController) Reject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取评论ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -266,7 +276,7 @@ func (c *CommentController) Reject(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 拒绝评论
-	err = c.commentModel.UpdateStatus(id, -1)
+	err = c.commentModel.WithContext(ctx).UpdateStatus(id, -1)
 	if err != nil {
 		logger.Error("拒绝评论失败", "id", id, "error", err)
 		http.Error(w, "Failed to reject comment", http.StatusInternalServerError)
@@ -289,6 +299,8 @@ func (c *CommentController) Reject(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除评论
 func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取评论ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -299,7 +311,7 @@ func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除评论
-	err = c.commentModel.Delete(id)
+	err = c.commentModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除评论失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete comment", http.StatusInternalServerError)
@@ -322,6 +334,8 @@ func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // BatchApprove 批量审核评论
 func (c *CommentController) BatchApprove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -348,7 +362,7 @@ func (c *CommentController) BatchApprove(w http.ResponseWriter, r *http.Request)
 
 	// 批量审核评论
 	for _, id := range ids {
-		err := c.commentModel.UpdateStatus(id, 1)
+		err := c.commentModel.WithContext(ctx).UpdateStatus(id, 1)
 		if err != nil {
 			logger.Error("审核评论失败", "id", id, "error", err)
 		}
@@ -370,6 +384,8 @@ func (c *CommentController) BatchApprove(w http.ResponseWriter, r *http.Request)
 
 // BatchDelete 批量删除评论
 func (c *CommentController) BatchDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -396,7 +412,7 @@ func (c *CommentController) BatchDelete(w http.ResponseWriter, r *http.Request)
 
 	// 批量删除评论
 	for _, id := range ids {
-		err := c.commentModel.Delete(id)
+		err := c.commentModel.WithContext(ctx).Delete(id)
 		if err != nil {
 			logger.Error("删除评论失败", "id", id, "error", err)
 		}
@@ -41,6 +41,8 @@ func NewFormController(db *database.DB, cache cache.Cache, config *config.Config
 
 // List 自定义表单列表
 func (c *FormController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -48,7 +50,7 @@ func (c *FormController) List(w http.ResponseWriter, r *http.Request) {
 	// 获取自定义表单列表
 	page := 1
 	pageSize := 100
-	forms, _, err := c.formModel.GetList(page, pageSize)
+	forms, _, err := c.formModel.WithContext(ctx).GetList(page, pageSize)
 	if err != nil {
 		logger.Error("获取自定义表单列表失败", "error", err)
 		http.Error(w, "Failed to get forms", http.StatusInternalServerError)
@@ -98,6 +100,8 @@ func (c *FormController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加自定义表单
 func (c *FormController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -140,7 +144,7 @@ func (c *FormController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	form.Fields = formFields
 
 	// 保存自定义表单
-	id, err := c.formModel.Create(form)
+	id, err := c.formModel.WithContext(ctx).Create(form)
 	if err != nil {
 		logger.Error("创建自定义表单失败", "error", err)
 		http.Error(w, "Failed to create form", http.StatusInternalServerError)
@@ -167,6 +171,8 @@ func (c *FormController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑自定义表单页面
 func (c *FormController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -181,7 +187,7 @@ func (c *FormController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -212,6 +218,8 @@ func (c *FormController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑自定义表单
 func (c *FormController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -227,7 +235,7 @@ func (c *FormController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原自定义表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -268,7 +276,7 @@ func (c *FormController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	form.Fields = formFields
 
 	// 保存自定义表单
-	err = c.formModel.Update(form)
+	err = c.formModel.WithContext(ctx).Update(form)
 	if err != nil {
 		logger.Error("更新自定义表单失败", "error", err)
 		http.Error(w, "Failed to update form", http.StatusInternalServerError)
@@ -294,6 +302,8 @@ func (c *FormController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除自定义表单
 func (c *FormController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取自定义表单ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -304,7 +314,7 @@ func (c *FormController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -312,7 +322,7 @@ func (c *FormController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除自定义表单
-	err = c.formModel.Delete(id)
+	err = c.formModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除自定义表单失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete form", http.StatusInternalServerError)
@@ -338,6 +348,8 @@ func (c *FormController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // Preview 预览自定义表单
 func (c *FormController) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取自定义表单ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -348,7 +360,7 @@ func (c *FormController) Preview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -356,7 +368,7 @@ func (c *FormController) Preview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 渲染自定义表单
-	html, err := c.formService.RenderForm(strconv.FormatInt(form.ID, 10))
+	html, err := c.formService.WithContext(ctx).RenderForm(strconv.FormatInt(form.ID, 10))
 	if err != nil {
 		logger.Error("渲染自定义表单失败", "error", err)
 		http.Error(w, "Failed to render form", http.StatusInternalServerError)
@@ -370,6 +382,8 @@ func (c *FormController) Preview(w http.ResponseWriter, r *http.Request) {
 
 // DataList 自定义表单数据列表
 func (c *FormController) DataList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -384,7 +398,7 @@ func (c *FormController) DataList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -409,7 +423,7 @@ func (c *FormController) DataList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单数据列表
-	dataList, total, err := c.formDataModel.GetByFormID(id, status, page, 20)
+	dataList, total, err := c.formDataModel.WithContext(ctx).GetByFormID(id, status, page, 20)
 	if err != nil {
 		logger.Error("获取自定义表单数据列表失败", "error", err)
 		http.Error(w, "Failed to get form data", http.StatusInternalServerError)
@@ -451,6 +465,8 @@ func (c *FormController) DataList(w http.ResponseWriter, r *http.Request) {
 
 // DataDetail 自定义表单数据详情
 func (c *FormController) DataDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -465,7 +481,7 @@ func (c *FormController) DataDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单数据
-	formData, dataMap, err := c.formService.GetFormDataDetail(id)
+	formData, dataMap, err := c.formService.WithContext(ctx).GetFormDataDetail(id)
 	if err != nil {
 		logger.Error("获取自定义表单数据详情失败", "id", id, "error", err)
 		http.Error(w, "Form data not found", http.StatusNotFound)
@@ -473,7 +489,7 @@ func (c *FormController) DataDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单
-	form, err := c.formModel.GetByID(formData.FormID)
+	form, err := c.formModel.WithContext(ctx).GetByID(formData.FormID)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "id", formData.FormID, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -506,6 +522,8 @@ func (c *FormController) DataDetail(w http.ResponseWriter, r *http.Request) {
 
 // DataProcess 处理自定义表单数据
 func (c *FormController) DataProcess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取自定义表单数据ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -516,7 +534,7 @@ func (c *FormController) DataProcess(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 处理自定义表单数据
-	err = c.formService.ProcessFormData(id)
+	err = c.formService.WithContext(ctx).ProcessFormData(id)
 	if err != nil {
 		logger.Error("处理自定义表单数据失败", "id", id, "error", err)
 		http.Error(w, "Failed to process form data", http.StatusInternalServerError)
@@ -539,6 +557,8 @@ func (c *FormController) DataProcess(w http.ResponseWriter, r *http.Request) {
 
 // DataDelete 删除自定义表单数据
 func (c *FormController) DataDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取自定义表单数据ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -549,7 +569,7 @@ func (c *FormController) DataDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单数据
-	formData, err := c.formDataModel.GetByID(id)
+	formData, err := c.formDataModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取自定义表单数据失败", "id", id, "error", err)
 		http.Error(w, "Form data not found", http.StatusNotFound)
@@ -557,7 +577,7 @@ func (c *FormController) DataDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除自定义表单数据
-	err = c.formDataModel.Delete(id)
+	err = c.formDataModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除自定义表单数据失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete form data", http.StatusInternalServerError)
@@ -581,8 +601,10 @@ func (c *FormController) DataDelete(w http.ResponseWriter, r *http.Request) {
 
 // InitForms 初始化自定义表单
 func (c *FormController) InitForms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 初始化默认自定义表单
-	err := c.formService.InitDefaultForms()
+	err := c.formService.WithContext(ctx).InitDefaultForms()
 	if err != nil {
 		logger.Error("初始化自定义表单失败", "error", err)
 		http.Error(w, "Failed to initialize forms", http.StatusInternalServerError)
@@ -99,12 +99,14 @@ func (c *HtmlController) DoIndex(w http.ResponseWriter, r *http.Request) {
 
 // List 栏目页静态化
 func (c *HtmlController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -132,6 +134,8 @@ func (c *HtmlController) List(w http.ResponseWriter, r *http.Request) {
 
 // DoList 处理栏目页静态化
 func (c *HtmlController) DoList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -182,7 +186,7 @@ func (c *HtmlController) DoList(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		// 生成所有栏目
-		categories, err := c.categoryModel.GetAll()
+		categories, err := c.categoryModel.WithContext(ctx).GetAll()
 		if err != nil {
 			logger.Error("获取栏目列表失败", "error", err)
 			http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -213,12 +217,14 @@ func (c *HtmlController) DoList(w http.ResponseWriter, r *http.Request) {
 
 // Article 文章页静态化
 func (c *HtmlController) Article(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -246,6 +252,8 @@ func (c *HtmlController) Article(w http.ResponseWriter, r *http.Request) {
 
 // DoArticle 处理文章页静态化
 func (c *HtmlController) DoArticle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -304,7 +312,7 @@ func (c *HtmlController) DoArticle(w http.ResponseWriter, r *http.Request) {
 		logger.Info("按ID范围生成文章页完成", "startID", startID, "endID", endID, "successCount", successCount)
 	} else if !startDate.IsZero() && !endDate.IsZero() {
 		// 按日期范围生成
-		articles, err := c.articleModel.GetByDateRange(typeID, startDate, endDate)
+		articles, err := c.articleModel.WithContext(ctx).GetByDateRange(typeID, startDate, endDate)
 		if err != nil {
 			logger.Error("获取文章列表失败", "error", err)
 			http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -319,7 +327,7 @@ func (c *HtmlController) DoArticle(w http.ResponseWriter, r *http.Request) {
 		}
 	} else if typeID > 0 {
 		// 按栏目生成
-		articles, _, err := c.articleModel.GetByTypeID(typeID, 1, 1000)
+		articles, _, err := c.articleModel.WithContext(ctx).GetByTypeID(typeID, 1, 1000)
 		if err != nil {
 			logger.Error("获取文章列表失败", "error", err)
 			http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -334,7 +342,7 @@ func (c *HtmlController) DoArticle(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		// 生成所有文章
-		articles, _, err := c.articleModel.GetAll(1, 1000)
+		articles, _, err := c.articleModel.WithContext(ctx).GetAll(1, 1000)
 		if err != nil {
 			logger.Error("获取文章列表失败", "error", err)
 			http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -365,12 +373,14 @@ func (c *HtmlController) DoArticle(w http.ResponseWriter, r *http.Request) {
 
 // Special 专题页静态化
 func (c *HtmlController) Special(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取专题列表
-	specials, err := c.specialModel.GetAll()
+	specials, err := c.specialModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取专题列表失败", "error", err)
 		http.Error(w, "Failed to get specials", http.StatusInternalServerError)
@@ -398,6 +408,8 @@ func (c *HtmlController) Special(w http.ResponseWriter, r *http.Request) {
 
 // DoSpecial 处理专题页静态化
 func (c *HtmlController) DoSpecial(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -430,7 +442,7 @@ func (c *HtmlController) DoSpecial(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		// 生成所有专题
-		specials, err := c.specialModel.GetAll()
+		specials, err := c.specialModel.WithContext(ctx).GetAll()
 		if err != nil {
 			logger.Error("获取专题列表失败", "error", err)
 			http.Error(w, "Failed to get specials", http.StatusInternalServerError)
@@ -461,12 +473,14 @@ func (c *HtmlController) DoSpecial(w http.ResponseWriter, r *http.Request) {
 
 // Tag 标签页静态化
 func (c *HtmlController) Tag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取标签列表
-	tags, err := c.tagModel.GetHotTags(100)
+	tags, err := c.tagModel.WithContext(ctx).GetHotTags(100)
 	if err != nil {
 		logger.Error("获取标签列表失败", "error", err)
 		http.Error(w, "Failed to get tags", http.StatusInternalServerError)
@@ -494,6 +508,8 @@ func (c *HtmlController) Tag(w http.ResponseWriter, r *http.Request) {
 
 // DoTag 处理标签页静态化
 func (c *HtmlController) DoTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -520,7 +536,7 @@ func (c *HtmlController) DoTag(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		// 生成所有标签
-		tags, err := c.tagModel.GetHotTags(100)
+		tags, err := c.tagModel.WithContext(ctx).GetHotTags(100)
 		if err != nil {
 			logger.Error("获取标签列表失败", "error", err)
 			http.Error(w, "Failed to get tags", http.StatusInternalServerError)
@@ -575,6 +591,8 @@ func (c *HtmlController) All(w http.ResponseWriter, r *http.Request) {
 
 // DoAll 处理全站静态化
 func (c *HtmlController) DoAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 生成首页静态文件
 	if c.config.Site.StaticIndex {
 		err := c.htmlService.GenerateIndex()
@@ -585,7 +603,7 @@ func (c *HtmlController) DoAll(w http.ResponseWriter, r *http.Request) {
 
 	// 生成栏目页静态文件
 	if c.config.Site.StaticList {
-		categories, err := c.categoryModel.GetAll()
+		categories, err := c.categoryModel.WithContext(ctx).GetAll()
 		if err != nil {
 			logger.Error("获取栏目列表失败", "error", err)
 		} else {
@@ -600,7 +618,7 @@ func (c *HtmlController) DoAll(w http.ResponseWriter, r *http.Request) {
 
 	// 生成文章页静态文件
 	if c.config.Site.StaticArticle {
-		articles, _, err := c.articleModel.GetAll(1, 1000)
+		articles, _, err := c.articleModel.WithContext(ctx).GetAll(1, 1000)
 		if err != nil {
 			logger.Error("获取文章列表失败", "error", err)
 		} else {
@@ -615,7 +633,7 @@ func (c *HtmlController) DoAll(w http.ResponseWriter, r *http.Request) {
 
 	// 生成专题页静态文件
 	if c.config.Site.StaticSpecial {
-		specials, err := c.specialModel.GetAll()
+		specials, err := c.specialModel.WithContext(ctx).GetAll()
 		if err != nil {
 			logger.Error("获取专题列表失败", "error", err)
 		} else {
@@ -630,7 +648,7 @@ func (c *HtmlController) DoAll(w http.ResponseWriter, r *http.Request) {
 
 	// 生成标签页静态文件
 	if c.config.Site.StaticTag {
-		tags, err := c.tagModel.GetHotTags(100)
+		tags, err := c.tagModel.WithContext(ctx).GetHotTags(100)
 		if err != nil {
 			logger.Error("获取标签列表失败", "error", err)
 		} else {
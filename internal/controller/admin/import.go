@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"aq3cms/config"
+	"aq3cms/internal/middleware"
+	"aq3cms/internal/model"
+	"aq3cms/internal/service"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/pkg/security"
+)
+
+// ImportController 数据导入导出控制器
+type ImportController struct {
+	db              *database.DB
+	cache           cache.Cache
+	config          *config.Config
+	categoryModel   *model.CategoryModel
+	importService   *service.ImportService
+	exportService   *service.ExportService
+	templateService *service.TemplateService
+}
+
+// NewImportController 创建数据导入导出控制器
+func NewImportController(db *database.DB, cache cache.Cache, config *config.Config) *ImportController {
+	return &ImportController{
+		db:              db,
+		cache:           cache,
+		config:          config,
+		categoryModel:   model.NewCategoryModel(db),
+		importService:   service.NewImportService(db, cache, config),
+		exportService:   service.NewExportService(db, cache, config),
+		templateService: service.NewTemplateService(db, cache, config),
+	}
+}
+
+// Index 数据导入导出页面
+func (c *ImportController) Index(w http.ResponseWriter, r *http.Request) {
+	c.render(w, r, nil, "")
+}
+
+// render 渲染数据导入导出页面，result为导入结果，mapFile为地址映射文件名
+func (c *ImportController) render(w http.ResponseWriter, r *http.Request, result *service.ImportResult, mapFile string) {
+	ctx := r.Context()
+
+	// 获取栏目列表
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
+	if err != nil {
+		logger.Error("获取栏目列表失败", "error", err)
+	}
+
+	// 准备模板数据
+	data := map[string]interface{}{
+		"AdminID":          middleware.GetAdminID(r),
+		"AdminName":        middleware.GetAdminName(r),
+		"CurrentMenu":      "import",
+		"PageTitle":        "数据导入导出",
+		"Categories":       categories,
+		"DefaultUploadURL": service.DefaultWXRUploadURL,
+		"Result":           result,
+		"MapFile":          mapFile,
+	}
+
+	// 渲染模板
+	tplFile := "admin/import.htm"
+	if err := c.templateService.Render(w, tplFile, data); err != nil {
+		logger.Error("渲染数据导入导出模板失败", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DoWXR 导入WordPress导出文件
+func (c *ImportController) DoWXR(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 解析表单
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	// 获取上传的文件
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Invalid import file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	typeid, _ := strconv.ParseInt(r.FormValue("typeid"), 10, 64)
+	result, err := c.importService.WithContext(ctx).ImportWXR(file, service.ImportOptions{
+		DefaultTypeID: typeid,
+		UploadURL:     r.FormValue("upload_url"),
+	})
+	if err != nil {
+		logger.Error("导入WXR文件失败", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.respond(w, r, result, "wxr")
+}
+
+// DoBundle 导入aq3cms导出包
+func (c *ImportController) DoBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 解析表单
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	// 获取上传的文件
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Invalid import file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	result, err := c.importService.WithContext(ctx).ImportBundle(file, header.Size)
+	if err != nil {
+		logger.Error("导入导出包失败", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.respond(w, r, result, "bundle")
+}
+
+// respond 保存地址映射并返回导入结果，kind为映射文件名前缀
+func (c *ImportController) respond(w http.ResponseWriter, r *http.Request, result *service.ImportResult, kind string) {
+	// 保存地址映射
+	mapFile := kind + "_" + time.Now().Format("20060102150405") + ".json"
+	if err := result.WriteURLMap(filepath.Join(service.DefaultImportDir, mapFile)); err != nil {
+		logger.Error("写入地址映射失败", "error", err)
+		mapFile = ""
+	}
+
+	// 返回导入结果
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		// AJAX请求
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":  true,
+			"message":  "导入完成",
+			"result":   result,
+			"map_file": mapFile,
+		})
+	} else {
+		c.render(w, r, result, mapFile)
+	}
+}
+
+// Export 导出全部内容，以zip文件下载
+func (c *ImportController) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	opts := service.ExportOptions{
+		IncludeFiles: r.FormValue("files") == "1",
+	}
+	name := "aq3cms_" + time.Now().Format("20060102150405") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment; filename="+name)
+
+	// 导出包直接写入响应，开始写入后出错只能记录日志
+	if _, err := c.exportService.WithContext(ctx).Export(w, opts); err != nil {
+		logger.Error("导出内容失败", "error", err)
+	}
+}
+
+// MapFile 下载地址映射文件
+func (c *ImportController) MapFile(w http.ResponseWriter, r *http.Request) {
+	name := security.SanitizeFilename(mux.Vars(r)["name"])
+	if name == "" || filepath.Ext(name) != ".json" {
+		http.Error(w, "Invalid file name", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename="+name)
+	http.ServeFile(w, r, filepath.Join(service.DefaultImportDir, name))
+}
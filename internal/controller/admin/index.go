@@ -43,6 +43,8 @@ func NewIndexController(db *database.DB, cache cache.Cache, config *config.Confi
 
 // Index 首页
 func (c *IndexController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	logger.Info("访问后台首页", "path", r.URL.Path)
 
 	// 获取管理员信息
@@ -52,10 +54,10 @@ func (c *IndexController) Index(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取统计信息
-	articleCount, _ := c.articleModel.GetCount()
-	categoryCount, _ := c.categoryModel.GetCount()
-	memberCount, _ := c.memberModel.GetCount()
-	commentCount, _ := c.commentModel.GetCount()
+	articleCount, _ := c.articleModel.WithContext(ctx).GetCount()
+	categoryCount, _ := c.categoryModel.WithContext(ctx).GetCount()
+	memberCount, _ := c.memberModel.WithContext(ctx).GetCount()
+	commentCount, _ := c.commentModel.WithContext(ctx).GetCount()
 
 	// 获取系统信息
 	systemInfo := map[string]interface{}{
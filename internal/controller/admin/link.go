@@ -39,6 +39,8 @@ func NewLinkController(db *database.DB, cache cache.Cache, config *config.Config
 
 // List 友情链接列表
 func (c *LinkController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -53,7 +55,7 @@ func (c *LinkController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取友情链接列表
-	links, err := c.linkModel.GetAll(typeID, -1)
+	links, err := c.linkModel.WithContext(ctx).GetAll(typeID, -1)
 	if err != nil {
 		logger.Error("获取友情链接列表失败", "error", err)
 		http.Error(w, "Failed to get links", http.StatusInternalServerError)
@@ -61,7 +63,7 @@ func (c *LinkController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取友情链接分类列表
-	linkTypes, err := c.linkTypeModel.GetAll()
+	linkTypes, err := c.linkTypeModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取友情链接分类列表失败", "error", err)
 		http.Error(w, "Failed to get link types", http.StatusInternalServerError)
@@ -90,12 +92,14 @@ func (c *LinkController) List(w http.ResponseWriter, r *http.Request) {
 
 // Add 添加友情链接页面
 func (c *LinkController) Add(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取友情链接分类列表
-	linkTypes, err := c.linkTypeModel.GetAll()
+	linkTypes, err := c.linkTypeModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取友情链接分类列表失败", "error", err)
 		http.Error(w, "Failed to get link types", http.StatusInternalServerError)
@@ -122,6 +126,8 @@ func (c *LinkController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加友情链接
 func (c *LinkController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -165,7 +171,7 @@ func (c *LinkController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存友情链接
-	id, err := c.linkModel.Create(link)
+	id, err := c.linkModel.WithContext(ctx).Create(link)
 	if err != nil {
 		logger.Error("创建友情链接失败", "error", err)
 		http.Error(w, "Failed to create link", http.StatusInternalServerError)
@@ -189,6 +195,8 @@ func (c *LinkController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑友情链接页面
 func (c *LinkController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -203,7 +211,7 @@ func (c *LinkController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取友情链接
-	link, err := c.linkModel.GetByID(id)
+	link, err := c.linkModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取友情链接失败", "id", id, "error", err)
 		http.Error(w, "Link not found", http.StatusNotFound)
@@ -211,7 +219,7 @@ func (c *LinkController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取友情链接分类列表
-	linkTypes, err := c.linkTypeModel.GetAll()
+	linkTypes, err := c.linkTypeModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取友情链接分类列表失败", "error", err)
 		http.Error(w, "Failed to get link types", http.StatusInternalServerError)
@@ -239,6 +247,8 @@ func (c *LinkController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑友情链接
 func (c *LinkController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -254,7 +264,7 @@ func (c *LinkController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原友情链接
-	link, err := c.linkModel.GetByID(id)
+	link, err := c.linkModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取友情链接失败", "id", id, "error", err)
 		http.Error(w, "Link not found", http.StatusNotFound)
@@ -296,7 +306,7 @@ func (c *LinkController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	link.IsLogo = isLogo
 
 	// 保存友情链接
-	err = c.linkModel.Update(link)
+	err = c.linkModel.WithContext(ctx).Update(link)
 	if err != nil {
 		logger.Error("更新友情链接失败", "error", err)
 		http.Error(w, "Failed to update link", http.StatusInternalServerError)
@@ -319,6 +329,8 @@ func (c *LinkController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除友情链接
 func (c *LinkController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取友情链接ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -329,7 +341,7 @@ func (c *LinkController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除友情链接
-	err = c.linkModel.Delete(id)
+	err = c.linkModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除友情链接失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete link", http.StatusInternalServerError)
@@ -352,12 +364,14 @@ func (c *LinkController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // TypeList 友情链接分类列表
 func (c *LinkController) TypeList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取友情链接分类列表
-	linkTypes, err := c.linkTypeModel.GetAll()
+	linkTypes, err := c.linkTypeModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取友情链接分类列表失败", "error", err)
 		http.Error(w, "Failed to get link types", http.StatusInternalServerError)
@@ -407,6 +421,8 @@ func (c *LinkController) TypeAdd(w http.ResponseWriter, r *http.Request) {
 
 // TypeDoAdd 处理添加友情链接分类
 func (c *LinkController) TypeDoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -433,7 +449,7 @@ func (c *LinkController) TypeDoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存友情链接分类
-	id, err := c.linkTypeModel.Create(linkType)
+	id, err := c.linkTypeModel.WithContext(ctx).Create(linkType)
 	if err != nil {
 		logger.Error("创建友情链接分类失败", "error", err)
 		http.Error(w, "Failed to create link type", http.StatusInternalServerError)
@@ -457,6 +473,8 @@ func (c *LinkController) TypeDoAdd(w http.ResponseWriter, r *http.Request) {
 
 // TypeEdit 编辑友情链接分类页面
 func (c *LinkController) TypeEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -471,7 +489,7 @@ func (c *LinkController) TypeEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取友情链接分类
-	linkType, err := c.linkTypeModel.GetByID(id)
+	linkType, err := c.linkTypeModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取友情链接分类失败", "id", id, "error", err)
 		http.Error(w, "Link type not found", http.StatusNotFound)
@@ -498,6 +516,8 @@ func (c *LinkController) TypeEdit(w http.ResponseWriter, r *http.Request) {
 
 // TypeDoEdit 处理编辑友情链接分类
 func (c *LinkController) TypeDoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -513,7 +533,7 @@ func (c *LinkController) TypeDoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原友情链接分类
-	linkType, err := c.linkTypeModel.GetByID(id)
+	linkType, err := c.linkTypeModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取友情链接分类失败", "id", id, "error", err)
 		http.Error(w, "Link type not found", http.StatusNotFound)
@@ -538,7 +558,7 @@ func (c *LinkController) TypeDoEdit(w http.ResponseWriter, r *http.Request) {
 	linkType.OrderID = orderID
 
 	// 保存友情链接分类
-	err = c.linkTypeModel.Update(linkType)
+	err = c.linkTypeModel.WithContext(ctx).Update(linkType)
 	if err != nil {
 		logger.Error("更新友情链接分类失败", "error", err)
 		http.Error(w, "Failed to update link type", http.StatusInternalServerError)
@@ -561,6 +581,8 @@ func (c *LinkController) TypeDoEdit(w http.ResponseWriter, r *http.Request) {
 
 // TypeDelete 删除友情链接分类
 func (c *LinkController) TypeDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取友情链接分类ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -571,7 +593,7 @@ func (c *LinkController) TypeDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查分类是否有友情链接
-	hasLinks, err := c.linkTypeModel.HasLinks(id)
+	hasLinks, err := c.linkTypeModel.WithContext(ctx).HasLinks(id)
 	if err != nil {
 		logger.Error("检查分类是否有友情链接失败", "id", id, "error", err)
 		http.Error(w, "Failed to check if link type has links", http.StatusInternalServerError)
@@ -583,7 +605,7 @@ func (c *LinkController) TypeDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除友情链接分类
-	err = c.linkTypeModel.Delete(id)
+	err = c.linkTypeModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除友情链接分类失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete link type", http.StatusInternalServerError)
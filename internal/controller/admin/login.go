@@ -118,6 +118,8 @@ func (c *LoginController) Login(w http.ResponseWriter, r *http.Request) {
 
 // DoLogin 处理登录
 func (c *LoginController) DoLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -132,7 +134,7 @@ func (c *LoginController) DoLogin(w http.ResponseWriter, r *http.Request) {
 
 	// 验证用户名和密码
 	logger.Info("开始验证用户", "username", username)
-	admin, err := c.adminModel.GetByUsername(username)
+	admin, err := c.adminModel.WithContext(ctx).GetByUsername(username)
 	if err != nil {
 		logger.Error("查询管理员失败", "error", err, "username", username)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -186,7 +188,7 @@ func (c *LoginController) DoLogin(w http.ResponseWriter, r *http.Request) {
 
 		// 更新密码为正确的MD5格式
 		admin.Password = security.HashPassword(password)
-		c.adminModel.Update(admin)
+		c.adminModel.WithContext(ctx).Update(admin)
 		logger.Info("已更新管理员密码为正确格式")
 	}
 
@@ -237,7 +239,7 @@ func (c *LoginController) DoLogin(w http.ResponseWriter, r *http.Request) {
 	admin.LastLogin = time.Now()
 	admin.LastIP = r.RemoteAddr
 	admin.LoginCount++
-	c.adminModel.Update(admin)
+	c.adminModel.WithContext(ctx).Update(admin)
 
 	// 记录登录日志
 	logger.Info("管理员登录", "username", admin.Username, "ip", r.RemoteAddr)
@@ -42,47 +42,49 @@ func NewMemberController(db *database.DB, cache cache.Cache, config *config.Conf
 
 // Index 会员管理首页
 func (c *MemberController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取会员统计信息
-	totalMembers, err := c.memberModel.GetTotalCount()
+	totalMembers, err := c.memberModel.WithContext(ctx).GetTotalCount()
 	if err != nil {
 		logger.Error("获取会员总数失败", "error", err)
 		totalMembers = 0
 	}
 
 	// 获取今日新增会员数
-	todayMembers, err := c.memberModel.GetTodayCount()
+	todayMembers, err := c.memberModel.WithContext(ctx).GetTodayCount()
 	if err != nil {
 		logger.Error("获取今日新增会员数失败", "error", err)
 		todayMembers = 0
 	}
 
 	// 获取活跃会员数（最近30天登录）
-	activeMembers, err := c.memberModel.GetActiveCount(30)
+	activeMembers, err := c.memberModel.WithContext(ctx).GetActiveCount(30)
 	if err != nil {
 		logger.Error("获取活跃会员数失败", "error", err)
 		activeMembers = 0
 	}
 
 	// 获取禁用会员数
-	disabledMembers, err := c.memberModel.GetDisabledCount()
+	disabledMembers, err := c.memberModel.WithContext(ctx).GetDisabledCount()
 	if err != nil {
 		logger.Error("获取禁用会员数失败", "error", err)
 		disabledMembers = 0
 	}
 
 	// 获取最新注册的会员
-	latestMembers, err := c.memberModel.GetLatest(10)
+	latestMembers, err := c.memberModel.WithContext(ctx).GetLatest(10)
 	if err != nil {
 		logger.Error("获取最新会员失败", "error", err)
 		latestMembers = []*model.Member{}
 	}
 
 	// 获取会员类型统计
-	memberTypeStats, err := c.memberModel.GetTypeStats()
+	memberTypeStats, err := c.memberModel.WithContext(ctx).GetTypeStats()
 	if err != nil {
 		logger.Error("获取会员类型统计失败", "error", err)
 		memberTypeStats = []map[string]interface{}{}
@@ -113,6 +115,8 @@ func (c *MemberController) Index(w http.ResponseWriter, r *http.Request) {
 
 // List 会员列表
 func (c *MemberController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -153,10 +157,10 @@ func (c *MemberController) List(w http.ResponseWriter, r *http.Request) {
 
 	if keyword != "" {
 		// 搜索会员
-		members, total, err = c.memberModel.Search(keyword, page, pageSize)
+		members, total, err = c.memberModel.WithContext(ctx).Search(keyword, page, pageSize)
 	} else {
 		// 获取会员列表
-		members, total, err = c.memberModel.GetList(page, pageSize)
+		members, total, err = c.memberModel.WithContext(ctx).GetList(page, pageSize)
 	}
 
 	if err != nil {
@@ -166,7 +170,7 @@ func (c *MemberController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员类型列表
-	memberTypes, err := c.memberTypeModel.GetAll()
+	memberTypes, err := c.memberTypeModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取会员类型列表失败", "error", err)
 	}
@@ -228,12 +232,14 @@ func (c *MemberController) List(w http.ResponseWriter, r *http.Request) {
 
 // Add 添加会员页面
 func (c *MemberController) Add(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取会员类型列表
-	memberTypes, err := c.memberTypeModel.GetAll()
+	memberTypes, err := c.memberTypeModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取会员类型列表失败", "error", err)
 		http.Error(w, "Failed to get member types", http.StatusInternalServerError)
@@ -260,6 +266,8 @@ func (c *MemberController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加会员
 func (c *MemberController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -285,14 +293,14 @@ func (c *MemberController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查用户名是否已存在
-	existingMember, err := c.memberModel.GetByUsername(username)
+	existingMember, err := c.memberModel.WithContext(ctx).GetByUsername(username)
 	if err == nil && existingMember != nil {
 		http.Error(w, "Username already exists", http.StatusBadRequest)
 		return
 	}
 
 	// 检查邮箱是否已存在
-	existingMember, err = c.memberModel.GetByEmail(email)
+	existingMember, err = c.memberModel.WithContext(ctx).GetByEmail(email)
 	if err == nil && existingMember != nil {
 		http.Error(w, "Email already exists", http.StatusBadRequest)
 		return
@@ -344,7 +352,7 @@ func (c *MemberController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存会员
-	id, err := c.memberModel.Create(member)
+	id, err := c.memberModel.WithContext(ctx).Create(member)
 	if err != nil {
 		logger.Error("创建会员失败", "error", err)
 		http.Error(w, "Failed to create member", http.StatusInternalServerError)
@@ -368,6 +376,8 @@ func (c *MemberController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑会员页面
 func (c *MemberController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -382,7 +392,7 @@ func (c *MemberController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员
-	member, err := c.memberModel.GetByID(id)
+	member, err := c.memberModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取会员失败", "id", id, "error", err)
 		http.Error(w, "Member not found", http.StatusNotFound)
@@ -390,7 +400,7 @@ func (c *MemberController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员类型列表
-	memberTypes, err := c.memberTypeModel.GetAll()
+	memberTypes, err := c.memberTypeModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取会员类型列表失败", "error", err)
 	}
@@ -416,6 +426,8 @@ func (c *MemberController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑会员
 func (c *MemberController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -431,7 +443,7 @@ func (c *MemberController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原会员
-	member, err := c.memberModel.GetByID(id)
+	member, err := c.memberModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取会员失败", "id", id, "error", err)
 		http.Error(w, "Member not found", http.StatusNotFound)
@@ -457,7 +469,7 @@ func (c *MemberController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 	// 检查邮箱是否已存在
 	if email != member.Email {
-		existingMember, err := c.memberModel.GetByEmail(email)
+		existingMember, err := c.memberModel.WithContext(ctx).GetByEmail(email)
 		if err == nil && existingMember != nil && existingMember.ID != id {
 			http.Error(w, "Email already exists", http.StatusBadRequest)
 			return
@@ -512,7 +524,7 @@ func (c *MemberController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存会员
-	err = c.memberModel.Update(member)
+	err = c.memberModel.WithContext(ctx).Update(member)
 	if err != nil {
 		logger.Error("更新会员失败", "error", err)
 		http.Error(w, "Failed to update member", http.StatusInternalServerError)
@@ -535,6 +547,8 @@ func (c *MemberController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除会员
 func (c *MemberController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取会员ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -545,7 +559,7 @@ func (c *MemberController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除会员
-	err = c.memberModel.Delete(id)
+	err = c.memberModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除会员失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete member", http.StatusInternalServerError)
@@ -568,6 +582,8 @@ func (c *MemberController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // BatchDelete 批量删除会员
 func (c *MemberController) BatchDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -594,7 +610,7 @@ func (c *MemberController) BatchDelete(w http.ResponseWriter, r *http.Request) {
 
 	// 批量删除会员
 	for _, id := range ids {
-		err := c.memberModel.Delete(id)
+		err := c.memberModel.WithContext(ctx).Delete(id)
 		if err != nil {
 			logger.Error("删除会员失败", "id", id, "error", err)
 		}
@@ -38,12 +38,14 @@ func NewModelController(db *database.DB, cache cache.Cache, config *config.Confi
 
 // List 模型列表
 func (c *ModelController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取模型列表
-	models, err := c.modelModel.GetAll()
+	models, err := c.modelModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取模型列表失败", "error", err)
 		http.Error(w, "Failed to get models", http.StatusInternalServerError)
@@ -93,6 +95,8 @@ func (c *ModelController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加模型
 func (c *ModelController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -112,21 +116,14 @@ func (c *ModelController) DoAdd(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 解析字段
-	var fields []model.Field
-	err := json.Unmarshal([]byte(fieldsJSON), &fields)
-	if err != nil {
-		logger.Error("解析字段失败", "error", err)
-		http.Error(w, "Invalid fields format", http.StatusBadRequest)
+	// 验证表名和字段
+	if err := model.ValidateTableName(tableName); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	// 验证字段
-	for _, field := range fields {
-		if field.Name == "" || field.Title == "" || field.Type == "" {
-			http.Error(w, "Invalid field definition", http.StatusBadRequest)
-			return
-		}
+	if _, err := model.DecodeFields(fieldsJSON); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	// 解析状态
@@ -147,7 +144,7 @@ func (c *ModelController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存模型
-	id, err := c.modelModel.Create(contentModel)
+	id, err := c.modelModel.WithContext(ctx).Create(contentModel)
 	if err != nil {
 		logger.Error("创建模型失败", "error", err)
 		http.Error(w, "Failed to create model", http.StatusInternalServerError)
@@ -171,6 +168,8 @@ func (c *ModelController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑模型页面
 func (c *ModelController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -185,7 +184,7 @@ func (c *ModelController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型
-	contentModel, err := c.modelModel.GetByID(id)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取模型失败", "id", id, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -212,6 +211,8 @@ func (c *ModelController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑模型
 func (c *ModelController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -227,7 +228,7 @@ func (c *ModelController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原模型
-	contentModel, err := c.modelModel.GetByID(id)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取模型失败", "id", id, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -246,21 +247,10 @@ func (c *ModelController) DoEdit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 解析字段
-	var fields []model.Field
-	err = json.Unmarshal([]byte(fieldsJSON), &fields)
-	if err != nil {
-		logger.Error("解析字段失败", "error", err)
-		http.Error(w, "Invalid fields format", http.StatusBadRequest)
-		return
-	}
-
 	// 验证字段
-	for _, field := range fields {
-		if field.Name == "" || field.Title == "" || field.Type == "" {
-			http.Error(w, "Invalid field definition", http.StatusBadRequest)
-			return
-		}
+	if _, err := model.DecodeFields(fieldsJSON); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	// 解析状态
@@ -276,8 +266,13 @@ func (c *ModelController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	contentModel.Fields = fieldsJSON
 	contentModel.UpdateTime = time.Now()
 
-	// 保存模型
-	err = c.modelModel.Update(contentModel)
+	// 保存模型，删除字段或缩小类型等可能丢失数据的变更需要确认
+	force := r.FormValue("force") == "1"
+	err = c.modelModel.WithContext(ctx).Update(contentModel, force)
+	if err == model.ErrDestructiveChange {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
 	if err != nil {
 		logger.Error("更新模型失败", "error", err)
 		http.Error(w, "Failed to update model", http.StatusInternalServerError)
@@ -298,8 +293,58 @@ func (c *ModelController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Preview 预览模型字段变更将执行的DDL
+func (c *ModelController) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 解析表单
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	// 新模型按表名生成建表语句，已有模型与原字段对比
+	modelModel := c.modelModel.WithContext(ctx)
+	newModel := &model.ContentModel{
+		TableName: r.FormValue("tablename"),
+		Fields:    r.FormValue("fields"),
+	}
+
+	var plan *model.TablePlan
+	var err error
+	if id, _ := strconv.ParseInt(r.FormValue("id"), 10, 64); id > 0 {
+		oldModel, getErr := modelModel.GetByID(id)
+		if getErr != nil {
+			http.Error(w, "Model not found", http.StatusNotFound)
+			return
+		}
+		plan, err = modelModel.PlanUpdate(oldModel, newModel)
+	} else {
+		plan, err = modelModel.PlanCreate(newModel)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"table":       plan.Table,
+		"statements":  plan.Statements,
+		"warnings":    plan.Warnings,
+		"destructive": plan.Destructive,
+	})
+}
+
 // Delete 删除模型
 func (c *ModelController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取模型ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -310,7 +355,7 @@ func (c *ModelController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除模型
-	err = c.modelModel.Delete(id)
+	err = c.modelModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除模型失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete model", http.StatusInternalServerError)
@@ -333,6 +378,8 @@ func (c *ModelController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // Fields 获取模型字段
 func (c *ModelController) Fields(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取模型ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -343,7 +390,7 @@ func (c *ModelController) Fields(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型
-	contentModel, err := c.modelModel.GetByID(id)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取模型失败", "id", id, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -357,6 +404,8 @@ func (c *ModelController) Fields(w http.ResponseWriter, r *http.Request) {
 
 // Content 内容列表
 func (c *ModelController) Content(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -371,7 +420,7 @@ func (c *ModelController) Content(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型
-	contentModel, err := c.modelModel.GetByID(id)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取模型失败", "id", id, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -400,7 +449,7 @@ func (c *ModelController) Content(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取内容列表
-	qb := database.NewQueryBuilder(c.db, contentModel.TableName)
+	qb := database.NewQueryBuilder(c.db.WithContext(ctx), c.modelModel.WithContext(ctx).ContentTable(contentModel))
 	qb.OrderBy("id DESC")
 	qb.Limit(pageSize)
 	qb.Offset((page - 1) * pageSize)
@@ -463,6 +512,8 @@ func (c *ModelController) Content(w http.ResponseWriter, r *http.Request) {
 
 // ContentAdd 添加内容页面
 func (c *ModelController) ContentAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -477,7 +528,7 @@ func (c *ModelController) ContentAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型
-	contentModel, err := c.modelModel.GetByID(id)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取模型失败", "id", id, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -514,6 +565,8 @@ func (c *ModelController) ContentAdd(w http.ResponseWriter, r *http.Request) {
 
 // ContentDoAdd 处理添加内容
 func (c *ModelController) ContentDoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -537,7 +590,7 @@ func (c *ModelController) ContentDoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型
-	contentModel, err := c.modelModel.GetByID(modelID)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(modelID)
 	if err != nil {
 		logger.Error("获取模型失败", "id", modelID, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -553,15 +606,19 @@ func (c *ModelController) ContentDoAdd(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 构建数据
+	// 构建数据，按字段类型转换
 	data := make(map[string]interface{})
 	for _, field := range fields {
-		value := r.FormValue(field.Name)
+		value, err := model.FieldValue(field, r.FormValue(field.Name))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		data[field.Name] = value
 	}
 
 	// 保存内容
-	err = c.modelModel.SaveContent(modelID, aid, data)
+	err = c.modelModel.WithContext(ctx).SaveContent(modelID, aid, data)
 	if err != nil {
 		logger.Error("保存内容失败", "error", err)
 		http.Error(w, "Failed to save content", http.StatusInternalServerError)
@@ -584,6 +641,8 @@ func (c *ModelController) ContentDoAdd(w http.ResponseWriter, r *http.Request) {
 
 // ContentEdit 编辑内容页面
 func (c *ModelController) ContentEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -604,7 +663,7 @@ func (c *ModelController) ContentEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取模型
-	contentModel, err := c.modelModel.GetByID(modelID)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(modelID)
 	if err != nil {
 		logger.Error("获取模型失败", "id", modelID, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -612,7 +671,7 @@ func (c *ModelController) ContentEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取内容
-	content, err := c.modelModel.GetContent(modelID, aid)
+	content, err := c.modelModel.WithContext(ctx).GetContent(modelID, aid)
 	if err != nil {
 		logger.Error("获取内容失败", "error", err)
 		http.Error(w, "Content not found", http.StatusNotFound)
@@ -650,6 +709,8 @@ func (c *ModelController) ContentEdit(w http.ResponseWriter, r *http.Request) {
 
 // ContentDoEdit 处理编辑内容
 func (c *ModelController) ContentDoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -673,7 +734,7 @@ func (c *ModelController) ContentDoEdit(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 获取模型
-	contentModel, err := c.modelModel.GetByID(modelID)
+	contentModel, err := c.modelModel.WithContext(ctx).GetByID(modelID)
 	if err != nil {
 		logger.Error("获取模型失败", "id", modelID, "error", err)
 		http.Error(w, "Model not found", http.StatusNotFound)
@@ -689,15 +750,19 @@ func (c *ModelController) ContentDoEdit(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// 构建数据
+	// 构建数据，按字段类型转换
 	data := make(map[string]interface{})
 	for _, field := range fields {
-		value := r.FormValue(field.Name)
+		value, err := model.FieldValue(field, r.FormValue(field.Name))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		data[field.Name] = value
 	}
 
 	// 保存内容
-	err = c.modelModel.SaveContent(modelID, aid, data)
+	err = c.modelModel.WithContext(ctx).SaveContent(modelID, aid, data)
 	if err != nil {
 		logger.Error("保存内容失败", "error", err)
 		http.Error(w, "Failed to save content", http.StatusInternalServerError)
@@ -720,6 +785,8 @@ func (c *ModelController) ContentDoEdit(w http.ResponseWriter, r *http.Request)
 
 // ContentDelete 删除内容
 func (c *ModelController) ContentDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取模型ID和内容ID
 	vars := mux.Vars(r)
 	modelIDStr := vars["id"]
@@ -736,7 +803,7 @@ func (c *ModelController) ContentDelete(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 删除内容
-	err = c.modelModel.DeleteContent(modelID, aid)
+	err = c.modelModel.WithContext(ctx).DeleteContent(modelID, aid)
 	if err != nil {
 		logger.Error("删除内容失败", "error", err)
 		http.Error(w, "Failed to delete content", http.StatusInternalServerError)
@@ -43,12 +43,14 @@ func NewPaymentController(db *database.DB, cache cache.Cache, config *config.Con
 
 // MethodList 支付方式列表
 func (c *PaymentController) MethodList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取支付方式列表
-	methods, err := c.paymentMethodModel.GetAll(-1)
+	methods, err := c.paymentMethodModel.WithContext(ctx).GetAll(-1)
 	if err != nil {
 		logger.Error("获取支付方式列表失败", "error", err)
 		http.Error(w, "Failed to get payment methods", http.StatusInternalServerError)
@@ -98,6 +100,8 @@ func (c *PaymentController) MethodAdd(w http.ResponseWriter, r *http.Request) {
 
 // MethodDoAdd 处理添加支付方式
 func (c *PaymentController) MethodDoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -135,7 +139,7 @@ func (c *PaymentController) MethodDoAdd(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 保存支付方式
-	id, err := c.paymentMethodModel.Create(method)
+	id, err := c.paymentMethodModel.WithContext(ctx).Create(method)
 	if err != nil {
 		logger.Error("创建支付方式失败", "error", err)
 		http.Error(w, "Failed to create payment method", http.StatusInternalServerError)
@@ -163,6 +167,8 @@ func (c *PaymentController) MethodDoAdd(w http.ResponseWriter, r *http.Request)
 
 // MethodEdit 编辑支付方式页面
 func (c *PaymentController) MethodEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -177,7 +183,7 @@ func (c *PaymentController) MethodEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取支付方式
-	method, err := c.paymentMethodModel.GetByID(id)
+	method, err := c.paymentMethodModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取支付方式失败", "id", id, "error", err)
 		http.Error(w, "Payment method not found", http.StatusNotFound)
@@ -204,6 +210,8 @@ func (c *PaymentController) MethodEdit(w http.ResponseWriter, r *http.Request) {
 
 // MethodDoEdit 处理编辑支付方式
 func (c *PaymentController) MethodDoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -219,7 +227,7 @@ func (c *PaymentController) MethodDoEdit(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 获取原支付方式
-	method, err := c.paymentMethodModel.GetByID(id)
+	method, err := c.paymentMethodModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取支付方式失败", "id", id, "error", err)
 		http.Error(w, "Payment method not found", http.StatusNotFound)
@@ -255,7 +263,7 @@ func (c *PaymentController) MethodDoEdit(w http.ResponseWriter, r *http.Request)
 	method.Status = status
 
 	// 保存支付方式
-	err = c.paymentMethodModel.Update(method)
+	err = c.paymentMethodModel.WithContext(ctx).Update(method)
 	if err != nil {
 		logger.Error("更新支付方式失败", "error", err)
 		http.Error(w, "Failed to update payment method", http.StatusInternalServerError)
@@ -282,6 +290,8 @@ func (c *PaymentController) MethodDoEdit(w http.ResponseWriter, r *http.Request)
 
 // MethodDelete 删除支付方式
 func (c *PaymentController) MethodDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取支付方式ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -292,7 +302,7 @@ func (c *PaymentController) MethodDelete(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 获取支付方式
-	method, err := c.paymentMethodModel.GetByID(id)
+	method, err := c.paymentMethodModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取支付方式失败", "id", id, "error", err)
 		http.Error(w, "Payment method not found", http.StatusNotFound)
@@ -300,7 +310,7 @@ func (c *PaymentController) MethodDelete(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 删除支付方式
-	err = c.paymentMethodModel.Delete(id)
+	err = c.paymentMethodModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除支付方式失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete payment method", http.StatusInternalServerError)
@@ -327,6 +337,8 @@ func (c *PaymentController) MethodDelete(w http.ResponseWriter, r *http.Request)
 
 // OrderList 支付订单列表
 func (c *PaymentController) OrderList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -349,7 +361,7 @@ func (c *PaymentController) OrderList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取支付订单列表
-	orders, total, err := c.paymentOrderModel.GetAll(status, page, 20)
+	orders, total, err := c.paymentOrderModel.WithContext(ctx).GetAll(status, page, 20)
 	if err != nil {
 		logger.Error("获取支付订单列表失败", "error", err)
 		http.Error(w, "Failed to get payment orders", http.StatusInternalServerError)
@@ -390,6 +402,8 @@ func (c *PaymentController) OrderList(w http.ResponseWriter, r *http.Request) {
 
 // OrderDetail 支付订单详情
 func (c *PaymentController) OrderDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -404,7 +418,7 @@ func (c *PaymentController) OrderDetail(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 获取支付订单
-	order, err := c.paymentOrderModel.GetByID(id)
+	order, err := c.paymentOrderModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取支付订单失败", "id", id, "error", err)
 		http.Error(w, "Payment order not found", http.StatusNotFound)
@@ -414,14 +428,14 @@ func (c *PaymentController) OrderDetail(w http.ResponseWriter, r *http.Request)
 	// 获取会员
 	var member *model.Member
 	if order.MemberID > 0 {
-		member, err = c.memberModel.GetByID(order.MemberID)
+		member, err = c.memberModel.WithContext(ctx).GetByID(order.MemberID)
 		if err != nil {
 			logger.Error("获取会员失败", "id", order.MemberID, "error", err)
 		}
 	}
 
 	// 获取额外数据
-	extraData, err := c.paymentOrderModel.GetExtraData(id)
+	extraData, err := c.paymentOrderModel.WithContext(ctx).GetExtraData(id)
 	if err != nil {
 		logger.Error("获取额外数据失败", "error", err)
 	}
@@ -448,6 +462,8 @@ func (c *PaymentController) OrderDetail(w http.ResponseWriter, r *http.Request)
 
 // OrderCancel 取消支付订单
 func (c *PaymentController) OrderCancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取支付订单ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -458,7 +474,7 @@ func (c *PaymentController) OrderCancel(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 获取支付订单
-	order, err := c.paymentOrderModel.GetByID(id)
+	order, err := c.paymentOrderModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取支付订单失败", "id", id, "error", err)
 		http.Error(w, "Payment order not found", http.StatusNotFound)
@@ -466,7 +482,7 @@ func (c *PaymentController) OrderCancel(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 取消支付订单
-	err = c.paymentService.CancelOrder(order.OrderNo)
+	err = c.paymentService.WithContext(ctx).CancelOrder(order.OrderNo)
 	if err != nil {
 		logger.Error("取消支付订单失败", "id", id, "error", err)
 		http.Error(w, "Failed to cancel payment order", http.StatusInternalServerError)
@@ -489,6 +505,8 @@ func (c *PaymentController) OrderCancel(w http.ResponseWriter, r *http.Request)
 
 // OrderRefund 退款支付订单
 func (c *PaymentController) OrderRefund(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取支付订单ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -499,7 +517,7 @@ func (c *PaymentController) OrderRefund(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 获取支付订单
-	order, err := c.paymentOrderModel.GetByID(id)
+	order, err := c.paymentOrderModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取支付订单失败", "id", id, "error", err)
 		http.Error(w, "Payment order not found", http.StatusNotFound)
@@ -507,7 +525,7 @@ func (c *PaymentController) OrderRefund(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 退款支付订单
-	err = c.paymentService.RefundOrder(order.OrderNo)
+	err = c.paymentService.WithContext(ctx).RefundOrder(order.OrderNo)
 	if err != nil {
 		logger.Error("退款支付订单失败", "id", id, "error", err)
 		http.Error(w, "Failed to refund payment order", http.StatusInternalServerError)
@@ -530,6 +548,8 @@ func (c *PaymentController) OrderRefund(w http.ResponseWriter, r *http.Request)
 
 // OrderDelete 删除支付订单
 func (c *PaymentController) OrderDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取支付订单ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -540,7 +560,7 @@ func (c *PaymentController) OrderDelete(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 删除支付订单
-	err = c.paymentOrderModel.Delete(id)
+	err = c.paymentOrderModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除支付订单失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete payment order", http.StatusInternalServerError)
@@ -563,8 +583,10 @@ func (c *PaymentController) OrderDelete(w http.ResponseWriter, r *http.Request)
 
 // InitMethods 初始化支付方式
 func (c *PaymentController) InitMethods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 初始化默认支付方式
-	err := c.paymentService.InitDefaultMethods()
+	err := c.paymentService.WithContext(ctx).InitDefaultMethods()
 	if err != nil {
 		logger.Error("初始化支付方式失败", "error", err)
 		http.Error(w, "Failed to initialize payment methods", http.StatusInternalServerError)
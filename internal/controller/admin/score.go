@@ -43,12 +43,14 @@ func NewScoreController(db *database.DB, cache cache.Cache, config *config.Confi
 
 // RuleList 积分规则列表
 func (c *ScoreController) RuleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
 
 	// 获取积分规则列表
-	rules, err := c.scoreRuleModel.GetAll(-1)
+	rules, err := c.scoreRuleModel.WithContext(ctx).GetAll(-1)
 	if err != nil {
 		logger.Error("获取积分规则列表失败", "error", err)
 		http.Error(w, "Failed to get score rules", http.StatusInternalServerError)
@@ -98,6 +100,8 @@ func (c *ScoreController) RuleAdd(w http.ResponseWriter, r *http.Request) {
 
 // RuleDoAdd 处理添加积分规则
 func (c *ScoreController) RuleDoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -137,7 +141,7 @@ func (c *ScoreController) RuleDoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存积分规则
-	id, err := c.scoreRuleModel.Create(rule)
+	id, err := c.scoreRuleModel.WithContext(ctx).Create(rule)
 	if err != nil {
 		logger.Error("创建积分规则失败", "error", err)
 		http.Error(w, "Failed to create score rule", http.StatusInternalServerError)
@@ -161,6 +165,8 @@ func (c *ScoreController) RuleDoAdd(w http.ResponseWriter, r *http.Request) {
 
 // RuleEdit 编辑积分规则页面
 func (c *ScoreController) RuleEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -175,7 +181,7 @@ func (c *ScoreController) RuleEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取积分规则
-	rule, err := c.scoreRuleModel.GetByID(id)
+	rule, err := c.scoreRuleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取积分规则失败", "id", id, "error", err)
 		http.Error(w, "Score rule not found", http.StatusNotFound)
@@ -202,6 +208,8 @@ func (c *ScoreController) RuleEdit(w http.ResponseWriter, r *http.Request) {
 
 // RuleDoEdit 处理编辑积分规则
 func (c *ScoreController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -217,7 +225,7 @@ func (c *ScoreController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原积分规则
-	rule, err := c.scoreRuleModel.GetByID(id)
+	rule, err := c.scoreRuleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取积分规则失败", "id", id, "error", err)
 		http.Error(w, "Score rule not found", http.StatusNotFound)
@@ -255,7 +263,7 @@ func (c *ScoreController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
 	rule.Description = description
 
 	// 保存积分规则
-	err = c.scoreRuleModel.Update(rule)
+	err = c.scoreRuleModel.WithContext(ctx).Update(rule)
 	if err != nil {
 		logger.Error("更新积分规则失败", "error", err)
 		http.Error(w, "Failed to update score rule", http.StatusInternalServerError)
@@ -278,6 +286,8 @@ func (c *ScoreController) RuleDoEdit(w http.ResponseWriter, r *http.Request) {
 
 // RuleDelete 删除积分规则
 func (c *ScoreController) RuleDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取积分规则ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -288,7 +298,7 @@ func (c *ScoreController) RuleDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除积分规则
-	err = c.scoreRuleModel.Delete(id)
+	err = c.scoreRuleModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除积分规则失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete score rule", http.StatusInternalServerError)
@@ -296,7 +306,7 @@ func (c *ScoreController) RuleDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除积分日志
-	err = c.scoreLogModel.DeleteByRuleID(id)
+	err = c.scoreLogModel.WithContext(ctx).DeleteByRuleID(id)
 	if err != nil {
 		logger.Error("删除积分日志失败", "ruleid", id, "error", err)
 	}
@@ -317,6 +327,8 @@ func (c *ScoreController) RuleDelete(w http.ResponseWriter, r *http.Request) {
 
 // LogList 积分日志列表
 func (c *ScoreController) LogList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -348,14 +360,14 @@ func (c *ScoreController) LogList(w http.ResponseWriter, r *http.Request) {
 	var total int
 	var err error
 	if memberID > 0 {
-		logs, total, err = c.scoreLogModel.GetByMemberID(memberID, page, 20)
+		logs, total, err = c.scoreLogModel.WithContext(ctx).GetByMemberID(memberID, page, 20)
 	} else if ruleID > 0 {
-		logs, total, err = c.scoreLogModel.GetByRuleID(ruleID, page, 20)
+		logs, total, err = c.scoreLogModel.WithContext(ctx).GetByRuleID(ruleID, page, 20)
 	} else {
 		// 获取所有积分日志
 		// 这里需要实现一个获取所有积分日志的方法
 		// 暂时使用获取会员ID为0的日志代替
-		logs, total, err = c.scoreLogModel.GetByMemberID(0, page, 20)
+		logs, total, err = c.scoreLogModel.WithContext(ctx).GetByMemberID(0, page, 20)
 	}
 	if err != nil {
 		logger.Error("获取积分日志失败", "error", err)
@@ -364,7 +376,7 @@ func (c *ScoreController) LogList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取积分规则列表
-	rules, err := c.scoreRuleModel.GetAll(-1)
+	rules, err := c.scoreRuleModel.WithContext(ctx).GetAll(-1)
 	if err != nil {
 		logger.Error("获取积分规则列表失败", "error", err)
 	}
@@ -405,6 +417,8 @@ func (c *ScoreController) LogList(w http.ResponseWriter, r *http.Request) {
 
 // LogDelete 删除积分日志
 func (c *ScoreController) LogDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取积分日志ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -415,7 +429,7 @@ func (c *ScoreController) LogDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取积分日志
-	log, err := c.scoreLogModel.GetByID(id)
+	log, err := c.scoreLogModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取积分日志失败", "id", id, "error", err)
 		http.Error(w, "Score log not found", http.StatusNotFound)
@@ -423,13 +437,13 @@ func (c *ScoreController) LogDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 更新会员积分
-	err = c.memberModel.UpdateScore(log.MemberID, -log.Score)
+	err = c.memberModel.WithContext(ctx).UpdateScore(log.MemberID, -log.Score)
 	if err != nil {
 		logger.Error("更新会员积分失败", "memberid", log.MemberID, "error", err)
 	}
 
 	// 删除积分日志
-	err = c.scoreLogModel.Delete(id)
+	err = c.scoreLogModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除积分日志失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete score log", http.StatusInternalServerError)
@@ -452,6 +466,8 @@ func (c *ScoreController) LogDelete(w http.ResponseWriter, r *http.Request) {
 
 // AddScore 添加积分页面
 func (c *ScoreController) AddScore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -466,7 +482,7 @@ func (c *ScoreController) AddScore(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员失败", "id", memberID, "error", err)
 		http.Error(w, "Member not found", http.StatusNotFound)
@@ -493,6 +509,8 @@ func (c *ScoreController) AddScore(w http.ResponseWriter, r *http.Request) {
 
 // DoAddScore 处理添加积分
 func (c *ScoreController) DoAddScore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -515,7 +533,7 @@ func (c *ScoreController) DoAddScore(w http.ResponseWriter, r *http.Request) {
 	score, _ := strconv.Atoi(scoreStr)
 
 	// 检查会员是否存在
-	_, err := c.memberModel.GetByID(memberID)
+	_, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员失败", "id", memberID, "error", err)
 		http.Error(w, "Member not found", http.StatusNotFound)
@@ -530,7 +548,7 @@ func (c *ScoreController) DoAddScore(w http.ResponseWriter, r *http.Request) {
 		Remark:   remark,
 		IP:       r.RemoteAddr,
 	}
-	_, err = c.scoreLogModel.Create(log)
+	_, err = c.scoreLogModel.WithContext(ctx).Create(log)
 	if err != nil {
 		logger.Error("创建积分日志失败", "error", err)
 		http.Error(w, "Failed to create score log", http.StatusInternalServerError)
@@ -538,7 +556,7 @@ func (c *ScoreController) DoAddScore(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 更新会员积分
-	err = c.memberModel.UpdateScore(memberID, score)
+	err = c.memberModel.WithContext(ctx).UpdateScore(memberID, score)
 	if err != nil {
 		logger.Error("更新会员积分失败", "error", err)
 		http.Error(w, "Failed to update member score", http.StatusInternalServerError)
@@ -561,8 +579,10 @@ func (c *ScoreController) DoAddScore(w http.ResponseWriter, r *http.Request) {
 
 // InitRules 初始化积分规则
 func (c *ScoreController) InitRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 初始化默认规则
-	err := c.scoreService.InitDefaultRules()
+	err := c.scoreService.WithContext(ctx).InitDefaultRules()
 	if err != nil {
 		logger.Error("初始化积分规则失败", "error", err)
 		http.Error(w, "Failed to initialize score rules", http.StatusInternalServerError)
@@ -36,6 +36,8 @@ func NewStatsController(db *database.DB, cache cache.Cache, config *config.Confi
 
 // Index 统计首页
 func (c *StatsController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -43,7 +45,7 @@ func (c *StatsController) Index(w http.ResponseWriter, r *http.Request) {
 	// 获取站点统计
 	now := time.Now().Unix()
 	startTime := now - 30*24*60*60 // 30天前
-	siteStats, err := c.statsService.GetSiteStats(startTime, now)
+	siteStats, err := c.statsService.WithContext(ctx).GetSiteStats(startTime, now)
 	if err != nil {
 		logger.Error("获取站点统计失败", "error", err)
 		http.Error(w, "Failed to get site stats", http.StatusInternalServerError)
@@ -70,6 +72,8 @@ func (c *StatsController) Index(w http.ResponseWriter, r *http.Request) {
 
 // Category 栏目统计
 func (c *StatsController) Category(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -77,7 +81,7 @@ func (c *StatsController) Category(w http.ResponseWriter, r *http.Request) {
 	// 获取栏目统计
 	now := time.Now().Unix()
 	startTime := now - 30*24*60*60 // 30天前
-	categoryStats, err := c.statsService.GetCategoryStats(startTime, now)
+	categoryStats, err := c.statsService.WithContext(ctx).GetCategoryStats(startTime, now)
 	if err != nil {
 		logger.Error("获取栏目统计失败", "error", err)
 		http.Error(w, "Failed to get category stats", http.StatusInternalServerError)
@@ -104,6 +108,8 @@ func (c *StatsController) Category(w http.ResponseWriter, r *http.Request) {
 
 // Member 会员统计
 func (c *StatsController) Member(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -111,7 +117,7 @@ func (c *StatsController) Member(w http.ResponseWriter, r *http.Request) {
 	// 获取会员统计
 	now := time.Now().Unix()
 	startTime := now - 30*24*60*60 // 30天前
-	memberStats, err := c.statsService.GetMemberStats(startTime, now)
+	memberStats, err := c.statsService.WithContext(ctx).GetMemberStats(startTime, now)
 	if err != nil {
 		logger.Error("获取会员统计失败", "error", err)
 		http.Error(w, "Failed to get member stats", http.StatusInternalServerError)
@@ -138,6 +144,8 @@ func (c *StatsController) Member(w http.ResponseWriter, r *http.Request) {
 
 // Visit 访问统计
 func (c *StatsController) Visit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -154,7 +162,7 @@ func (c *StatsController) Visit(w http.ResponseWriter, r *http.Request) {
 	// 获取访问统计
 	now := time.Now().Unix()
 	startTime := now - int64(days)*24*60*60
-	visitStats, err := c.statsService.GetVisitStats(startTime, now)
+	visitStats, err := c.statsService.WithContext(ctx).GetVisitStats(startTime, now)
 	if err != nil {
 		logger.Error("获取访问统计失败", "error", err)
 		http.Error(w, "Failed to get visit stats", http.StatusInternalServerError)
@@ -182,6 +190,8 @@ func (c *StatsController) Visit(w http.ResponseWriter, r *http.Request) {
 
 // Search 搜索统计
 func (c *StatsController) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -198,7 +208,7 @@ func (c *StatsController) Search(w http.ResponseWriter, r *http.Request) {
 	// 获取搜索统计
 	now := time.Now().Unix()
 	startTime := now - int64(days)*24*60*60
-	searchStats, err := c.statsService.GetSearchStats(startTime, now)
+	searchStats, err := c.statsService.WithContext(ctx).GetSearchStats(startTime, now)
 	if err != nil {
 		logger.Error("获取搜索统计失败", "error", err)
 		http.Error(w, "Failed to get search stats", http.StatusInternalServerError)
@@ -226,8 +236,10 @@ func (c *StatsController) Search(w http.ResponseWriter, r *http.Request) {
 
 // Export 导出统计数据
 func (c *StatsController) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 导出统计数据
-	data, err := c.statsService.ExportStats()
+	data, err := c.statsService.WithContext(ctx).ExportStats()
 	if err != nil {
 		logger.Error("导出统计数据失败", "error", err)
 		http.Error(w, "Failed to export stats", http.StatusInternalServerError)
@@ -245,6 +257,8 @@ func (c *StatsController) Export(w http.ResponseWriter, r *http.Request) {
 
 // GetData 获取统计数据
 func (c *StatsController) GetData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -275,23 +289,23 @@ func (c *StatsController) GetData(w http.ResponseWriter, r *http.Request) {
 	case "site":
 		now := time.Now().Unix()
 		startTime := now - 30*24*60*60 // 30天前
-		data, err = c.statsService.GetSiteStats(startTime, now)
+		data, err = c.statsService.WithContext(ctx).GetSiteStats(startTime, now)
 	case "category":
 		now := time.Now().Unix()
 		startTime := now - 30*24*60*60 // 30天前
-		data, err = c.statsService.GetCategoryStats(startTime, now)
+		data, err = c.statsService.WithContext(ctx).GetCategoryStats(startTime, now)
 	case "member":
 		now := time.Now().Unix()
 		startTime := now - 30*24*60*60 // 30天前
-		data, err = c.statsService.GetMemberStats(startTime, now)
+		data, err = c.statsService.WithContext(ctx).GetMemberStats(startTime, now)
 	case "visit":
 		now := time.Now().Unix()
 		startTime := now - int64(days)*24*60*60
-		data, err = c.statsService.GetVisitStats(startTime, now)
+		data, err = c.statsService.WithContext(ctx).GetVisitStats(startTime, now)
 	case "search":
 		now := time.Now().Unix()
 		startTime := now - int64(days)*24*60*60
-		data, err = c.statsService.GetSearchStats(startTime, now)
+		data, err = c.statsService.WithContext(ctx).GetSearchStats(startTime, now)
 	default:
 		http.Error(w, "Invalid data type", http.StatusBadRequest)
 		return
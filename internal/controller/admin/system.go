@@ -3,6 +3,7 @@ package admin
 import (
 	"encoding/json"
 	"net/http"
+	"path/filepath"
 	"strconv"
 
 	"aq3cms/config"
@@ -119,12 +120,14 @@ func (c *SystemController) Database(w http.ResponseWriter, r *http.Request) {
 
 	// 准备模板数据
 	data := map[string]interface{}{
-		"AdminID":     adminID,
-		"AdminName":   adminName,
-		"Config":      c.config,
-		"CurrentMenu": "system",
-		"SubMenu":     "database",
-		"PageTitle":   "数据库管理",
+		"AdminID":            adminID,
+		"AdminName":          adminName,
+		"Config":             c.config,
+		"CurrentMenu":        "system",
+		"SubMenu":            "database",
+		"PageTitle":          "数据库管理",
+		"SlowQueries":        c.db.SlowQueries(),
+		"SlowQueryThreshold": c.db.SlowQueryThreshold(),
 	}
 
 	// 检查是否有成功消息
@@ -137,6 +140,12 @@ func (c *SystemController) Database(w http.ResponseWriter, r *http.Request) {
 		case "restore":
 			data["Message"] = "数据库恢复成功！"
 			data["MessageType"] = "success"
+		case "validate":
+			data["Message"] = "备份文件校验通过！"
+			data["MessageType"] = "success"
+		case "slow_query":
+			data["Message"] = "慢查询统计已清空！"
+			data["MessageType"] = "success"
 		}
 	}
 
@@ -151,8 +160,17 @@ func (c *SystemController) Database(w http.ResponseWriter, r *http.Request) {
 
 // Backup 数据库备份
 func (c *SystemController) Backup(w http.ResponseWriter, r *http.Request) {
+	// 解析表单
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
 	// 执行数据库备份
-	backupFile, err := c.db.Backup()
+	backupFile, err := c.db.BackupWithOptions(database.BackupOptions{
+		Dir:  database.DefaultBackupDir,
+		Gzip: r.FormValue("gzip") == "1",
+	})
 	if err != nil {
 		logger.Error("数据库备份失败", "error", err)
 		http.Error(w, "Failed to backup database", http.StatusInternalServerError)
@@ -166,7 +184,7 @@ func (c *SystemController) Backup(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": true,
 			"message": "数据库备份成功",
-			"file":    backupFile,
+			"file":    filepath.Base(backupFile),
 		})
 	} else {
 		// 普通表单提交
@@ -190,28 +208,64 @@ func (c *SystemController) Restore(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	// 执行数据库恢复
-	err = c.db.Restore(file)
+	// 执行数据库恢复，dry_run=1 时只校验文件
+	dryRun := r.FormValue("dry_run") == "1"
+	result, err := c.db.RestoreWithOptions(file, database.RestoreOptions{DryRun: dryRun})
 	if err != nil {
-		logger.Error("数据库恢复失败", "error", err)
+		logger.Error("数据库恢复失败", "dryRun", dryRun, "error", err)
+		if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"message": err.Error(),
+			})
+			return
+		}
 		http.Error(w, "Failed to restore database", http.StatusInternalServerError)
 		return
 	}
 
+	message := "数据库恢复成功"
+	if dryRun {
+		message = "备份文件校验通过"
+	}
+
 	// 返回成功信息
 	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
 		// AJAX请求
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": true,
-			"message": "数据库恢复成功",
+			"success":    true,
+			"message":    message,
+			"dry_run":    dryRun,
+			"statements": result.Statements,
+			"inserts":    result.Inserts,
+			"tables":     result.Tables,
 		})
+	} else if dryRun {
+		http.Redirect(w, r, "/aq3cms/system_database?success=validate", http.StatusFound)
 	} else {
 		// 普通表单提交
 		http.Redirect(w, r, "/aq3cms/system_database?success=restore", http.StatusFound)
 	}
 }
 
+// ResetSlowQueries 清空慢查询统计
+func (c *SystemController) ResetSlowQueries(w http.ResponseWriter, r *http.Request) {
+	c.db.ResetSlowQueries()
+
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "慢查询统计已清空",
+		})
+		return
+	}
+	http.Redirect(w, r, "/aq3cms/system_database?success=slow_query", http.StatusFound)
+}
+
 // Cache 缓存管理
 func (c *SystemController) Cache(w http.ResponseWriter, r *http.Request) {
 	// 获取管理员信息
@@ -61,6 +61,8 @@ func (c *TagController) Index(w http.ResponseWriter, r *http.Request) {
 
 // List 标签列表
 func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -81,9 +83,9 @@ func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
 
 	if keyword != "" {
 		// 暂时使用GetList，后续添加Search方法
-		tags, total, err = c.tagModel.GetList(page, pageSize)
+		tags, total, err = c.tagModel.WithContext(ctx).GetList(page, pageSize)
 	} else {
-		tags, total, err = c.tagModel.GetList(page, pageSize)
+		tags, total, err = c.tagModel.WithContext(ctx).GetList(page, pageSize)
 	}
 
 	if err != nil {
@@ -145,6 +147,8 @@ func (c *TagController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 执行添加标签
 func (c *TagController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	err := r.ParseForm()
 	if err != nil {
@@ -165,7 +169,7 @@ func (c *TagController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查标签是否已存在
-	existingTag, err := c.tagModel.GetByName(tagName)
+	existingTag, err := c.tagModel.WithContext(ctx).GetByName(tagName)
 	if err == nil && existingTag != nil {
 		c.showError(w, "标签已存在")
 		return
@@ -191,7 +195,7 @@ func (c *TagController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存标签
-	id, err := c.tagModel.Create(tag)
+	id, err := c.tagModel.WithContext(ctx).Create(tag)
 	if err != nil {
 		logger.Error("创建标签失败", "error", err)
 		c.showError(w, "创建标签失败: "+err.Error())
@@ -217,6 +221,8 @@ func (c *TagController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑标签页面
 func (c *TagController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取标签ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -228,7 +234,7 @@ func (c *TagController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取标签信息
-	tag, err := c.tagModel.GetByID(id)
+	tag, err := c.tagModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取标签失败", "id", id, "error", err)
 		http.Error(w, "Tag not found", http.StatusNotFound)
@@ -258,6 +264,8 @@ func (c *TagController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 执行编辑标签
 func (c *TagController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	err := r.ParseForm()
 	if err != nil {
@@ -287,7 +295,7 @@ func (c *TagController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原标签信息
-	tag, err := c.tagModel.GetByID(id)
+	tag, err := c.tagModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取标签失败", "id", id, "error", err)
 		c.showError(w, "标签不存在")
@@ -296,7 +304,7 @@ func (c *TagController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 	// 检查标签名是否被其他标签使用
 	if tag.Tag != tagName {
-		existingTag, err := c.tagModel.GetByName(tagName)
+		existingTag, err := c.tagModel.WithContext(ctx).GetByName(tagName)
 		if err == nil && existingTag != nil && existingTag.ID != id {
 			c.showError(w, "标签名已被其他标签使用")
 			return
@@ -320,7 +328,7 @@ func (c *TagController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	tag.Rank = rank
 
 	// 保存标签
-	err = c.tagModel.Update(tag)
+	err = c.tagModel.WithContext(ctx).Update(tag)
 	if err != nil {
 		logger.Error("更新标签失败", "error", err)
 		c.showError(w, "更新标签失败: "+err.Error())
@@ -345,6 +353,8 @@ func (c *TagController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除标签
 func (c *TagController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取标签ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -356,7 +366,7 @@ func (c *TagController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除标签
-	err = c.tagModel.Delete(id)
+	err = c.tagModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除标签失败", "id", id, "error", err)
 		c.showError(w, "删除标签失败: "+err.Error())
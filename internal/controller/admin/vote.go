@@ -44,6 +44,8 @@ func NewVoteController(db *database.DB, cache cache.Cache, config *config.Config
 
 // List 投票列表
 func (c *VoteController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -61,7 +63,7 @@ func (c *VoteController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取投票列表
-	votes, total, err := c.voteModel.GetAll(page, 20)
+	votes, total, err := c.voteModel.WithContext(ctx).GetAll(page, 20)
 	if err != nil {
 		logger.Error("获取投票列表失败", "error", err)
 		http.Error(w, "Failed to get votes", http.StatusInternalServerError)
@@ -124,6 +126,8 @@ func (c *VoteController) Add(w http.ResponseWriter, r *http.Request) {
 
 // DoAdd 处理添加投票
 func (c *VoteController) DoAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -194,7 +198,7 @@ func (c *VoteController) DoAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存投票
-	id, err := c.voteService.CreateVote(vote, options)
+	id, err := c.voteService.WithContext(ctx).CreateVote(vote, options)
 	if err != nil {
 		logger.Error("创建投票失败", "error", err)
 		http.Error(w, "Failed to create vote", http.StatusInternalServerError)
@@ -218,6 +222,8 @@ func (c *VoteController) DoAdd(w http.ResponseWriter, r *http.Request) {
 
 // Edit 编辑投票页面
 func (c *VoteController) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -232,7 +238,7 @@ func (c *VoteController) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取投票
-	vote, options, err := c.voteService.GetVote(id)
+	vote, options, err := c.voteService.WithContext(ctx).GetVote(id)
 	if err != nil {
 		logger.Error("获取投票失败", "id", id, "error", err)
 		http.Error(w, "Vote not found", http.StatusNotFound)
@@ -260,6 +266,8 @@ func (c *VoteController) Edit(w http.ResponseWriter, r *http.Request) {
 
 // DoEdit 处理编辑投票
 func (c *VoteController) DoEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -275,7 +283,7 @@ func (c *VoteController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原投票
-	vote, _, err := c.voteService.GetVote(id)
+	vote, _, err := c.voteService.WithContext(ctx).GetVote(id)
 	if err != nil {
 		logger.Error("获取投票失败", "id", id, "error", err)
 		http.Error(w, "Vote not found", http.StatusNotFound)
@@ -343,7 +351,7 @@ func (c *VoteController) DoEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存投票
-	err = c.voteService.UpdateVote(vote, options)
+	err = c.voteService.WithContext(ctx).UpdateVote(vote, options)
 	if err != nil {
 		logger.Error("更新投票失败", "error", err)
 		http.Error(w, "Failed to update vote", http.StatusInternalServerError)
@@ -366,6 +374,8 @@ func (c *VoteController) DoEdit(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除投票
 func (c *VoteController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取投票ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -376,7 +386,7 @@ func (c *VoteController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除投票
-	err = c.voteService.DeleteVote(id)
+	err = c.voteService.WithContext(ctx).DeleteVote(id)
 	if err != nil {
 		logger.Error("删除投票失败", "id", id, "error", err)
 		http.Error(w, "Failed to delete vote", http.StatusInternalServerError)
@@ -399,6 +409,8 @@ func (c *VoteController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // Result 投票结果
 func (c *VoteController) Result(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -413,7 +425,7 @@ func (c *VoteController) Result(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取投票结果
-	vote, options, err := c.voteService.GetVoteResult(id)
+	vote, options, err := c.voteService.WithContext(ctx).GetVoteResult(id)
 	if err != nil {
 		logger.Error("获取投票结果失败", "id", id, "error", err)
 		http.Error(w, "Failed to get vote result", http.StatusInternalServerError)
@@ -441,6 +453,8 @@ func (c *VoteController) Result(w http.ResponseWriter, r *http.Request) {
 
 // Logs 投票日志
 func (c *VoteController) Logs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取管理员信息
 	adminID := middleware.GetAdminID(r)
 	adminName := middleware.GetAdminName(r)
@@ -467,7 +481,7 @@ func (c *VoteController) Logs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取投票
-	vote, _, err := c.voteService.GetVote(id)
+	vote, _, err := c.voteService.WithContext(ctx).GetVote(id)
 	if err != nil {
 		logger.Error("获取投票失败", "id", id, "error", err)
 		http.Error(w, "Vote not found", http.StatusNotFound)
@@ -475,7 +489,7 @@ func (c *VoteController) Logs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取投票日志
-	logs, total, err := c.voteService.GetVoteLogs(id, page, 20)
+	logs, total, err := c.voteService.WithContext(ctx).GetVoteLogs(id, page, 20)
 	if err != nil {
 		logger.Error("获取投票日志失败", "error", err)
 		http.Error(w, "Failed to get vote logs", http.StatusInternalServerError)
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"aq3cms/config"
+	"aq3cms/internal/middleware"
 	"aq3cms/internal/model"
 	"aq3cms/internal/service"
 	"aq3cms/pkg/cache"
@@ -29,9 +31,14 @@ type BaseController struct {
 	specialModel    *model.SpecialModel
 	contentModel    *model.ContentModelModel
 	templateService *service.TemplateService
+	batchService    *service.ArticleBatchService
 	htmlService     *service.HtmlService
+	keywordService  *service.KeywordService
+	recycleService  *service.RecycleService
+	revisionService *service.RevisionService
 	seoService      *service.SEOService
 	statsService    *service.StatsService
+	workflowService *service.WorkflowService
 }
 
 // NewBaseController 创建API基础控制器
@@ -48,9 +55,14 @@ func NewBaseController(db *database.DB, cache cache.Cache, config *config.Config
 		specialModel:    model.NewSpecialModel(db),
 		contentModel:    model.NewContentModelModel(db),
 		templateService: service.NewTemplateService(db, cache, config),
+		batchService:    service.NewArticleBatchService(db, cache, config),
 		htmlService:     service.NewHtmlService(db, cache, config),
+		keywordService:  service.NewKeywordService(db, cache, config),
+		recycleService:  service.NewRecycleService(db, cache, config),
+		revisionService: service.NewRevisionService(db, cache, config),
 		seoService:      service.NewSEOService(db, cache, config),
 		statsService:    service.NewStatsService(db, cache, config),
+		workflowService: service.NewWorkflowService(db, cache, config),
 	}
 }
 
@@ -238,8 +250,22 @@ func (c *BaseController) CheckAuth(w http.ResponseWriter, r *http.Request) (int6
 	return memberID, true
 }
 
-// CheckAPIKey 检查API密钥
+// placeholderAPIKeys 示例配置中的占位密钥，视为未配置
+var placeholderAPIKeys = map[string]bool{
+	"your-api-key": true,
+	"changeme":     true,
+}
+
+// CheckAPIKey 检查API密钥，未配置或仍为占位值时拒绝所有请求
 func (c *BaseController) CheckAPIKey(w http.ResponseWriter, r *http.Request) bool {
+	// 检查服务端密钥
+	key := c.config.API.Key
+	if key == "" || placeholderAPIKeys[key] {
+		logger.Error("API密钥未配置或为默认值，已拒绝请求", "path", r.URL.Path)
+		c.Error(w, 403, "API key is not configured")
+		return false
+	}
+
 	// 获取API密钥
 	apiKey := r.Header.Get("X-API-Key")
 	if apiKey == "" {
@@ -247,7 +273,7 @@ func (c *BaseController) CheckAPIKey(w http.ResponseWriter, r *http.Request) boo
 	}
 
 	// 检查API密钥
-	if apiKey != c.config.API.Key {
+	if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) != 1 {
 		c.Error(w, 401, "Invalid API key")
 		return false
 	}
@@ -255,6 +281,17 @@ func (c *BaseController) CheckAPIKey(w http.ResponseWriter, r *http.Request) boo
 	return true
 }
 
+// CheckAdmin 检查是否为已登录的管理员
+func (c *BaseController) CheckAdmin(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	adminID := middleware.GetAdminID(r)
+	if adminID <= 0 || middleware.GetAdminRank(r) < 1 {
+		c.Error(w, 401, "Admin login required")
+		return 0, false
+	}
+
+	return adminID, true
+}
+
 // RecordAPIAccess 记录API访问
 func (c *BaseController) RecordAPIAccess(r *http.Request, memberID int64) {
 	// 记录访问
@@ -7,6 +7,7 @@ import (
 
 	"aq3cms/config"
 	"aq3cms/internal/model"
+	"aq3cms/internal/service"
 	"aq3cms/pkg/cache"
 	"aq3cms/pkg/database"
 	"aq3cms/pkg/logger"
@@ -26,6 +27,8 @@ func NewArticleController(db *database.DB, cache cache.Cache, config *config.Con
 
 // List 文章列表
 func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -53,13 +56,13 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 
 	if keyword != "" {
 		// 搜索文章
-		articles, total, err = c.articleModel.Search(keyword, page, pageSize)
+		articles, total, err = c.articleModel.WithContext(ctx).Search(keyword, page, pageSize)
 	} else if typeID > 0 {
 		// 获取栏目文章
-		articles, total, err = c.articleModel.GetByTypeID(typeID, page, pageSize)
+		articles, total, err = c.articleModel.WithContext(ctx).GetByTypeID(typeID, page, pageSize)
 	} else {
 		// 获取所有文章
-		articles, total, err = c.articleModel.GetList(0, page, pageSize)
+		articles, total, err = c.articleModel.WithContext(ctx).GetList(0, page, pageSize)
 	}
 
 	if err != nil {
@@ -86,6 +89,8 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 
 // Detail 文章详情
 func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -96,49 +101,59 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 获取文章
-	article, err := c.articleModel.GetByID(id)
+	// 获取文章，未发布的文章视为不存在
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取文章失败", "id", id, "error", err)
 		c.Error(w, 404, "Article not found")
 		return
 	}
+	if !article.IsPublished() {
+		c.Error(w, 404, "Article not found")
+		return
+	}
 
 	// 更新点击量
-	c.articleModel.IncrementClick(id)
+	c.articleModel.WithContext(ctx).IncrementClick(id)
 
 	// 获取栏目
-	category, err := c.categoryModel.GetByID(article.TypeID)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(article.TypeID)
 	if err != nil {
 		logger.Error("获取栏目失败", "id", article.TypeID, "error", err)
 	}
 
 	// 获取标签
-	tags, err := c.tagModel.GetByAID(id)
+	tags, err := c.tagModel.WithContext(ctx).GetByAID(id)
 	if err != nil {
 		logger.Error("获取标签失败", "id", id, "error", err)
 	}
 
+	// 获取副栏目
+	article.CrossTypeIDs, err = c.articleModel.WithContext(ctx).GetCrossTypeIDs(id)
+	if err != nil {
+		logger.Error("获取副栏目失败", "id", id, "error", err)
+	}
+
 	// 获取相关文章
-	relatedArticles, err := c.articleModel.GetRelatedArticles(article.Keywords, id, 5)
+	relatedArticles, err := c.articleModel.WithContext(ctx).GetRelatedArticles(article.Keywords, id, 5)
 	if err != nil {
 		logger.Error("获取相关文章失败", "id", id, "error", err)
 	}
 
 	// 获取上一篇文章
-	prevArticle, err := c.articleModel.GetPrevArticle(id, article.TypeID)
+	prevArticle, err := c.articleModel.WithContext(ctx).GetPrevArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取上一篇文章失败", "id", id, "error", err)
 	}
 
 	// 获取下一篇文章
-	nextArticle, err := c.articleModel.GetNextArticle(id, article.TypeID)
+	nextArticle, err := c.articleModel.WithContext(ctx).GetNextArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取下一篇文章失败", "id", id, "error", err)
 	}
 
 	// 获取评论
-	comments, err := c.commentModel.GetByAID(id)
+	comments, err := c.commentModel.WithContext(ctx).GetByAID(id)
 	if err != nil {
 		logger.Error("获取评论失败", "id", id, "error", err)
 	}
@@ -159,8 +174,50 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Related 相关文章
+func (c *ArticleController) Related(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 记录API访问
+	c.RecordAPIAccess(r, 0)
+
+	// 获取文章ID
+	id, err := c.GetInt64Param(r, "id")
+	if err != nil || id <= 0 {
+		c.Error(w, 400, "Invalid article ID")
+		return
+	}
+
+	limit := c.GetQueryInt(r, "limit", 10)
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	// 获取文章，未发布的文章视为不存在
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
+	if err != nil || !article.IsPublished() {
+		c.Error(w, 404, "Article not found")
+		return
+	}
+
+	// 获取相关文章
+	articles, err := c.articleModel.WithContext(ctx).GetRelatedArticles(article.Keywords, id, limit)
+	if err != nil {
+		logger.Error("获取相关文章失败", "id", id, "error", err)
+		c.Error(w, 500, "Failed to get related articles")
+		return
+	}
+
+	// 返回数据
+	c.Success(w, map[string]interface{}{
+		"articles": articles,
+	})
+}
+
 // Create 创建文章
 func (c *ArticleController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -183,18 +240,28 @@ func (c *ArticleController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 内容保存在body字段，format=markdown时为Markdown原文
+	if err := article.SetBody(bodyFormat(r, article.Format), bodyText(&article), c.config.Site.PageBreak); err != nil {
+		c.Error(w, 400, "Invalid markdown body")
+		return
+	}
+
+	// 没有填写关键词和摘要时自动提取
+	c.keywordService.WithContext(ctx).Fill(&article)
+
 	// 设置默认值
 	article.Click = 0
 	article.IsTop = 0
 	article.IsRecommend = 0
 	article.IsHot = 0
 	article.Status = 0 // 待审核
+	article.State = model.ArticleStatePending
 	article.PubDate = time.Now()
 	article.SendDate = time.Now()
 	article.UpdateDate = time.Now()
 
 	// 创建文章
-	id, err := c.articleModel.Create(&article)
+	id, err := c.articleModel.WithContext(ctx).Create(&article)
 	if err != nil {
 		logger.Error("创建文章失败", "error", err)
 		c.Error(w, 500, "Failed to create article")
@@ -203,7 +270,12 @@ func (c *ArticleController) Create(w http.ResponseWriter, r *http.Request) {
 
 	// 处理标签
 	if article.Tags != "" {
-		c.tagModel.UpdateTags(id, article.Tags)
+		c.tagModel.WithContext(ctx).UpdateTags(id, article.Tags)
+	}
+
+	// 处理副栏目
+	if len(article.CrossTypeIDs) > 0 {
+		c.articleModel.WithContext(ctx).SetCrossTypeIDs(id, article.TypeID, article.CrossTypeIDs)
 	}
 
 	// 处理扩展模型内容
@@ -217,6 +289,8 @@ func (c *ArticleController) Create(w http.ResponseWriter, r *http.Request) {
 
 // Update 更新文章
 func (c *ArticleController) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -234,7 +308,7 @@ func (c *ArticleController) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原文章
-	article, err := c.articleModel.GetByID(id)
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取文章失败", "id", id, "error", err)
 		c.Error(w, 404, "Article not found")
@@ -246,6 +320,10 @@ func (c *ArticleController) Update(w http.ResponseWriter, r *http.Request) {
 		c.Error(w, 403, "Permission denied")
 		return
 	}
+	if article.ArcRank == model.ArcRankRecycled {
+		c.Error(w, 400, "Article is in the recycle bin")
+		return
+	}
 
 	// 解析请求体
 	var updateArticle model.Article
@@ -263,20 +341,44 @@ func (c *ArticleController) Update(w http.ResponseWriter, r *http.Request) {
 	article.LitPic = updateArticle.LitPic
 	article.Description = updateArticle.Description
 	article.Keywords = updateArticle.Keywords
-	article.Content = updateArticle.Content
 	article.UpdateDate = time.Now()
 
-	// 保存文章
-	err = c.articleModel.Update(article)
+	// 内容保存在body字段，兼容旧版的content字段；format=markdown时为Markdown原文
+	format := bodyFormat(r, updateArticle.Format)
+	if format == "" {
+		format = article.Format
+	}
+	if text := bodyText(&updateArticle); text != "" {
+		if err := article.SetBody(format, text, c.config.Site.PageBreak); err != nil {
+			c.Error(w, 400, "Invalid markdown body")
+			return
+		}
+	} else {
+		article.Content = article.Body
+	}
+	c.keywordService.WithContext(ctx).Fill(article)
+
+	// 保存文章并记录修订版本
+	err = c.revisionService.WithContext(ctx).UpdateArticle(article, 0, memberID)
 	if err != nil {
 		logger.Error("更新文章失败", "error", err)
 		c.Error(w, 500, "Failed to update article")
 		return
 	}
 
+	// 会员修改后的文章需要重新审核
+	if err := c.workflowService.WithContext(ctx).Resubmit(article, 0, 0); err != nil {
+		logger.Error("文章退回待审核失败", "id", id, "error", err)
+	}
+
 	// 处理标签
 	if updateArticle.Tags != "" {
-		c.tagModel.UpdateTags(id, updateArticle.Tags)
+		c.tagModel.WithContext(ctx).UpdateTags(id, updateArticle.Tags)
+	}
+
+	// 处理副栏目，传入空数组时清除
+	if updateArticle.CrossTypeIDs != nil {
+		c.articleModel.WithContext(ctx).SetCrossTypeIDs(id, article.TypeID, updateArticle.CrossTypeIDs)
 	}
 
 	// 处理扩展模型内容
@@ -289,6 +391,8 @@ func (c *ArticleController) Update(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除文章
 func (c *ArticleController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -306,30 +410,95 @@ func (c *ArticleController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取原文章
-	_, err = c.articleModel.GetByID(id)
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取文章失败", "id", id, "error", err)
 		c.Error(w, 404, "Article not found")
 		return
 	}
+	if article.ArcRank == model.ArcRankRecycled {
+		c.Error(w, 404, "Article not found")
+		return
+	}
 
 	// 检查权限
 
-	// 删除文章
-	err = c.articleModel.Delete(id)
+	// 移入回收站，标签和评论保留到彻底删除时处理
+	err = c.recycleService.WithContext(ctx).Trash(id)
 	if err != nil {
 		logger.Error("删除文章失败", "error", err)
 		c.Error(w, 500, "Failed to delete article")
 		return
 	}
 
-	// 删除标签关联
-	c.tagModel.DeleteByAID(id)
-
-	// 删除扩展模型内容
-
 	// 返回数据
 	c.Success(w, map[string]interface{}{
 		"message": "Article deleted successfully",
 	})
 }
+
+// Batch 文章批量操作，需要API密钥和管理员登录，返回每篇文章的处理结果
+func (c *ArticleController) Batch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 检查API密钥
+	if !c.CheckAPIKey(w, r) {
+		return
+	}
+
+	// 批量修改不区分文章归属，只允许管理员操作
+	adminID, ok := c.CheckAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	// 记录API访问
+	c.RecordAPIAccess(r, 0)
+
+	// 解析请求体
+	var req service.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.Error(w, 400, "Invalid request body")
+		return
+	}
+	logger.Info("API文章批量操作", "adminID", adminID, "action", req.Action, "count", len(req.IDs))
+
+	// 执行批量操作
+	result, err := c.batchService.WithContext(ctx).Run(&req)
+	if err != nil {
+		logger.Error("文章批量操作失败", "action", req.Action, "error", err)
+		if result == nil {
+			// 参数错误，没有修改任何文章
+			c.Error(w, 400, err.Error())
+			return
+		}
+		c.JSON(w, http.StatusOK, &Response{
+			Code:    500,
+			Message: err.Error(),
+			Data:    result,
+		})
+		return
+	}
+
+	// 返回数据
+	c.Success(w, result)
+}
+
+// bodyFormat 获取请求的内容格式，请求体中未指定时使用format查询参数
+func bodyFormat(r *http.Request, format string) string {
+	if format == "" {
+		format = r.URL.Query().Get("format")
+	}
+	return format
+}
+
+// bodyText 获取请求的内容，依次使用body、markdown和content字段
+func bodyText(article *model.Article) string {
+	switch {
+	case article.Body != "":
+		return article.Body
+	case article.Markdown != "":
+		return article.Markdown
+	}
+	return article.Content
+}
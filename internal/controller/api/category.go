@@ -24,6 +24,8 @@ func NewCategoryController(db *database.DB, cache cache.Cache, config *config.Co
 
 // List 栏目列表
 func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -32,7 +34,7 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 	_ = c.GetQueryInt(r, "is_nav", -1) // 暂时不使用
 
 	// 获取栏目列表
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 		c.Error(w, 500, "Failed to get categories")
@@ -58,6 +60,8 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 
 // Detail 栏目详情
 func (c *CategoryController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -69,7 +73,7 @@ func (c *CategoryController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目
-	category, err := c.categoryModel.GetByID(id)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取栏目失败", "id", id, "error", err)
 		c.Error(w, 404, "Category not found")
@@ -77,13 +81,13 @@ func (c *CategoryController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取子栏目
-	children, err := c.categoryModel.GetChildCategories(id)
+	children, err := c.categoryModel.WithContext(ctx).GetChildCategories(id)
 	if err != nil {
 		logger.Error("获取子栏目失败", "id", id, "error", err)
 	}
 
 	// 获取栏目文章
-	articles, total, err := c.articleModel.GetByTypeID(id, 1, 10)
+	articles, total, err := c.articleModel.WithContext(ctx).GetByTypeID(id, 1, 10)
 	if err != nil {
 		logger.Error("获取栏目文章失败", "id", id, "error", err)
 	}
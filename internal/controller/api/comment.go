@@ -28,6 +28,8 @@ func NewCommentController(db *database.DB, cache cache.Cache, config *config.Con
 
 // List 评论列表
 func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -51,7 +53,7 @@ func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取评论列表
-	comments, err := c.commentModel.GetByAID(aid)
+	comments, err := c.commentModel.WithContext(ctx).GetByAID(aid)
 	if err != nil {
 		logger.Error("获取评论列表失败", "error", err)
 		c.Error(w, 500, "Failed to get comments")
@@ -79,6 +81,8 @@ func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
 
 // Create 创建评论
 func (c *CommentController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -106,7 +110,7 @@ func (c *CommentController) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员失败", "id", memberID, "error", err)
 		c.Error(w, 404, "Member not found")
@@ -114,12 +118,16 @@ func (c *CommentController) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章
-	article, err := c.articleModel.GetByID(commentData.AID)
+	article, err := c.articleModel.WithContext(ctx).GetByID(commentData.AID)
 	if err != nil {
 		logger.Error("获取文章失败", "id", commentData.AID, "error", err)
 		c.Error(w, 404, "Article not found")
 		return
 	}
+	if !article.IsPublished() {
+		c.Error(w, 404, "Article not found")
+		return
+	}
 
 	// 创建评论
 	isCheck := 0
@@ -143,7 +151,7 @@ func (c *CommentController) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存评论
-	id, err := c.commentModel.Create(comment)
+	id, err := c.commentModel.WithContext(ctx).Create(comment)
 	if err != nil {
 		logger.Error("创建评论失败", "error", err)
 		c.Error(w, 500, "Failed to create comment")
@@ -151,7 +159,7 @@ func (c *CommentController) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 更新文章评论数
-	c.articleModel.IncrementCommentCount(commentData.AID)
+	c.articleModel.WithContext(ctx).IncrementCommentCount(commentData.AID)
 
 	// 返回数据
 	c.Success(w, map[string]interface{}{
@@ -163,6 +171,8 @@ func (c *CommentController) Create(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除评论
 func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -180,7 +190,7 @@ func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取评论
-	comment, err := c.commentModel.GetByID(id)
+	comment, err := c.commentModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取评论失败", "id", id, "error", err)
 		c.Error(w, 404, "Comment not found")
@@ -194,7 +204,7 @@ func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 删除评论
-	err = c.commentModel.Delete(id)
+	err = c.commentModel.WithContext(ctx).Delete(id)
 	if err != nil {
 		logger.Error("删除评论失败", "error", err)
 		c.Error(w, 500, "Failed to delete comment")
@@ -202,7 +212,7 @@ func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 更新文章评论数
-	c.articleModel.DecrementCommentCount(comment.AID)
+	c.articleModel.WithContext(ctx).DecrementCommentCount(comment.AID)
 
 	// 返回数据
 	c.Success(w, map[string]interface{}{
@@ -212,6 +222,8 @@ func (c *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
 
 // Vote 评论投票
 func (c *CommentController) Vote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -244,7 +256,7 @@ func (c *CommentController) Vote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取评论
-	comment, err := c.commentModel.GetByID(id)
+	comment, err := c.commentModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取评论失败", "id", id, "error", err)
 		c.Error(w, 404, "Comment not found")
@@ -266,7 +278,7 @@ func (c *CommentController) Vote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存评论
-	err = c.commentModel.Update(comment)
+	err = c.commentModel.WithContext(ctx).Update(comment)
 	if err != nil {
 		logger.Error("更新评论失败", "error", err)
 		c.Error(w, 500, "Failed to update comment")
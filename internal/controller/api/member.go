@@ -27,6 +27,8 @@ func NewMemberController(db *database.DB, cache cache.Cache, config *config.Conf
 
 // Login 会员登录
 func (c *MemberController) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -47,7 +49,7 @@ func (c *MemberController) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员
-	member, err := c.memberModel.GetByUsername(loginData.Username)
+	member, err := c.memberModel.WithContext(ctx).GetByUsername(loginData.Username)
 	if err != nil || member == nil {
 		c.Error(w, 401, "Invalid username or password")
 		return
@@ -69,7 +71,7 @@ func (c *MemberController) Login(w http.ResponseWriter, r *http.Request) {
 	member.LastLogin = time.Now()
 	member.LastIP = r.RemoteAddr
 	member.LoginCount++
-	c.memberModel.Update(member)
+	c.memberModel.WithContext(ctx).Update(member)
 
 	// 生成Token
 	token, err := security.GenerateToken(map[string]interface{}{
@@ -96,6 +98,8 @@ func (c *MemberController) Login(w http.ResponseWriter, r *http.Request) {
 
 // Register 会员注册
 func (c *MemberController) Register(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -118,14 +122,14 @@ func (c *MemberController) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查用户名是否已存在
-	existingMember, err := c.memberModel.GetByUsername(registerData.Username)
+	existingMember, err := c.memberModel.WithContext(ctx).GetByUsername(registerData.Username)
 	if err == nil && existingMember != nil {
 		c.Error(w, 400, "Username already exists")
 		return
 	}
 
 	// 检查邮箱是否已存在
-	existingMember, err = c.memberModel.GetByEmail(registerData.Email)
+	existingMember, err = c.memberModel.WithContext(ctx).GetByEmail(registerData.Email)
 	if err == nil && existingMember != nil {
 		c.Error(w, 400, "Email already exists")
 		return
@@ -149,7 +153,7 @@ func (c *MemberController) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存会员
-	id, err := c.memberModel.Create(member)
+	id, err := c.memberModel.WithContext(ctx).Create(member)
 	if err != nil {
 		logger.Error("创建会员失败", "error", err)
 		c.Error(w, 500, "Failed to create member")
@@ -181,6 +185,8 @@ func (c *MemberController) Register(w http.ResponseWriter, r *http.Request) {
 
 // Profile 会员资料
 func (c *MemberController) Profile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -191,7 +197,7 @@ func (c *MemberController) Profile(w http.ResponseWriter, r *http.Request) {
 	c.RecordAPIAccess(r, memberID)
 
 	// 获取会员
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员失败", "id", memberID, "error", err)
 		c.Error(w, 404, "Member not found")
@@ -199,13 +205,13 @@ func (c *MemberController) Profile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员文章
-	articles, _, err := c.articleModel.GetByMemberID(memberID, 1, 10)
+	articles, _, err := c.articleModel.WithContext(ctx).GetByMemberID(memberID, 1, 10)
 	if err != nil {
 		logger.Error("获取会员文章失败", "id", memberID, "error", err)
 	}
 
 	// 获取会员评论
-	comments, _, err := c.commentModel.GetByMemberID(memberID, 1, 10)
+	comments, _, err := c.commentModel.WithContext(ctx).GetByMemberID(memberID, 1, 10)
 	if err != nil {
 		logger.Error("获取会员评论失败", "id", memberID, "error", err)
 	}
@@ -220,6 +226,8 @@ func (c *MemberController) Profile(w http.ResponseWriter, r *http.Request) {
 
 // UpdateProfile 更新会员资料
 func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -230,7 +238,7 @@ func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request)
 	c.RecordAPIAccess(r, memberID)
 
 	// 获取会员
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员失败", "id", memberID, "error", err)
 		c.Error(w, 404, "Member not found")
@@ -252,7 +260,7 @@ func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request)
 
 	// 检查邮箱是否已存在
 	if updateData.Email != "" && updateData.Email != member.Email {
-		existingMember, err := c.memberModel.GetByEmail(updateData.Email)
+		existingMember, err := c.memberModel.WithContext(ctx).GetByEmail(updateData.Email)
 		if err == nil && existingMember != nil && existingMember.ID != memberID {
 			c.Error(w, 400, "Email already exists")
 			return
@@ -278,7 +286,7 @@ func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 保存会员
-	err = c.memberModel.Update(member)
+	err = c.memberModel.WithContext(ctx).Update(member)
 	if err != nil {
 		logger.Error("更新会员失败", "error", err)
 		c.Error(w, 500, "Failed to update member")
@@ -293,6 +301,8 @@ func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request)
 
 // ChangePassword 修改密码
 func (c *MemberController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查认证
 	memberID, ok := c.CheckAuth(w, r)
 	if !ok {
@@ -303,7 +313,7 @@ func (c *MemberController) ChangePassword(w http.ResponseWriter, r *http.Request
 	c.RecordAPIAccess(r, memberID)
 
 	// 获取会员
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员失败", "id", memberID, "error", err)
 		c.Error(w, 404, "Member not found")
@@ -336,7 +346,7 @@ func (c *MemberController) ChangePassword(w http.ResponseWriter, r *http.Request
 	member.Password = security.HashPassword(passwordData.NewPassword)
 
 	// 保存会员
-	err = c.memberModel.Update(member)
+	err = c.memberModel.WithContext(ctx).Update(member)
 	if err != nil {
 		logger.Error("更新会员失败", "error", err)
 		c.Error(w, 500, "Failed to update member")
@@ -28,7 +28,9 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, conf
 	// 文章API
 	apiRouter.HandleFunc("/articles", articleController.List).Methods("GET")
 	apiRouter.HandleFunc("/articles/{id:[0-9]+}", articleController.Detail).Methods("GET")
+	apiRouter.HandleFunc("/articles/{id:[0-9]+}/related", articleController.Related).Methods("GET")
 	apiRouter.HandleFunc("/articles", articleController.Create).Methods("POST")
+	apiRouter.HandleFunc("/articles/batch", articleController.Batch).Methods("POST")
 	apiRouter.HandleFunc("/articles/{id:[0-9]+}", articleController.Update).Methods("PUT")
 	apiRouter.HandleFunc("/articles/{id:[0-9]+}", articleController.Delete).Methods("DELETE")
 
@@ -23,6 +23,8 @@ func NewSearchController(db *database.DB, cache cache.Cache, config *config.Conf
 
 // Search 搜索
 func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -46,7 +48,7 @@ func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 搜索文章
-	articles, total, err := c.articleModel.Search(keyword, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).Search(keyword, page, pageSize)
 	if err != nil {
 		logger.Error("搜索文章失败", "error", err)
 		c.Error(w, 500, "Failed to search articles")
@@ -63,7 +65,7 @@ func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 记录搜索
-	c.statsService.RecordSearch(r, keyword, total)
+	c.statsService.WithContext(ctx).RecordSearch(r, keyword, total)
 
 	// 返回数据
 	c.Success(w, map[string]interface{}{
@@ -75,6 +77,8 @@ func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
 
 // Hot 热门搜索
 func (c *SearchController) Hot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -87,7 +91,7 @@ func (c *SearchController) Hot(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取热门搜索
-	hotSearches, err := c.statsService.GetHotSearches(limit)
+	hotSearches, err := c.statsService.WithContext(ctx).GetHotSearches(limit)
 	if err != nil {
 		logger.Error("获取热门搜索失败", "error", err)
 		c.Error(w, 500, "Failed to get hot searches")
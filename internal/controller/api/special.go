@@ -24,6 +24,8 @@ func NewSpecialController(db *database.DB, cache cache.Cache, config *config.Con
 
 // List 专题列表
 func (c *SpecialController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -40,7 +42,7 @@ func (c *SpecialController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取专题列表
-	specials, err := c.specialModel.GetAll()
+	specials, err := c.specialModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取专题列表失败", "error", err)
 		c.Error(w, 500, "Failed to get specials")
@@ -83,6 +85,8 @@ func (c *SpecialController) List(w http.ResponseWriter, r *http.Request) {
 
 // Detail 专题详情
 func (c *SpecialController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -106,7 +110,7 @@ func (c *SpecialController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取专题
-	special, err := c.specialModel.GetByID(id)
+	special, err := c.specialModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取专题失败", "id", id, "error", err)
 		c.Error(w, 404, "Special not found")
@@ -114,7 +118,7 @@ func (c *SpecialController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取专题文章
-	articles, total, err := c.specialModel.GetArticles(id, page, pageSize)
+	articles, total, err := c.specialModel.WithContext(ctx).GetArticles(id, page, pageSize)
 	if err != nil {
 		logger.Error("获取专题文章失败", "id", id, "error", err)
 		c.Error(w, 500, "Failed to get special articles")
@@ -23,6 +23,8 @@ func NewTagController(db *database.DB, cache cache.Cache, config *config.Config)
 
 // List 标签列表
 func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -35,7 +37,7 @@ func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取热门标签
-	tags, err := c.tagModel.GetHotTags(limit)
+	tags, err := c.tagModel.WithContext(ctx).GetHotTags(limit)
 	if err != nil {
 		logger.Error("获取标签列表失败", "error", err)
 		c.Error(w, 500, "Failed to get tags")
@@ -50,6 +52,8 @@ func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
 
 // Detail 标签详情
 func (c *TagController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 记录API访问
 	c.RecordAPIAccess(r, 0)
 
@@ -73,7 +77,7 @@ func (c *TagController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取标签
-	tag, err := c.tagModel.GetByName(name)
+	tag, err := c.tagModel.WithContext(ctx).GetByName(name)
 	if err != nil {
 		logger.Error("获取标签失败", "name", name, "error", err)
 		c.Error(w, 404, "Tag not found")
@@ -81,7 +85,7 @@ func (c *TagController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取标签文章
-	articles, total, err := c.tagModel.GetArticles(name, page, pageSize)
+	articles, total, err := c.tagModel.WithContext(ctx).GetArticles(name, page, pageSize)
 	if err != nil {
 		logger.Error("获取标签文章失败", "name", name, "error", err)
 		c.Error(w, 500, "Failed to get tag articles")
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -110,7 +111,7 @@ func (c *UploadController) Image(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 构建URL
-	url := "/" + strings.ReplaceAll(filePath, "\\", "/")
+	url := c.config.Upload.URLPrefix() + path.Join("images", yearMonth, filename)
 
 	// 返回数据
 	c.Success(w, map[string]interface{}{
@@ -198,7 +199,7 @@ func (c *UploadController) File(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 构建URL
-	url := "/" + strings.ReplaceAll(filePath, "\\", "/")
+	url := c.config.Upload.URLPrefix() + path.Join("files", yearMonth, filename)
 
 	// 返回数据
 	c.Success(w, map[string]interface{}{
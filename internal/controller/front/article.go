@@ -45,6 +45,8 @@ func NewArticleController(db *database.DB, cache cache.Cache, config *config.Con
 
 // Show 显示文章
 func (c *ArticleController) Show(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取文章ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -55,7 +57,7 @@ func (c *ArticleController) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章
-	article, err := c.articleModel.GetByID(id)
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取文章失败", "id", id, "error", err)
 		http.Error(w, "Article not found", http.StatusNotFound)
@@ -72,7 +74,7 @@ func (c *ArticleController) Show(w http.ResponseWriter, r *http.Request) {
 	go c.articleModel.IncrementClick(id)
 
 	// 获取文章栏目
-	category, err := c.categoryService.GetCategoryByID(article.TypeID)
+	category, err := c.categoryService.WithContext(ctx).GetCategoryByID(article.TypeID)
 	if err != nil {
 		logger.Error("获取文章栏目失败", "id", article.TypeID, "error", err)
 	}
@@ -84,25 +86,25 @@ func (c *ArticleController) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取相关文章
-	relatedArticles, _, err := c.articleModel.GetByTypeID(article.TypeID, 1, 5)
+	relatedArticles, _, err := c.articleModel.WithContext(ctx).GetByTypeID(article.TypeID, 1, 5)
 	if err != nil {
 		logger.Error("获取相关文章失败", "error", err)
 	}
 
 	// 获取上一篇文章
-	prevArticle, err := c.articleModel.GetPrevArticle(id, article.TypeID)
+	prevArticle, err := c.articleModel.WithContext(ctx).GetPrevArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取上一篇文章失败", "error", err)
 	}
 
 	// 获取下一篇文章
-	nextArticle, err := c.articleModel.GetNextArticle(id, article.TypeID)
+	nextArticle, err := c.articleModel.WithContext(ctx).GetNextArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取下一篇文章失败", "error", err)
 	}
 
 	// 获取文章评论
-	comments, total, err := c.commentModel.GetListByAID(id, 1, 100)
+	comments, total, err := c.commentModel.WithContext(ctx).GetListByAID(id, 1, 100)
 	if err != nil {
 		logger.Error("获取文章评论失败", "error", err)
 	}
@@ -119,7 +121,7 @@ func (c *ArticleController) Show(w http.ResponseWriter, r *http.Request) {
 	memberID := middleware.GetMemberID(r)
 	var member *model.Member
 	if memberID > 0 {
-		member, _ = c.memberService.GetMemberByID(memberID)
+		member, _ = c.memberService.WithContext(ctx).GetMemberByID(memberID)
 	}
 
 	// 准备模板数据
@@ -152,6 +154,8 @@ func (c *ArticleController) Show(w http.ResponseWriter, r *http.Request) {
 
 // AddComment 添加评论
 func (c *ArticleController) AddComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -189,7 +193,7 @@ func (c *ArticleController) AddComment(w http.ResponseWriter, r *http.Request) {
 		IP:       r.RemoteAddr,
 		Dtime:    time.Now(),
 	}
-	id, err := c.commentModel.Create(comment)
+	id, err := c.commentModel.WithContext(ctx).Create(comment)
 	if err != nil {
 		logger.Error("添加评论失败", "error", err)
 		http.Error(w, "Failed to add comment", http.StatusInternalServerError)
@@ -219,6 +223,8 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 
 // Like 点赞文章
 func (c *ArticleController) Like(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取文章ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -229,7 +235,7 @@ func (c *ArticleController) Like(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 增加文章点赞数 - 简化处理
-	err = c.articleModel.IncrementClick(id)
+	err = c.articleModel.WithContext(ctx).IncrementClick(id)
 	if err != nil {
 		logger.Error("增加文章点赞数失败", "error", err)
 		http.Error(w, "Failed to like article", http.StatusInternalServerError)
@@ -252,6 +258,8 @@ func (c *ArticleController) Like(w http.ResponseWriter, r *http.Request) {
 
 // List 显示文章列表
 func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取栏目ID
 	vars := mux.Vars(r)
 	typeidStr := vars["typeid"]
@@ -272,7 +280,7 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 	pageSize := 10
 
 	// 获取栏目
-	category, err := c.categoryService.GetCategoryByID(typeid)
+	category, err := c.categoryService.WithContext(ctx).GetCategoryByID(typeid)
 	if err != nil {
 		logger.Error("获取栏目失败", "id", typeid, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -280,7 +288,7 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章列表
-	articles, total, err := c.articleModel.GetByTypeID(typeid, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).GetByTypeID(typeid, page, pageSize)
 	if err != nil {
 		logger.Error("获取文章列表失败", "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
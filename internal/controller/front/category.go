@@ -39,6 +39,8 @@ func NewCategoryController(db *database.DB, cache cache.Cache, config *config.Co
 
 // Show 显示栏目
 func (c *CategoryController) Show(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取栏目ID
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -49,7 +51,7 @@ func (c *CategoryController) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目
-	category, err := c.categoryService.GetCategoryByID(id)
+	category, err := c.categoryService.WithContext(ctx).GetCategoryByID(id)
 	if err != nil {
 		logger.Error("获取栏目失败", "id", id, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -75,7 +77,7 @@ func (c *CategoryController) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目文章
-	articles, total, err := c.articleModel.GetByTypeID(id, page, 20)
+	articles, total, err := c.articleModel.WithContext(ctx).GetByTypeID(id, page, 20)
 	if err != nil {
 		logger.Error("获取栏目文章失败", "error", err)
 		http.Error(w, "Failed to get category articles", http.StatusInternalServerError)
@@ -83,13 +85,13 @@ func (c *CategoryController) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取子栏目
-	childCategories, err := c.categoryService.GetChildCategories(id)
+	childCategories, err := c.categoryService.WithContext(ctx).GetChildCategories(id)
 	if err != nil {
 		logger.Error("获取子栏目失败", "error", err)
 	}
 
 	// 获取栏目路径
-	categoryPath, err := c.categoryService.GetCategoryPath(id)
+	categoryPath, err := c.categoryService.WithContext(ctx).GetCategoryPath(id)
 	if err != nil {
 		logger.Error("获取栏目路径失败", "error", err)
 	}
@@ -140,12 +142,14 @@ func (c *CategoryController) Show(w http.ResponseWriter, r *http.Request) {
 
 // ShowByDir 通过目录显示栏目
 func (c *CategoryController) ShowByDir(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取栏目目录
 	vars := mux.Vars(r)
 	dir := vars["dir"]
 
 	// 获取栏目
-	category, err := c.categoryService.GetCategoryByDir(dir)
+	category, err := c.categoryService.WithContext(ctx).GetCategoryByDir(dir)
 	if err != nil {
 		logger.Error("获取栏目失败", "dir", dir, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -171,7 +175,7 @@ func (c *CategoryController) ShowByDir(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目文章
-	articles, total, err := c.articleModel.GetByTypeID(category.ID, page, 20)
+	articles, total, err := c.articleModel.WithContext(ctx).GetByTypeID(category.ID, page, 20)
 	if err != nil {
 		logger.Error("获取栏目文章失败", "error", err)
 		http.Error(w, "Failed to get category articles", http.StatusInternalServerError)
@@ -179,13 +183,13 @@ func (c *CategoryController) ShowByDir(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取子栏目
-	childCategories, err := c.categoryService.GetChildCategories(category.ID)
+	childCategories, err := c.categoryService.WithContext(ctx).GetChildCategories(category.ID)
 	if err != nil {
 		logger.Error("获取子栏目失败", "error", err)
 	}
 
 	// 获取栏目路径
-	categoryPath, err := c.categoryService.GetCategoryPath(category.ID)
+	categoryPath, err := c.categoryService.WithContext(ctx).GetCategoryPath(category.ID)
 	if err != nil {
 		logger.Error("获取栏目路径失败", "error", err)
 	}
@@ -34,12 +34,14 @@ func NewFormController(db *database.DB, cache cache.Cache, config *config.Config
 
 // Show 显示自定义表单
 func (c *FormController) Show(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取自定义表单代码
 	vars := mux.Vars(r)
 	code := vars["code"]
 
 	// 获取自定义表单
-	form, err := c.formService.GetForm(code)
+	form, err := c.formService.WithContext(ctx).GetForm(code)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "code", code, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -53,7 +55,7 @@ func (c *FormController) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 渲染自定义表单
-	html, err := c.formService.RenderForm(code)
+	html, err := c.formService.WithContext(ctx).RenderForm(code)
 	if err != nil {
 		logger.Error("渲染自定义表单失败", "error", err)
 		http.Error(w, "Failed to render form", http.StatusInternalServerError)
@@ -78,6 +80,8 @@ func (c *FormController) Show(w http.ResponseWriter, r *http.Request) {
 
 // Submit 提交自定义表单
 func (c *FormController) Submit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取自定义表单代码
 	vars := mux.Vars(r)
 	code := vars["code"]
@@ -99,7 +103,7 @@ func (c *FormController) Submit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 提交自定义表单
-	err := c.formService.SubmitForm(code, formData, r.RemoteAddr)
+	err := c.formService.WithContext(ctx).SubmitForm(code, formData, r.RemoteAddr)
 	if err != nil {
 		logger.Error("提交自定义表单失败", "error", err)
 		http.Error(w, "Failed to submit form", http.StatusInternalServerError)
@@ -107,7 +111,7 @@ func (c *FormController) Submit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取自定义表单
-	form, err := c.formService.GetForm(code)
+	form, err := c.formService.WithContext(ctx).GetForm(code)
 	if err != nil {
 		logger.Error("获取自定义表单失败", "code", code, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
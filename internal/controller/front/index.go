@@ -37,29 +37,31 @@ func NewIndexController(db *database.DB, cache cache.Cache, config *config.Confi
 
 // Index 首页
 func (c *IndexController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取站点配置
 	siteConfig := c.config.Site
 
 	// 获取首页推荐文章
-	recommendArticles, err := c.articleService.GetRecommendArticles(10)
+	recommendArticles, err := c.articleService.WithContext(ctx).GetRecommendArticles(10)
 	if err != nil {
 		logger.Error("获取首页推荐文章失败", "error", err)
 	}
 
 	// 获取首页最新文章
-	latestArticles, err := c.articleService.GetLatestArticles(10)
+	latestArticles, err := c.articleService.WithContext(ctx).GetLatestArticles(10)
 	if err != nil {
 		logger.Error("获取首页最新文章失败", "error", err)
 	}
 
 	// 获取首页热门文章
-	hotArticles, err := c.articleService.GetHotArticles(10)
+	hotArticles, err := c.articleService.WithContext(ctx).GetHotArticles(10)
 	if err != nil {
 		logger.Error("获取首页热门文章失败", "error", err)
 	}
 
 	// 获取顶级栏目
-	topCategories, err := c.categoryService.GetTopCategories()
+	topCategories, err := c.categoryService.WithContext(ctx).GetTopCategories()
 	if err != nil {
 		logger.Error("获取顶级栏目失败", "error", err)
 	}
@@ -70,19 +72,19 @@ func (c *IndexController) Index(w http.ResponseWriter, r *http.Request) {
 	var footerBanner string
 
 	// 获取顶部广告
-	topBannerHTML, err := c.adService.GetPositionHTMLByCode("index_top")
+	topBannerHTML, err := c.adService.WithContext(ctx).GetPositionHTMLByCode("index_top")
 	if err == nil {
 		topBanner = topBannerHTML
 	}
 
 	// 获取侧边栏广告
-	sideBannerHTML, err := c.adService.GetPositionHTMLByCode("index_side")
+	sideBannerHTML, err := c.adService.WithContext(ctx).GetPositionHTMLByCode("index_side")
 	if err == nil {
 		sideBanner = sideBannerHTML
 	}
 
 	// 获取底部广告
-	footerBannerHTML, err := c.adService.GetPositionHTMLByCode("index_footer")
+	footerBannerHTML, err := c.adService.WithContext(ctx).GetPositionHTMLByCode("index_footer")
 	if err == nil {
 		footerBanner = footerBannerHTML
 	}
@@ -111,6 +113,8 @@ func (c *IndexController) Index(w http.ResponseWriter, r *http.Request) {
 
 // Search 搜索
 func (c *IndexController) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取查询参数
 	query := r.URL.Query().Get("q")
 	pageStr := r.URL.Query().Get("page")
@@ -124,7 +128,7 @@ func (c *IndexController) Search(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 搜索文章
-	articles, total, err := c.articleService.SearchArticles(query, page, 20)
+	articles, total, err := c.articleService.WithContext(ctx).SearchArticles(query, page, 20)
 	if err != nil {
 		logger.Error("搜索文章失败", "error", err)
 		http.Error(w, "Failed to search articles", http.StatusInternalServerError)
@@ -162,6 +166,8 @@ func (c *IndexController) Search(w http.ResponseWriter, r *http.Request) {
 
 // Tag 标签页
 func (c *IndexController) Tag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取查询参数
 	tag := r.URL.Query().Get("tag")
 	pageStr := r.URL.Query().Get("page")
@@ -175,7 +181,7 @@ func (c *IndexController) Tag(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取标签文章
-	articles, total, err := c.articleService.GetArticlesByTag(tag, page, 20)
+	articles, total, err := c.articleService.WithContext(ctx).GetArticlesByTag(tag, page, 20)
 	if err != nil {
 		logger.Error("获取标签文章失败", "error", err)
 		http.Error(w, "Failed to get tag articles", http.StatusInternalServerError)
@@ -213,8 +219,10 @@ func (c *IndexController) Tag(w http.ResponseWriter, r *http.Request) {
 
 // Sitemap 站点地图
 func (c *IndexController) Sitemap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取所有栏目
-	categories, err := c.categoryService.GetAllCategories()
+	categories, err := c.categoryService.WithContext(ctx).GetAllCategories()
 	if err != nil {
 		logger.Error("获取所有栏目失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -222,7 +230,7 @@ func (c *IndexController) Sitemap(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取最新文章
-	articles, err := c.articleService.GetLatestArticles(100)
+	articles, err := c.articleService.WithContext(ctx).GetLatestArticles(100)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 		http.Error(w, "Failed to get latest articles", http.StatusInternalServerError)
@@ -247,8 +255,10 @@ func (c *IndexController) Sitemap(w http.ResponseWriter, r *http.Request) {
 
 // SitemapXML 站点地图XML
 func (c *IndexController) SitemapXML(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取所有栏目
-	categories, err := c.categoryService.GetAllCategories()
+	categories, err := c.categoryService.WithContext(ctx).GetAllCategories()
 	if err != nil {
 		logger.Error("获取所有栏目失败", "error", err)
 		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
@@ -256,7 +266,7 @@ func (c *IndexController) SitemapXML(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取最新文章
-	articles, err := c.articleService.GetLatestArticles(1000)
+	articles, err := c.articleService.WithContext(ctx).GetLatestArticles(1000)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 		http.Error(w, "Failed to get latest articles", http.StatusInternalServerError)
@@ -284,8 +294,10 @@ func (c *IndexController) SitemapXML(w http.ResponseWriter, r *http.Request) {
 
 // RSS RSS订阅
 func (c *IndexController) RSS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取最新文章
-	articles, err := c.articleService.GetLatestArticles(50)
+	articles, err := c.articleService.WithContext(ctx).GetLatestArticles(50)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 		http.Error(w, "Failed to get latest articles", http.StatusInternalServerError)
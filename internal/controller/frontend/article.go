@@ -40,6 +40,8 @@ func NewArticleController(db *database.DB, cache cache.Cache, config *config.Con
 
 // Detail 文章详情
 func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -48,9 +50,19 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 获取页码，/article/{id}_{page}.html
+	page := 1
+	if pageStr := vars["page"]; pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 2 {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// 检查是否有静态文章页
 	if c.config.Site.StaticArticle && r.URL.Query().Get("upcache") == "" {
-		staticPath := fmt.Sprintf("a/%d.html", id)
+		staticPath := service.ArticleStaticPath(id, page)
 		if _, err := http.Dir(".").Open(staticPath); err == nil {
 			http.ServeFile(w, r, staticPath)
 			return
@@ -58,12 +70,23 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章详情
-	article, err := c.articleModel.GetByID(id)
+	article, err := c.articleModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取文章详情失败", "id", id, "error", err)
 		http.Error(w, "Article not found", http.StatusNotFound)
 		return
 	}
+	if !article.IsPublished() {
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+
+	// 按分页符拆分内容
+	pages := model.SplitPages(article.Body, c.config.Site.PageBreak)
+	if page > len(pages) {
+		http.Error(w, "Page not found", http.StatusNotFound)
+		return
+	}
 
 	// 增加点击量
 	go func() {
@@ -73,43 +96,43 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	// 获取栏目信息
-	category, err := c.categoryModel.GetByID(article.TypeID)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(article.TypeID)
 	if err != nil {
 		logger.Error("获取栏目信息失败", "typeid", article.TypeID, "error", err)
 	}
 
 	// 获取上一篇文章
-	prevArticle, err := c.articleModel.GetPrevArticle(id, article.TypeID)
+	prevArticle, err := c.articleModel.WithContext(ctx).GetPrevArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取上一篇文章失败", "id", id, "error", err)
 	}
 
 	// 获取下一篇文章
-	nextArticle, err := c.articleModel.GetNextArticle(id, article.TypeID)
+	nextArticle, err := c.articleModel.WithContext(ctx).GetNextArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取下一篇文章失败", "id", id, "error", err)
 	}
 
 	// 获取相关文章
-	relatedArticles, err := c.articleModel.GetRelatedArticles(article.Keywords, article.ID, 10)
+	relatedArticles, err := c.articleModel.WithContext(ctx).GetRelatedArticles(article.Keywords, article.ID, 10)
 	if err != nil {
 		logger.Error("获取相关文章失败", "id", id, "error", err)
 	}
 
 	// 获取热门文章（按点击量排序）
-	hotArticles, _, err := c.articleModel.GetList(0, 1, 5)
+	hotArticles, _, err := c.articleModel.WithContext(ctx).GetList(0, 1, 5)
 	if err != nil {
 		logger.Error("获取热门文章失败", "error", err)
 	}
 
 	// 获取本栏目热门文章
-	categoryHotArticles, _, err := c.articleModel.GetList(article.TypeID, 1, 5)
+	categoryHotArticles, _, err := c.articleModel.WithContext(ctx).GetList(article.TypeID, 1, 5)
 	if err != nil {
 		logger.Error("获取本栏目热门文章失败", "typeid", article.TypeID, "error", err)
 	}
 
 	// 获取最新文章
-	latestArticles, err := c.articleModel.GetLatestArticles(5)
+	latestArticles, err := c.articleModel.WithContext(ctx).GetLatestArticles(5)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 	}
@@ -144,6 +167,9 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 		"CopyRight":           "© 2025 " + c.config.Site.Name + ". All rights reserved.",
 	}
 
+	// 替换为当前页的内容
+	data = service.ArticlePageData(data, article, pages, page, service.ArticlePageURL(id, c.config.Site.StaticArticle))
+
 	// 确定模板文件
 	var tplFile string
 	if article.TemplateFile != "" {
@@ -164,14 +190,14 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 	// 如果需要生成静态页面
 	if c.config.Site.StaticArticle {
 		go func() {
-			staticPath := fmt.Sprintf("a/%d.html", id)
+			staticPath := service.ArticleStaticPath(id, page)
 			dir := filepath.Dir(staticPath)
 			if err := os.MkdirAll(dir, 0755); err != nil {
 				logger.Error("创建静态文章目录失败", "dir", dir, "error", err)
 				return
 			}
 			if err := c.templateService.GenerateStaticPage(tplFile, data, staticPath); err != nil {
-				logger.Error("生成静态文章页失败", "id", id, "error", err)
+				logger.Error("生成静态文章页失败", "id", id, "page", page, "error", err)
 			}
 		}()
 	}
@@ -179,6 +205,8 @@ func (c *ArticleController) Detail(w http.ResponseWriter, r *http.Request) {
 
 // List 文章列表
 func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	typeIDStr := vars["typeid"]
 	typeID, err := strconv.ParseInt(typeIDStr, 10, 64)
@@ -207,7 +235,7 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目信息
-	category, err := c.categoryModel.GetByID(typeID)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(typeID)
 	if err != nil {
 		logger.Error("获取栏目信息失败", "typeid", typeID, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -216,7 +244,7 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 
 	// 获取文章列表
 	pageSize := 10
-	articles, total, err := c.articleModel.GetList(typeID, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).GetList(typeID, page, pageSize)
 	if err != nil {
 		logger.Error("获取文章列表失败", "typeid", typeID, "error", err)
 		http.Error(w, "Failed to get article list", http.StatusInternalServerError)
@@ -239,7 +267,7 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 	globals := c.templateService.GetGlobals()
 
 	// 获取子栏目
-	subCategories, err := c.categoryModel.GetChildCategories(typeID)
+	subCategories, err := c.categoryModel.WithContext(ctx).GetChildCategories(typeID)
 	if err != nil {
 		logger.Error("获取子栏目失败", "typeid", typeID, "error", err)
 	}
@@ -292,6 +320,8 @@ func (c *ArticleController) List(w http.ResponseWriter, r *http.Request) {
 
 // Search 搜索文章
 func (c *ArticleController) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取搜索关键词
 	keyword := r.URL.Query().Get("keyword")
 	if keyword == "" {
@@ -308,7 +338,7 @@ func (c *ArticleController) Search(w http.ResponseWriter, r *http.Request) {
 
 	// 获取文章列表
 	pageSize := 10
-	articles, total, err := c.articleModel.Search(keyword, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).Search(keyword, page, pageSize)
 	if err != nil {
 		logger.Error("搜索文章失败", "keyword", keyword, "error", err)
 		http.Error(w, "Failed to search articles", http.StatusInternalServerError)
@@ -352,6 +382,8 @@ func (c *ArticleController) Search(w http.ResponseWriter, r *http.Request) {
 
 // AllArticles 显示所有文章列表
 func (c *ArticleController) AllArticles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取页码
 	pageStr := r.URL.Query().Get("page")
 	page, err := strconv.Atoi(pageStr)
@@ -373,7 +405,7 @@ func (c *ArticleController) AllArticles(w http.ResponseWriter, r *http.Request)
 
 	// 获取文章列表（所有栏目，typeID=0表示所有）
 	pageSize := 15
-	articles, total, err := c.articleModel.GetList(0, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).GetList(0, page, pageSize)
 	if err != nil {
 		logger.Error("获取文章列表失败", "error", err)
 		http.Error(w, "Failed to get article list", http.StatusInternalServerError)
@@ -396,19 +428,19 @@ func (c *ArticleController) AllArticles(w http.ResponseWriter, r *http.Request)
 	globals := c.templateService.GetGlobals()
 
 	// 获取所有栏目
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取栏目列表失败", "error", err)
 	}
 
 	// 获取热门文章
-	hotArticles, _, err := c.articleModel.GetList(0, 1, 10)
+	hotArticles, _, err := c.articleModel.WithContext(ctx).GetList(0, 1, 10)
 	if err != nil {
 		logger.Error("获取热门文章失败", "error", err)
 	}
 
 	// 获取最新文章
-	latestArticles, err := c.articleModel.GetLatestArticles(10)
+	latestArticles, err := c.articleModel.WithContext(ctx).GetLatestArticles(10)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 	}
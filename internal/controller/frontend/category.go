@@ -38,6 +38,8 @@ func NewCategoryController(db *database.DB, cache cache.Cache, config *config.Co
 
 // List 栏目列表页
 func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	typeIDStr := vars["typeid"]
 	typeID, err := strconv.ParseInt(typeIDStr, 10, 64)
@@ -66,7 +68,7 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目信息
-	category, err := c.categoryModel.GetByID(typeID)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(typeID)
 	if err != nil {
 		logger.Error("获取栏目信息失败", "typeid", typeID, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -75,7 +77,7 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 
 	// 获取文章列表
 	pageSize := 20
-	articles, total, err := c.articleModel.GetByTypeID(typeID, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).GetByTypeID(typeID, page, pageSize)
 	if err != nil {
 		logger.Error("获取文章列表失败", "typeid", typeID, "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -98,7 +100,7 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 	globals := c.templateService.GetGlobals()
 
 	// 获取子栏目
-	subCategories, err := c.categoryModel.GetChildCategories(typeID)
+	subCategories, err := c.categoryModel.WithContext(ctx).GetChildCategories(typeID)
 	if err != nil {
 		logger.Error("获取子栏目失败", "typeid", typeID, "error", err)
 	}
@@ -139,11 +141,13 @@ func (c *CategoryController) List(w http.ResponseWriter, r *http.Request) {
 
 // ShowByDir 通过目录显示栏目
 func (c *CategoryController) ShowByDir(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	dir := vars["dir"]
 
 	// 获取栏目
-	category, err := c.categoryModel.GetByDir(dir)
+	category, err := c.categoryModel.WithContext(ctx).GetByDir(dir)
 	if err != nil {
 		logger.Error("获取栏目失败", "dir", dir, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -173,7 +177,7 @@ func (c *CategoryController) ShowByDir(w http.ResponseWriter, r *http.Request) {
 
 	// 获取文章列表
 	pageSize := 20
-	articles, total, err := c.articleModel.GetByTypeID(category.ID, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).GetByTypeID(category.ID, page, pageSize)
 	if err != nil {
 		logger.Error("获取文章列表失败", "typeid", category.ID, "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -196,7 +200,7 @@ func (c *CategoryController) ShowByDir(w http.ResponseWriter, r *http.Request) {
 	globals := c.templateService.GetGlobals()
 
 	// 获取子栏目
-	subCategories, err := c.categoryModel.GetChildCategories(category.ID)
+	subCategories, err := c.categoryModel.WithContext(ctx).GetChildCategories(category.ID)
 	if err != nil {
 		logger.Error("获取子栏目失败", "typeid", category.ID, "error", err)
 	}
@@ -237,12 +241,14 @@ func (c *CategoryController) ShowByDir(w http.ResponseWriter, r *http.Request) {
 
 // ShowByPath 通过多级路径显示栏目
 func (c *CategoryController) ShowByPath(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	dir := vars["dir"]
 	subdir := vars["subdir"]
 
 	// 获取栏目
-	category, err := c.categoryModel.GetByPath(dir, subdir)
+	category, err := c.categoryModel.WithContext(ctx).GetByPath(dir, subdir)
 	if err != nil {
 		logger.Error("获取栏目失败", "dir", dir, "subdir", subdir, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -272,7 +278,7 @@ func (c *CategoryController) ShowByPath(w http.ResponseWriter, r *http.Request)
 
 	// 获取文章列表
 	pageSize := 20
-	articles, total, err := c.articleModel.GetByTypeID(category.ID, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).GetByTypeID(category.ID, page, pageSize)
 	if err != nil {
 		logger.Error("获取文章列表失败", "typeid", category.ID, "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -295,7 +301,7 @@ func (c *CategoryController) ShowByPath(w http.ResponseWriter, r *http.Request)
 	globals := c.templateService.GetGlobals()
 
 	// 获取子栏目
-	subCategories, err := c.categoryModel.GetChildCategories(category.ID)
+	subCategories, err := c.categoryModel.WithContext(ctx).GetChildCategories(category.ID)
 	if err != nil {
 		logger.Error("获取子栏目失败", "typeid", category.ID, "error", err)
 	}
@@ -303,7 +309,7 @@ func (c *CategoryController) ShowByPath(w http.ResponseWriter, r *http.Request)
 	// 获取父栏目信息
 	var parentCategory *model.Category
 	if category.ParentID > 0 {
-		parentCategory, err = c.categoryModel.GetByID(category.ParentID)
+		parentCategory, err = c.categoryModel.WithContext(ctx).GetByID(category.ParentID)
 		if err != nil {
 			logger.Error("获取父栏目失败", "parentID", category.ParentID, "error", err)
 		}
@@ -53,6 +53,8 @@ func NewCommentController(db *database.DB, cache cache.Cache, config *config.Con
 
 // List 评论列表
 func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	aidStr := vars["aid"]
 	aid, err := strconv.ParseInt(aidStr, 10, 64)
@@ -70,7 +72,7 @@ func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
 
 	// 获取评论列表
 	pageSize := 10
-	comments, total, err := c.commentModel.GetListByAID(aid, page, pageSize)
+	comments, total, err := c.commentModel.WithContext(ctx).GetListByAID(aid, page, pageSize)
 	if err != nil {
 		logger.Error("获取评论列表失败", "aid", aid, "error", err)
 		http.Error(w, "Failed to get comments", http.StatusInternalServerError)
@@ -90,10 +92,14 @@ func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章信息
-	article, err := c.articleModel.GetByID(aid)
+	article, err := c.articleModel.WithContext(ctx).GetByID(aid)
 	if err != nil {
 		logger.Error("获取文章信息失败", "aid", aid, "error", err)
 	}
+	if article != nil && !article.IsPublished() {
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
 
 	// 获取全局变量
 	globals := c.templateService.GetGlobals()
@@ -118,6 +124,8 @@ func (c *CommentController) List(w http.ResponseWriter, r *http.Request) {
 
 // Post 发表评论
 func (c *CommentController) Post(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -132,6 +140,12 @@ func (c *CommentController) Post(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 未发布的文章不能评论
+	if article, err := c.articleModel.WithContext(ctx).GetByID(aid); err == nil && !article.IsPublished() {
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+
 	typeidStr := r.FormValue("typeid")
 	typeid, err := strconv.ParseInt(typeidStr, 10, 64)
 	if err != nil {
@@ -158,7 +172,7 @@ func (c *CommentController) Post(w http.ResponseWriter, r *http.Request) {
 		username = middleware.GetMemberName(r)
 
 		// 获取会员信息
-		member, err := c.memberModel.GetByID(mid)
+		member, err := c.memberModel.WithContext(ctx).GetByID(mid)
 		if err == nil && member != nil {
 			userface = member.Face
 		}
@@ -211,7 +225,7 @@ func (c *CommentController) Post(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存评论
-	_, err = c.commentModel.Create(comment)
+	_, err = c.commentModel.WithContext(ctx).Create(comment)
 	if err != nil {
 		logger.Error("保存评论失败", "error", err)
 		http.Error(w, "Failed to save comment", http.StatusInternalServerError)
@@ -234,6 +248,8 @@ func (c *CommentController) Post(w http.ResponseWriter, r *http.Request) {
 
 // Vote 评论投票
 func (c *CommentController) Vote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -269,9 +285,9 @@ func (c *CommentController) Vote(w http.ResponseWriter, r *http.Request) {
 	// 更新评论投票数
 	var err2 error
 	if action == "good" {
-		err2 = c.commentModel.UpdateGoodCount(id, 1)
+		err2 = c.commentModel.WithContext(ctx).UpdateGoodCount(id, 1)
 	} else {
-		err2 = c.commentModel.UpdateBadCount(id, 1)
+		err2 = c.commentModel.WithContext(ctx).UpdateBadCount(id, 1)
 	}
 
 	if err2 != nil {
@@ -299,6 +315,8 @@ func (c *CommentController) Vote(w http.ResponseWriter, r *http.Request) {
 
 // Reply 回复评论
 func (c *CommentController) Reply(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -313,6 +331,12 @@ func (c *CommentController) Reply(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 未发布的文章不能评论
+	if article, err := c.articleModel.WithContext(ctx).GetByID(aid); err == nil && !article.IsPublished() {
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+
 	typeidStr := r.FormValue("typeid")
 	typeid, err := strconv.ParseInt(typeidStr, 10, 64)
 	if err != nil {
@@ -346,7 +370,7 @@ func (c *CommentController) Reply(w http.ResponseWriter, r *http.Request) {
 		username = middleware.GetMemberName(r)
 
 		// 获取会员信息
-		member, err := c.memberModel.GetByID(mid)
+		member, err := c.memberModel.WithContext(ctx).GetByID(mid)
 		if err == nil && member != nil {
 			userface = member.Face
 		}
@@ -382,7 +406,7 @@ func (c *CommentController) Reply(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存评论
-	_, err = c.commentModel.Create(comment)
+	_, err = c.commentModel.WithContext(ctx).Create(comment)
 	if err != nil {
 		logger.Error("保存回复失败", "error", err)
 		http.Error(w, "Failed to save reply", http.StatusInternalServerError)
@@ -36,6 +36,8 @@ func NewFormController(db *database.DB, cache cache.Cache, config *config.Config
 
 // Show 显示表单
 func (c *FormController) Show(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -45,7 +47,7 @@ func (c *FormController) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -88,6 +90,8 @@ func (c *FormController) Show(w http.ResponseWriter, r *http.Request) {
 
 // Submit 提交表单
 func (c *FormController) Submit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -103,7 +107,7 @@ func (c *FormController) Submit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -131,7 +135,7 @@ func (c *FormController) Submit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 提交表单
-	_, err = c.formModel.SubmitForm(id, formData, r.RemoteAddr)
+	_, err = c.formModel.WithContext(ctx).SubmitForm(id, formData, r.RemoteAddr)
 	if err != nil {
 		logger.Error("提交表单失败", "id", id, "error", err)
 		http.Error(w, "Failed to submit form", http.StatusInternalServerError)
@@ -154,6 +158,8 @@ func (c *FormController) Submit(w http.ResponseWriter, r *http.Request) {
 
 // Success 表单提交成功
 func (c *FormController) Success(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -163,7 +169,7 @@ func (c *FormController) Success(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取表单
-	form, err := c.formModel.GetByID(id)
+	form, err := c.formModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取表单失败", "id", id, "error", err)
 		http.Error(w, "Form not found", http.StatusNotFound)
@@ -70,6 +70,8 @@ func (c *MemberController) Login(w http.ResponseWriter, r *http.Request) {
 
 // DoLogin 处理登录请求
 func (c *MemberController) DoLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -81,7 +83,7 @@ func (c *MemberController) DoLogin(w http.ResponseWriter, r *http.Request) {
 	password := r.FormValue("password")
 
 	// 验证用户名和密码
-	member, err := c.memberModel.CheckLogin(username, password)
+	member, err := c.memberModel.WithContext(ctx).CheckLogin(username, password)
 	if err != nil {
 		logger.Error("登录失败", "username", username, "error", err)
 		http.Redirect(w, r, "/member/login?error=1", http.StatusFound)
@@ -144,6 +146,8 @@ func (c *MemberController) Register(w http.ResponseWriter, r *http.Request) {
 
 // DoRegister 处理注册请求
 func (c *MemberController) DoRegister(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 解析表单
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -156,7 +160,7 @@ func (c *MemberController) DoRegister(w http.ResponseWriter, r *http.Request) {
 	email := r.FormValue("email")
 
 	// 检查用户名是否已存在
-	member, err := c.memberModel.GetByUsername(username)
+	member, err := c.memberModel.WithContext(ctx).GetByUsername(username)
 	exists := err == nil && member != nil
 	if err != nil {
 		logger.Error("检查用户名失败", "username", username, "error", err)
@@ -169,7 +173,7 @@ func (c *MemberController) DoRegister(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查邮箱是否已存在
-	memberByEmail, err := c.memberModel.GetByEmail(email)
+	memberByEmail, err := c.memberModel.WithContext(ctx).GetByEmail(email)
 	exists = err == nil && memberByEmail != nil
 	if err != nil {
 		logger.Error("检查邮箱失败", "email", email, "error", err)
@@ -193,7 +197,7 @@ func (c *MemberController) DoRegister(w http.ResponseWriter, r *http.Request) {
 		Status:    1, // 正常状态
 	}
 
-	id, err := c.memberModel.Create(newMember)
+	id, err := c.memberModel.WithContext(ctx).Create(newMember)
 	if err != nil {
 		logger.Error("创建会员失败", "username", username, "error", err)
 		http.Redirect(w, r, "/member/register?error=1", http.StatusFound)
@@ -213,6 +217,8 @@ func (c *MemberController) DoRegister(w http.ResponseWriter, r *http.Request) {
 
 // Index 会员中心首页
 func (c *MemberController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查是否已登录
 	session, _ := c.sessionStore.Get(r, "member-session")
 	memberID, ok := session.Values["member_id"].(int64)
@@ -222,7 +228,7 @@ func (c *MemberController) Index(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员信息
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "id", memberID, "error", err)
 		http.Redirect(w, r, "/member/login", http.StatusFound)
@@ -230,7 +236,7 @@ func (c *MemberController) Index(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员文章
-	articles, _, err := c.articleModel.GetMemberArticles(memberID, 1, 10)
+	articles, _, err := c.articleModel.WithContext(ctx).GetMemberArticles(memberID, 1, 10)
 	if err != nil {
 		logger.Error("获取会员文章失败", "id", memberID, "error", err)
 	}
@@ -259,6 +265,8 @@ func (c *MemberController) Index(w http.ResponseWriter, r *http.Request) {
 
 // Profile 会员资料
 func (c *MemberController) Profile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查是否已登录
 	session, _ := c.sessionStore.Get(r, "member-session")
 	memberID, ok := session.Values["member_id"].(int64)
@@ -268,7 +276,7 @@ func (c *MemberController) Profile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员信息
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "id", memberID, "error", err)
 		http.Redirect(w, r, "/member/login", http.StatusFound)
@@ -298,6 +306,8 @@ func (c *MemberController) Profile(w http.ResponseWriter, r *http.Request) {
 
 // UpdateProfile 更新会员资料
 func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查是否已登录
 	session, _ := c.sessionStore.Get(r, "member-session")
 	memberID, ok := session.Values["member_id"].(int64)
@@ -322,7 +332,7 @@ func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request)
 	// tel := r.FormValue("tel")
 
 	// 获取会员信息
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "id", memberID, "error", err)
 		http.Redirect(w, r, "/member/profile?error=1", http.StatusFound)
@@ -337,7 +347,7 @@ func (c *MemberController) UpdateProfile(w http.ResponseWriter, r *http.Request)
 	member.QQ = qq
 	// member.Tel = tel // 不存在的字段
 
-	if err := c.memberModel.Update(member); err != nil {
+	if err := c.memberModel.WithContext(ctx).Update(member); err != nil {
 		logger.Error("更新会员信息失败", "id", memberID, "error", err)
 		http.Redirect(w, r, "/member/profile?error=1", http.StatusFound)
 		return
@@ -380,6 +390,8 @@ func (c *MemberController) ChangePassword(w http.ResponseWriter, r *http.Request
 
 // DoChangePassword 处理修改密码请求
 func (c *MemberController) DoChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查是否已登录
 	session, _ := c.sessionStore.Get(r, "member-session")
 	memberID, ok := session.Values["member_id"].(int64)
@@ -406,7 +418,7 @@ func (c *MemberController) DoChangePassword(w http.ResponseWriter, r *http.Reque
 	}
 
 	// 验证旧密码
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "id", memberID, "error", err)
 		http.Redirect(w, r, "/member/password?error=2", http.StatusFound)
@@ -420,7 +432,7 @@ func (c *MemberController) DoChangePassword(w http.ResponseWriter, r *http.Reque
 
 	// 更新密码
 	member.Password = security.HashPassword(newPassword)
-	if err := c.memberModel.Update(member); err != nil {
+	if err := c.memberModel.WithContext(ctx).Update(member); err != nil {
 		logger.Error("更新密码失败", "id", memberID, "error", err)
 		http.Redirect(w, r, "/member/password?error=2", http.StatusFound)
 		return
@@ -432,6 +444,8 @@ func (c *MemberController) DoChangePassword(w http.ResponseWriter, r *http.Reque
 
 // Articles 会员文章列表
 func (c *MemberController) Articles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查是否已登录
 	session, _ := c.sessionStore.Get(r, "member-session")
 	memberID, ok := session.Values["member_id"].(int64)
@@ -449,7 +463,7 @@ func (c *MemberController) Articles(w http.ResponseWriter, r *http.Request) {
 
 	// 获取会员文章
 	pageSize := 10
-	articles, total, err := c.articleModel.GetMemberArticles(memberID, page, pageSize)
+	articles, total, err := c.articleModel.WithContext(ctx).GetMemberArticles(memberID, page, pageSize)
 	if err != nil {
 		logger.Error("获取会员文章失败", "id", memberID, "error", err)
 		http.Error(w, "Failed to get member articles", http.StatusInternalServerError)
@@ -39,6 +39,8 @@ func NewMessageController(db *database.DB, cache cache.Cache, config *config.Con
 
 // Inbox 收件箱
 func (c *MessageController) Inbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取会员ID
 	memberID := middleware.GetMemberID(r)
 	if memberID == 0 {
@@ -55,7 +57,7 @@ func (c *MessageController) Inbox(w http.ResponseWriter, r *http.Request) {
 
 	// 获取收件箱
 	pageSize := 10
-	messages, total, err := c.messageModel.GetInbox(memberID, page, pageSize)
+	messages, total, err := c.messageModel.WithContext(ctx).GetInbox(memberID, page, pageSize)
 	if err != nil {
 		logger.Error("获取收件箱失败", "memberid", memberID, "error", err)
 		http.Error(w, "Failed to get inbox", http.StatusInternalServerError)
@@ -75,7 +77,7 @@ func (c *MessageController) Inbox(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员信息
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "memberid", memberID, "error", err)
 		http.Error(w, "Failed to get member info", http.StatusInternalServerError)
@@ -83,7 +85,7 @@ func (c *MessageController) Inbox(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取未读消息数量
-	unreadCount, err := c.messageModel.GetUnreadCount(memberID)
+	unreadCount, err := c.messageModel.WithContext(ctx).GetUnreadCount(memberID)
 	if err != nil {
 		logger.Error("获取未读消息数量失败", "memberid", memberID, "error", err)
 	}
@@ -112,6 +114,8 @@ func (c *MessageController) Inbox(w http.ResponseWriter, r *http.Request) {
 
 // Outbox 发件箱
 func (c *MessageController) Outbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取会员ID
 	memberID := middleware.GetMemberID(r)
 	if memberID == 0 {
@@ -128,7 +132,7 @@ func (c *MessageController) Outbox(w http.ResponseWriter, r *http.Request) {
 
 	// 获取发件箱
 	pageSize := 10
-	messages, total, err := c.messageModel.GetOutbox(memberID, page, pageSize)
+	messages, total, err := c.messageModel.WithContext(ctx).GetOutbox(memberID, page, pageSize)
 	if err != nil {
 		logger.Error("获取发件箱失败", "memberid", memberID, "error", err)
 		http.Error(w, "Failed to get outbox", http.StatusInternalServerError)
@@ -148,7 +152,7 @@ func (c *MessageController) Outbox(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员信息
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "memberid", memberID, "error", err)
 		http.Error(w, "Failed to get member info", http.StatusInternalServerError)
@@ -156,7 +160,7 @@ func (c *MessageController) Outbox(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取未读消息数量
-	unreadCount, err := c.messageModel.GetUnreadCount(memberID)
+	unreadCount, err := c.messageModel.WithContext(ctx).GetUnreadCount(memberID)
 	if err != nil {
 		logger.Error("获取未读消息数量失败", "memberid", memberID, "error", err)
 	}
@@ -185,6 +189,8 @@ func (c *MessageController) Outbox(w http.ResponseWriter, r *http.Request) {
 
 // Read 阅读消息
 func (c *MessageController) Read(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取会员ID
 	memberID := middleware.GetMemberID(r)
 	if memberID == 0 {
@@ -201,7 +207,7 @@ func (c *MessageController) Read(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取消息
-	message, err := c.messageModel.GetByID(id)
+	message, err := c.messageModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取消息失败", "id", id, "error", err)
 		http.Error(w, "Message not found", http.StatusNotFound)
@@ -216,14 +222,14 @@ func (c *MessageController) Read(w http.ResponseWriter, r *http.Request) {
 
 	// 如果是收件人，标记为已读
 	if message.ToID == memberID && message.IsRead == 0 {
-		err = c.messageModel.MarkAsRead(id, memberID)
+		err = c.messageModel.WithContext(ctx).MarkAsRead(id, memberID)
 		if err != nil {
 			logger.Error("标记消息为已读失败", "id", id, "memberid", memberID, "error", err)
 		}
 	}
 
 	// 获取会员信息
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "memberid", memberID, "error", err)
 		http.Error(w, "Failed to get member info", http.StatusInternalServerError)
@@ -231,7 +237,7 @@ func (c *MessageController) Read(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取未读消息数量
-	unreadCount, err := c.messageModel.GetUnreadCount(memberID)
+	unreadCount, err := c.messageModel.WithContext(ctx).GetUnreadCount(memberID)
 	if err != nil {
 		logger.Error("获取未读消息数量失败", "memberid", memberID, "error", err)
 	}
@@ -259,6 +265,8 @@ func (c *MessageController) Read(w http.ResponseWriter, r *http.Request) {
 
 // Send 发送消息页面
 func (c *MessageController) Send(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取会员ID
 	memberID := middleware.GetMemberID(r)
 	if memberID == 0 {
@@ -275,7 +283,7 @@ func (c *MessageController) Send(w http.ResponseWriter, r *http.Request) {
 		if toID > 0 {
 			// 获取收件人信息
 			var err error
-			toMember, err = c.memberModel.GetByID(toID)
+			toMember, err = c.memberModel.WithContext(ctx).GetByID(toID)
 			if err != nil {
 				logger.Error("获取收件人信息失败", "toid", toID, "error", err)
 			}
@@ -283,7 +291,7 @@ func (c *MessageController) Send(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取会员信息
-	member, err := c.memberModel.GetByID(memberID)
+	member, err := c.memberModel.WithContext(ctx).GetByID(memberID)
 	if err != nil {
 		logger.Error("获取会员信息失败", "memberid", memberID, "error", err)
 		http.Error(w, "Failed to get member info", http.StatusInternalServerError)
@@ -291,7 +299,7 @@ func (c *MessageController) Send(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取未读消息数量
-	unreadCount, err := c.messageModel.GetUnreadCount(memberID)
+	unreadCount, err := c.messageModel.WithContext(ctx).GetUnreadCount(memberID)
 	if err != nil {
 		logger.Error("获取未读消息数量失败", "memberid", memberID, "error", err)
 	}
@@ -319,6 +327,8 @@ func (c *MessageController) Send(w http.ResponseWriter, r *http.Request) {
 
 // DoSend 处理发送消息
 func (c *MessageController) DoSend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取会员ID
 	memberID := middleware.GetMemberID(r)
 	if memberID == 0 {
@@ -353,7 +363,7 @@ func (c *MessageController) DoSend(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 检查收件人是否存在
-	toMember, err := c.memberModel.GetByID(toID)
+	toMember, err := c.memberModel.WithContext(ctx).GetByID(toID)
 	if err != nil || toMember == nil {
 		logger.Error("收件人不存在", "toid", toID, "error", err)
 		http.Error(w, "Recipient not found", http.StatusBadRequest)
@@ -373,7 +383,7 @@ func (c *MessageController) DoSend(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 保存消息
-	_, err = c.messageModel.Create(message)
+	_, err = c.messageModel.WithContext(ctx).Create(message)
 	if err != nil {
 		logger.Error("发送消息失败", "error", err)
 		http.Error(w, "Failed to send message", http.StatusInternalServerError)
@@ -396,6 +406,8 @@ func (c *MessageController) DoSend(w http.ResponseWriter, r *http.Request) {
 
 // Delete 删除消息
 func (c *MessageController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取会员ID
 	memberID := middleware.GetMemberID(r)
 	if memberID == 0 {
@@ -418,7 +430,7 @@ func (c *MessageController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取消息
-	message, err := c.messageModel.GetByID(id)
+	message, err := c.messageModel.WithContext(ctx).GetByID(id)
 	if err != nil {
 		logger.Error("获取消息失败", "id", id, "error", err)
 		http.Error(w, "Message not found", http.StatusNotFound)
@@ -445,9 +457,9 @@ func (c *MessageController) Delete(w http.ResponseWriter, r *http.Request) {
 	// 删除消息
 	var err2 error
 	if action == "inbox" {
-		err2 = c.messageModel.DeleteFromInbox(id, memberID)
+		err2 = c.messageModel.WithContext(ctx).DeleteFromInbox(id, memberID)
 	} else {
-		err2 = c.messageModel.DeleteFromOutbox(id, memberID)
+		err2 = c.messageModel.WithContext(ctx).DeleteFromOutbox(id, memberID)
 	}
 
 	if err2 != nil {
@@ -458,7 +470,7 @@ func (c *MessageController) Delete(w http.ResponseWriter, r *http.Request) {
 
 	// 如果发件人和收件人都已删除，完全删除消息
 	if (action == "inbox" && message.FromDel == 1) || (action == "outbox" && message.ToDel == 1) {
-		err = c.messageModel.DeleteCompletely(id)
+		err = c.messageModel.WithContext(ctx).DeleteCompletely(id)
 		if err != nil {
 			logger.Error("完全删除消息失败", "id", id, "error", err)
 		}
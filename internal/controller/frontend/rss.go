@@ -66,6 +66,8 @@ type Item struct {
 
 // Index 首页RSS
 func (c *RSSController) Index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查缓存
 	if cached, ok := c.cache.Get("rss:index"); ok {
 		if rssXML, ok := cached.(string); ok {
@@ -76,7 +78,7 @@ func (c *RSSController) Index(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取最新文章
-	articles, err := c.articleModel.GetLatestArticles(20)
+	articles, err := c.articleModel.WithContext(ctx).GetLatestArticles(20)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -141,6 +143,8 @@ func (c *RSSController) Index(w http.ResponseWriter, r *http.Request) {
 
 // Category 栏目RSS
 func (c *RSSController) Category(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	typeidStr := vars["typeid"]
 	typeid, err := strconv.ParseInt(typeidStr, 10, 64)
@@ -160,7 +164,7 @@ func (c *RSSController) Category(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目信息
-	category, err := c.categoryModel.GetByID(typeid)
+	category, err := c.categoryModel.WithContext(ctx).GetByID(typeid)
 	if err != nil {
 		logger.Error("获取栏目信息失败", "typeid", typeid, "error", err)
 		http.Error(w, "Category not found", http.StatusNotFound)
@@ -168,7 +172,7 @@ func (c *RSSController) Category(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取栏目文章
-	articles, _, err := c.articleModel.GetList(typeid, 1, 20)
+	articles, _, err := c.articleModel.WithContext(ctx).GetList(typeid, 1, 20)
 	if err != nil {
 		logger.Error("获取栏目文章失败", "typeid", typeid, "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -209,6 +213,10 @@ func (c *RSSController) Category(w http.ResponseWriter, r *http.Request) {
 			Author:      article.Writer,
 			Category:    category.TypeName,
 		}
+		// 发布到副栏目或交叉栏目的文章使用主栏目
+		if article.TypeName != "" {
+			item.Category = article.TypeName
+		}
 
 		rss.Channel.Items = append(rss.Channel.Items, item)
 	}
@@ -37,6 +37,8 @@ func NewSearchController(db *database.DB, cache cache.Cache, config *config.Conf
 
 // Search 搜索
 func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取搜索参数
 	keyword := r.URL.Query().Get("keyword")
 	channelTypeStr := r.URL.Query().Get("channeltype")
@@ -96,7 +98,7 @@ func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 执行搜索
-	results, total, err := c.searchService.Search(options)
+	results, total, err := c.searchService.WithContext(ctx).Search(options)
 	if err != nil {
 		logger.Error("搜索失败", "keyword", keyword, "error", err)
 		http.Error(w, "Failed to search", http.StatusInternalServerError)
@@ -106,14 +108,14 @@ func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
 	// 获取栏目信息
 	var category *model.Category
 	if typeID > 0 {
-		category, err = c.categoryModel.GetByID(typeID)
+		category, err = c.categoryModel.WithContext(ctx).GetByID(typeID)
 		if err != nil {
 			logger.Error("获取栏目信息失败", "typeid", typeID, "error", err)
 		}
 	}
 
 	// 获取所有栏目
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取所有栏目失败", "error", err)
 	}
@@ -161,8 +163,10 @@ func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
 
 // AdvancedSearch 高级搜索
 func (c *SearchController) AdvancedSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 获取所有栏目
-	categories, err := c.categoryModel.GetAll()
+	categories, err := c.categoryModel.WithContext(ctx).GetAll()
 	if err != nil {
 		logger.Error("获取所有栏目失败", "error", err)
 	}
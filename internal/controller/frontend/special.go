@@ -37,6 +37,8 @@ func NewSpecialController(db *database.DB, cache cache.Cache, config *config.Con
 
 // List 专题列表
 func (c *SpecialController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查是否有静态专题列表页
 	if c.config.Site.StaticSpecial && r.URL.Query().Get("upcache") == "" {
 		http.ServeFile(w, r, "special/index.html")
@@ -52,7 +54,7 @@ func (c *SpecialController) List(w http.ResponseWriter, r *http.Request) {
 
 	// 获取专题列表
 	pageSize := 10
-	specials, err := c.specialModel.GetAll()
+	specials, err := c.specialModel.WithContext(ctx).GetAll()
 
 	// 计算总数
 	total := len(specials)
@@ -89,7 +91,7 @@ func (c *SpecialController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取热门专题
-	hotSpecials, err := c.specialModel.GetHotSpecials(5)
+	hotSpecials, err := c.specialModel.WithContext(ctx).GetHotSpecials(5)
 	if err != nil {
 		logger.Error("获取热门专题失败", "error", err)
 	}
@@ -131,6 +133,8 @@ func (c *SpecialController) List(w http.ResponseWriter, r *http.Request) {
 
 // Detail 专题详情
 func (c *SpecialController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	filename := vars["filename"]
 	if filename == "" {
@@ -148,7 +152,7 @@ func (c *SpecialController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取专题信息
-	special, err := c.specialModel.GetByFilename(filename)
+	special, err := c.specialModel.WithContext(ctx).GetByFilename(filename)
 	if err != nil {
 		logger.Error("获取专题信息失败", "filename", filename, "error", err)
 		http.Error(w, "Special not found", http.StatusNotFound)
@@ -167,7 +171,7 @@ func (c *SpecialController) Detail(w http.ResponseWriter, r *http.Request) {
 
 	// 获取专题文章
 	pageSize := 10
-	articles, total, err := c.specialModel.GetArticles(special.ID, page, pageSize)
+	articles, total, err := c.specialModel.WithContext(ctx).GetArticles(special.ID, page, pageSize)
 	if err != nil {
 		logger.Error("获取专题文章失败", "id", special.ID, "error", err)
 	}
@@ -185,7 +189,7 @@ func (c *SpecialController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取热门专题
-	hotSpecials, err := c.specialModel.GetHotSpecials(5)
+	hotSpecials, err := c.specialModel.WithContext(ctx).GetHotSpecials(5)
 	if err != nil {
 		logger.Error("获取热门专题失败", "error", err)
 	}
@@ -40,6 +40,8 @@ func NewTagController(db *database.DB, cache cache.Cache, config *config.Config)
 
 // List 标签列表
 func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	// 检查是否有静态页面
 	if c.config.Site.StaticTag && r.URL.Query().Get("upcache") == "" {
 		http.ServeFile(w, r, "tags.html")
@@ -55,7 +57,7 @@ func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
 
 	// 获取标签列表
 	pageSize := 200
-	tags, total, err := c.tagModel.GetList(page, pageSize)
+	tags, total, err := c.tagModel.WithContext(ctx).GetList(page, pageSize)
 	if err != nil {
 		logger.Error("获取标签列表失败", "error", err)
 		http.Error(w, "Failed to get tags", http.StatusInternalServerError)
@@ -75,25 +77,25 @@ func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取热门标签
-	hotTags, err := c.tagModel.GetHotTags(50)
+	hotTags, err := c.tagModel.WithContext(ctx).GetHotTags(50)
 	if err != nil {
 		logger.Error("获取热门标签失败", "error", err)
 	}
 
 	// 获取最新标签
-	newTags, err := c.tagModel.GetNewTags(30)
+	newTags, err := c.tagModel.WithContext(ctx).GetNewTags(30)
 	if err != nil {
 		logger.Error("获取最新标签失败", "error", err)
 	}
 
 	// 获取热门文章
-	hotArticles, err := c.articleService.GetHotArticles(10)
+	hotArticles, err := c.articleService.WithContext(ctx).GetHotArticles(10)
 	if err != nil {
 		logger.Error("获取热门文章失败", "error", err)
 	}
 
 	// 获取最新文章
-	latestArticles, err := c.articleService.GetLatestArticles(10)
+	latestArticles, err := c.articleService.WithContext(ctx).GetLatestArticles(10)
 	if err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 	}
@@ -136,6 +138,8 @@ func (c *TagController) List(w http.ResponseWriter, r *http.Request) {
 
 // Detail 标签详情
 func (c *TagController) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	vars := mux.Vars(r)
 	tagName := vars["tag"]
 	if tagName == "" {
@@ -158,7 +162,7 @@ func (c *TagController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取标签信息
-	tag, err := c.tagModel.GetByName(tagName)
+	tag, err := c.tagModel.WithContext(ctx).GetByName(tagName)
 	if err != nil {
 		logger.Error("获取标签信息失败", "tag", tagName, "error", err)
 		http.Error(w, "Tag not found", http.StatusNotFound)
@@ -167,7 +171,7 @@ func (c *TagController) Detail(w http.ResponseWriter, r *http.Request) {
 
 	// 获取标签相关文章
 	pageSize := 10
-	articles, total, err := c.articleService.GetArticlesByTag(tagName, page, pageSize)
+	articles, total, err := c.articleService.WithContext(ctx).GetArticlesByTag(tagName, page, pageSize)
 	if err != nil {
 		logger.Error("获取标签相关文章失败", "tag", tagName, "error", err)
 		http.Error(w, "Failed to get articles", http.StatusInternalServerError)
@@ -187,19 +191,19 @@ func (c *TagController) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取热门标签
-	hotTags, err := c.tagModel.GetHotTags(30)
+	hotTags, err := c.tagModel.WithContext(ctx).GetHotTags(30)
 	if err != nil {
 		logger.Error("获取热门标签失败", "error", err)
 	}
 
 	// 获取最新标签
-	newTags, err := c.tagModel.GetNewTags(30)
+	newTags, err := c.tagModel.WithContext(ctx).GetNewTags(30)
 	if err != nil {
 		logger.Error("获取最新标签失败", "error", err)
 	}
 
 	// 获取热门文章
-	hotArticles, err := c.articleService.GetHotArticles(10)
+	hotArticles, err := c.articleService.WithContext(ctx).GetHotArticles(10)
 	if err != nil {
 		logger.Error("获取热门文章失败", "error", err)
 	}
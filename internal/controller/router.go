@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"aq3cms/config"
@@ -71,8 +72,9 @@ func (c *HealthController) Health(w http.ResponseWriter, r *http.Request) {
 	// 服务状态
 	services := map[string]interface{}{
 		"database": map[string]interface{}{
-			"status": dbStatus,
-			"error":  dbError,
+			"status":   dbStatus,
+			"error":    dbError,
+			"replicas": c.db.ReplicaStatus(),
 		},
 		"cache": map[string]interface{}{
 			"status": cacheStatus,
@@ -212,6 +214,7 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 	router.Use(middleware.Logger)
 	router.Use(middleware.Security)
 	router.Use(middleware.I18nMiddleware(i18nInstance))
+	router.Use(middleware.DBSession)
 
 	// 添加速率限制中间件
 	if cfg.Server.EnableRateLimit {
@@ -226,7 +229,9 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 
 	// 静态文件
 	router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
-	router.PathPrefix("/uploads/").Handler(http.StripPrefix("/uploads/", http.FileServer(http.Dir("uploads"))))
+	if uploadPrefix := cfg.Upload.URLPrefix(); !strings.Contains(uploadPrefix, "://") {
+		router.PathPrefix(uploadPrefix).Handler(http.StripPrefix(uploadPrefix, http.FileServer(http.Dir(cfg.Upload.LocalDir()))))
+	}
 
 	// 注册API路由
 	api.RegisterRoutes(router, db, cache, cfg, pluginManager)
@@ -249,6 +254,10 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 
 	// 文章路由
 	router.HandleFunc("/article/{id:[0-9]+}.html", articleController.Detail).Methods("GET")
+	router.HandleFunc("/article/{id:[0-9]+}_{page:[0-9]+}.html", articleController.Detail).Methods("GET")
+	// 静态文章页的地址，静态文件不存在时动态生成
+	router.HandleFunc("/a/{id:[0-9]+}.html", articleController.Detail).Methods("GET")
+	router.HandleFunc("/a/{id:[0-9]+}_{page:[0-9]+}.html", articleController.Detail).Methods("GET")
 	router.HandleFunc("/list/{typeid:[0-9]+}.html", articleController.List).Methods("GET")
 	router.HandleFunc("/articles", articleController.AllArticles).Methods("GET")
 
@@ -340,9 +349,9 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 	adminModelController := admin.NewModelController(db, cache, cfg)
 	adminPluginController := admin.NewPluginController(db, cache, cfg, pluginManager)
 	adminCollectController := admin.NewCollectController(db, cache, cfg)
+	adminImportController := admin.NewImportController(db, cache, cfg)
 	adminVoteController := admin.NewVoteController(db, cache, cfg)
 	adminLinkController := admin.NewLinkController(db, cache, cfg)
-	adminDBFixController := admin.NewDBFixController(db)
 
 	// 后台路由
 	adminRouter := router.PathPrefix("/aq3cms").Subrouter()
@@ -371,6 +380,19 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 	adminAuthRouter.HandleFunc("/article_edit/{id:[0-9]+}", adminArticleController.Edit).Methods("GET")
 	adminAuthRouter.HandleFunc("/article_edit/{id:[0-9]+}", adminArticleController.DoEdit).Methods("POST")
 	adminAuthRouter.HandleFunc("/article_delete/{id:[0-9]+}", adminArticleController.Delete).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_suggest", adminArticleController.Suggest).Methods("POST")
+	adminAuthRouter.HandleFunc("/article_batch", adminArticleController.Batch).Methods("POST")
+	adminAuthRouter.HandleFunc("/article_batch_delete", adminArticleController.BatchDelete).Methods("POST")
+	adminAuthRouter.HandleFunc("/article_batch_localize", adminArticleController.BatchLocalizeImages).Methods("POST")
+	adminAuthRouter.HandleFunc("/article_schedule", adminArticleController.Schedule).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_recycle", adminArticleController.Recycle).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_restore/{id:[0-9]+}", adminArticleController.Restore).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_purge/{id:[0-9]+}", adminArticleController.Purge).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_revisions/{id:[0-9]+}", adminArticleController.Revisions).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_revision_diff", adminArticleController.RevisionDiff).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_revision_restore/{id:[0-9]+}", adminArticleController.RevisionRestore).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_workflow/{id:[0-9]+}", adminArticleController.Workflow).Methods("GET")
+	adminAuthRouter.HandleFunc("/article_transition/{id:[0-9]+}", adminArticleController.Transition).Methods("POST")
 
 	// 栏目管理
 	adminAuthRouter.HandleFunc("/category", adminCategoryController.Index).Methods("GET")
@@ -400,16 +422,13 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 	adminAuthRouter.HandleFunc("/member_delete/{id:[0-9]+}", adminMemberController.Delete).Methods("GET")
 	adminAuthRouter.HandleFunc("/member_batch_delete", adminMemberController.BatchDelete).Methods("POST")
 
-	// 数据库修复
-	adminAuthRouter.HandleFunc("/dbfix_member_table", adminDBFixController.FixMemberTable).Methods("POST")
-	adminAuthRouter.HandleFunc("/dbfix_create_feedback_table", adminDBFixController.CreateFeedbackTable).Methods("POST")
-
 	// 系统设置
 	adminAuthRouter.HandleFunc("/system_config", adminSystemController.Config).Methods("GET")
 	adminAuthRouter.HandleFunc("/system_config", adminSystemController.SaveConfig).Methods("POST")
 	adminAuthRouter.HandleFunc("/system_database", adminSystemController.Database).Methods("GET")
 	adminAuthRouter.HandleFunc("/system_backup", adminSystemController.Backup).Methods("POST")
 	adminAuthRouter.HandleFunc("/system_restore", adminSystemController.Restore).Methods("POST")
+	adminAuthRouter.HandleFunc("/system_slow_query_reset", adminSystemController.ResetSlowQueries).Methods("POST")
 	adminAuthRouter.HandleFunc("/system_cache", adminSystemController.Cache).Methods("GET")
 	adminAuthRouter.HandleFunc("/system_clear_cache", adminSystemController.ClearCache).Methods("POST")
 	adminAuthRouter.HandleFunc("/system_log", adminSystemController.Log).Methods("GET")
@@ -491,6 +510,7 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 	adminAuthRouter.HandleFunc("/model_add", adminModelController.DoAdd).Methods("POST")
 	adminAuthRouter.HandleFunc("/model_edit/{id:[0-9]+}", adminModelController.Edit).Methods("GET")
 	adminAuthRouter.HandleFunc("/model_edit", adminModelController.DoEdit).Methods("POST")
+	adminAuthRouter.HandleFunc("/model_preview", adminModelController.Preview).Methods("POST")
 	adminAuthRouter.HandleFunc("/model_delete/{id:[0-9]+}", adminModelController.Delete).Methods("GET")
 	adminAuthRouter.HandleFunc("/model_fields/{id:[0-9]+}", adminModelController.Fields).Methods("GET")
 	adminAuthRouter.HandleFunc("/model_content/{id:[0-9]+}", adminModelController.Content).Methods("GET")
@@ -523,6 +543,14 @@ func RegisterRoutes(router *mux.Router, db *database.DB, cache cache.Cache, cfg
 	adminAuthRouter.HandleFunc("/collect_item_publish/{id:[0-9]+}", adminCollectController.ItemPublish).Methods("GET")
 	adminAuthRouter.HandleFunc("/collect_item_delete/{id:[0-9]+}", adminCollectController.ItemDelete).Methods("GET")
 	adminAuthRouter.HandleFunc("/collect_batch_publish/{id:[0-9]+}", adminCollectController.BatchPublish).Methods("GET")
+	adminAuthRouter.HandleFunc("/collect_duplicates", adminCollectController.Duplicates).Methods("GET")
+
+	// 数据导入导出
+	adminAuthRouter.HandleFunc("/import", adminImportController.Index).Methods("GET")
+	adminAuthRouter.HandleFunc("/import_wxr", adminImportController.DoWXR).Methods("POST")
+	adminAuthRouter.HandleFunc("/import_bundle", adminImportController.DoBundle).Methods("POST")
+	adminAuthRouter.HandleFunc("/export", adminImportController.Export).Methods("POST")
+	adminAuthRouter.HandleFunc("/import_map/{name}", adminImportController.MapFile).Methods("GET")
 
 	// 投票管理
 	adminAuthRouter.HandleFunc("/vote_list", adminVoteController.List).Methods("GET")
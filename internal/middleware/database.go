@@ -0,0 +1,14 @@
+package middleware
+
+import (
+	"net/http"
+
+	"aq3cms/pkg/database"
+)
+
+// DBSession 数据库会话中间件，请求中执行写操作后，后续读取都走主库
+func DBSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(database.WithSession(r.Context())))
+	})
+}
@@ -10,39 +10,39 @@ import (
 
 // Ad 广告
 type Ad struct {
-	ID         int64     `json:"id"`
-	PositionID int64     `json:"positionid"` // 广告位ID
-	Title      string    `json:"title"`      // 广告标题
-	Type       int       `json:"type"`       // 广告类型：0图片，1Flash，2代码，3文字
-	Image      string    `json:"image"`      // 图片地址
-	Flash      string    `json:"flash"`      // Flash地址
-	Code       string    `json:"code"`       // 代码内容
-	Text       string    `json:"text"`       // 文字内容
-	URL        string    `json:"url"`        // 链接地址
-	StartTime  time.Time `json:"starttime"`  // 开始时间
-	EndTime    time.Time `json:"endtime"`    // 结束时间
-	OrderID    int       `json:"orderid"`    // 排序ID
-	Status     int       `json:"status"`     // 状态：0禁用，1启用
-	Target     string    `json:"target"`     // 打开方式：_blank, _self
-	Width      int       `json:"width"`      // 宽度
-	Height     int       `json:"height"`     // 高度
-	Click      int       `json:"click"`      // 点击次数
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	PositionID int64     `json:"positionid" db:"positionid"` // 广告位ID
+	Title      string    `json:"title" db:"title"`           // 广告标题
+	Type       int       `json:"type" db:"type"`             // 广告类型：0图片，1Flash，2代码，3文字
+	Image      string    `json:"image" db:"image"`           // 图片地址
+	Flash      string    `json:"flash" db:"flash"`           // Flash地址
+	Code       string    `json:"code" db:"code"`             // 代码内容
+	Text       string    `json:"text" db:"text"`             // 文字内容
+	URL        string    `json:"url" db:"url"`               // 链接地址
+	StartTime  time.Time `json:"starttime" db:"starttime"`   // 开始时间
+	EndTime    time.Time `json:"endtime" db:"endtime"`       // 结束时间
+	OrderID    int       `json:"orderid" db:"orderid"`       // 排序ID
+	Status     int       `json:"status" db:"status"`         // 状态：0禁用，1启用
+	Target     string    `json:"target" db:"target"`         // 打开方式：_blank, _self
+	Width      int       `json:"width" db:"width"`           // 宽度
+	Height     int       `json:"height" db:"height"`         // 高度
+	Click      int       `json:"click" db:"click"`           // 点击次数
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // AdPosition 广告位
 type AdPosition struct {
-	ID         int64     `json:"id"`
-	Name       string    `json:"name"`       // 广告位名称
-	Code       string    `json:"code"`       // 广告位代码
-	Width      int       `json:"width"`      // 宽度
-	Height     int       `json:"height"`     // 高度
-	Template   string    `json:"template"`   // 模板
-	Description string   `json:"description"` // 描述
-	Status     int       `json:"status"`     // 状态：0禁用，1启用
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`               // 广告位名称
+	Code        string    `json:"code" db:"code"`               // 广告位代码
+	Width       int       `json:"width" db:"width"`             // 宽度
+	Height      int       `json:"height" db:"height"`           // 高度
+	Template    string    `json:"template" db:"template"`       // 模板
+	Description string    `json:"description" db:"description"` // 描述
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // AdModel 广告模型
@@ -64,39 +64,18 @@ func (m *AdModel) GetByID(id int64) (*Ad, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	ad := &Ad{}
+	found, err := qb.FirstInto(ad)
 	if err != nil {
 		logger.Error("获取广告失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("ad not found: %d", id)
 	}
 
-	// 转换为广告
-	ad := &Ad{}
-	ad.ID, _ = result["id"].(int64)
-	ad.PositionID, _ = result["positionid"].(int64)
-	ad.Title, _ = result["title"].(string)
-	ad.Type, _ = result["type"].(int)
-	ad.Image, _ = result["image"].(string)
-	ad.Flash, _ = result["flash"].(string)
-	ad.Code, _ = result["code"].(string)
-	ad.Text, _ = result["text"].(string)
-	ad.URL, _ = result["url"].(string)
-	ad.StartTime, _ = result["starttime"].(time.Time)
-	ad.EndTime, _ = result["endtime"].(time.Time)
-	ad.OrderID, _ = result["orderid"].(int)
-	ad.Status, _ = result["status"].(int)
-	ad.Target, _ = result["target"].(string)
-	ad.Width, _ = result["width"].(int)
-	ad.Height, _ = result["height"].(int)
-	ad.Click, _ = result["click"].(int)
-	ad.CreateTime, _ = result["createtime"].(time.Time)
-	ad.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return ad, nil
 }
 
@@ -111,38 +90,12 @@ func (m *AdModel) GetByPositionID(positionID int64) ([]*Ad, error) {
 	qb.OrderBy("orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	ads := make([]*Ad, 0)
+	if err := qb.GetInto(&ads); err != nil {
 		logger.Error("获取广告失败", "positionid", positionID, "error", err)
 		return nil, err
 	}
 
-	// 转换为广告列表
-	ads := make([]*Ad, 0, len(results))
-	for _, result := range results {
-		ad := &Ad{}
-		ad.ID, _ = result["id"].(int64)
-		ad.PositionID, _ = result["positionid"].(int64)
-		ad.Title, _ = result["title"].(string)
-		ad.Type, _ = result["type"].(int)
-		ad.Image, _ = result["image"].(string)
-		ad.Flash, _ = result["flash"].(string)
-		ad.Code, _ = result["code"].(string)
-		ad.Text, _ = result["text"].(string)
-		ad.URL, _ = result["url"].(string)
-		ad.StartTime, _ = result["starttime"].(time.Time)
-		ad.EndTime, _ = result["endtime"].(time.Time)
-		ad.OrderID, _ = result["orderid"].(int)
-		ad.Status, _ = result["status"].(int)
-		ad.Target, _ = result["target"].(string)
-		ad.Width, _ = result["width"].(int)
-		ad.Height, _ = result["height"].(int)
-		ad.Click, _ = result["click"].(int)
-		ad.CreateTime, _ = result["createtime"].(time.Time)
-		ad.UpdateTime, _ = result["updatetime"].(time.Time)
-		ads = append(ads, ad)
-	}
-
 	return ads, nil
 }
 
@@ -159,38 +112,12 @@ func (m *AdModel) GetAll(positionID int64, status int) ([]*Ad, error) {
 	qb.OrderBy("orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	ads := make([]*Ad, 0)
+	if err := qb.GetInto(&ads); err != nil {
 		logger.Error("获取所有广告失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为广告列表
-	ads := make([]*Ad, 0, len(results))
-	for _, result := range results {
-		ad := &Ad{}
-		ad.ID, _ = result["id"].(int64)
-		ad.PositionID, _ = result["positionid"].(int64)
-		ad.Title, _ = result["title"].(string)
-		ad.Type, _ = result["type"].(int)
-		ad.Image, _ = result["image"].(string)
-		ad.Flash, _ = result["flash"].(string)
-		ad.Code, _ = result["code"].(string)
-		ad.Text, _ = result["text"].(string)
-		ad.URL, _ = result["url"].(string)
-		ad.StartTime, _ = result["starttime"].(time.Time)
-		ad.EndTime, _ = result["endtime"].(time.Time)
-		ad.OrderID, _ = result["orderid"].(int)
-		ad.Status, _ = result["status"].(int)
-		ad.Target, _ = result["target"].(string)
-		ad.Width, _ = result["width"].(int)
-		ad.Height, _ = result["height"].(int)
-		ad.Click, _ = result["click"].(int)
-		ad.CreateTime, _ = result["createtime"].(time.Time)
-		ad.UpdateTime, _ = result["updatetime"].(time.Time)
-		ads = append(ads, ad)
-	}
-
 	return ads, nil
 }
 
@@ -300,30 +227,18 @@ func (m *AdPositionModel) GetByID(id int64) (*AdPosition, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	position := &AdPosition{}
+	found, err := qb.FirstInto(position)
 	if err != nil {
 		logger.Error("获取广告位失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("ad position not found: %d", id)
 	}
 
-	// 转换为广告位
-	position := &AdPosition{}
-	position.ID, _ = result["id"].(int64)
-	position.Name, _ = result["name"].(string)
-	position.Code, _ = result["code"].(string)
-	position.Width, _ = result["width"].(int)
-	position.Height, _ = result["height"].(int)
-	position.Template, _ = result["template"].(string)
-	position.Description, _ = result["description"].(string)
-	position.Status, _ = result["status"].(int)
-	position.CreateTime, _ = result["createtime"].(time.Time)
-	position.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return position, nil
 }
 
@@ -334,30 +249,18 @@ func (m *AdPositionModel) GetByCode(code string) (*AdPosition, error) {
 	qb.Where("code = ?", code)
 
 	// 执行查询
-	result, err := qb.First()
+	position := &AdPosition{}
+	found, err := qb.FirstInto(position)
 	if err != nil {
 		logger.Error("获取广告位失败", "code", code, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("ad position not found: %s", code)
 	}
 
-	// 转换为广告位
-	position := &AdPosition{}
-	position.ID, _ = result["id"].(int64)
-	position.Name, _ = result["name"].(string)
-	position.Code, _ = result["code"].(string)
-	position.Width, _ = result["width"].(int)
-	position.Height, _ = result["height"].(int)
-	position.Template, _ = result["template"].(string)
-	position.Description, _ = result["description"].(string)
-	position.Status, _ = result["status"].(int)
-	position.CreateTime, _ = result["createtime"].(time.Time)
-	position.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return position, nil
 }
 
@@ -371,29 +274,12 @@ func (m *AdPositionModel) GetAll(status int) ([]*AdPosition, error) {
 	qb.OrderBy("id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	positions := make([]*AdPosition, 0)
+	if err := qb.GetInto(&positions); err != nil {
 		logger.Error("获取所有广告位失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为广告位列表
-	positions := make([]*AdPosition, 0, len(results))
-	for _, result := range results {
-		position := &AdPosition{}
-		position.ID, _ = result["id"].(int64)
-		position.Name, _ = result["name"].(string)
-		position.Code, _ = result["code"].(string)
-		position.Width, _ = result["width"].(int)
-		position.Height, _ = result["height"].(int)
-		position.Template, _ = result["template"].(string)
-		position.Description, _ = result["description"].(string)
-		position.Status, _ = result["status"].(int)
-		position.CreateTime, _ = result["createtime"].(time.Time)
-		position.UpdateTime, _ = result["updatetime"].(time.Time)
-		positions = append(positions, position)
-	}
-
 	return positions, nil
 }
 
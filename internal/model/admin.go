@@ -11,16 +11,16 @@ import (
 
 // Admin 管理员模型
 type Admin struct {
-	ID         int64     `json:"id"`
-	Username   string    `json:"username"`
-	Password   string    `json:"password"`
-	Email      string    `json:"email"`
-	RealName   string    `json:"realname"`
-	Rank       int       `json:"rank"`
-	Status     int       `json:"status"`
-	LastLogin  time.Time `json:"lastlogin"`
-	LastIP     string    `json:"lastip"`
-	LoginCount int       `json:"logincount"`
+	ID         int64     `json:"id" db:"id"`
+	Username   string    `json:"username" db:"username"`
+	Password   string    `json:"password" db:"password"`
+	Email      string    `json:"email" db:"email"`
+	RealName   string    `json:"realname" db:"realname"`
+	Rank       int       `json:"rank" db:"rank"`
+	Status     int       `json:"status" db:"status"`
+	LastLogin  time.Time `json:"lastlogin" db:"lastlogin"`
+	LastIP     string    `json:"lastip" db:"lastip"`
+	LoginCount int       `json:"logincount" db:"logincount"`
 }
 
 // AdminModel 管理员模型操作
@@ -43,44 +43,17 @@ func (m *AdminModel) GetByID(id int64) (*Admin, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	admin := &Admin{}
+	found, err := qb.FirstInto(admin)
 	if err != nil {
 		logger.Error("查询管理员失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, nil
 	}
 
-	// 转换为管理员对象
-	admin := &Admin{}
-	admin.ID, _ = result["id"].(int64)
-	admin.Username, _ = result["username"].(string)
-	admin.Password, _ = result["password"].(string)
-	admin.Email, _ = result["email"].(string)
-	admin.RealName, _ = result["realname"].(string)
-
-	// 处理整数字段
-	if rank, ok := result["rank"].(int64); ok {
-		admin.Rank = int(rank)
-	}
-	if status, ok := result["status"].(int64); ok {
-		admin.Status = int(status)
-	}
-
-	// 处理日期
-	if lastlogin, ok := result["lastlogin"].(time.Time); ok {
-		admin.LastLogin = lastlogin
-	}
-
-	admin.LastIP, _ = result["lastip"].(string)
-
-	// 处理整数字段
-	if logincount, ok := result["logincount"].(int64); ok {
-		admin.LoginCount = int(logincount)
-	}
-
 	return admin, nil
 }
 
@@ -92,44 +65,17 @@ func (m *AdminModel) GetByUsername(username string) (*Admin, error) {
 	qb.Where("username = ?", username)
 
 	// 执行查询
-	result, err := qb.First()
+	admin := &Admin{}
+	found, err := qb.FirstInto(admin)
 	if err != nil {
 		logger.Error("查询管理员失败", "username", username, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, nil
 	}
 
-	// 转换为管理员对象
-	admin := &Admin{}
-	admin.ID, _ = result["id"].(int64)
-	admin.Username, _ = result["username"].(string)
-	admin.Password, _ = result["password"].(string)
-	admin.Email, _ = result["email"].(string)
-	admin.RealName, _ = result["realname"].(string)
-
-	// 处理整数字段
-	if rank, ok := result["rank"].(int64); ok {
-		admin.Rank = int(rank)
-	}
-	if status, ok := result["status"].(int64); ok {
-		admin.Status = int(status)
-	}
-
-	// 处理日期
-	if lastlogin, ok := result["lastlogin"].(time.Time); ok {
-		admin.LastLogin = lastlogin
-	}
-
-	admin.LastIP, _ = result["lastip"].(string)
-
-	// 处理整数字段
-	if logincount, ok := result["logincount"].(int64); ok {
-		admin.LoginCount = int(logincount)
-	}
-
 	return admin, nil
 }
 
@@ -141,45 +87,12 @@ func (m *AdminModel) GetAll() ([]*Admin, error) {
 	qb.OrderBy("id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	admins := make([]*Admin, 0)
+	if err := qb.GetInto(&admins); err != nil {
 		logger.Error("查询所有管理员失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为管理员对象
-	admins := make([]*Admin, 0, len(results))
-	for _, result := range results {
-		admin := &Admin{}
-		admin.ID, _ = result["id"].(int64)
-		admin.Username, _ = result["username"].(string)
-		admin.Password, _ = result["password"].(string)
-		admin.Email, _ = result["email"].(string)
-		admin.RealName, _ = result["realname"].(string)
-
-		// 处理整数字段
-		if rank, ok := result["rank"].(int64); ok {
-			admin.Rank = int(rank)
-		}
-		if status, ok := result["status"].(int64); ok {
-			admin.Status = int(status)
-		}
-
-		// 处理日期
-		if lastlogin, ok := result["lastlogin"].(time.Time); ok {
-			admin.LastLogin = lastlogin
-		}
-
-		admin.LastIP, _ = result["lastip"].(string)
-
-		// 处理整数字段
-		if logincount, ok := result["logincount"].(int64); ok {
-			admin.LoginCount = int(logincount)
-		}
-
-		admins = append(admins, admin)
-	}
-
 	return admins, nil
 }
 
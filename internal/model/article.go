@@ -2,47 +2,58 @@ package model
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"aq3cms/pkg/database"
+	"aq3cms/pkg/extract"
 	"aq3cms/pkg/logger"
+	"aq3cms/pkg/similarity"
 )
 
 // Article 文章模型
 type Article struct {
-	ID           int64     `json:"id"`
-	TypeID       int64     `json:"typeid"`
-	Title        string    `json:"title"`
-	ShortTitle   string    `json:"shorttitle"`
-	Color        string    `json:"color"`
-	Writer       string    `json:"writer"`
-	Source       string    `json:"source"`
+	ID           int64     `json:"id" db:"id"`
+	TypeID       int64     `json:"typeid" db:"typeid"`
+	Title        string    `json:"title" db:"title"`
+	ShortTitle   string    `json:"shorttitle" db:"shorttitle"`
+	Color        string    `json:"color" db:"color"`
+	Writer       string    `json:"writer" db:"writer"`
+	Source       string    `json:"source" db:"source"`
 	Author       string    `json:"author"` // 作者
-	LitPic       string    `json:"litpic"`
-	PubDate      time.Time `json:"pubdate"`
-	SendDate     time.Time `json:"senddate"`
-	UpdateDate   time.Time `json:"updatedate"` // 更新时间
-	Keywords     string    `json:"keywords"`
-	Description  string    `json:"description"`
-	Filename     string    `json:"filename"`
+	LitPic       string    `json:"litpic" db:"litpic"`
+	PubDate      time.Time `json:"pubdate" db:"pubdate"`
+	SendDate     time.Time `json:"senddate" db:"senddate"`
+	ExpireDate   time.Time `json:"expiredate" db:"expiredate"` // 到期下线时间，零值表示不下线
+	UpdateDate   time.Time `json:"updatedate"`                 // 更新时间
+	Keywords     string    `json:"keywords" db:"keywords"`
+	Description  string    `json:"description" db:"description"`
+	Filename     string    `json:"filename" db:"filename"`
+	Flag         string    `json:"flag" db:"flag"` // 属性：c置顶 h推荐 p热门
 	IsTop        int       `json:"istop"`
 	IsRecommend  int       `json:"isrecommend"`
 	IsHot        int       `json:"ishot"`
-	ArcRank      int       `json:"arcrank"`
-	Click        int       `json:"click"`
-	Status       int       `json:"status"`   // 状态
-	MemberID     int64     `json:"memberid"` // 会员ID
-	Body         string    `json:"body"`
-	Content      string    `json:"content"` // 内容（兼容旧版）
-	TypeName     string    `json:"typename"`
-	TypeDir      string    `json:"typedir"`
-	CategoryName string    `json:"categoryname"` // 栏目名称（兼容模板）
-	TemplateFile string    `json:"templatefile"` // 自定义模板文件
-	Tags         string    `json:"tags"`         // 标签
+	ArcRank      int       `json:"arcrank" db:"arcrank"`
+	State        int       `json:"state" db:"state"` // 审核流程状态
+	Click        int       `json:"click" db:"click"`
+	Status       int       `json:"status"`            // 状态
+	MemberID     int64     `json:"memberid" db:"mid"` // 会员ID
+	Body         string    `json:"body" db:"body"`
+	Format       string    `json:"format" db:"format"`     // 内容格式：html或markdown
+	Markdown     string    `json:"markdown" db:"markdown"` // Markdown原文
+	Content      string    `json:"content"`                // 内容（兼容旧版）
+	TypeName     string    `json:"typename" db:"typename"`
+	TypeDir      string    `json:"typedir" db:"typedir"`
+	CategoryName string    `json:"categoryname"`               // 栏目名称（兼容模板）
+	TemplateFile string    `json:"templatefile"`               // 自定义模板文件
+	Tags         string    `json:"tags"`                       // 标签
+	CrossTypeIDs []int64   `json:"crosstypeids"`               // 副栏目ID
+	DeletedAt    time.Time `json:"deleted_at" db:"deleted_at"` // 移入回收站的时间
 }
 
+// ArcRankRecycled 文档在回收站中的arcrank
+const ArcRankRecycled = -2
+
 // ArticleModel 文章模型操作
 type ArticleModel struct {
 	db *database.DB
@@ -55,6 +66,25 @@ func NewArticleModel(db *database.DB) *ArticleModel {
 	}
 }
 
+// AfterScan 根据数据库字段填充派生字段
+func (a *Article) AfterScan() {
+	a.IsTop, a.IsRecommend, a.IsHot = parseFlags(a.Flag)
+	a.Content = a.Body
+	a.CategoryName = a.TypeName
+	a.TemplateFile = a.Filename // filename字段对应模板文件
+	if a.ExpireDate.Unix() <= 0 {
+		a.ExpireDate = time.Time{}
+	}
+}
+
+// expireUnix 到期下线时间的Unix时间戳，未设置时为0
+func (a *Article) expireUnix() int64 {
+	if a.ExpireDate.IsZero() {
+		return 0
+	}
+	return a.ExpireDate.Unix()
+}
+
 // parseFlags 解析flag字段
 func parseFlags(flagStr string) (isTop, isRecommend, isHot int) {
 	if flagStr == "" {
@@ -76,128 +106,91 @@ func parseFlags(flagStr string) (isTop, isRecommend, isHot int) {
 	return isTop, isRecommend, isHot
 }
 
+// ChangeFlags 在flag字段中加入set中的属性并去掉clear中的属性，保留其他属性
+func ChangeFlags(flagStr string, set, clear []string) string {
+	flags := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, flag := range append(strings.Split(flagStr, ","), set...) {
+		flag = strings.TrimSpace(flag)
+		if flag == "" || seen[flag] {
+			continue
+		}
+		seen[flag] = true
+		flags = append(flags, flag)
+	}
+
+	result := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		removed := false
+		for _, c := range clear {
+			if flag == c {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			result = append(result, flag)
+		}
+	}
+	return strings.Join(result, ",")
+}
+
+// FlagString 根据置顶、推荐、热门属性构建flag字段
+func (a *Article) FlagString() string {
+	var flags []string
+	if a.IsTop == 1 {
+		flags = append(flags, "c") // 置顶
+	}
+	if a.IsRecommend == 1 {
+		flags = append(flags, "h") // 推荐
+	}
+	if a.IsHot == 1 {
+		flags = append(flags, "p") // 热门
+	}
+	return strings.Join(flags, ",")
+}
+
+// ContentSimHash 根据标题和HTML正文计算内容指纹，按有符号整数保存到数据库，0表示没有文字
+func ContentSimHash(title, body string) int64 {
+	text := title + "\n" + strings.Join(extract.Paragraphs(body), "\n")
+	return int64(similarity.SimHash(text))
+}
+
+// IsPublished 是否已发布且不在回收站中
+func (a *Article) IsPublished() bool {
+	return a.State == ArticleStatePublished && a.ArcRank > -1
+}
+
+// StateName 审核流程状态名称
+func (a *Article) StateName() string {
+	if a.ArcRank == ArcRankRecycled {
+		return "回收站"
+	}
+	return ArticleStateNames[a.State]
+}
+
 // GetByID 根据ID获取文章
 func (m *ArticleModel) GetByID(id int64) (*Article, error) {
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "archives")
-	qb.Select("a.id", "a.typeid", "a.title", "a.shorttitle", "a.color", "a.writer", "a.source", "a.litpic", "a.pubdate", "a.senddate", "a.keywords", "a.description", "a.filename", "a.flag", "a.arcrank", "a.click", "t.typename", "t.typedir", "ad.body")
+	qb.Select("a.id", "a.typeid", "a.title", "a.shorttitle", "a.color", "a.writer", "a.source", "a.litpic", "a.pubdate", "a.senddate", "a.expiredate", "a.keywords", "a.description", "a.filename", "a.flag", "a.arcrank", "a.state", "a.click", "t.typename", "t.typedir", "ad.body", "ad.format", "ad.markdown")
 	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.LeftJoin(m.db.TableName("addonarticle")+" AS ad", "a.id = ad.aid")
 	qb.Where("a.id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	article := &Article{}
+	found, err := qb.FirstInto(article)
 	if err != nil {
 		logger.Error("查询文章失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("文章不存在")
 	}
 
-	// 转换为文章对象
-	article := &Article{}
-
-	// 处理ID字段 - 使用通用转换方法
-	if idVal := result["id"]; idVal != nil {
-		switch v := idVal.(type) {
-		case int64:
-			article.ID = v
-		case int:
-			article.ID = int64(v)
-		case int32:
-			article.ID = int64(v)
-		case []uint8: // MySQL可能返回字节数组
-			if len(v) > 0 {
-				if id, err := strconv.ParseInt(string(v), 10, 64); err == nil {
-					article.ID = id
-				}
-			}
-		case string:
-			if id, err := strconv.ParseInt(v, 10, 64); err == nil {
-				article.ID = id
-			}
-		}
-	}
-
-	// 处理TypeID字段 - 使用通用转换方法
-	if typeidVal := result["typeid"]; typeidVal != nil {
-		switch v := typeidVal.(type) {
-		case int64:
-			article.TypeID = v
-		case int:
-			article.TypeID = int64(v)
-		case int32:
-			article.TypeID = int64(v)
-		case []uint8:
-			if len(v) > 0 {
-				if typeid, err := strconv.ParseInt(string(v), 10, 64); err == nil {
-					article.TypeID = typeid
-				}
-			}
-		case string:
-			if typeid, err := strconv.ParseInt(v, 10, 64); err == nil {
-				article.TypeID = typeid
-			}
-		}
-	}
-	article.Title, _ = result["title"].(string)
-	article.ShortTitle, _ = result["shorttitle"].(string)
-	article.Color, _ = result["color"].(string)
-	article.Writer, _ = result["writer"].(string)
-	article.Source, _ = result["source"].(string)
-	article.LitPic, _ = result["litpic"].(string)
-
-	// 处理日期 - 数据库中存储的是Unix时间戳
-	if pubdate, ok := result["pubdate"].(int64); ok {
-		article.PubDate = time.Unix(pubdate, 0)
-	} else if pubdate, ok := result["pubdate"].(int); ok {
-		article.PubDate = time.Unix(int64(pubdate), 0)
-	} else if pubdate, ok := result["pubdate"].(time.Time); ok {
-		article.PubDate = pubdate
-	}
-
-	if senddate, ok := result["senddate"].(int64); ok {
-		article.SendDate = time.Unix(senddate, 0)
-	} else if senddate, ok := result["senddate"].(int); ok {
-		article.SendDate = time.Unix(int64(senddate), 0)
-	} else if senddate, ok := result["senddate"].(time.Time); ok {
-		article.SendDate = senddate
-	}
-
-	article.Keywords, _ = result["keywords"].(string)
-	article.Description, _ = result["description"].(string)
-	article.Filename, _ = result["filename"].(string)
-	article.TemplateFile, _ = result["filename"].(string) // filename字段对应模板文件
-
-	// 解析flag字段
-	if flagStr, ok := result["flag"].(string); ok {
-		article.IsTop, article.IsRecommend, article.IsHot = parseFlags(flagStr)
-	}
-
-	// 处理整数字段
-	if arcrank, ok := result["arcrank"].(int64); ok {
-		article.ArcRank = int(arcrank)
-	} else if arcrank, ok := result["arcrank"].(int); ok {
-		article.ArcRank = arcrank
-	}
-
-	if click, ok := result["click"].(int64); ok {
-		article.Click = int(click)
-	} else if click, ok := result["click"].(int); ok {
-		article.Click = click
-	}
-
-	article.Body, _ = result["body"].(string)
-	article.Content = article.Body // 设置Content字段与Body相同
-	article.TypeName, _ = result["typename"].(string)
-	article.TypeDir, _ = result["typedir"].(string)
-
-	// 设置CategoryName字段以兼容模板
-	article.CategoryName = article.TypeName
-
 	return article, nil
 }
 
@@ -205,14 +198,20 @@ func (m *ArticleModel) GetByID(id int64) (*Article, error) {
 func (m *ArticleModel) GetList(typeid int64, page, pageSize int) ([]*Article, int, error) {
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "archives")
-	qb.Select("a.id", "a.typeid", "a.title", "a.shorttitle", "a.color", "a.writer", "a.source", "a.litpic", "a.pubdate", "a.senddate", "a.keywords", "a.description", "a.filename", "a.flag", "a.arcrank", "a.click", "t.typename", "t.typedir")
+	qb.Select("a.id", "a.typeid", "a.title", "a.shorttitle", "a.color", "a.writer", "a.source", "a.litpic", "a.pubdate", "a.senddate", "a.keywords", "a.description", "a.filename", "a.flag", "a.arcrank", "a.state", "a.click", "t.typename", "t.typedir")
 	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 
 	// 添加条件
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	if typeid > 0 {
-		qb.Where("a.typeid = ?", typeid)
+		// 包括发布到副栏目和交叉栏目的文章
+		condition, args, err := m.CategoryCondition(typeid)
+		if err != nil {
+			return nil, 0, err
+		}
+		qb.Where(condition, args...)
 	}
 
 	// 获取总数
@@ -228,118 +227,12 @@ func (m *ArticleModel) GetList(typeid int64, page, pageSize int) ([]*Article, in
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
 		logger.Error("查询文章列表失败", "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-
-		// 处理ID字段 - 使用通用转换方法
-		if idVal := result["id"]; idVal != nil {
-			switch v := idVal.(type) {
-			case int64:
-				article.ID = v
-			case int:
-				article.ID = int64(v)
-			case int32:
-				article.ID = int64(v)
-			case []uint8: // MySQL可能返回字节数组
-				if len(v) > 0 {
-					if id, err := strconv.ParseInt(string(v), 10, 64); err == nil {
-						article.ID = id
-					}
-				}
-			case string:
-				if id, err := strconv.ParseInt(v, 10, 64); err == nil {
-					article.ID = id
-				}
-			default:
-				logger.Error("ID字段类型不支持", "value", idVal, "type", fmt.Sprintf("%T", idVal))
-			}
-		}
-
-		// 处理TypeID字段 - 使用通用转换方法
-		if typeidVal := result["typeid"]; typeidVal != nil {
-			switch v := typeidVal.(type) {
-			case int64:
-				article.TypeID = v
-			case int:
-				article.TypeID = int64(v)
-			case int32:
-				article.TypeID = int64(v)
-			case []uint8:
-				if len(v) > 0 {
-					if typeid, err := strconv.ParseInt(string(v), 10, 64); err == nil {
-						article.TypeID = typeid
-					}
-				}
-			case string:
-				if typeid, err := strconv.ParseInt(v, 10, 64); err == nil {
-					article.TypeID = typeid
-				}
-			}
-		}
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期 - 数据库中存储的是Unix时间戳
-		if pubdate, ok := result["pubdate"].(int64); ok {
-			article.PubDate = time.Unix(pubdate, 0)
-		} else if pubdate, ok := result["pubdate"].(int); ok {
-			article.PubDate = time.Unix(int64(pubdate), 0)
-		} else if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-
-		if senddate, ok := result["senddate"].(int64); ok {
-			article.SendDate = time.Unix(senddate, 0)
-		} else if senddate, ok := result["senddate"].(int); ok {
-			article.SendDate = time.Unix(int64(senddate), 0)
-		} else if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-		article.TemplateFile, _ = result["filename"].(string) // filename字段对应模板文件
-
-		// 解析flag字段
-		if flagStr, ok := result["flag"].(string); ok {
-			article.IsTop, article.IsRecommend, article.IsHot = parseFlags(flagStr)
-		}
-
-		// 处理整数字段
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		} else if arcrank, ok := result["arcrank"].(int); ok {
-			article.ArcRank = arcrank
-		}
-
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
-		} else if click, ok := result["click"].(int); ok {
-			article.Click = click
-		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		// 设置CategoryName字段以兼容模板
-		article.CategoryName = article.TypeName
-
-		articles = append(articles, article)
-	}
-
 	return articles, total, nil
 }
 
@@ -385,8 +278,8 @@ func (m *ArticleModel) Create(article *Article) (int64, error) {
 
 	// 执行插入
 	result, err := tx.Exec(
-		"INSERT INTO "+m.db.TableName("archives")+" (typeid, title, shorttitle, color, writer, source, litpic, pubdate, senddate, keywords, description, filename, flag, arcrank, click) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		article.TypeID, article.Title, article.ShortTitle, article.Color, article.Writer, article.Source, article.LitPic, pubdate, senddate, article.Keywords, article.Description, article.Filename, flagStr, article.ArcRank, article.Click,
+		"INSERT INTO "+m.db.TableName("archives")+" (typeid, title, shorttitle, color, writer, source, litpic, pubdate, senddate, expiredate, keywords, description, filename, flag, arcrank, state, click, simhash) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		article.TypeID, article.Title, article.ShortTitle, article.Color, article.Writer, article.Source, article.LitPic, pubdate, senddate, article.expireUnix(), article.Keywords, article.Description, article.Filename, flagStr, article.ArcRank, article.State, article.Click, ContentSimHash(article.Title, article.Body),
 	)
 	if err != nil {
 		logger.Error("插入文章主表失败", "error", err)
@@ -402,8 +295,8 @@ func (m *ArticleModel) Create(article *Article) (int64, error) {
 
 	// 插入附加表
 	_, err = tx.Exec(
-		"INSERT INTO "+m.db.TableName("addonarticle")+" (aid, body) VALUES (?, ?)",
-		id, article.Body,
+		"INSERT INTO "+m.db.TableName("addonarticle")+" (aid, body, format, markdown) VALUES (?, ?, ?, ?)",
+		id, article.Body, NormalizeBodyFormat(article.Format), article.Markdown,
 	)
 	if err != nil {
 		logger.Error("插入文章附加表失败", "error", err)
@@ -419,28 +312,9 @@ func (m *ArticleModel) Create(article *Article) (int64, error) {
 	return id, nil
 }
 
-// Update 更新文章
+// Update 更新文章，已在事务中时加入当前事务
 func (m *ArticleModel) Update(article *Article) error {
-	// 开始事务
-	tx, err := m.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// 构建flag字段
-	var flags []string
-	if article.IsTop == 1 {
-		flags = append(flags, "c") // 置顶
-	}
-	if article.IsRecommend == 1 {
-		flags = append(flags, "h") // 推荐
-	}
-	if article.IsHot == 1 {
-		flags = append(flags, "p") // 热门
-	}
-	flagStr := strings.Join(flags, ",")
+	flagStr := article.FlagString()
 
 	// 转换时间为Unix时间戳，处理无效时间
 	var pubdate, senddate int64
@@ -459,68 +333,352 @@ func (m *ArticleModel) Update(article *Article) error {
 		senddate = article.SendDate.Unix()
 	}
 
-	// 更新主表
-	_, err = tx.Exec(
-		"UPDATE "+m.db.TableName("archives")+" SET typeid=?, title=?, shorttitle=?, color=?, writer=?, source=?, litpic=?, pubdate=?, senddate=?, keywords=?, description=?, filename=?, flag=?, arcrank=?, click=? WHERE id=?",
-		article.TypeID, article.Title, article.ShortTitle, article.Color, article.Writer, article.Source, article.LitPic, pubdate, senddate, article.Keywords, article.Description, article.Filename, flagStr, article.ArcRank, article.Click, article.ID,
+	return m.db.WithTx(func(tx *database.Tx) error {
+		// 更新主表
+		_, err := tx.Exec(
+			"UPDATE "+m.db.TableName("archives")+" SET typeid=?, title=?, shorttitle=?, color=?, writer=?, source=?, litpic=?, pubdate=?, senddate=?, expiredate=?, keywords=?, description=?, filename=?, flag=?, arcrank=?, click=?, simhash=? WHERE id=?",
+			article.TypeID, article.Title, article.ShortTitle, article.Color, article.Writer, article.Source, article.LitPic, pubdate, senddate, article.expireUnix(), article.Keywords, article.Description, article.Filename, flagStr, article.ArcRank, article.Click, ContentSimHash(article.Title, article.Body), article.ID,
+		)
+		if err != nil {
+			logger.Error("更新文章主表失败", "error", err)
+			return err
+		}
+
+		// 更新附加表
+		_, err = tx.Exec(
+			"UPDATE "+m.db.TableName("addonarticle")+" SET body=?, format=?, markdown=? WHERE aid=?",
+			article.Body, NormalizeBodyFormat(article.Format), article.Markdown, article.ID,
+		)
+		if err != nil {
+			logger.Error("更新文章附加表失败", "error", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Delete 彻底删除文章
+func (m *ArticleModel) Delete(id int64) error {
+	return m.db.WithTx(func(tx *database.Tx) error {
+		// 删除主表
+		_, err := tx.Exec(
+			"DELETE FROM "+m.db.TableName("archives")+" WHERE id=?",
+			id,
+		)
+		if err != nil {
+			logger.Error("删除文章主表失败", "error", err)
+			return err
+		}
+
+		// 删除附加表
+		_, err = tx.Exec(
+			"DELETE FROM "+m.db.TableName("addonarticle")+" WHERE aid=?",
+			id,
+		)
+		if err != nil {
+			logger.Error("删除文章附加表失败", "error", err)
+			return err
+		}
+
+		// 删除副栏目
+		_, err = tx.Exec(
+			"DELETE FROM "+m.db.TableName("arccross")+" WHERE aid=?",
+			id,
+		)
+		if err != nil {
+			logger.Error("删除文章副栏目失败", "error", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// MoveToRecycle 将文章移入回收站，保留删除前的arcrank用于恢复
+func (m *ArticleModel) MoveToRecycle(id int64) error {
+	result, err := m.db.Exec(
+		"UPDATE "+m.db.TableName("archives")+" SET deleted_arcrank=arcrank, arcrank=?, deleted_at=? WHERE id=? AND arcrank<>?",
+		ArcRankRecycled, time.Now().Unix(), id, ArcRankRecycled,
 	)
 	if err != nil {
-		logger.Error("更新文章主表失败", "error", err)
+		logger.Error("文章移入回收站失败", "id", id, "error", err)
 		return err
 	}
 
-	// 更新附加表
-	_, err = tx.Exec(
-		"UPDATE "+m.db.TableName("addonarticle")+" SET body=? WHERE aid=?",
-		article.Body, article.ID,
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("文章不存在或已在回收站中")
+	}
+
+	return nil
+}
+
+// Restore 从回收站恢复文章
+func (m *ArticleModel) Restore(id int64) error {
+	result, err := m.db.Exec(
+		"UPDATE "+m.db.TableName("archives")+" SET arcrank=deleted_arcrank, deleted_arcrank=0, deleted_at=0 WHERE id=? AND arcrank=?",
+		id, ArcRankRecycled,
 	)
 	if err != nil {
-		logger.Error("更新文章附加表失败", "error", err)
+		logger.Error("恢复文章失败", "id", id, "error", err)
 		return err
 	}
 
-	// 提交事务
-	if err := tx.Commit(); err != nil {
-		logger.Error("提交事务失败", "error", err)
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("文章不在回收站中")
+	}
+
+	return nil
+}
+
+// GetRecycleList 获取回收站中的文章，按删除时间倒序
+func (m *ArticleModel) GetRecycleList(page, pageSize int) ([]*Article, int, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "archives")
+	qb.Select("a.id", "a.typeid", "a.title", "a.writer", "a.pubdate", "a.senddate", "a.flag", "a.arcrank", "a.state", "a.click", "a.deleted_at", "t.typename", "t.typedir")
+	qb.From(m.db.TableName("archives") + " AS a")
+	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
+	qb.Where("a.arcrank = ?", ArcRankRecycled)
+
+	// 获取总数
+	total, err := qb.Count()
+	if err != nil {
+		logger.Error("查询回收站文章总数失败", "error", err)
+		return nil, 0, err
+	}
+
+	// 设置分页
+	offset := (page - 1) * pageSize
+	qb.OrderBy("a.deleted_at DESC")
+	qb.Limit(pageSize, offset)
+
+	// 执行查询
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
+		logger.Error("查询回收站文章失败", "error", err)
+		return nil, 0, err
+	}
+
+	return articles, total, nil
+}
+
+// GetRecycledBefore 获取在指定时间之前移入回收站的文章ID
+func (m *ArticleModel) GetRecycledBefore(before time.Time, limit int) ([]int64, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "archives")
+	qb.Select("id")
+	qb.Where("arcrank = ?", ArcRankRecycled)
+	qb.Where("deleted_at < ?", before.Unix())
+	qb.OrderBy("deleted_at ASC")
+	qb.Limit(limit)
+	return m.getIDs(qb)
+}
+
+// adminListQuery 构建后台文章列表的查询条件，state小于0时不限状态，不含回收站中的文章
+func (m *ArticleModel) adminListQuery(typeid int64, state int, keyword string) *database.QueryBuilder {
+	qb := database.NewQueryBuilder(m.db, "archives")
+	qb.From(m.db.TableName("archives") + " AS a")
+	qb.Where("a.arcrank <> ?", ArcRankRecycled)
+	if typeid > 0 {
+		qb.Where("a.typeid = ?", typeid)
+	}
+	if state >= 0 {
+		qb.Where("a.state = ?", state)
+	}
+	if keyword != "" {
+		qb.Where("a.title LIKE ?", "%"+keyword+"%")
+	}
+	return qb
+}
+
+// GetAdminList 获取后台文章列表，state小于0时不限状态，不含回收站中的文章
+func (m *ArticleModel) GetAdminList(typeid int64, state int, keyword string, page, pageSize int) ([]*Article, int, error) {
+	// 构建查询
+	qb := m.adminListQuery(typeid, state, keyword)
+	qb.Select("a.id", "a.typeid", "a.title", "a.shorttitle", "a.color", "a.writer", "a.source", "a.litpic", "a.pubdate", "a.senddate", "a.expiredate", "a.keywords", "a.description", "a.filename", "a.flag", "a.arcrank", "a.state", "a.click", "t.typename", "t.typedir")
+	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
+
+	// 获取总数
+	total, err := qb.Count()
+	if err != nil {
+		logger.Error("查询文章总数失败", "error", err)
+		return nil, 0, err
+	}
+
+	// 设置分页
+	offset := (page - 1) * pageSize
+	qb.OrderBy("a.id DESC")
+	qb.Limit(pageSize, offset)
+
+	// 执行查询
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
+		logger.Error("查询文章列表失败", "error", err)
+		return nil, 0, err
+	}
+
+	return articles, total, nil
+}
+
+// GetAdminIDs 获取后台文章列表条件下的文章ID，最多limit个
+func (m *ArticleModel) GetAdminIDs(typeid int64, state int, keyword string, limit int) ([]int64, error) {
+	qb := m.adminListQuery(typeid, state, keyword)
+	qb.Select("a.id")
+	qb.OrderBy("a.id DESC")
+	qb.Limit(limit)
+	return m.getIDs(qb)
+}
+
+// GetScheduled 获取等待定时发布的文章，按发布时间排序
+func (m *ArticleModel) GetScheduled(limit int) ([]*Article, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "archives")
+	qb.Select("a.id", "a.typeid", "a.title", "a.writer", "a.pubdate", "a.senddate", "a.expiredate", "a.flag", "a.arcrank", "a.state", "a.click", "t.typename", "t.typedir")
+	qb.From(m.db.TableName("archives") + " AS a")
+	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
+	qb.Where("a.state = ?", ArticleStateScheduled)
+	qb.Where("a.arcrank <> ?", ArcRankRecycled)
+	qb.OrderBy("a.pubdate ASC")
+	qb.Limit(limit)
+
+	// 执行查询
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
+		logger.Error("查询定时发布文章失败", "error", err)
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// GetExpiring 获取设置了下线时间的已发布文章，按下线时间排序
+func (m *ArticleModel) GetExpiring(limit int) ([]*Article, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "archives")
+	qb.Select("a.id", "a.typeid", "a.title", "a.writer", "a.pubdate", "a.senddate", "a.expiredate", "a.flag", "a.arcrank", "a.state", "a.click", "t.typename", "t.typedir")
+	qb.From(m.db.TableName("archives") + " AS a")
+	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
+	qb.Where("a.state = ?", ArticleStatePublished)
+	qb.Where("a.arcrank <> ?", ArcRankRecycled)
+	qb.Where("a.expiredate > 0")
+	qb.OrderBy("a.expiredate ASC")
+	qb.Limit(limit)
+
+	// 执行查询
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
+		logger.Error("查询到期下线文章失败", "error", err)
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// GetDueScheduled 获取发布时间已到的定时发布文章ID
+func (m *ArticleModel) GetDueScheduled(now time.Time, limit int) ([]int64, error) {
+	qb := database.NewQueryBuilder(m.db, "archives")
+	qb.Select("id")
+	qb.Where("state = ?", ArticleStateScheduled)
+	qb.Where("arcrank <> ?", ArcRankRecycled)
+	qb.Where("pubdate <= ?", now.Unix())
+	qb.OrderBy("pubdate ASC")
+	qb.Limit(limit)
+	return m.getIDs(qb)
+}
+
+// GetDueExpired 获取下线时间已到的已发布文章ID
+func (m *ArticleModel) GetDueExpired(now time.Time, limit int) ([]int64, error) {
+	qb := database.NewQueryBuilder(m.db, "archives")
+	qb.Select("id")
+	qb.Where("state = ?", ArticleStatePublished)
+	qb.Where("arcrank <> ?", ArcRankRecycled)
+	qb.Where("expiredate > 0")
+	qb.Where("expiredate <= ?", now.Unix())
+	qb.OrderBy("expiredate ASC")
+	qb.Limit(limit)
+	return m.getIDs(qb)
+}
+
+// getIDs 执行只查询id的查询
+func (m *ArticleModel) getIDs(qb *database.QueryBuilder) ([]int64, error) {
+	var rows []struct {
+		ID int64 `db:"id"`
+	}
+	if err := qb.GetInto(&rows); err != nil {
+		logger.Error("查询文章ID失败", "error", err)
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	return ids, nil
+}
+
+// SetState 将文章从from状态改为to状态，状态已被修改时返回错误；发布时待审核的阅读权限改为开放浏览
+func (m *ArticleModel) SetState(id int64, from, to int) error {
+	query := "UPDATE " + m.db.TableName("archives") + " SET state=? WHERE id=? AND state=? AND arcrank<>?"
+	if to == ArticleStatePublished {
+		query = "UPDATE " + m.db.TableName("archives") + " SET state=?, arcrank=CASE WHEN arcrank < 0 THEN 0 ELSE arcrank END WHERE id=? AND state=? AND arcrank<>?"
+	}
+
+	result, err := m.db.Exec(query, to, id, from, ArcRankRecycled)
+	if err != nil {
+		logger.Error("修改文章状态失败", "id", id, "error", err)
 		return err
 	}
 
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("文章状态已被修改或文章在回收站中")
+	}
+
 	return nil
 }
 
-// Delete 删除文章
-func (m *ArticleModel) Delete(id int64) error {
-	// 开始事务
-	tx, err := m.db.Begin()
+// SetTypeID 修改文章所属栏目
+func (m *ArticleModel) SetTypeID(id, typeid int64) error {
+	_, err := m.db.Exec("UPDATE "+m.db.TableName("archives")+" SET typeid=? WHERE id=?", typeid, id)
 	if err != nil {
-		logger.Error("开始事务失败", "error", err)
+		logger.Error("修改文章栏目失败", "id", id, "error", err)
 		return err
 	}
-	defer tx.Rollback()
 
-	// 删除主表
-	_, err = tx.Exec(
-		"DELETE FROM "+m.db.TableName("archives")+" WHERE id=?",
-		id,
-	)
+	return nil
+}
+
+// SetFlag 修改文章的flag字段
+func (m *ArticleModel) SetFlag(id int64, flagStr string) error {
+	_, err := m.db.Exec("UPDATE "+m.db.TableName("archives")+" SET flag=? WHERE id=?", flagStr, id)
 	if err != nil {
-		logger.Error("删除文章主表失败", "error", err)
+		logger.Error("修改文章属性失败", "id", id, "error", err)
 		return err
 	}
 
-	// 删除附加表
-	_, err = tx.Exec(
-		"DELETE FROM "+m.db.TableName("addonarticle")+" WHERE aid=?",
-		id,
-	)
+	return nil
+}
+
+// SetArcRank 修改文章的阅读权限
+func (m *ArticleModel) SetArcRank(id int64, arcrank int) error {
+	_, err := m.db.Exec("UPDATE "+m.db.TableName("archives")+" SET arcrank=? WHERE id=?", arcrank, id)
 	if err != nil {
-		logger.Error("删除文章附加表失败", "error", err)
+		logger.Error("修改文章阅读权限失败", "id", id, "error", err)
 		return err
 	}
 
-	// 提交事务
-	if err := tx.Commit(); err != nil {
-		logger.Error("提交事务失败", "error", err)
+	return nil
+}
+
+// SetSimHash 保存文章的内容指纹，用于补算旧文章的指纹
+func (m *ArticleModel) SetSimHash(id, hash int64) error {
+	_, err := m.db.Exec("UPDATE "+m.db.TableName("archives")+" SET simhash=? WHERE id=?", hash, id)
+	if err != nil {
+		logger.Error("保存文章内容指纹失败", "id", id, "error", err)
 		return err
 	}
 
@@ -537,6 +695,7 @@ func (m *ArticleModel) Search(keyword string, page, pageSize int) ([]*Article, i
 
 	// 添加搜索条件
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.Where("(a.title LIKE ? OR a.keywords LIKE ? OR a.description LIKE ?)",
 		"%"+keyword+"%", "%"+keyword+"%", "%"+keyword+"%")
 
@@ -553,61 +712,12 @@ func (m *ArticleModel) Search(keyword string, page, pageSize int) ([]*Article, i
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
 		logger.Error("搜索文章列表失败", "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-		article.ID, _ = result["id"].(int64)
-		article.TypeID, _ = result["typeid"].(int64)
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-
-		// 解析flag字段
-		if flagStr, ok := result["flag"].(string); ok {
-			article.IsTop, article.IsRecommend, article.IsHot = parseFlags(flagStr)
-		}
-
-		// 处理整数字段
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		} else if arcrank, ok := result["arcrank"].(int); ok {
-			article.ArcRank = arcrank
-		}
-
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
-		} else if click, ok := result["click"].(int); ok {
-			article.Click = click
-		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		articles = append(articles, article)
-	}
-
 	return articles, total, nil
 }
 
@@ -639,6 +749,7 @@ func (m *ArticleModel) GetPrevArticle(id int64, typeid int64) (*Article, error)
 	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.Where("a.id < ?", id)
 
 	if typeid > 0 {
@@ -649,59 +760,17 @@ func (m *ArticleModel) GetPrevArticle(id int64, typeid int64) (*Article, error)
 	qb.Limit(1, 0)
 
 	// 执行查询
-	result, err := qb.First()
+	article := &Article{}
+	found, err := qb.FirstInto(article)
 	if err != nil {
 		logger.Error("查询上一篇文章失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, nil
 	}
 
-	// 转换为文章对象
-	article := &Article{}
-	article.ID, _ = result["id"].(int64)
-	article.TypeID, _ = result["typeid"].(int64)
-	article.Title, _ = result["title"].(string)
-	article.ShortTitle, _ = result["shorttitle"].(string)
-	article.Color, _ = result["color"].(string)
-	article.Writer, _ = result["writer"].(string)
-	article.Source, _ = result["source"].(string)
-	article.LitPic, _ = result["litpic"].(string)
-
-	// 处理日期
-	if pubdate, ok := result["pubdate"].(time.Time); ok {
-		article.PubDate = pubdate
-	}
-	if senddate, ok := result["senddate"].(time.Time); ok {
-		article.SendDate = senddate
-	}
-
-	article.Keywords, _ = result["keywords"].(string)
-	article.Description, _ = result["description"].(string)
-	article.Filename, _ = result["filename"].(string)
-
-	// 处理整数字段
-	if istop, ok := result["istop"].(int64); ok {
-		article.IsTop = int(istop)
-	}
-	if isrecommend, ok := result["isrecommend"].(int64); ok {
-		article.IsRecommend = int(isrecommend)
-	}
-	if ishot, ok := result["ishot"].(int64); ok {
-		article.IsHot = int(ishot)
-	}
-	if arcrank, ok := result["arcrank"].(int64); ok {
-		article.ArcRank = int(arcrank)
-	}
-	if click, ok := result["click"].(int64); ok {
-		article.Click = int(click)
-	}
-
-	article.TypeName, _ = result["typename"].(string)
-	article.TypeDir, _ = result["typedir"].(string)
-
 	return article, nil
 }
 
@@ -713,6 +782,7 @@ func (m *ArticleModel) GetNextArticle(id int64, typeid int64) (*Article, error)
 	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.Where("a.id > ?", id)
 
 	if typeid > 0 {
@@ -723,138 +793,120 @@ func (m *ArticleModel) GetNextArticle(id int64, typeid int64) (*Article, error)
 	qb.Limit(1, 0)
 
 	// 执行查询
-	result, err := qb.First()
+	article := &Article{}
+	found, err := qb.FirstInto(article)
 	if err != nil {
 		logger.Error("查询下一篇文章失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, nil
 	}
 
-	// 转换为文章对象
-	article := &Article{}
-	article.ID, _ = result["id"].(int64)
-	article.TypeID, _ = result["typeid"].(int64)
-	article.Title, _ = result["title"].(string)
-	article.ShortTitle, _ = result["shorttitle"].(string)
-	article.Color, _ = result["color"].(string)
-	article.Writer, _ = result["writer"].(string)
-	article.Source, _ = result["source"].(string)
-	article.LitPic, _ = result["litpic"].(string)
+	return article, nil
+}
+
+// GetRelatedArticles 获取相关文章：优先使用后台按内容相似度计算的结果，
+// 尚未计算时按共同标签和关键词的数量排序
+func (m *ArticleModel) GetRelatedArticles(keywords string, id int64, limit int) ([]*Article, error) {
+	// 按相似度排名查询
+	qb := database.NewQueryBuilder(m.db, "arcrelated")
+	qb.Select("a.*", "t.typename", "t.typedir")
+	qb.From(m.db.TableName("arcrelated") + " AS r")
+	qb.Join(m.db.TableName("archives")+" AS a", "r.relid = a.id")
+	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
+	qb.Where("r.aid = ?", id)
+	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
+	qb.OrderBy("r.sortrank ASC")
+	qb.Limit(limit)
 
-	// 处理日期
-	if pubdate, ok := result["pubdate"].(time.Time); ok {
-		article.PubDate = pubdate
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
+		logger.Error("查询相关文章失败", "id", id, "error", err)
+		return nil, err
 	}
-	if senddate, ok := result["senddate"].(time.Time); ok {
-		article.SendDate = senddate
+	if len(articles) > 0 {
+		return articles, nil
 	}
 
-	article.Keywords, _ = result["keywords"].(string)
-	article.Description, _ = result["description"].(string)
-	article.Filename, _ = result["filename"].(string)
+	return m.getRelatedByTags(keywords, id, limit)
+}
 
-	// 处理整数字段
-	if istop, ok := result["istop"].(int64); ok {
-		article.IsTop = int(istop)
-	}
-	if isrecommend, ok := result["isrecommend"].(int64); ok {
-		article.IsRecommend = int(isrecommend)
-	}
-	if ishot, ok := result["ishot"].(int64); ok {
-		article.IsHot = int(ishot)
-	}
-	if arcrank, ok := result["arcrank"].(int64); ok {
-		article.ArcRank = int(arcrank)
+// getRelatedByTags 获取与文章标签或关键词相同最多的文章
+func (m *ArticleModel) getRelatedByTags(keywords string, id int64, limit int) ([]*Article, error) {
+	// 文章的标签和关键词
+	args := []interface{}{id}
+	seen := make(map[string]bool)
+	for _, keyword := range strings.Split(keywords, ",") {
+		keyword = strings.TrimSpace(keyword)
+		if keyword != "" && !seen[keyword] {
+			seen[keyword] = true
+			args = append(args, keyword)
+		}
 	}
-	if click, ok := result["click"].(int64); ok {
-		article.Click = int(click)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)-1), ", ")
+
+	// 先按共同标签数量取出文章ID，再查询文章内容
+	tagCondition := "l.tag IN (SELECT tag FROM " + m.db.TableName("taglist") + " WHERE aid = ?)"
+	if placeholders != "" {
+		tagCondition = "(" + tagCondition + " OR l.tag IN (" + placeholders + "))"
 	}
+	qb := database.NewQueryBuilder(m.db, "taglist")
+	qb.Select("l.aid AS id", "COUNT(*) AS matches")
+	qb.From(m.db.TableName("taglist") + " AS l")
+	qb.Join(m.db.TableName("archives")+" AS a", "l.aid = a.id")
+	qb.Where(tagCondition, args...)
+	qb.Where("l.aid <> ?", id)
+	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
+	qb.GroupBy("l.aid")
+	qb.OrderBy("matches DESC, l.aid DESC")
+	qb.Limit(limit)
 
-	article.TypeName, _ = result["typename"].(string)
-	article.TypeDir, _ = result["typedir"].(string)
+	ids, err := m.getIDs(qb)
+	if err != nil {
+		return nil, err
+	}
 
-	return article, nil
+	return m.GetByIDs(ids)
 }
 
-// GetRelatedArticles 获取相关文章
-func (m *ArticleModel) GetRelatedArticles(keywords string, id int64, limit int) ([]*Article, error) {
+// GetByIDs 按ids的顺序获取文章，不存在的ID被忽略
+func (m *ArticleModel) GetByIDs(ids []int64) ([]*Article, error) {
+	articles := make([]*Article, 0, len(ids))
+	if len(ids) == 0 {
+		return articles, nil
+	}
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "archives")
 	qb.Select("a.*", "t.typename", "t.typedir")
 	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
-	qb.Where("a.arcrank > -1")
-	qb.Where("a.id <> ?", id)
-
-	// 如果有关键词，则按关键词匹配
-	if keywords != "" {
-		keywordList := strings.Split(keywords, ",")
-		for _, keyword := range keywordList {
-			if keyword != "" {
-				qb.Where("a.keywords LIKE ?", "%"+keyword+"%")
-			}
-		}
-	}
-
-	qb.OrderBy("a.pubdate DESC")
-	qb.Limit(limit, 0)
+	qb.Where("a.id IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")+")", args...)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
-		logger.Error("查询相关文章失败", "id", id, "error", err)
+	rows := make([]*Article, 0, len(ids))
+	if err := qb.GetInto(&rows); err != nil {
+		logger.Error("批量获取文章失败", "count", len(ids), "error", err)
 		return nil, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-		article.ID, _ = result["id"].(int64)
-		article.TypeID, _ = result["typeid"].(int64)
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			article.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			article.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			article.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
+	byID := make(map[int64]*Article, len(rows))
+	for _, article := range rows {
+		byID[article.ID] = article
+	}
+	for _, id := range ids {
+		if article, ok := byID[id]; ok {
+			articles = append(articles, article)
 		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		articles = append(articles, article)
 	}
 
 	return articles, nil
@@ -899,6 +951,7 @@ func (m *ArticleModel) GetMemberArticles(memberID int64, page, pageSize int) ([]
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.Where("a.memberid = ?", memberID)
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 
 	// 获取总数
 	total, err := qb.Count()
@@ -913,60 +966,12 @@ func (m *ArticleModel) GetMemberArticles(memberID int64, page, pageSize int) ([]
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
 		logger.Error("获取会员文章列表失败", "memberID", memberID, "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-		article.ID, _ = result["id"].(int64)
-		article.TypeID, _ = result["typeid"].(int64)
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			article.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			article.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			article.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
-		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		articles = append(articles, article)
-	}
-
 	return articles, total, nil
 }
 
@@ -979,6 +984,7 @@ func (m *ArticleModel) GetByMemberID(memberID int64, page, pageSize int) ([]*Art
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.Where("a.memberid = ?", memberID)
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 
 	// 获取总数
 	total, err := qb.Count()
@@ -993,60 +999,12 @@ func (m *ArticleModel) GetByMemberID(memberID int64, page, pageSize int) ([]*Art
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
 		logger.Error("获取会员文章列表失败", "memberID", memberID, "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-		article.ID, _ = result["id"].(int64)
-		article.TypeID, _ = result["typeid"].(int64)
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			article.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			article.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			article.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
-		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		articles = append(articles, article)
-	}
-
 	return articles, total, nil
 }
 
@@ -1058,6 +1016,7 @@ func (m *ArticleModel) GetByTag(tag string, page, pageSize int) ([]*Article, int
 	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.Where("a.keywords LIKE ?", "%"+tag+"%")
 
 	// 获取总数
@@ -1073,60 +1032,12 @@ func (m *ArticleModel) GetByTag(tag string, page, pageSize int) ([]*Article, int
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
 		logger.Error("查询标签文章列表失败", "tag", tag, "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-		article.ID, _ = result["id"].(int64)
-		article.TypeID, _ = result["typeid"].(int64)
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			article.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			article.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			article.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
-		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		articles = append(articles, article)
-	}
-
 	return articles, total, nil
 }
 
@@ -1146,63 +1057,16 @@ func (m *ArticleModel) GetByDateRange(typeID int64, startDate, endDate time.Time
 	qb.Where("a.pubdate >= ?", startDate)
 	qb.Where("a.pubdate <= ?", endDate)
 	qb.Where("a.arcrank > 0")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.OrderBy("a.pubdate DESC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
 		logger.Error("查询文章列表失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-		article.ID, _ = result["id"].(int64)
-		article.TypeID, _ = result["typeid"].(int64)
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			article.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			article.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			article.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
-		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		articles = append(articles, article)
-	}
-
 	return articles, nil
 }
 
@@ -1235,64 +1099,17 @@ func (m *ArticleModel) GetLatestArticles(limit int) ([]*Article, error) {
 	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.OrderBy("a.pubdate DESC")
 	qb.Limit(limit, 0)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	articles := make([]*Article, 0)
+	if err := qb.GetInto(&articles); err != nil {
 		logger.Error("获取最新文章失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为文章对象
-	articles := make([]*Article, 0, len(results))
-	for _, result := range results {
-		article := &Article{}
-		article.ID, _ = result["id"].(int64)
-		article.TypeID, _ = result["typeid"].(int64)
-		article.Title, _ = result["title"].(string)
-		article.ShortTitle, _ = result["shorttitle"].(string)
-		article.Color, _ = result["color"].(string)
-		article.Writer, _ = result["writer"].(string)
-		article.Source, _ = result["source"].(string)
-		article.LitPic, _ = result["litpic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			article.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			article.SendDate = senddate
-		}
-
-		article.Keywords, _ = result["keywords"].(string)
-		article.Description, _ = result["description"].(string)
-		article.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			article.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			article.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			article.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			article.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			article.Click = int(click)
-		}
-
-		article.TypeName, _ = result["typename"].(string)
-		article.TypeDir, _ = result["typedir"].(string)
-
-		articles = append(articles, article)
-	}
-
 	return articles, nil
 }
 
@@ -1337,7 +1154,8 @@ func (m *ArticleModel) GetTodayCount() (int, error) {
 func (m *ArticleModel) GetPendingCount() (int, error) {
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "archives")
-	qb.Where("arcrank = -1") // 待审核状态
+	qb.Where("state = ?", ArticleStatePending)
+	qb.Where("arcrank <> ?", ArcRankRecycled)
 
 	// 执行查询
 	count, err := qb.Count()
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"strings"
+
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// GetCrossTypeIDs 获取文章的副栏目ID
+func (m *ArticleModel) GetCrossTypeIDs(aid int64) ([]int64, error) {
+	qb := database.NewQueryBuilder(m.db, "arccross")
+	qb.Select("typeid")
+	qb.Where("aid = ?", aid)
+	qb.OrderBy("typeid ASC")
+
+	var rows []struct {
+		TypeID int64 `db:"typeid"`
+	}
+	if err := qb.GetInto(&rows); err != nil {
+		logger.Error("获取文章副栏目失败", "aid", aid, "error", err)
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.TypeID)
+	}
+	return ids, nil
+}
+
+// SetCrossTypeIDs 设置文章的副栏目，忽略主栏目typeid，已在事务中时加入当前事务
+func (m *ArticleModel) SetCrossTypeIDs(aid, typeid int64, typeids []int64) error {
+	rows := make([]map[string]interface{}, 0, len(typeids))
+	seen := map[int64]bool{typeid: true}
+	for _, id := range typeids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		rows = append(rows, map[string]interface{}{"aid": aid, "typeid": id})
+	}
+
+	err := m.db.WithTx(func(tx *database.Tx) error {
+		if _, err := tx.DB().Exec("DELETE FROM "+m.db.TableName("arccross")+" WHERE aid = ?", aid); err != nil {
+			return err
+		}
+		_, err := database.NewQueryBuilder(tx.DB(), "arccross").InsertBatch(rows)
+		return err
+	})
+	if err != nil {
+		logger.Error("设置文章副栏目失败", "aid", aid, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// CategoryCondition 栏目文章的查询条件，文章表别名为a：主栏目或副栏目属于这些栏目或其交叉栏目
+func (m *ArticleModel) CategoryCondition(typeids ...int64) (string, []interface{}, error) {
+	categoryModel := NewCategoryModel(m.db)
+	all := make([]int64, 0, len(typeids))
+	seen := make(map[int64]bool)
+	for _, typeid := range typeids {
+		ids, err := categoryModel.ListTypeIDs(typeid)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				all = append(all, id)
+			}
+		}
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(all)), ", ")
+	args := make([]interface{}, 0, len(all)*2)
+	for _, id := range all {
+		args = append(args, id)
+	}
+	args = append(args, args...)
+
+	// 副栏目用关联的EXISTS按(aid, typeid)主键查找，避免IN子查询在MySQL中无法使用a.typeid索引
+	condition := "(a.typeid IN (" + placeholders + ") OR EXISTS (SELECT 1 FROM " + m.db.TableName("arccross") + " AS ac WHERE ac.aid = a.id AND ac.typeid IN (" + placeholders + ")))"
+	return condition, args, nil
+}
+
+// ListedTypeIDs 列表中包含该文章的栏目：主栏目、副栏目以及交叉栏目包含它们的栏目
+func (m *ArticleModel) ListedTypeIDs(aid, typeid int64) ([]int64, error) {
+	crossIDs, err := m.GetCrossTypeIDs(aid)
+	if err != nil {
+		return nil, err
+	}
+	ids := append([]int64{typeid}, crossIDs...)
+
+	referrers, err := NewCategoryModel(m.db).GetCrossReferrers(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]int64, 0, len(ids)+len(referrers))
+	seen := make(map[int64]bool)
+	for _, id := range append(ids, referrers...) {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			list = append(list, id)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"aq3cms/pkg/markdown"
+)
+
+// 文章内容格式
+const (
+	BodyFormatHTML     = "html"
+	BodyFormatMarkdown = "markdown"
+)
+
+// NormalizeBodyFormat 规范化内容格式，空值和不支持的格式按html处理
+func NormalizeBodyFormat(format string) string {
+	if strings.EqualFold(strings.TrimSpace(format), BodyFormatMarkdown) {
+		return BodyFormatMarkdown
+	}
+	return BodyFormatHTML
+}
+
+// IsMarkdown 内容是否使用Markdown编写
+func (a *Article) IsMarkdown() bool {
+	return NormalizeBodyFormat(a.Format) == BodyFormatMarkdown
+}
+
+// SourceBody 编辑用的内容原文，Markdown格式时为Markdown原文
+func (a *Article) SourceBody() string {
+	if a.IsMarkdown() {
+		return a.Markdown
+	}
+	return a.Body
+}
+
+// SetBody 按format设置文章内容，Markdown格式保存原文并转换为过滤后的HTML；pageBreak为分页符，
+// 整篇一起转换，引用链接和脚注可以跨页使用
+func (a *Article) SetBody(format, text, pageBreak string) error {
+	a.Format = NormalizeBodyFormat(format)
+	if a.Format == BodyFormatHTML {
+		a.Body = text
+		a.Markdown = ""
+		a.Content = a.Body
+		return nil
+	}
+
+	if pageBreak == "" {
+		pageBreak = DefaultPageBreak
+	}
+
+	// 分页符替换为单独成段的占位符，转换后再换回分页符
+	pages := SplitPages(text, pageBreak)
+	var source strings.Builder
+	for i, page := range pages {
+		if i > 0 {
+			source.WriteString("\n\n" + pagePlaceholder(i) + "\n\n")
+		}
+		source.WriteString(page.Body)
+	}
+	body, err := markdown.ToHTML(source.String())
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(pages); i++ {
+		marker := pageBreak
+		if pages[i].Title != "" {
+			marker += pages[i].Title + pageTitleEnd
+		}
+		placeholder := pagePlaceholder(i)
+		if paragraph := "<p>" + placeholder + "</p>"; strings.Contains(body, paragraph) {
+			placeholder = paragraph
+		}
+		body = strings.Replace(body, placeholder, marker, 1)
+	}
+
+	a.Markdown = text
+	a.Body = body
+	a.Content = a.Body
+	return nil
+}
+
+// pagePlaceholder 转换Markdown时第i个分页符的占位符
+func pagePlaceholder(i int) string {
+	return fmt.Sprintf("aq3cms-page-break-%d", i)
+}
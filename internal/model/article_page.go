@@ -0,0 +1,51 @@
+package model
+
+import "strings"
+
+// DefaultPageBreak 默认的文章分页符
+const DefaultPageBreak = "#p#"
+
+// 分页标题结束符，#p#分页标题#e# 中的 #e#
+const pageTitleEnd = "#e#"
+
+// ArticlePage 文章的一页
+type ArticlePage struct {
+	Page  int    `json:"page"`  // 页码，从1开始
+	Title string `json:"title"` // 分页标题
+	Body  string `json:"body"`  // 本页内容
+}
+
+// SplitPages 按分页符拆分文章内容，分页符后可以用 标题#e# 指定分页标题，没有分页符时只有一页
+func SplitPages(body, marker string) []ArticlePage {
+	if marker == "" {
+		marker = DefaultPageBreak
+	}
+
+	parts := strings.Split(body, marker)
+	pages := make([]ArticlePage, 0, len(parts))
+	for i, part := range parts {
+		title := ""
+		if i > 0 {
+			if end := strings.Index(part, pageTitleEnd); end >= 0 && !strings.Contains(part[:end], "\n") {
+				title = strings.TrimSpace(part[:end])
+				part = part[end+len(pageTitleEnd):]
+			}
+		}
+
+		// 跳过开头、结尾和连续分页符产生的空页
+		if len(parts) > 1 && strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		pages = append(pages, ArticlePage{
+			Page:  len(pages) + 1,
+			Title: title,
+			Body:  part,
+		})
+	}
+	if len(pages) == 0 {
+		pages = append(pages, ArticlePage{Page: 1, Body: body})
+	}
+
+	return pages
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"aq3cms/pkg/database"
@@ -11,25 +12,65 @@ import (
 
 // Category 栏目
 type Category struct {
-	ID              int64       `json:"id"`
-	ParentID        int64       `json:"reid"`            // 父栏目ID
-	TypeName        string      `json:"typename"`        // 栏目名称
-	EnName          string      `json:"enname"`          // 英文名称
-	TypeDir         string      `json:"typedir"`         // 栏目目录
-	IsHidden        int         `json:"ishidden"`        // 是否隐藏
-	ChannelType     int         `json:"channeltype"`     // 栏目类型
-	CrossID         int64       `json:"crossid"`         // 交叉栏目ID
-	Description     string      `json:"description"`     // 栏目描述
-	Keywords        string      `json:"keywords"`        // 关键词
-	SortRank        int         `json:"sortrank"`        // 排序
-	ListTpl         string      `json:"listtpl"`         // 列表模板
-	ArticleTpl      string      `json:"articletpl"`      // 文章模板
-	ArticleTemplate string      `json:"articletemplate"` // 文章模板（兼容旧版）
-	Status          int         `json:"status"`          // 状态
-	CreateTime      time.Time   `json:"createtime"`      // 创建时间
-	UpdateTime      time.Time   `json:"updatetime"`      // 更新时间
-	Children        []*Category `json:"-"`               // 子栏目
-	ArticleCount    int         `json:"article_count"`   // 文章数量（运行时计算）
+	ID              int64       `json:"id" db:"id"`
+	ParentID        int64       `json:"reid" db:"reid"`               // 父栏目ID
+	TypeName        string      `json:"typename" db:"typename"`       // 栏目名称
+	EnName          string      `json:"enname"`                       // 英文名称
+	TypeDir         string      `json:"typedir" db:"typedir"`         // 栏目目录
+	IsHidden        int         `json:"ishidden" db:"ishidden"`       // 是否隐藏
+	ChannelType     int         `json:"channeltype" db:"channeltype"` // 栏目类型
+	CrossID         string      `json:"crossid" db:"crossid"`         // 交叉栏目ID，逗号分隔，这些栏目的文章同时出现在本栏目中
+	Description     string      `json:"description" db:"description"` // 栏目描述
+	Keywords        string      `json:"keywords" db:"keywords"`       // 关键词
+	SortRank        int         `json:"sortrank" db:"sortrank"`       // 排序
+	ListTpl         string      `json:"listtpl" db:"templist"`        // 列表模板
+	ArticleTpl      string      `json:"articletpl" db:"temparticle"`  // 文章模板
+	ArticleTemplate string      `json:"articletemplate"`              // 文章模板（兼容旧版）
+	Status          int         `json:"status"`                       // 状态
+	CreateTime      time.Time   `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime      time.Time   `json:"updatetime" db:"updatetime"`   // 更新时间
+	Children        []*Category `json:"-"`                            // 子栏目
+	ArticleCount    int         `json:"article_count"`                // 文章数量（运行时计算）
+}
+
+// AfterScan 栏目表没有状态字段，默认为启用
+func (c *Category) AfterScan() {
+	c.Status = 1
+}
+
+// CrossTypeIDs 交叉栏目ID列表，不包括本栏目
+func (c *Category) CrossTypeIDs() []int64 {
+	ids := make([]int64, 0)
+	for _, id := range ParseIDList(c.CrossID) {
+		if id != c.ID {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ParseIDList 解析逗号分隔的ID列表，忽略无效和重复的ID，兼容中文逗号
+func ParseIDList(value string) []int64 {
+	ids := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for _, part := range strings.Split(strings.ReplaceAll(value, "，", ","), ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// JoinIDList 将ID列表转换为逗号分隔的字符串
+func JoinIDList(ids []int64) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parts, ",")
 }
 
 // CategoryModel 栏目模型
@@ -51,79 +92,18 @@ func (m *CategoryModel) GetByID(id int64) (*Category, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	category := &Category{}
+	found, err := qb.FirstInto(category)
 	if err != nil {
 		logger.Error("获取栏目失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("category not found: %d", id)
 	}
 
-	// 转换为栏目
-	category := &Category{}
-
-	// 处理ID字段 - 支持多种类型
-	if id, ok := result["id"].(int64); ok {
-		category.ID = id
-	} else if id, ok := result["id"].(int); ok {
-		category.ID = int64(id)
-	} else if id, ok := result["id"].(string); ok {
-		if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-			category.ID = idInt
-		}
-	}
-
-	// 处理ParentID字段
-	if reid, ok := result["reid"].(int64); ok {
-		category.ParentID = reid
-	} else if reid, ok := result["reid"].(int); ok {
-		category.ParentID = int64(reid)
-	} else if reid, ok := result["reid"].(string); ok {
-		if reidInt, err := strconv.ParseInt(reid, 10, 64); err == nil {
-			category.ParentID = reidInt
-		}
-	}
-
-	category.TypeName, _ = result["typename"].(string)
-	category.TypeDir, _ = result["typedir"].(string)
-
-	// 处理整数字段
-	if ishidden, ok := result["ishidden"].(int64); ok {
-		category.IsHidden = int(ishidden)
-	} else if ishidden, ok := result["ishidden"].(int); ok {
-		category.IsHidden = ishidden
-	}
-
-	if channeltype, ok := result["channeltype"].(int64); ok {
-		category.ChannelType = int(channeltype)
-	} else if channeltype, ok := result["channeltype"].(int); ok {
-		category.ChannelType = channeltype
-	}
-
-	if sortrank, ok := result["sortrank"].(int64); ok {
-		category.SortRank = int(sortrank)
-	} else if sortrank, ok := result["sortrank"].(int); ok {
-		category.SortRank = sortrank
-	}
-
-	// 处理CrossID字段
-	if crossid, ok := result["crossid"].(int64); ok {
-		category.CrossID = crossid
-	} else if crossid, ok := result["crossid"].(int); ok {
-		category.CrossID = int64(crossid)
-	}
-
-	category.Description, _ = result["description"].(string)
-	category.Keywords, _ = result["keywords"].(string)
-	category.ListTpl, _ = result["templist"].(string)
-	category.ArticleTpl, _ = result["temparticle"].(string)
-
-	// 设置默认状态为启用
-	category.Status = 1
-
 	return category, nil
 }
 
@@ -134,75 +114,12 @@ func (m *CategoryModel) GetAll() ([]*Category, error) {
 	qb.OrderBy("sortrank ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	categories := make([]*Category, 0)
+	if err := qb.GetInto(&categories); err != nil {
 		logger.Error("获取所有栏目失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为栏目列表
-	categories := make([]*Category, 0, len(results))
-	for _, result := range results {
-		category := &Category{}
-
-		// 处理ID字段 - 支持多种类型
-		if id, ok := result["id"].(int64); ok {
-			category.ID = id
-		} else if id, ok := result["id"].(int); ok {
-			category.ID = int64(id)
-		} else if id, ok := result["id"].(string); ok {
-			if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-				category.ID = idInt
-			}
-		}
-
-		// 处理ParentID字段
-		if reid, ok := result["reid"].(int64); ok {
-			category.ParentID = reid
-		} else if reid, ok := result["reid"].(int); ok {
-			category.ParentID = int64(reid)
-		} else if reid, ok := result["reid"].(string); ok {
-			if reidInt, err := strconv.ParseInt(reid, 10, 64); err == nil {
-				category.ParentID = reidInt
-			}
-		}
-
-		category.TypeName, _ = result["typename"].(string)
-		category.TypeDir, _ = result["typedir"].(string)
-
-		// 处理整数字段
-		if ishidden, ok := result["ishidden"].(int64); ok {
-			category.IsHidden = int(ishidden)
-		} else if ishidden, ok := result["ishidden"].(int); ok {
-			category.IsHidden = ishidden
-		}
-
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			category.ChannelType = int(channeltype)
-		} else if channeltype, ok := result["channeltype"].(int); ok {
-			category.ChannelType = channeltype
-		}
-
-		if sortrank, ok := result["sortrank"].(int64); ok {
-			category.SortRank = int(sortrank)
-		} else if sortrank, ok := result["sortrank"].(int); ok {
-			category.SortRank = sortrank
-		}
-
-		// 处理CrossID字段
-		if crossid, ok := result["crossid"].(int64); ok {
-			category.CrossID = crossid
-		} else if crossid, ok := result["crossid"].(int); ok {
-			category.CrossID = int64(crossid)
-		}
-
-		category.Description, _ = result["description"].(string)
-		category.Keywords, _ = result["keywords"].(string)
-		category.ListTpl, _ = result["templist"].(string)
-		category.ArticleTpl, _ = result["temparticle"].(string)
-		categories = append(categories, category)
-	}
-
 	return categories, nil
 }
 
@@ -214,75 +131,12 @@ func (m *CategoryModel) GetTopCategories() ([]*Category, error) {
 	qb.OrderBy("sortrank ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	categories := make([]*Category, 0)
+	if err := qb.GetInto(&categories); err != nil {
 		logger.Error("获取顶级栏目失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为栏目列表
-	categories := make([]*Category, 0, len(results))
-	for _, result := range results {
-		category := &Category{}
-
-		// 处理ID字段 - 支持多种类型
-		if id, ok := result["id"].(int64); ok {
-			category.ID = id
-		} else if id, ok := result["id"].(int); ok {
-			category.ID = int64(id)
-		} else if id, ok := result["id"].(string); ok {
-			if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-				category.ID = idInt
-			}
-		}
-
-		// 处理ParentID字段
-		if reid, ok := result["reid"].(int64); ok {
-			category.ParentID = reid
-		} else if reid, ok := result["reid"].(int); ok {
-			category.ParentID = int64(reid)
-		} else if reid, ok := result["reid"].(string); ok {
-			if reidInt, err := strconv.ParseInt(reid, 10, 64); err == nil {
-				category.ParentID = reidInt
-			}
-		}
-
-		category.TypeName, _ = result["typename"].(string)
-		category.TypeDir, _ = result["typedir"].(string)
-
-		// 处理整数字段
-		if ishidden, ok := result["ishidden"].(int64); ok {
-			category.IsHidden = int(ishidden)
-		} else if ishidden, ok := result["ishidden"].(int); ok {
-			category.IsHidden = ishidden
-		}
-
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			category.ChannelType = int(channeltype)
-		} else if channeltype, ok := result["channeltype"].(int); ok {
-			category.ChannelType = channeltype
-		}
-
-		if sortrank, ok := result["sortrank"].(int64); ok {
-			category.SortRank = int(sortrank)
-		} else if sortrank, ok := result["sortrank"].(int); ok {
-			category.SortRank = sortrank
-		}
-
-		// 处理CrossID字段
-		if crossid, ok := result["crossid"].(int64); ok {
-			category.CrossID = crossid
-		} else if crossid, ok := result["crossid"].(int); ok {
-			category.CrossID = int64(crossid)
-		}
-
-		category.Description, _ = result["description"].(string)
-		category.Keywords, _ = result["keywords"].(string)
-		category.ListTpl, _ = result["templist"].(string)
-		category.ArticleTpl, _ = result["temparticle"].(string)
-		categories = append(categories, category)
-	}
-
 	return categories, nil
 }
 
@@ -294,75 +148,12 @@ func (m *CategoryModel) GetChildCategories(parentID int64) ([]*Category, error)
 	qb.OrderBy("sortrank ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	categories := make([]*Category, 0)
+	if err := qb.GetInto(&categories); err != nil {
 		logger.Error("获取子栏目失败", "parentid", parentID, "error", err)
 		return nil, err
 	}
 
-	// 转换为栏目列表
-	categories := make([]*Category, 0, len(results))
-	for _, result := range results {
-		category := &Category{}
-
-		// 处理ID字段 - 支持多种类型
-		if id, ok := result["id"].(int64); ok {
-			category.ID = id
-		} else if id, ok := result["id"].(int); ok {
-			category.ID = int64(id)
-		} else if id, ok := result["id"].(string); ok {
-			if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-				category.ID = idInt
-			}
-		}
-
-		// 处理ParentID字段
-		if reid, ok := result["reid"].(int64); ok {
-			category.ParentID = reid
-		} else if reid, ok := result["reid"].(int); ok {
-			category.ParentID = int64(reid)
-		} else if reid, ok := result["reid"].(string); ok {
-			if reidInt, err := strconv.ParseInt(reid, 10, 64); err == nil {
-				category.ParentID = reidInt
-			}
-		}
-
-		category.TypeName, _ = result["typename"].(string)
-		category.TypeDir, _ = result["typedir"].(string)
-
-		// 处理整数字段
-		if ishidden, ok := result["ishidden"].(int64); ok {
-			category.IsHidden = int(ishidden)
-		} else if ishidden, ok := result["ishidden"].(int); ok {
-			category.IsHidden = ishidden
-		}
-
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			category.ChannelType = int(channeltype)
-		} else if channeltype, ok := result["channeltype"].(int); ok {
-			category.ChannelType = channeltype
-		}
-
-		if sortrank, ok := result["sortrank"].(int64); ok {
-			category.SortRank = int(sortrank)
-		} else if sortrank, ok := result["sortrank"].(int); ok {
-			category.SortRank = sortrank
-		}
-
-		// 处理CrossID字段
-		if crossid, ok := result["crossid"].(int64); ok {
-			category.CrossID = crossid
-		} else if crossid, ok := result["crossid"].(int); ok {
-			category.CrossID = int64(crossid)
-		}
-
-		category.Description, _ = result["description"].(string)
-		category.Keywords, _ = result["keywords"].(string)
-		category.ListTpl, _ = result["templist"].(string)
-		category.ArticleTpl, _ = result["temparticle"].(string)
-		categories = append(categories, category)
-	}
-
 	return categories, nil
 }
 
@@ -372,6 +163,7 @@ func (m *CategoryModel) Create(category *Category) (int64, error) {
 	now := time.Now()
 	category.CreateTime = now
 	category.UpdateTime = now
+	category.CrossID = JoinIDList(ParseIDList(category.CrossID))
 
 	// 执行插入
 	result, err := m.db.Exec(
@@ -397,6 +189,7 @@ func (m *CategoryModel) Create(category *Category) (int64, error) {
 func (m *CategoryModel) Update(category *Category) error {
 	// 设置更新时间
 	category.UpdateTime = time.Now()
+	category.CrossID = JoinIDList(category.CrossTypeIDs())
 
 	// 执行更新
 	_, err := m.db.Exec(
@@ -411,113 +204,94 @@ func (m *CategoryModel) Update(category *Category) error {
 	return nil
 }
 
-// Delete 删除栏目
-func (m *CategoryModel) Delete(id int64) error {
-	// 执行删除
-	_, err := m.db.Exec("DELETE FROM "+m.db.TableName("arctype")+" WHERE id = ?", id)
+// SetCrossID 设置栏目的交叉栏目
+func (m *CategoryModel) SetCrossID(id int64, crossIDs []int64) error {
+	_, err := m.db.Exec("UPDATE "+m.db.TableName("arctype")+" SET crossid = ? WHERE id = ?", JoinIDList(crossIDs), id)
 	if err != nil {
-		logger.Error("删除栏目失败", "error", err)
+		logger.Error("设置交叉栏目失败", "id", id, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-// GetByDir 根据目录获取栏目
-func (m *CategoryModel) GetByDir(dir string) (*Category, error) {
-	// 构建查询
+// ListTypeIDs 栏目列表包含的栏目：本栏目及其交叉栏目
+func (m *CategoryModel) ListTypeIDs(typeid int64) ([]int64, error) {
 	qb := database.NewQueryBuilder(m.db, "arctype")
-	qb.Select("*")
-	qb.Where("typedir = ?", dir)
+	qb.Select("id", "crossid")
+	qb.Where("id = ?", typeid)
 
-	// 执行查询
-	result, err := qb.First()
+	category := &Category{}
+	found, err := qb.FirstInto(category)
 	if err != nil {
-		logger.Error("根据目录获取栏目失败", "dir", dir, "error", err)
+		logger.Error("获取交叉栏目失败", "id", typeid, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
-		return nil, nil
+	ids := []int64{typeid}
+	if found {
+		ids = append(ids, category.CrossTypeIDs()...)
 	}
+	return ids, nil
+}
 
-	// 转换为栏目对象
-	category := &Category{}
-
-	// 处理ID字段 - 支持多种类型
-	if id, ok := result["id"].(int64); ok {
-		category.ID = id
-	} else if id, ok := result["id"].(int); ok {
-		category.ID = int64(id)
-	} else if id, ok := result["id"].(string); ok {
-		if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-			category.ID = idInt
-		}
-	}
+// GetCrossReferrers 交叉栏目中包含指定栏目的栏目ID
+func (m *CategoryModel) GetCrossReferrers(typeids []int64) ([]int64, error) {
+	qb := database.NewQueryBuilder(m.db, "arctype")
+	qb.Select("id", "crossid")
+	qb.Where("crossid <> ''")
 
-	// 处理ParentID字段
-	if reid, ok := result["reid"].(int64); ok {
-		category.ParentID = reid
-	} else if reid, ok := result["reid"].(int); ok {
-		category.ParentID = int64(reid)
-	} else if reid, ok := result["reid"].(string); ok {
-		if reidInt, err := strconv.ParseInt(reid, 10, 64); err == nil {
-			category.ParentID = reidInt
-		}
+	categories := make([]*Category, 0)
+	if err := qb.GetInto(&categories); err != nil {
+		logger.Error("获取交叉栏目失败", "error", err)
+		return nil, err
 	}
 
-	category.TypeName, _ = result["typename"].(string)
-	category.TypeDir, _ = result["typedir"].(string)
-
-	// 处理整数字段
-	if ishidden, ok := result["ishidden"].(int64); ok {
-		category.IsHidden = int(ishidden)
-	} else if ishidden, ok := result["ishidden"].(int); ok {
-		category.IsHidden = ishidden
+	target := make(map[int64]bool, len(typeids))
+	for _, id := range typeids {
+		target[id] = true
 	}
-
-	if channeltype, ok := result["channeltype"].(int64); ok {
-		category.ChannelType = int(channeltype)
-	} else if channeltype, ok := result["channeltype"].(int); ok {
-		category.ChannelType = channeltype
+	ids := make([]int64, 0)
+	for _, category := range categories {
+		for _, id := range category.CrossTypeIDs() {
+			if target[id] {
+				ids = append(ids, category.ID)
+				break
+			}
+		}
 	}
+	return ids, nil
+}
 
-	// 处理CrossID字段
-	if crossid, ok := result["crossid"].(int64); ok {
-		category.CrossID = crossid
-	} else if crossid, ok := result["crossid"].(int); ok {
-		category.CrossID = int64(crossid)
+// Delete 删除栏目
+func (m *CategoryModel) Delete(id int64) error {
+	// 执行删除
+	_, err := m.db.Exec("DELETE FROM "+m.db.TableName("arctype")+" WHERE id = ?", id)
+	if err != nil {
+		logger.Error("删除栏目失败", "error", err)
+		return err
 	}
 
-	category.Description, _ = result["description"].(string)
-	category.Keywords, _ = result["keywords"].(string)
+	return nil
+}
 
-	// 处理整数字段
-	if sortrank, ok := result["sortrank"].(int64); ok {
-		category.SortRank = int(sortrank)
-	} else if sortrank, ok := result["sortrank"].(int); ok {
-		category.SortRank = sortrank
-	}
+// GetByDir 根据目录获取栏目
+func (m *CategoryModel) GetByDir(dir string) (*Category, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "arctype")
+	qb.Select("*")
+	qb.Where("typedir = ?", dir)
 
-	category.ListTpl, _ = result["templist"].(string)
-	category.ArticleTpl, _ = result["temparticle"].(string)
-
-	// 处理整数字段
-	if status, ok := result["status"].(int64); ok {
-		category.Status = int(status)
-	} else if status, ok := result["status"].(int); ok {
-		category.Status = status
-	} else {
-		// 如果数据库中没有status字段，设置默认值为启用
-		category.Status = 1
+	// 执行查询
+	category := &Category{}
+	found, err := qb.FirstInto(category)
+	if err != nil {
+		logger.Error("根据目录获取栏目失败", "dir", dir, "error", err)
+		return nil, err
 	}
 
-	// 处理日期
-	if createtime, ok := result["createtime"].(time.Time); ok {
-		category.CreateTime = createtime
-	}
-	if updatetime, ok := result["updatetime"].(time.Time); ok {
-		category.UpdateTime = updatetime
+	if !found {
+		return nil, nil
 	}
 
 	return category, nil
@@ -542,92 +316,17 @@ func (m *CategoryModel) GetByPath(parentDir, subDir string) (*Category, error) {
 	qb.Where("typedir = ? AND reid = ?", subDir, parentCategory.ID)
 
 	// 执行查询
-	result, err := qb.First()
+	category := &Category{}
+	found, err := qb.FirstInto(category)
 	if err != nil {
 		logger.Error("根据路径获取子栏目失败", "parentDir", parentDir, "subDir", subDir, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, nil
 	}
 
-	// 转换为栏目对象
-	category := &Category{}
-
-	// 处理ID字段 - 支持多种类型
-	if id, ok := result["id"].(int64); ok {
-		category.ID = id
-	} else if id, ok := result["id"].(int); ok {
-		category.ID = int64(id)
-	} else if id, ok := result["id"].(string); ok {
-		if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-			category.ID = idInt
-		}
-	}
-
-	// 处理ParentID字段
-	if reid, ok := result["reid"].(int64); ok {
-		category.ParentID = reid
-	} else if reid, ok := result["reid"].(int); ok {
-		category.ParentID = int64(reid)
-	} else if reid, ok := result["reid"].(string); ok {
-		if reidInt, err := strconv.ParseInt(reid, 10, 64); err == nil {
-			category.ParentID = reidInt
-		}
-	}
-
-	category.TypeName, _ = result["typename"].(string)
-	category.TypeDir, _ = result["typedir"].(string)
-
-	// 处理整数字段
-	if ishidden, ok := result["ishidden"].(int64); ok {
-		category.IsHidden = int(ishidden)
-	} else if ishidden, ok := result["ishidden"].(int); ok {
-		category.IsHidden = ishidden
-	}
-
-	if channeltype, ok := result["channeltype"].(int64); ok {
-		category.ChannelType = int(channeltype)
-	} else if channeltype, ok := result["channeltype"].(int); ok {
-		category.ChannelType = channeltype
-	}
-
-	if crossid, ok := result["crossid"].(int64); ok {
-		category.CrossID = crossid
-	} else if crossid, ok := result["crossid"].(int); ok {
-		category.CrossID = int64(crossid)
-	}
-
-	if sortrank, ok := result["sortrank"].(int64); ok {
-		category.SortRank = int(sortrank)
-	} else if sortrank, ok := result["sortrank"].(int); ok {
-		category.SortRank = sortrank
-	}
-
-	category.Description, _ = result["description"].(string)
-	category.Keywords, _ = result["keywords"].(string)
-	category.ListTpl, _ = result["templist"].(string)
-	category.ArticleTpl, _ = result["temparticle"].(string)
-
-	// 处理整数字段
-	if status, ok := result["status"].(int64); ok {
-		category.Status = int(status)
-	} else if status, ok := result["status"].(int); ok {
-		category.Status = status
-	} else {
-		// 如果数据库中没有status字段，设置默认值为启用
-		category.Status = 1
-	}
-
-	// 处理日期
-	if createtime, ok := result["createtime"].(time.Time); ok {
-		category.CreateTime = createtime
-	}
-	if updatetime, ok := result["updatetime"].(time.Time); ok {
-		category.UpdateTime = updatetime
-	}
-
 	return category, nil
 }
 
@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"aq3cms/pkg/database"
@@ -11,23 +12,23 @@ import (
 
 // CollectRule 采集规则
 type CollectRule struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`        // 规则名称
-	SourceType  int       `json:"sourcetype"`  // 来源类型：0列表，1RSS
-	SourceURL   string    `json:"sourceurl"`   // 来源URL
-	StartPage   int       `json:"startpage"`   // 开始页码
-	EndPage     int       `json:"endpage"`     // 结束页码
-	PageRule    string    `json:"pagerule"`    // 分页规则
-	ListRule    string    `json:"listrule"`    // 列表规则
-	TitleRule   string    `json:"titlerule"`   // 标题规则
-	ContentRule string    `json:"contentrule"` // 内容规则
-	TypeID      int64     `json:"typeid"`      // 栏目ID
-	ModelID     int64     `json:"modelid"`     // 模型ID
-	Status      int       `json:"status"`      // 状态：0禁用，1启用
-	LastTime    time.Time `json:"lasttime"`    // 最后采集时间
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
-	FieldRules  string    `json:"fieldrules"`  // 字段规则，JSON格式
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`               // 规则名称
+	SourceType  int       `json:"sourcetype" db:"sourcetype"`   // 来源类型：0列表，1RSS
+	SourceURL   string    `json:"sourceurl" db:"sourceurl"`     // 来源URL
+	StartPage   int       `json:"startpage" db:"startpage"`     // 开始页码
+	EndPage     int       `json:"endpage" db:"endpage"`         // 结束页码
+	PageRule    string    `json:"pagerule" db:"pagerule"`       // 分页规则
+	ListRule    string    `json:"listrule" db:"listrule"`       // 列表规则
+	TitleRule   string    `json:"titlerule" db:"titlerule"`     // 标题规则
+	ContentRule string    `json:"contentrule" db:"contentrule"` // 内容规则
+	TypeID      int64     `json:"typeid" db:"typeid"`           // 栏目ID
+	ModelID     int64     `json:"modelid" db:"modelid"`         // 模型ID
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	LastTime    time.Time `json:"lasttime" db:"lasttime"`       // 最后采集时间
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
+	FieldRules  string    `json:"fieldrules" db:"fieldrules"`   // 字段规则，JSON格式
 }
 
 // FieldRule 字段规则
@@ -40,17 +41,22 @@ type FieldRule struct {
 
 // CollectItem 采集项目
 type CollectItem struct {
-	ID         int64     `json:"id"`
-	RuleID     int64     `json:"ruleid"`     // 规则ID
-	Title      string    `json:"title"`      // 标题
-	URL        string    `json:"url"`        // 来源URL
-	Content    string    `json:"content"`    // 内容
-	Status     int       `json:"status"`     // 状态：0未处理，1已处理，2已发布
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
-	FieldData  string    `json:"fielddata"`  // 字段数据，JSON格式
+	ID         int64     `json:"id" db:"id"`
+	RuleID     int64     `json:"ruleid" db:"ruleid"`         // 规则ID
+	Title      string    `json:"title" db:"title"`           // 标题
+	URL        string    `json:"url" db:"url"`               // 来源URL
+	Content    string    `json:"content" db:"content"`       // 内容
+	Status     int       `json:"status" db:"status"`         // 状态：0未处理，1已处理，2已发布，3重复未发布
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
+	FieldData  string    `json:"fielddata" db:"fielddata"`   // 字段数据，JSON格式
+	SimHash    int64     `json:"simhash" db:"simhash"`       // 内容指纹
+	DupAID     int64     `json:"dupaid" db:"dupaid"`         // 内容重复的已有文档ID
 }
 
+// CollectItemStatusDuplicate 与已有文档重复而未发布的采集项目状态
+const CollectItemStatusDuplicate = 3
+
 // CollectRuleModel 采集规则模型
 type CollectRuleModel struct {
 	db *database.DB
@@ -70,37 +76,18 @@ func (m *CollectRuleModel) GetByID(id int64) (*CollectRule, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	rule := &CollectRule{}
+	found, err := qb.FirstInto(rule)
 	if err != nil {
 		logger.Error("获取采集规则失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("collect rule not found: %d", id)
 	}
 
-	// 转换为采集规则
-	rule := &CollectRule{}
-	rule.ID, _ = result["id"].(int64)
-	rule.Name, _ = result["name"].(string)
-	rule.SourceType, _ = result["sourcetype"].(int)
-	rule.SourceURL, _ = result["sourceurl"].(string)
-	rule.StartPage, _ = result["startpage"].(int)
-	rule.EndPage, _ = result["endpage"].(int)
-	rule.PageRule, _ = result["pagerule"].(string)
-	rule.ListRule, _ = result["listrule"].(string)
-	rule.TitleRule, _ = result["titlerule"].(string)
-	rule.ContentRule, _ = result["contentrule"].(string)
-	rule.TypeID, _ = result["typeid"].(int64)
-	rule.ModelID, _ = result["modelid"].(int64)
-	rule.Status, _ = result["status"].(int)
-	rule.LastTime, _ = result["lasttime"].(time.Time)
-	rule.CreateTime, _ = result["createtime"].(time.Time)
-	rule.UpdateTime, _ = result["updatetime"].(time.Time)
-	rule.FieldRules, _ = result["fieldrules"].(string)
-
 	return rule, nil
 }
 
@@ -111,36 +98,12 @@ func (m *CollectRuleModel) GetAll() ([]*CollectRule, error) {
 	qb.OrderBy("id DESC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	rules := make([]*CollectRule, 0)
+	if err := qb.GetInto(&rules); err != nil {
 		logger.Error("获取所有采集规则失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为采集规则列表
-	rules := make([]*CollectRule, 0, len(results))
-	for _, result := range results {
-		rule := &CollectRule{}
-		rule.ID, _ = result["id"].(int64)
-		rule.Name, _ = result["name"].(string)
-		rule.SourceType, _ = result["sourcetype"].(int)
-		rule.SourceURL, _ = result["sourceurl"].(string)
-		rule.StartPage, _ = result["startpage"].(int)
-		rule.EndPage, _ = result["endpage"].(int)
-		rule.PageRule, _ = result["pagerule"].(string)
-		rule.ListRule, _ = result["listrule"].(string)
-		rule.TitleRule, _ = result["titlerule"].(string)
-		rule.ContentRule, _ = result["contentrule"].(string)
-		rule.TypeID, _ = result["typeid"].(int64)
-		rule.ModelID, _ = result["modelid"].(int64)
-		rule.Status, _ = result["status"].(int)
-		rule.LastTime, _ = result["lasttime"].(time.Time)
-		rule.CreateTime, _ = result["createtime"].(time.Time)
-		rule.UpdateTime, _ = result["updatetime"].(time.Time)
-		rule.FieldRules, _ = result["fieldrules"].(string)
-		rules = append(rules, rule)
-	}
-
 	return rules, nil
 }
 
@@ -235,29 +198,18 @@ func (m *CollectItemModel) GetByID(id int64) (*CollectItem, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	item := &CollectItem{}
+	found, err := qb.FirstInto(item)
 	if err != nil {
 		logger.Error("获取采集项目失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("collect item not found: %d", id)
 	}
 
-	// 转换为采集项目
-	item := &CollectItem{}
-	item.ID, _ = result["id"].(int64)
-	item.RuleID, _ = result["ruleid"].(int64)
-	item.Title, _ = result["title"].(string)
-	item.URL, _ = result["url"].(string)
-	item.Content, _ = result["content"].(string)
-	item.Status, _ = result["status"].(int)
-	item.CreateTime, _ = result["createtime"].(time.Time)
-	item.UpdateTime, _ = result["updatetime"].(time.Time)
-	item.FieldData, _ = result["fielddata"].(string)
-
 	return item, nil
 }
 
@@ -274,8 +226,8 @@ func (m *CollectItemModel) GetByRuleID(ruleID int64, status int, page, pageSize
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	items := make([]*CollectItem, 0)
+	if err := qb.GetInto(&items); err != nil {
 		logger.Error("获取采集项目失败", "ruleid", ruleID, "error", err)
 		return nil, 0, err
 	}
@@ -287,22 +239,6 @@ func (m *CollectItemModel) GetByRuleID(ruleID int64, status int, page, pageSize
 		return nil, 0, err
 	}
 
-	// 转换为采集项目列表
-	items := make([]*CollectItem, 0, len(results))
-	for _, result := range results {
-		item := &CollectItem{}
-		item.ID, _ = result["id"].(int64)
-		item.RuleID, _ = result["ruleid"].(int64)
-		item.Title, _ = result["title"].(string)
-		item.URL, _ = result["url"].(string)
-		item.Content, _ = result["content"].(string)
-		item.Status, _ = result["status"].(int)
-		item.CreateTime, _ = result["createtime"].(time.Time)
-		item.UpdateTime, _ = result["updatetime"].(time.Time)
-		item.FieldData, _ = result["fielddata"].(string)
-		items = append(items, item)
-	}
-
 	return items, total, nil
 }
 
@@ -313,10 +249,12 @@ func (m *CollectItemModel) Create(item *CollectItem) (int64, error) {
 	item.CreateTime = now
 	item.UpdateTime = now
 
+	item.SimHash = ContentSimHash(item.Title, item.Content)
+
 	// 执行插入
 	result, err := m.db.Exec(
-		"INSERT INTO "+m.db.TableName("collect_item")+" (ruleid, title, url, content, status, createtime, updatetime, fielddata) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
-		item.RuleID, item.Title, item.URL, item.Content, item.Status, item.CreateTime, item.UpdateTime, item.FieldData,
+		"INSERT INTO "+m.db.TableName("collect_item")+" (ruleid, title, url, content, status, createtime, updatetime, fielddata, simhash) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		item.RuleID, item.Title, item.URL, item.Content, item.Status, item.CreateTime, item.UpdateTime, item.FieldData, item.SimHash,
 	)
 	if err != nil {
 		logger.Error("创建采集项目失败", "error", err)
@@ -337,11 +275,12 @@ func (m *CollectItemModel) Create(item *CollectItem) (int64, error) {
 func (m *CollectItemModel) Update(item *CollectItem) error {
 	// 设置更新时间
 	item.UpdateTime = time.Now()
+	item.SimHash = ContentSimHash(item.Title, item.Content)
 
 	// 执行更新
 	_, err := m.db.Exec(
-		"UPDATE "+m.db.TableName("collect_item")+" SET title = ?, content = ?, status = ?, updatetime = ?, fielddata = ? WHERE id = ?",
-		item.Title, item.Content, item.Status, item.UpdateTime, item.FieldData, item.ID,
+		"UPDATE "+m.db.TableName("collect_item")+" SET title = ?, content = ?, status = ?, updatetime = ?, fielddata = ?, simhash = ?, dupaid = ? WHERE id = ?",
+		item.Title, item.Content, item.Status, item.UpdateTime, item.FieldData, item.SimHash, item.DupAID, item.ID,
 	)
 	if err != nil {
 		logger.Error("更新采集项目失败", "error", err)
@@ -390,6 +329,68 @@ func (m *CollectItemModel) UpdateStatus(id int64, status int) error {
 	return nil
 }
 
+// MarkDuplicate 更新采集项目状态并记录内容重复的已有文档
+func (m *CollectItemModel) MarkDuplicate(id int64, status int, dupAID int64) error {
+	// 执行更新
+	_, err := m.db.Exec(
+		"UPDATE "+m.db.TableName("collect_item")+" SET status = ?, dupaid = ?, updatetime = ? WHERE id = ?",
+		status, dupAID, time.Now(), id,
+	)
+	if err != nil {
+		logger.Error("记录采集项目重复内容失败", "id", id, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetSimHash 保存采集项目的内容指纹，用于补算旧数据的指纹
+func (m *CollectItemModel) SetSimHash(id, hash int64) error {
+	_, err := m.db.Exec("UPDATE "+m.db.TableName("collect_item")+" SET simhash = ? WHERE id = ?", hash, id)
+	if err != nil {
+		logger.Error("保存采集项目内容指纹失败", "id", id, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByIDs 批量获取采集项目，按ids的顺序返回，不存在的ID被忽略
+func (m *CollectItemModel) GetByIDs(ids []int64) ([]*CollectItem, error) {
+	items := make([]*CollectItem, 0, len(ids))
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "collect_item")
+	qb.Where("id IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")+")", args...)
+
+	// 执行查询
+	rows := make([]*CollectItem, 0, len(ids))
+	if err := qb.GetInto(&rows); err != nil {
+		logger.Error("批量获取采集项目失败", "count", len(ids), "error", err)
+		return nil, err
+	}
+
+	byID := make(map[int64]*CollectItem, len(rows))
+	for _, item := range rows {
+		byID[item.ID] = item
+	}
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
 // GetFieldData 获取字段数据
 func (m *CollectItemModel) GetFieldData(id int64) (map[string]interface{}, error) {
 	// 获取采集项目
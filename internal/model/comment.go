@@ -11,21 +11,21 @@ import (
 
 // Comment 评论模型
 type Comment struct {
-	ID          int64     `json:"id"`
-	AID         int64     `json:"aid"`         // 文章ID
-	TypeID      int64     `json:"typeid"`      // 栏目ID
-	Username    string    `json:"username"`    // 用户名
-	MID         int64     `json:"mid"`         // 会员ID
-	IP          string    `json:"ip"`          // IP地址
-	IsCheck     int       `json:"ischeck"`     // 是否审核
-	Dtime       time.Time `json:"dtime"`       // 评论时间
-	Content     string    `json:"content"`     // 评论内容
-	ParentID    int64     `json:"parentid"`    // 父评论ID
-	Score       int       `json:"score"`       // 评分
-	GoodCount   int       `json:"goodcount"`   // 点赞数
-	BadCount    int       `json:"badcount"`    // 踩数
-	UserFace    string    `json:"userface"`    // 用户头像
-	ChannelType int       `json:"channeltype"` // 频道类型
+	ID          int64     `json:"id" db:"id"`
+	AID         int64     `json:"aid" db:"aid"`                 // 文章ID
+	TypeID      int64     `json:"typeid" db:"typeid"`           // 栏目ID
+	Username    string    `json:"username" db:"username"`       // 用户名
+	MID         int64     `json:"mid" db:"mid"`                 // 会员ID
+	IP          string    `json:"ip" db:"ip"`                   // IP地址
+	IsCheck     int       `json:"ischeck" db:"ischeck"`         // 是否审核
+	Dtime       time.Time `json:"dtime" db:"dtime"`             // 评论时间
+	Content     string    `json:"content" db:"content"`         // 评论内容
+	ParentID    int64     `json:"parentid" db:"parentid"`       // 父评论ID
+	Score       int       `json:"score" db:"score"`             // 评分
+	GoodCount   int       `json:"goodcount" db:"goodcount"`     // 点赞数
+	BadCount    int       `json:"badcount" db:"badcount"`       // 踩数
+	UserFace    string    `json:"userface" db:"userface"`       // 用户头像
+	ChannelType int       `json:"channeltype" db:"channeltype"` // 频道类型
 }
 
 // CommentModel 评论模型操作
@@ -48,56 +48,17 @@ func (m *CommentModel) GetByID(id int64) (*Comment, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	comment := &Comment{}
+	found, err := qb.FirstInto(comment)
 	if err != nil {
 		logger.Error("查询评论失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("评论不存在")
 	}
 
-	// 转换为评论对象
-	comment := &Comment{}
-	comment.ID, _ = result["id"].(int64)
-	comment.AID, _ = result["aid"].(int64)
-	comment.TypeID, _ = result["typeid"].(int64)
-	comment.Username, _ = result["username"].(string)
-	comment.MID, _ = result["mid"].(int64)
-	comment.IP, _ = result["ip"].(string)
-
-	// 处理整数字段
-	if ischeck, ok := result["ischeck"].(int64); ok {
-		comment.IsCheck = int(ischeck)
-	}
-
-	// 处理日期
-	if dtime, ok := result["dtime"].(time.Time); ok {
-		comment.Dtime = dtime
-	}
-
-	comment.Content, _ = result["content"].(string)
-	comment.ParentID, _ = result["parentid"].(int64)
-
-	// 处理整数字段
-	if score, ok := result["score"].(int64); ok {
-		comment.Score = int(score)
-	}
-	if goodcount, ok := result["goodcount"].(int64); ok {
-		comment.GoodCount = int(goodcount)
-	}
-	if badcount, ok := result["badcount"].(int64); ok {
-		comment.BadCount = int(badcount)
-	}
-
-	comment.UserFace, _ = result["userface"].(string)
-
-	// 处理整数字段
-	if channeltype, ok := result["channeltype"].(int64); ok {
-		comment.ChannelType = int(channeltype)
-	}
-
 	return comment, nil
 }
 
@@ -122,57 +83,12 @@ func (m *CommentModel) GetListByAID(aid int64, page, pageSize int) ([]*Comment,
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	comments := make([]*Comment, 0)
+	if err := qb.GetInto(&comments); err != nil {
 		logger.Error("查询评论列表失败", "aid", aid, "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为评论对象
-	comments := make([]*Comment, 0, len(results))
-	for _, result := range results {
-		comment := &Comment{}
-		comment.ID, _ = result["id"].(int64)
-		comment.AID, _ = result["aid"].(int64)
-		comment.TypeID, _ = result["typeid"].(int64)
-		comment.Username, _ = result["username"].(string)
-		comment.MID, _ = result["mid"].(int64)
-		comment.IP, _ = result["ip"].(string)
-
-		// 处理整数字段
-		if ischeck, ok := result["ischeck"].(int64); ok {
-			comment.IsCheck = int(ischeck)
-		}
-
-		// 处理日期
-		if dtime, ok := result["dtime"].(time.Time); ok {
-			comment.Dtime = dtime
-		}
-
-		comment.Content, _ = result["content"].(string)
-		comment.ParentID, _ = result["parentid"].(int64)
-
-		// 处理整数字段
-		if score, ok := result["score"].(int64); ok {
-			comment.Score = int(score)
-		}
-		if goodcount, ok := result["goodcount"].(int64); ok {
-			comment.GoodCount = int(goodcount)
-		}
-		if badcount, ok := result["badcount"].(int64); ok {
-			comment.BadCount = int(badcount)
-		}
-
-		comment.UserFace, _ = result["userface"].(string)
-
-		// 处理整数字段
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			comment.ChannelType = int(channeltype)
-		}
-
-		comments = append(comments, comment)
-	}
-
 	return comments, total, nil
 }
 
@@ -182,61 +98,18 @@ func (m *CommentModel) GetRecentComments(limit int) ([]*Comment, error) {
 	qb := database.NewQueryBuilder(m.db, "feedback")
 	qb.Select("*")
 	qb.Where("ischeck = 1")
+	// 不显示回收站中文章的评论
+	qb.Where("aid NOT IN (SELECT id FROM "+m.db.TableName("archives")+" WHERE arcrank = ?)", ArcRankRecycled)
 	qb.OrderBy("dtime DESC")
 	qb.Limit(limit)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	comments := make([]*Comment, 0)
+	if err := qb.GetInto(&comments); err != nil {
 		logger.Error("查询最新评论失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为评论对象
-	comments := make([]*Comment, 0, len(results))
-	for _, result := range results {
-		comment := &Comment{}
-		comment.ID, _ = result["id"].(int64)
-		comment.AID, _ = result["aid"].(int64)
-		comment.TypeID, _ = result["typeid"].(int64)
-		comment.Username, _ = result["username"].(string)
-		comment.MID, _ = result["mid"].(int64)
-		comment.IP, _ = result["ip"].(string)
-
-		// 处理整数字段
-		if ischeck, ok := result["ischeck"].(int64); ok {
-			comment.IsCheck = int(ischeck)
-		}
-
-		// 处理日期
-		if dtime, ok := result["dtime"].(time.Time); ok {
-			comment.Dtime = dtime
-		}
-
-		comment.Content, _ = result["content"].(string)
-		comment.ParentID, _ = result["parentid"].(int64)
-
-		// 处理整数字段
-		if score, ok := result["score"].(int64); ok {
-			comment.Score = int(score)
-		}
-		if goodcount, ok := result["goodcount"].(int64); ok {
-			comment.GoodCount = int(goodcount)
-		}
-		if badcount, ok := result["badcount"].(int64); ok {
-			comment.BadCount = int(badcount)
-		}
-
-		comment.UserFace, _ = result["userface"].(string)
-
-		// 处理整数字段
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			comment.ChannelType = int(channeltype)
-		}
-
-		comments = append(comments, comment)
-	}
-
 	return comments, nil
 }
 
@@ -290,6 +163,22 @@ func (m *CommentModel) Delete(id int64) error {
 	return nil
 }
 
+// DeleteByAID 删除文章的所有评论
+func (m *CommentModel) DeleteByAID(aid int64) error {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "feedback")
+	qb.Where("aid = ?", aid)
+
+	// 执行删除
+	_, err := qb.Delete()
+	if err != nil {
+		logger.Error("删除文章评论失败", "aid", aid, "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // UpdateStatus 更新评论状态
 func (m *CommentModel) UpdateStatus(id int64, isCheck int) error {
 	// 构建数据
@@ -345,57 +234,12 @@ func (m *CommentModel) GetByAID(aid int64) ([]*Comment, error) {
 	qb.OrderBy("dtime DESC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	comments := make([]*Comment, 0)
+	if err := qb.GetInto(&comments); err != nil {
 		logger.Error("查询文章评论失败", "aid", aid, "error", err)
 		return nil, err
 	}
 
-	// 转换为评论对象
-	comments := make([]*Comment, 0, len(results))
-	for _, result := range results {
-		comment := &Comment{}
-		comment.ID, _ = result["id"].(int64)
-		comment.AID, _ = result["aid"].(int64)
-		comment.TypeID, _ = result["typeid"].(int64)
-		comment.Username, _ = result["username"].(string)
-		comment.MID, _ = result["mid"].(int64)
-		comment.IP, _ = result["ip"].(string)
-
-		// 处理整数字段
-		if ischeck, ok := result["ischeck"].(int64); ok {
-			comment.IsCheck = int(ischeck)
-		}
-
-		// 处理日期
-		if dtime, ok := result["dtime"].(time.Time); ok {
-			comment.Dtime = dtime
-		}
-
-		comment.Content, _ = result["content"].(string)
-		comment.ParentID, _ = result["parentid"].(int64)
-
-		// 处理整数字段
-		if score, ok := result["score"].(int64); ok {
-			comment.Score = int(score)
-		}
-		if goodcount, ok := result["goodcount"].(int64); ok {
-			comment.GoodCount = int(goodcount)
-		}
-		if badcount, ok := result["badcount"].(int64); ok {
-			comment.BadCount = int(badcount)
-		}
-
-		comment.UserFace, _ = result["userface"].(string)
-
-		// 处理整数字段
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			comment.ChannelType = int(channeltype)
-		}
-
-		comments = append(comments, comment)
-	}
-
 	return comments, nil
 }
 
@@ -464,57 +308,12 @@ func (m *CommentModel) GetList(isCheck int, keyword string, page, pageSize int)
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	comments := make([]*Comment, 0)
+	if err := qb.GetInto(&comments); err != nil {
 		logger.Error("获取评论列表失败", "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为评论对象
-	comments := make([]*Comment, 0, len(results))
-	for _, result := range results {
-		comment := &Comment{}
-		comment.ID, _ = result["id"].(int64)
-		comment.AID, _ = result["aid"].(int64)
-		comment.TypeID, _ = result["typeid"].(int64)
-		comment.Username, _ = result["username"].(string)
-		comment.MID, _ = result["mid"].(int64)
-		comment.IP, _ = result["ip"].(string)
-
-		// 处理整数字段
-		if ischeck, ok := result["ischeck"].(int64); ok {
-			comment.IsCheck = int(ischeck)
-		}
-
-		// 处理日期
-		if dtime, ok := result["dtime"].(time.Time); ok {
-			comment.Dtime = dtime
-		}
-
-		comment.Content, _ = result["content"].(string)
-		comment.ParentID, _ = result["parentid"].(int64)
-
-		// 处理整数字段
-		if score, ok := result["score"].(int64); ok {
-			comment.Score = int(score)
-		}
-		if goodcount, ok := result["goodcount"].(int64); ok {
-			comment.GoodCount = int(goodcount)
-		}
-		if badcount, ok := result["badcount"].(int64); ok {
-			comment.BadCount = int(badcount)
-		}
-
-		comment.UserFace, _ = result["userface"].(string)
-
-		// 处理整数字段
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			comment.ChannelType = int(channeltype)
-		}
-
-		comments = append(comments, comment)
-	}
-
 	return comments, total, nil
 }
 
@@ -595,57 +394,12 @@ func (m *CommentModel) GetLatest(limit int) ([]*Comment, error) {
 	qb.Limit(limit)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	comments := make([]*Comment, 0)
+	if err := qb.GetInto(&comments); err != nil {
 		logger.Error("获取最新评论失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为评论对象
-	comments := make([]*Comment, 0, len(results))
-	for _, result := range results {
-		comment := &Comment{}
-		comment.ID, _ = result["id"].(int64)
-		comment.AID, _ = result["aid"].(int64)
-		comment.TypeID, _ = result["typeid"].(int64)
-		comment.Username, _ = result["username"].(string)
-		comment.MID, _ = result["mid"].(int64)
-		comment.IP, _ = result["ip"].(string)
-
-		// 处理整数字段
-		if ischeck, ok := result["ischeck"].(int64); ok {
-			comment.IsCheck = int(ischeck)
-		}
-
-		// 处理日期
-		if dtime, ok := result["dtime"].(time.Time); ok {
-			comment.Dtime = dtime
-		}
-
-		comment.Content, _ = result["content"].(string)
-		comment.ParentID, _ = result["parentid"].(int64)
-
-		// 处理整数字段
-		if score, ok := result["score"].(int64); ok {
-			comment.Score = int(score)
-		}
-		if goodcount, ok := result["goodcount"].(int64); ok {
-			comment.GoodCount = int(goodcount)
-		}
-		if badcount, ok := result["badcount"].(int64); ok {
-			comment.BadCount = int(badcount)
-		}
-
-		comment.UserFace, _ = result["userface"].(string)
-
-		// 处理整数字段
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			comment.ChannelType = int(channeltype)
-		}
-
-		comments = append(comments, comment)
-	}
-
 	return comments, nil
 }
 
@@ -669,56 +423,11 @@ func (m *CommentModel) GetByMemberID(memberID int64, page, pageSize int) ([]*Com
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	comments := make([]*Comment, 0)
+	if err := qb.GetInto(&comments); err != nil {
 		logger.Error("获取会员评论列表失败", "memberID", memberID, "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为评论对象
-	comments := make([]*Comment, 0, len(results))
-	for _, result := range results {
-		comment := &Comment{}
-		comment.ID, _ = result["id"].(int64)
-		comment.AID, _ = result["aid"].(int64)
-		comment.TypeID, _ = result["typeid"].(int64)
-		comment.Username, _ = result["username"].(string)
-		comment.MID, _ = result["mid"].(int64)
-		comment.IP, _ = result["ip"].(string)
-
-		// 处理整数字段
-		if ischeck, ok := result["ischeck"].(int64); ok {
-			comment.IsCheck = int(ischeck)
-		}
-
-		// 处理日期
-		if dtime, ok := result["dtime"].(time.Time); ok {
-			comment.Dtime = dtime
-		}
-
-		comment.Content, _ = result["content"].(string)
-		comment.ParentID, _ = result["parentid"].(int64)
-
-		// 处理整数字段
-		if score, ok := result["score"].(int64); ok {
-			comment.Score = int(score)
-		}
-		if goodcount, ok := result["goodcount"].(int64); ok {
-			comment.GoodCount = int(goodcount)
-		}
-		if badcount, ok := result["badcount"].(int64); ok {
-			comment.BadCount = int(badcount)
-		}
-
-		comment.UserFace, _ = result["userface"].(string)
-
-		// 处理整数字段
-		if channeltype, ok := result["channeltype"].(int64); ok {
-			comment.ChannelType = int(channeltype)
-		}
-
-		comments = append(comments, comment)
-	}
-
 	return comments, total, nil
 }
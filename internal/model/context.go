@@ -0,0 +1,210 @@
+package model
+
+import (
+	"context"
+)
+
+// WithContext 返回绑定ctx的广告模型
+func (m *AdModel) WithContext(ctx context.Context) *AdModel {
+	return &AdModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的广告位模型
+func (m *AdPositionModel) WithContext(ctx context.Context) *AdPositionModel {
+	return &AdPositionModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的管理员模型
+func (m *AdminModel) WithContext(ctx context.Context) *AdminModel {
+	return &AdminModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的文章模型
+func (m *ArticleModel) WithContext(ctx context.Context) *ArticleModel {
+	return &ArticleModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的文章相关度模型
+func (m *ArticleRelationModel) WithContext(ctx context.Context) *ArticleRelationModel {
+	return &ArticleRelationModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的文章修订版本模型
+func (m *ArticleRevisionModel) WithContext(ctx context.Context) *ArticleRevisionModel {
+	return &ArticleRevisionModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的文章审核记录模型
+func (m *ArticleWorkflowModel) WithContext(ctx context.Context) *ArticleWorkflowModel {
+	return &ArticleWorkflowModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的栏目模型
+func (m *CategoryModel) WithContext(ctx context.Context) *CategoryModel {
+	return &CategoryModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的采集规则模型
+func (m *CollectRuleModel) WithContext(ctx context.Context) *CollectRuleModel {
+	return &CollectRuleModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的采集项目模型
+func (m *CollectItemModel) WithContext(ctx context.Context) *CollectItemModel {
+	return &CollectItemModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的评论模型
+func (m *CommentModel) WithContext(ctx context.Context) *CommentModel {
+	return &CommentModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的下载模型
+func (m *DownloadModel) WithContext(ctx context.Context) *DownloadModel {
+	return &DownloadModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的表单模型
+func (m *FormModel) WithContext(ctx context.Context) *FormModel {
+	return &FormModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的表单数据模型
+func (m *FormDataModel) WithContext(ctx context.Context) *FormDataModel {
+	return &FormDataModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的语言模型
+func (m *LanguageModel) WithContext(ctx context.Context) *LanguageModel {
+	return &LanguageModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的语言文本模型
+func (m *LanguageTextModel) WithContext(ctx context.Context) *LanguageTextModel {
+	return &LanguageTextModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的友情链接模型
+func (m *LinkModel) WithContext(ctx context.Context) *LinkModel {
+	return &LinkModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的友情链接分类模型
+func (m *LinkTypeModel) WithContext(ctx context.Context) *LinkTypeModel {
+	return &LinkTypeModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的会员模型
+func (m *MemberModel) WithContext(ctx context.Context) *MemberModel {
+	return &MemberModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的会员类型模型
+func (m *MemberTypeModel) WithContext(ctx context.Context) *MemberTypeModel {
+	return &MemberTypeModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的消息模型
+func (m *MessageModel) WithContext(ctx context.Context) *MessageModel {
+	return &MessageModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的内容模型模型
+func (m *ContentModelModel) WithContext(ctx context.Context) *ContentModelModel {
+	return &ContentModelModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的支付方式模型
+func (m *PaymentMethodModel) WithContext(ctx context.Context) *PaymentMethodModel {
+	return &PaymentMethodModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的支付订单模型
+func (m *PaymentOrderModel) WithContext(ctx context.Context) *PaymentOrderModel {
+	return &PaymentOrderModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的插件模型
+func (m *PluginModel) WithContext(ctx context.Context) *PluginModel {
+	return &PluginModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的插件钩子模型
+func (m *PluginHookModel) WithContext(ctx context.Context) *PluginHookModel {
+	return &PluginHookModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的产品模型
+func (m *ProductModel) WithContext(ctx context.Context) *ProductModel {
+	return &ProductModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的积分规则模型
+func (m *ScoreRuleModel) WithContext(ctx context.Context) *ScoreRuleModel {
+	return &ScoreRuleModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的积分日志模型
+func (m *ScoreLogModel) WithContext(ctx context.Context) *ScoreLogModel {
+	return &ScoreLogModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的搜索日志模型
+func (m *SearchLogModel) WithContext(ctx context.Context) *SearchLogModel {
+	return &SearchLogModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的SEO规则模型
+func (m *SEORuleModel) WithContext(ctx context.Context) *SEORuleModel {
+	return &SEORuleModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的SEO关键词模型
+func (m *SEOKeywordModel) WithContext(ctx context.Context) *SEOKeywordModel {
+	return &SEOKeywordModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的专题模型
+func (m *SpecialModel) WithContext(ctx context.Context) *SpecialModel {
+	return &SpecialModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的访问记录模型
+func (m *VisitModel) WithContext(ctx context.Context) *VisitModel {
+	return &VisitModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的搜索关键词模型
+func (m *SearchKeywordModel) WithContext(ctx context.Context) *SearchKeywordModel {
+	return &SearchKeywordModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的标签模型
+func (m *TagModel) WithContext(ctx context.Context) *TagModel {
+	return &TagModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的访问日志模型
+func (m *VisitLogModel) WithContext(ctx context.Context) *VisitLogModel {
+	return &VisitLogModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的投票模型
+func (m *VoteModel) WithContext(ctx context.Context) *VoteModel {
+	return &VoteModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的投票选项模型
+func (m *VoteOptionModel) WithContext(ctx context.Context) *VoteOptionModel {
+	return &VoteOptionModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的投票日志模型
+func (m *VoteLogModel) WithContext(ctx context.Context) *VoteLogModel {
+	return &VoteLogModel{db: m.db.WithContext(ctx)}
+}
+
+// WithContext 返回绑定ctx的模板模型
+func (m *TemplateModel) WithContext(ctx context.Context) *TemplateModel {
+	return &TemplateModel{db: m.db.WithContext(ctx), templateDir: m.templateDir}
+}
@@ -10,41 +10,41 @@ import (
 
 // Download 下载模型
 type Download struct {
-	ID          int64     `json:"id"`
-	TypeID      int64     `json:"typeid"`
-	Title       string    `json:"title"`
-	ShortTitle  string    `json:"shorttitle"`
-	Color       string    `json:"color"`
-	Writer      string    `json:"writer"`
-	Source      string    `json:"source"`
-	LitPic      string    `json:"litpic"`
-	PubDate     time.Time `json:"pubdate"`
-	SendDate    time.Time `json:"senddate"`
-	Keywords    string    `json:"keywords"`
-	Description string    `json:"description"`
-	Filename    string    `json:"filename"`
-	IsTop       int       `json:"istop"`
-	IsRecommend int       `json:"isrecommend"`
-	IsHot       int       `json:"ishot"`
-	ArcRank     int       `json:"arcrank"`
-	Click       int       `json:"click"`
-	Body        string    `json:"body"`
-	TypeName    string    `json:"typename"`
-	TypeDir     string    `json:"typedir"`
+	ID          int64     `json:"id" db:"id"`
+	TypeID      int64     `json:"typeid" db:"typeid"`
+	Title       string    `json:"title" db:"title"`
+	ShortTitle  string    `json:"shorttitle" db:"shorttitle"`
+	Color       string    `json:"color" db:"color"`
+	Writer      string    `json:"writer" db:"writer"`
+	Source      string    `json:"source" db:"source"`
+	LitPic      string    `json:"litpic" db:"litpic"`
+	PubDate     time.Time `json:"pubdate" db:"pubdate"`
+	SendDate    time.Time `json:"senddate" db:"senddate"`
+	Keywords    string    `json:"keywords" db:"keywords"`
+	Description string    `json:"description" db:"description"`
+	Filename    string    `json:"filename" db:"filename"`
+	IsTop       int       `json:"istop" db:"istop"`
+	IsRecommend int       `json:"isrecommend" db:"isrecommend"`
+	IsHot       int       `json:"ishot" db:"ishot"`
+	ArcRank     int       `json:"arcrank" db:"arcrank"`
+	Click       int       `json:"click" db:"click"`
+	Body        string    `json:"body" db:"body"`
+	TypeName    string    `json:"typename" db:"typename"`
+	TypeDir     string    `json:"typedir" db:"typedir"`
 	// 下载特有字段
-	SoftName     string   `json:"softname"`
-	SoftVersion  string   `json:"softversion"`
-	SoftLanguage string   `json:"softlanguage"`
-	SoftType     string   `json:"softtype"`
-	SoftSize     string   `json:"softsize"`
-	SoftOS       string   `json:"softos"`
-	SoftDeveloper string  `json:"softdeveloper"`
-	SoftLicense  string   `json:"softlicense"`
-	SoftScore    float64  `json:"softscore"`
-	SoftURL      string   `json:"softurl"`
-	SoftMirrURL  string   `json:"softmirrurl"`
-	Screenshots  []string `json:"screenshots"`
-	DownCount    int      `json:"downcount"`
+	SoftName      string   `json:"softname" db:"softname"`
+	SoftVersion   string   `json:"softversion" db:"softversion"`
+	SoftLanguage  string   `json:"softlanguage" db:"softlanguage"`
+	SoftType      string   `json:"softtype" db:"softtype"`
+	SoftSize      string   `json:"softsize" db:"softsize"`
+	SoftOS        string   `json:"softos" db:"softos"`
+	SoftDeveloper string   `json:"softdeveloper" db:"softdeveloper"`
+	SoftLicense   string   `json:"softlicense" db:"softlicense"`
+	SoftScore     float64  `json:"softscore" db:"softscore"`
+	SoftURL       string   `json:"softurl" db:"softurl"`
+	SoftMirrURL   string   `json:"softmirrurl" db:"softmirrurl"`
+	Screenshots   []string `json:"screenshots"`
+	DownCount     int      `json:"downcount" db:"downcount"`
 }
 
 // DownloadModel 下载模型操作
@@ -71,87 +71,17 @@ func (m *DownloadModel) GetByID(id int64) (*Download, error) {
 	qb.Where("a.channel = 3") // 下载频道ID
 	
 	// 执行查询
-	result, err := qb.First()
+	download := &Download{}
+	found, err := qb.FirstInto(download)
 	if err != nil {
 		logger.Error("查询下载失败", "id", id, "error", err)
 		return nil, err
 	}
-	
-	if result == nil {
+
+	if !found {
 		return nil, fmt.Errorf("下载不存在")
 	}
-	
-	// 转换为下载对象
-	download := &Download{}
-	download.ID, _ = result["id"].(int64)
-	download.TypeID, _ = result["typeid"].(int64)
-	download.Title, _ = result["title"].(string)
-	download.ShortTitle, _ = result["shorttitle"].(string)
-	download.Color, _ = result["color"].(string)
-	download.Writer, _ = result["writer"].(string)
-	download.Source, _ = result["source"].(string)
-	download.LitPic, _ = result["litpic"].(string)
-	
-	// 处理日期
-	if pubdate, ok := result["pubdate"].(time.Time); ok {
-		download.PubDate = pubdate
-	}
-	if senddate, ok := result["senddate"].(time.Time); ok {
-		download.SendDate = senddate
-	}
-	
-	download.Keywords, _ = result["keywords"].(string)
-	download.Description, _ = result["description"].(string)
-	download.Filename, _ = result["filename"].(string)
-	
-	// 处理整数字段
-	if istop, ok := result["istop"].(int64); ok {
-		download.IsTop = int(istop)
-	}
-	if isrecommend, ok := result["isrecommend"].(int64); ok {
-		download.IsRecommend = int(isrecommend)
-	}
-	if ishot, ok := result["ishot"].(int64); ok {
-		download.IsHot = int(ishot)
-	}
-	if arcrank, ok := result["arcrank"].(int64); ok {
-		download.ArcRank = int(arcrank)
-	}
-	if click, ok := result["click"].(int64); ok {
-		download.Click = int(click)
-	}
-	
-	download.Body, _ = result["body"].(string)
-	download.TypeName, _ = result["typename"].(string)
-	download.TypeDir, _ = result["typedir"].(string)
-	
-	// 处理下载特有字段
-	download.SoftName, _ = result["softname"].(string)
-	download.SoftVersion, _ = result["softversion"].(string)
-	download.SoftLanguage, _ = result["softlanguage"].(string)
-	download.SoftType, _ = result["softtype"].(string)
-	download.SoftSize, _ = result["softsize"].(string)
-	download.SoftOS, _ = result["softos"].(string)
-	download.SoftDeveloper, _ = result["softdeveloper"].(string)
-	download.SoftLicense, _ = result["softlicense"].(string)
-	
-	// 处理评分
-	if softscore, ok := result["softscore"].(float64); ok {
-		download.SoftScore = softscore
-	} else if softscoreStr, ok := result["softscore"].(string); ok {
-		fmt.Sscanf(softscoreStr, "%f", &download.SoftScore)
-	}
-	
-	download.SoftURL, _ = result["softurl"].(string)
-	download.SoftMirrURL, _ = result["softmirrurl"].(string)
-	
-	// 处理下载次数
-	if downcount, ok := result["downcount"].(int64); ok {
-		download.DownCount = int(downcount)
-	} else if downcountStr, ok := result["downcount"].(string); ok {
-		fmt.Sscanf(downcountStr, "%d", &download.DownCount)
-	}
-	
+
 	// 获取截图
 	screenshots, err := m.getDownloadScreenshots(id)
 	if err != nil {
@@ -167,14 +97,21 @@ func (m *DownloadModel) GetList(typeid int64, page, pageSize int) ([]*Download,
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "archives")
 	qb.Select("a.*", "t.typename", "t.typedir", "d.softname", "d.softversion", "d.softsize", "d.softscore", "d.downcount")
+	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.LeftJoin(m.db.TableName("addondownload")+" AS d", "a.id = d.aid")
 	
 	// 添加条件
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.Where("a.channel = 3") // 下载频道ID
 	if typeid > 0 {
-		qb.Where("a.typeid = ?", typeid)
+		// 包括发布到副栏目和交叉栏目的下载
+		condition, args, err := NewArticleModel(m.db).CategoryCondition(typeid)
+		if err != nil {
+			return nil, 0, err
+		}
+		qb.Where(condition, args...)
 	}
 	
 	// 获取总数
@@ -190,79 +127,12 @@ func (m *DownloadModel) GetList(typeid int64, page, pageSize int) ([]*Download,
 	qb.Limit(pageSize, offset)
 	
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	downloads := make([]*Download, 0)
+	if err := qb.GetInto(&downloads); err != nil {
 		logger.Error("查询下载列表失败", "error", err)
 		return nil, 0, err
 	}
 	
-	// 转换为下载对象
-	downloads := make([]*Download, 0, len(results))
-	for _, result := range results {
-		download := &Download{}
-		download.ID, _ = result["id"].(int64)
-		download.TypeID, _ = result["typeid"].(int64)
-		download.Title, _ = result["title"].(string)
-		download.ShortTitle, _ = result["shorttitle"].(string)
-		download.Color, _ = result["color"].(string)
-		download.Writer, _ = result["writer"].(string)
-		download.Source, _ = result["source"].(string)
-		download.LitPic, _ = result["litpic"].(string)
-		
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			download.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			download.SendDate = senddate
-		}
-		
-		download.Keywords, _ = result["keywords"].(string)
-		download.Description, _ = result["description"].(string)
-		download.Filename, _ = result["filename"].(string)
-		
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			download.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			download.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			download.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			download.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			download.Click = int(click)
-		}
-		
-		download.TypeName, _ = result["typename"].(string)
-		download.TypeDir, _ = result["typedir"].(string)
-		
-		// 处理下载特有字段
-		download.SoftName, _ = result["softname"].(string)
-		download.SoftVersion, _ = result["softversion"].(string)
-		download.SoftSize, _ = result["softsize"].(string)
-		
-		// 处理评分
-		if softscore, ok := result["softscore"].(float64); ok {
-			download.SoftScore = softscore
-		} else if softscoreStr, ok := result["softscore"].(string); ok {
-			fmt.Sscanf(softscoreStr, "%f", &download.SoftScore)
-		}
-		
-		// 处理下载次数
-		if downcount, ok := result["downcount"].(int64); ok {
-			download.DownCount = int(downcount)
-		} else if downcountStr, ok := result["downcount"].(string); ok {
-			fmt.Sscanf(downcountStr, "%d", &download.DownCount)
-		}
-		
-		downloads = append(downloads, download)
-	}
-	
 	return downloads, total, nil
 }
 
@@ -486,17 +356,17 @@ func (m *DownloadModel) getDownloadScreenshots(id int64) ([]string, error) {
 	qb.OrderBy("id ASC")
 	
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	var rows []struct {
+		Screenshot string `db:"screenshot"`
+	}
+	if err := qb.GetInto(&rows); err != nil {
 		return nil, err
 	}
 	
 	// 提取截图URL
-	screenshots := make([]string, 0, len(results))
-	for _, result := range results {
-		if screenshot, ok := result["screenshot"].(string); ok {
-			screenshots = append(screenshots, screenshot)
-		}
+	screenshots := make([]string, 0, len(rows))
+	for _, row := range rows {
+		screenshots = append(screenshots, row.Screenshot)
 	}
 	
 	return screenshots, nil
@@ -11,36 +11,36 @@ import (
 
 // Form 表单模型
 type Form struct {
-	ID          int64     `json:"id"`
-	Title       string    `json:"title"`       // 表单标题
-	Description string    `json:"description"` // 表单描述
-	Template    string    `json:"template"`    // 表单模板
-	Status      int       `json:"status"`      // 状态
-	AddTime     time.Time `json:"addtime"`     // 添加时间
-	Fields      []*FormField `json:"fields"`   // 表单字段
-	SuccessURL  string    `json:"successurl"`  // 成功跳转URL
+	ID          int64        `json:"id" db:"id"`
+	Title       string       `json:"title" db:"title"`             // 表单标题
+	Description string       `json:"description" db:"description"` // 表单描述
+	Template    string       `json:"template" db:"template"`       // 表单模板
+	Status      int          `json:"status" db:"status"`           // 状态
+	AddTime     time.Time    `json:"addtime" db:"addtime"`         // 添加时间
+	Fields      []*FormField `json:"fields"`                       // 表单字段
+	SuccessURL  string       `json:"successurl"`                   // 成功跳转URL
 }
 
 // FormField 表单字段
 type FormField struct {
-	ID          int64  `json:"id"`
-	FormID      int64  `json:"formid"`      // 表单ID
-	FieldName   string `json:"fieldname"`   // 字段名称
-	FieldType   string `json:"fieldtype"`   // 字段类型
-	FieldTitle  string `json:"fieldtitle"`  // 字段标题
-	FieldValue  string `json:"fieldvalue"`  // 字段默认值
-	IsRequired  int    `json:"isrequired"`  // 是否必填
-	SortRank    int    `json:"sortrank"`    // 排序
+	ID         int64  `json:"id" db:"id"`
+	FormID     int64  `json:"formid" db:"formid"`         // 表单ID
+	FieldName  string `json:"fieldname" db:"fieldname"`   // 字段名称
+	FieldType  string `json:"fieldtype" db:"fieldtype"`   // 字段类型
+	FieldTitle string `json:"fieldtitle" db:"fieldtitle"` // 字段标题
+	FieldValue string `json:"fieldvalue" db:"fieldvalue"` // 字段默认值
+	IsRequired int    `json:"isrequired" db:"isrequired"` // 是否必填
+	SortRank   int    `json:"sortrank" db:"sortrank"`     // 排序
 }
 
 // FormData 表单数据
 type FormData struct {
-	ID          int64     `json:"id"`
-	FormID      int64     `json:"formid"`      // 表单ID
-	IP          string    `json:"ip"`          // IP地址
-	AddTime     time.Time `json:"addtime"`     // 添加时间
-	Status      int       `json:"status"`      // 状态
-	Data        map[string]string `json:"data"` // 表单数据
+	ID      int64             `json:"id" db:"id"`
+	FormID  int64             `json:"formid" db:"formid"`   // 表单ID
+	IP      string            `json:"ip" db:"ip"`           // IP地址
+	AddTime time.Time         `json:"addtime" db:"addtime"` // 添加时间
+	Status  int               `json:"status" db:"status"`   // 状态
+	Data    map[string]string `json:"data"`                 // 表单数据
 }
 
 // FormModel 表单模型操作
@@ -63,33 +63,17 @@ func (m *FormModel) GetByID(id int64) (*Form, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	form := &Form{}
+	found, err := qb.FirstInto(form)
 	if err != nil {
 		logger.Error("查询表单失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("表单不存在")
 	}
 
-	// 转换为表单对象
-	form := &Form{}
-	form.ID, _ = result["id"].(int64)
-	form.Title, _ = result["title"].(string)
-	form.Description, _ = result["description"].(string)
-	form.Template, _ = result["template"].(string)
-
-	// 处理整数字段
-	if status, ok := result["status"].(int64); ok {
-		form.Status = int(status)
-	}
-
-	// 处理日期
-	if addtime, ok := result["addtime"].(time.Time); ok {
-		form.AddTime = addtime
-	}
-
 	// 获取表单字段
 	fields, err := m.GetFormFields(form.ID)
 	if err != nil {
@@ -97,6 +81,7 @@ func (m *FormModel) GetByID(id int64) (*Form, error) {
 	}
 	form.Fields = fields
 
+
 	return form, nil
 }
 
@@ -119,34 +104,12 @@ func (m *FormModel) GetList(page, pageSize int) ([]*Form, int, error) {
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	forms := make([]*Form, 0)
+	if err := qb.GetInto(&forms); err != nil {
 		logger.Error("查询表单列表失败", "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为表单对象
-	forms := make([]*Form, 0, len(results))
-	for _, result := range results {
-		form := &Form{}
-		form.ID, _ = result["id"].(int64)
-		form.Title, _ = result["title"].(string)
-		form.Description, _ = result["description"].(string)
-		form.Template, _ = result["template"].(string)
-
-		// 处理整数字段
-		if status, ok := result["status"].(int64); ok {
-			form.Status = int(status)
-		}
-
-		// 处理日期
-		if addtime, ok := result["addtime"].(time.Time); ok {
-			form.AddTime = addtime
-		}
-
-		forms = append(forms, form)
-	}
-
 	return forms, total, nil
 }
 
@@ -159,34 +122,12 @@ func (m *FormModel) GetFormFields(formID int64) ([]*FormField, error) {
 	qb.OrderBy("sortrank ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	fields := make([]*FormField, 0)
+	if err := qb.GetInto(&fields); err != nil {
 		logger.Error("查询表单字段失败", "formid", formID, "error", err)
 		return nil, err
 	}
 
-	// 转换为表单字段对象
-	fields := make([]*FormField, 0, len(results))
-	for _, result := range results {
-		field := &FormField{}
-		field.ID, _ = result["id"].(int64)
-		field.FormID, _ = result["formid"].(int64)
-		field.FieldName, _ = result["fieldname"].(string)
-		field.FieldType, _ = result["fieldtype"].(string)
-		field.FieldTitle, _ = result["fieldtitle"].(string)
-		field.FieldValue, _ = result["fieldvalue"].(string)
-
-		// 处理整数字段
-		if isrequired, ok := result["isrequired"].(int64); ok {
-			field.IsRequired = int(isrequired)
-		}
-		if sortrank, ok := result["sortrank"].(int64); ok {
-			field.SortRank = int(sortrank)
-		}
-
-		fields = append(fields, field)
-	}
-
 	return fields, nil
 }
 
@@ -227,25 +168,16 @@ func (m *FormModel) GetFormData(formID int64, page, pageSize int) ([]*FormData,
 	formDataList := make([]*FormData, 0, len(results))
 	for _, result := range results {
 		formData := &FormData{}
-		formData.ID, _ = result["id"].(int64)
-		formData.FormID, _ = result["formid"].(int64)
-		formData.IP, _ = result["ip"].(string)
-
-		// 处理日期
-		if addtime, ok := result["addtime"].(time.Time); ok {
-			formData.AddTime = addtime
-		}
-
-		// 处理整数字段
-		if status, ok := result["status"].(int64); ok {
-			formData.Status = int(status)
+		if err := database.MapInto(result, formData); err != nil {
+			logger.Error("解析表单数据失败", "formid", formID, "error", err)
+			return nil, 0, err
 		}
 
-		// 获取表单数据
+		// 获取表单数据，数字和日期列同样转换为字符串
 		formData.Data = make(map[string]string)
 		for _, field := range fields {
-			if value, ok := result[field.FieldName].(string); ok {
-				formData.Data[field.FieldName] = value
+			if value, ok := result[field.FieldName]; ok {
+				formData.Data[field.FieldName] = database.ValueString(value)
 			}
 		}
 
@@ -11,13 +11,13 @@ import (
 
 // FormDataEntry 表单数据条目
 type FormDataEntry struct {
-	ID         int64     `json:"id"`
-	FormID     int64     `json:"formid"`     // 表单ID
-	Data       string    `json:"data"`       // 表单数据
-	IP         string    `json:"ip"`         // IP地址
-	Status     int       `json:"status"`     // 状态：0未处理，1已处理
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	FormID     int64     `json:"formid" db:"formid"`         // 表单ID
+	Data       string    `json:"data" db:"data"`             // 表单数据
+	IP         string    `json:"ip" db:"ip"`                 // IP地址
+	Status     int       `json:"status" db:"status"`         // 状态：0未处理，1已处理
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // FormDataModel 表单数据模型
@@ -39,27 +39,18 @@ func (m *FormDataModel) GetByID(id int64) (*FormDataEntry, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	formData := &FormDataEntry{}
+	found, err := qb.FirstInto(formData)
 	if err != nil {
 		logger.Error("获取表单数据失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("form data not found: %d", id)
 	}
 
-	// 转换为表单数据
-	formData := &FormDataEntry{}
-	formData.ID, _ = result["id"].(int64)
-	formData.FormID, _ = result["formid"].(int64)
-	formData.Data, _ = result["data"].(string)
-	formData.IP, _ = result["ip"].(string)
-	formData.Status, _ = result["status"].(int)
-	formData.CreateTime, _ = result["createtime"].(time.Time)
-	formData.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return formData, nil
 }
 
@@ -76,8 +67,8 @@ func (m *FormDataModel) GetByFormID(formID int64, status int, page, pageSize int
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	formDataList := make([]*FormDataEntry, 0)
+	if err := qb.GetInto(&formDataList); err != nil {
 		logger.Error("获取表单数据失败", "formid", formID, "error", err)
 		return nil, 0, err
 	}
@@ -89,20 +80,6 @@ func (m *FormDataModel) GetByFormID(formID int64, status int, page, pageSize int
 		return nil, 0, err
 	}
 
-	// 转换为表单数据列表
-	formDataList := make([]*FormDataEntry, 0, len(results))
-	for _, result := range results {
-		formData := &FormDataEntry{}
-		formData.ID, _ = result["id"].(int64)
-		formData.FormID, _ = result["formid"].(int64)
-		formData.Data, _ = result["data"].(string)
-		formData.IP, _ = result["ip"].(string)
-		formData.Status, _ = result["status"].(int)
-		formData.CreateTime, _ = result["createtime"].(time.Time)
-		formData.UpdateTime, _ = result["updatetime"].(time.Time)
-		formDataList = append(formDataList, formData)
-	}
-
 	return formDataList, total, nil
 }
 
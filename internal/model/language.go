@@ -10,26 +10,26 @@ import (
 
 // Language 语言
 type Language struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`        // 语言名称
-	Code        string    `json:"code"`        // 语言代码
-	Flag        string    `json:"flag"`        // 国旗图标
-	IsDefault   int       `json:"isdefault"`   // 是否默认：0否，1是
-	Status      int       `json:"status"`      // 状态：0禁用，1启用
-	OrderID     int       `json:"orderid"`     // 排序ID
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`             // 语言名称
+	Code       string    `json:"code" db:"code"`             // 语言代码
+	Flag       string    `json:"flag" db:"flag"`             // 国旗图标
+	IsDefault  int       `json:"isdefault" db:"isdefault"`   // 是否默认：0否，1是
+	Status     int       `json:"status" db:"status"`         // 状态：0禁用，1启用
+	OrderID    int       `json:"orderid" db:"orderid"`       // 排序ID
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // LanguageText 语言文本
 type LanguageText struct {
-	ID         int64     `json:"id"`
-	LanguageID int64     `json:"languageid"` // 语言ID
-	Key        string    `json:"key"`        // 键
-	Value      string    `json:"value"`      // 值
-	Module     string    `json:"module"`     // 模块
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	LanguageID int64     `json:"languageid" db:"languageid"` // 语言ID
+	Key        string    `json:"key" db:"key"`               // 键
+	Value      string    `json:"value" db:"value"`           // 值
+	Module     string    `json:"module" db:"module"`         // 模块
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // LanguageModel 语言模型
@@ -51,29 +51,18 @@ func (m *LanguageModel) GetByID(id int64) (*Language, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	language := &Language{}
+	found, err := qb.FirstInto(language)
 	if err != nil {
 		logger.Error("获取语言失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("language not found: %d", id)
 	}
 
-	// 转换为语言
-	language := &Language{}
-	language.ID, _ = result["id"].(int64)
-	language.Name, _ = result["name"].(string)
-	language.Code, _ = result["code"].(string)
-	language.Flag, _ = result["flag"].(string)
-	language.IsDefault, _ = result["isdefault"].(int)
-	language.Status, _ = result["status"].(int)
-	language.OrderID, _ = result["orderid"].(int)
-	language.CreateTime, _ = result["createtime"].(time.Time)
-	language.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return language, nil
 }
 
@@ -84,29 +73,18 @@ func (m *LanguageModel) GetByCode(code string) (*Language, error) {
 	qb.Where("code = ?", code)
 
 	// 执行查询
-	result, err := qb.First()
+	language := &Language{}
+	found, err := qb.FirstInto(language)
 	if err != nil {
 		logger.Error("获取语言失败", "code", code, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("language not found: %s", code)
 	}
 
-	// 转换为语言
-	language := &Language{}
-	language.ID, _ = result["id"].(int64)
-	language.Name, _ = result["name"].(string)
-	language.Code, _ = result["code"].(string)
-	language.Flag, _ = result["flag"].(string)
-	language.IsDefault, _ = result["isdefault"].(int)
-	language.Status, _ = result["status"].(int)
-	language.OrderID, _ = result["orderid"].(int)
-	language.CreateTime, _ = result["createtime"].(time.Time)
-	language.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return language, nil
 }
 
@@ -118,29 +96,18 @@ func (m *LanguageModel) GetDefault() (*Language, error) {
 	qb.Where("status = ?", 1)
 
 	// 执行查询
-	result, err := qb.First()
+	language := &Language{}
+	found, err := qb.FirstInto(language)
 	if err != nil {
 		logger.Error("获取默认语言失败", "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("default language not found")
 	}
 
-	// 转换为语言
-	language := &Language{}
-	language.ID, _ = result["id"].(int64)
-	language.Name, _ = result["name"].(string)
-	language.Code, _ = result["code"].(string)
-	language.Flag, _ = result["flag"].(string)
-	language.IsDefault, _ = result["isdefault"].(int)
-	language.Status, _ = result["status"].(int)
-	language.OrderID, _ = result["orderid"].(int)
-	language.CreateTime, _ = result["createtime"].(time.Time)
-	language.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return language, nil
 }
 
@@ -154,28 +121,12 @@ func (m *LanguageModel) GetAll(status int) ([]*Language, error) {
 	qb.OrderBy("orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	languages := make([]*Language, 0)
+	if err := qb.GetInto(&languages); err != nil {
 		logger.Error("获取所有语言失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为语言列表
-	languages := make([]*Language, 0, len(results))
-	for _, result := range results {
-		language := &Language{}
-		language.ID, _ = result["id"].(int64)
-		language.Name, _ = result["name"].(string)
-		language.Code, _ = result["code"].(string)
-		language.Flag, _ = result["flag"].(string)
-		language.IsDefault, _ = result["isdefault"].(int)
-		language.Status, _ = result["status"].(int)
-		language.OrderID, _ = result["orderid"].(int)
-		language.CreateTime, _ = result["createtime"].(time.Time)
-		language.UpdateTime, _ = result["updatetime"].(time.Time)
-		languages = append(languages, language)
-	}
-
 	return languages, nil
 }
 
@@ -313,27 +264,18 @@ func (m *LanguageTextModel) GetByID(id int64) (*LanguageText, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	text := &LanguageText{}
+	found, err := qb.FirstInto(text)
 	if err != nil {
 		logger.Error("获取语言文本失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("language text not found: %d", id)
 	}
 
-	// 转换为语言文本
-	text := &LanguageText{}
-	text.ID, _ = result["id"].(int64)
-	text.LanguageID, _ = result["languageid"].(int64)
-	text.Key, _ = result["key"].(string)
-	text.Value, _ = result["value"].(string)
-	text.Module, _ = result["module"].(string)
-	text.CreateTime, _ = result["createtime"].(time.Time)
-	text.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return text, nil
 }
 
@@ -345,27 +287,18 @@ func (m *LanguageTextModel) GetByKey(languageID int64, key string) (*LanguageTex
 	qb.Where("key = ?", key)
 
 	// 执行查询
-	result, err := qb.First()
+	text := &LanguageText{}
+	found, err := qb.FirstInto(text)
 	if err != nil {
 		logger.Error("获取语言文本失败", "languageid", languageID, "key", key, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("language text not found: %d, %s", languageID, key)
 	}
 
-	// 转换为语言文本
-	text := &LanguageText{}
-	text.ID, _ = result["id"].(int64)
-	text.LanguageID, _ = result["languageid"].(int64)
-	text.Key, _ = result["key"].(string)
-	text.Value, _ = result["value"].(string)
-	text.Module, _ = result["module"].(string)
-	text.CreateTime, _ = result["createtime"].(time.Time)
-	text.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return text, nil
 }
 
@@ -380,26 +313,12 @@ func (m *LanguageTextModel) GetByLanguageID(languageID int64, module string) ([]
 	qb.OrderBy("module ASC, key ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	texts := make([]*LanguageText, 0)
+	if err := qb.GetInto(&texts); err != nil {
 		logger.Error("获取语言文本失败", "languageid", languageID, "module", module, "error", err)
 		return nil, err
 	}
 
-	// 转换为语言文本列表
-	texts := make([]*LanguageText, 0, len(results))
-	for _, result := range results {
-		text := &LanguageText{}
-		text.ID, _ = result["id"].(int64)
-		text.LanguageID, _ = result["languageid"].(int64)
-		text.Key, _ = result["key"].(string)
-		text.Value, _ = result["value"].(string)
-		text.Module, _ = result["module"].(string)
-		text.CreateTime, _ = result["createtime"].(time.Time)
-		text.UpdateTime, _ = result["updatetime"].(time.Time)
-		texts = append(texts, text)
-	}
-
 	return texts, nil
 }
 
@@ -413,26 +332,12 @@ func (m *LanguageTextModel) GetAll(module string) ([]*LanguageText, error) {
 	qb.OrderBy("languageid ASC, module ASC, key ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	texts := make([]*LanguageText, 0)
+	if err := qb.GetInto(&texts); err != nil {
 		logger.Error("获取所有语言文本失败", "module", module, "error", err)
 		return nil, err
 	}
 
-	// 转换为语言文本列表
-	texts := make([]*LanguageText, 0, len(results))
-	for _, result := range results {
-		text := &LanguageText{}
-		text.ID, _ = result["id"].(int64)
-		text.LanguageID, _ = result["languageid"].(int64)
-		text.Key, _ = result["key"].(string)
-		text.Value, _ = result["value"].(string)
-		text.Module, _ = result["module"].(string)
-		text.CreateTime, _ = result["createtime"].(time.Time)
-		text.UpdateTime, _ = result["updatetime"].(time.Time)
-		texts = append(texts, text)
-	}
-
 	return texts, nil
 }
 
@@ -537,13 +442,14 @@ func (m *LanguageTextModel) ImportTexts(languageID int64, texts map[string]strin
 		qb.Where("languageid = ?", languageID)
 		qb.Where("key = ?", key)
 		qb.Where("module = ?", module)
-		result, err := qb.First()
+		existing := &LanguageText{}
+		found, err := qb.FirstInto(existing)
 		if err != nil {
 			logger.Error("检查语言文本是否存在失败", "error", err)
 			return err
 		}
 
-		if result == nil {
+		if !found {
 			// 不存在，创建
 			_, err = tx.Exec(
 				"INSERT INTO "+m.db.TableName("language_text")+" (languageid, `key`, value, module, createtime, updatetime) VALUES (?, ?, ?, ?, ?, ?)",
@@ -555,10 +461,9 @@ func (m *LanguageTextModel) ImportTexts(languageID int64, texts map[string]strin
 			}
 		} else {
 			// 存在，更新
-			id, _ := result["id"].(int64)
 			_, err = tx.Exec(
 				"UPDATE "+m.db.TableName("language_text")+" SET value = ?, updatetime = ? WHERE id = ?",
-				value, now, id,
+				value, now, existing.ID,
 			)
 			if err != nil {
 				logger.Error("更新语言文本失败", "error", err)
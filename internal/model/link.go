@@ -10,27 +10,27 @@ import (
 
 // Link 友情链接
 type Link struct {
-	ID          int64     `json:"id"`
-	TypeID      int64     `json:"typeid"`      // 分类ID
-	Title       string    `json:"title"`       // 链接标题
-	URL         string    `json:"url"`         // 链接URL
-	Logo        string    `json:"logo"`        // 链接LOGO
-	Description string    `json:"description"` // 链接描述
-	Email       string    `json:"email"`       // 联系邮箱
-	OrderID     int       `json:"orderid"`     // 排序ID
-	IsCheck     int       `json:"ischeck"`     // 状态：0禁用，1启用
-	IsLogo      int       `json:"islogo"`      // 是否显示LOGO：0否，1是
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	TypeID      int64     `json:"typeid" db:"typeid"`           // 分类ID
+	Title       string    `json:"title" db:"title"`             // 链接标题
+	URL         string    `json:"url" db:"url"`                 // 链接URL
+	Logo        string    `json:"logo" db:"logo"`               // 链接LOGO
+	Description string    `json:"description" db:"description"` // 链接描述
+	Email       string    `json:"email" db:"email"`             // 联系邮箱
+	OrderID     int       `json:"orderid" db:"orderid"`         // 排序ID
+	IsCheck     int       `json:"ischeck" db:"ischeck"`         // 状态：0禁用，1启用
+	IsLogo      int       `json:"islogo" db:"islogo"`           // 是否显示LOGO：0否，1是
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // LinkType 友情链接分类
 type LinkType struct {
-	ID         int64     `json:"id"`
-	Name       string    `json:"name"`       // 分类名称
-	OrderID    int       `json:"orderid"`    // 排序ID
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`             // 分类名称
+	OrderID    int       `json:"orderid" db:"orderid"`       // 排序ID
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // LinkModel 友情链接模型
@@ -52,32 +52,18 @@ func (m *LinkModel) GetByID(id int64) (*Link, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	link := &Link{}
+	found, err := qb.FirstInto(link)
 	if err != nil {
 		logger.Error("获取友情链接失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("link not found: %d", id)
 	}
 
-	// 转换为友情链接
-	link := &Link{}
-	link.ID, _ = result["id"].(int64)
-	link.TypeID, _ = result["typeid"].(int64)
-	link.Title, _ = result["title"].(string)
-	link.URL, _ = result["url"].(string)
-	link.Logo, _ = result["logo"].(string)
-	link.Description, _ = result["description"].(string)
-	link.Email, _ = result["email"].(string)
-	link.OrderID, _ = result["orderid"].(int)
-	link.IsCheck, _ = result["ischeck"].(int)
-	link.IsLogo, _ = result["islogo"].(int)
-	link.CreateTime, _ = result["createtime"].(time.Time)
-	link.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return link, nil
 }
 
@@ -94,31 +80,12 @@ func (m *LinkModel) GetAll(typeID int64, status int) ([]*Link, error) {
 	qb.OrderBy("id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	links := make([]*Link, 0)
+	if err := qb.GetInto(&links); err != nil {
 		logger.Error("获取所有友情链接失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为友情链接列表
-	links := make([]*Link, 0, len(results))
-	for _, result := range results {
-		link := &Link{}
-		link.ID, _ = result["id"].(int64)
-		link.TypeID, _ = result["typeid"].(int64)
-		link.Title, _ = result["title"].(string)
-		link.URL, _ = result["url"].(string)
-		link.Logo, _ = result["logo"].(string)
-		link.Description, _ = result["description"].(string)
-		link.Email, _ = result["email"].(string)
-		link.OrderID, _ = result["orderid"].(int)
-		link.IsCheck, _ = result["ischeck"].(int)
-		link.IsLogo, _ = result["islogo"].(int)
-		link.CreateTime, _ = result["createtime"].(time.Time)
-		link.UpdateTime, _ = result["updatetime"].(time.Time)
-		links = append(links, link)
-	}
-
 	return links, nil
 }
 
@@ -213,25 +180,18 @@ func (m *LinkTypeModel) GetByID(id int64) (*LinkType, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	linkType := &LinkType{}
+	found, err := qb.FirstInto(linkType)
 	if err != nil {
 		logger.Error("获取友情链接分类失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("link type not found: %d", id)
 	}
 
-	// 转换为友情链接分类
-	linkType := &LinkType{}
-	linkType.ID, _ = result["id"].(int64)
-	linkType.Name, _ = result["name"].(string)
-	linkType.OrderID, _ = result["orderid"].(int)
-	linkType.CreateTime, _ = result["createtime"].(time.Time)
-	linkType.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return linkType, nil
 }
 
@@ -242,24 +202,12 @@ func (m *LinkTypeModel) GetAll() ([]*LinkType, error) {
 	qb.OrderBy("orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	linkTypes := make([]*LinkType, 0)
+	if err := qb.GetInto(&linkTypes); err != nil {
 		logger.Error("获取所有友情链接分类失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为友情链接分类列表
-	linkTypes := make([]*LinkType, 0, len(results))
-	for _, result := range results {
-		linkType := &LinkType{}
-		linkType.ID, _ = result["id"].(int64)
-		linkType.Name, _ = result["name"].(string)
-		linkType.OrderID, _ = result["orderid"].(int)
-		linkType.CreateTime, _ = result["createtime"].(time.Time)
-		linkType.UpdateTime, _ = result["updatetime"].(time.Time)
-		linkTypes = append(linkTypes, linkType)
-	}
-
 	return linkTypes, nil
 }
 
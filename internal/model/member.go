@@ -2,7 +2,6 @@ package model
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 
 	"aq3cms/pkg/database"
@@ -12,24 +11,30 @@ import (
 
 // Member 会员
 type Member struct {
-	ID         int64     `json:"id"`
-	Username   string    `json:"username"`   // 用户名
-	Password   string    `json:"password"`   // 密码
-	Email      string    `json:"email"`      // 邮箱
-	Mobile     string    `json:"mobile"`     // 手机
-	MType      int       `json:"mtype"`      // 会员类型
-	Sex        string    `json:"sex"`        // 性别
-	Avatar     string    `json:"avatar"`     // 头像
-	Face       string    `json:"face"`       // 头像（兼容旧版）
-	QQ         string    `json:"qq"`         // QQ
-	Score      int       `json:"score"`      // 积分
-	Money      float64   `json:"money"`      // 余额
-	Status     int       `json:"status"`     // 状态
-	RegTime    time.Time `json:"regtime"`    // 注册时间
-	RegIP      string    `json:"regip"`      // 注册IP
-	LastLogin  time.Time `json:"lastlogin"`  // 最后登录时间
-	LastIP     string    `json:"lastip"`     // 最后登录IP
-	LoginCount int       `json:"logincount"` // 登录次数
+	ID         int64     `json:"id" db:"mid"`
+	Username   string    `json:"username" db:"userid"`     // 用户名
+	Password   string    `json:"password" db:"pwd"`        // 密码
+	Email      string    `json:"email" db:"email"`         // 邮箱
+	Mobile     string    `json:"mobile"`                   // 手机
+	MType      int       `json:"mtype" db:"mtype"`         // 会员类型
+	Sex        string    `json:"sex" db:"sex"`             // 性别
+	Avatar     string    `json:"avatar"`                   // 头像
+	Face       string    `json:"face" db:"face"`           // 头像（兼容旧版）
+	QQ         string    `json:"qq"`                       // QQ
+	Score      int       `json:"score" db:"scores"`        // 积分
+	Money      float64   `json:"money" db:"money"`         // 余额
+	Status     int       `json:"status"`                   // 状态
+	RegTime    time.Time `json:"regtime" db:"jointime"`    // 注册时间
+	RegIP      string    `json:"regip" db:"joinip"`        // 注册IP
+	LastLogin  time.Time `json:"lastlogin" db:"logintime"` // 最后登录时间
+	LastIP     string    `json:"lastip" db:"loginip"`      // 最后登录IP
+	LoginCount int       `json:"logincount"`               // 登录次数
+}
+
+// AfterScan 填充兼容字段
+func (m *Member) AfterScan() {
+	m.Avatar = m.Face // 兼容性
+	m.Status = 1      // 默认状态为正常
 }
 
 // MemberModel 会员模型
@@ -51,66 +56,18 @@ func (m *MemberModel) GetByID(id int64) (*Member, error) {
 	qb.Where("mid = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	member := &Member{}
+	found, err := qb.FirstInto(member)
 	if err != nil {
 		logger.Error("获取会员失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("member not found: %d", id)
 	}
 
-	// 转换为会员
-	member := &Member{}
-
-	// 处理ID字段
-	if mid, ok := result["mid"].(int64); ok {
-		member.ID = mid
-	} else if mid, ok := result["mid"].([]byte); ok {
-		if idStr := string(mid); idStr != "" {
-			if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-				member.ID = idInt
-			}
-		}
-	}
-
-	member.Username, _ = result["userid"].(string)
-	member.Password, _ = result["pwd"].(string)
-	member.Email, _ = result["email"].(string)
-	member.MType = convertToInt(result["mtype"])
-	member.Sex, _ = result["sex"].(string)
-	member.Face, _ = result["face"].(string)
-	member.Avatar = member.Face // 兼容性
-	member.Score = convertToInt(result["scores"])
-	member.Money = convertToFloat64(result["money"])
-
-	// 处理时间戳字段
-	if jointime, ok := result["jointime"].(int64); ok {
-		member.RegTime = time.Unix(jointime, 0)
-	} else if jointime, ok := result["jointime"].([]byte); ok {
-		if timeStr := string(jointime); timeStr != "" {
-			if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-				member.RegTime = time.Unix(timestamp, 0)
-			}
-		}
-	}
-
-	if logintime, ok := result["logintime"].(int64); ok {
-		member.LastLogin = time.Unix(logintime, 0)
-	} else if logintime, ok := result["logintime"].([]byte); ok {
-		if timeStr := string(logintime); timeStr != "" {
-			if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-				member.LastLogin = time.Unix(timestamp, 0)
-			}
-		}
-	}
-
-	member.RegIP, _ = result["joinip"].(string)
-	member.LastIP, _ = result["loginip"].(string)
-	member.Status = 1 // 默认状态为正常
-
 	return member, nil
 }
 
@@ -148,66 +105,12 @@ func (m *MemberModel) GetList(page, pageSize int) ([]*Member, int, error) {
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	members := make([]*Member, 0)
+	if err := qb.GetInto(&members); err != nil {
 		logger.Error("获取会员列表失败", "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为会员对象
-	members := make([]*Member, 0, len(results))
-	for _, result := range results {
-		member := &Member{}
-
-		// 处理ID字段
-		if mid, ok := result["mid"].(int64); ok {
-			member.ID = mid
-		} else if mid, ok := result["mid"].([]byte); ok {
-			if idStr := string(mid); idStr != "" {
-				if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-					member.ID = idInt
-				}
-			}
-		}
-
-		member.Username, _ = result["userid"].(string)
-		member.Password, _ = result["pwd"].(string)
-		member.Email, _ = result["email"].(string)
-		member.MType = convertToInt(result["mtype"])
-		member.Sex, _ = result["sex"].(string)
-		member.Face, _ = result["face"].(string)
-		member.Avatar = member.Face // 兼容性
-		member.Score = convertToInt(result["scores"])
-		member.Money = convertToFloat64(result["money"])
-
-		// 处理时间戳字段
-		if jointime, ok := result["jointime"].(int64); ok {
-			member.RegTime = time.Unix(jointime, 0)
-		} else if jointime, ok := result["jointime"].([]byte); ok {
-			if timeStr := string(jointime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.RegTime = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		if logintime, ok := result["logintime"].(int64); ok {
-			member.LastLogin = time.Unix(logintime, 0)
-		} else if logintime, ok := result["logintime"].([]byte); ok {
-			if timeStr := string(logintime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.LastLogin = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		member.RegIP, _ = result["joinip"].(string)
-		member.LastIP, _ = result["loginip"].(string)
-		member.Status = 1 // 默认状态为正常
-
-		members = append(members, member)
-	}
-
 	return members, total, nil
 }
 
@@ -231,66 +134,12 @@ func (m *MemberModel) Search(keyword string, page, pageSize int) ([]*Member, int
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	members := make([]*Member, 0)
+	if err := qb.GetInto(&members); err != nil {
 		logger.Error("搜索会员失败", "keyword", keyword, "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为会员对象
-	members := make([]*Member, 0, len(results))
-	for _, result := range results {
-		member := &Member{}
-
-		// 处理ID字段
-		if mid, ok := result["mid"].(int64); ok {
-			member.ID = mid
-		} else if mid, ok := result["mid"].([]byte); ok {
-			if idStr := string(mid); idStr != "" {
-				if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-					member.ID = idInt
-				}
-			}
-		}
-
-		member.Username, _ = result["userid"].(string)
-		member.Password, _ = result["pwd"].(string)
-		member.Email, _ = result["email"].(string)
-		member.MType = convertToInt(result["mtype"])
-		member.Sex, _ = result["sex"].(string)
-		member.Face, _ = result["face"].(string)
-		member.Avatar = member.Face // 兼容性
-		member.Score = convertToInt(result["scores"])
-		member.Money = convertToFloat64(result["money"])
-
-		// 处理时间戳字段
-		if jointime, ok := result["jointime"].(int64); ok {
-			member.RegTime = time.Unix(jointime, 0)
-		} else if jointime, ok := result["jointime"].([]byte); ok {
-			if timeStr := string(jointime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.RegTime = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		if logintime, ok := result["logintime"].(int64); ok {
-			member.LastLogin = time.Unix(logintime, 0)
-		} else if logintime, ok := result["logintime"].([]byte); ok {
-			if timeStr := string(logintime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.LastLogin = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		member.RegIP, _ = result["joinip"].(string)
-		member.LastIP, _ = result["loginip"].(string)
-		member.Status = 1 // 默认状态为正常
-
-		members = append(members, member)
-	}
-
 	return members, total, nil
 }
 
@@ -301,66 +150,18 @@ func (m *MemberModel) GetByUsername(username string) (*Member, error) {
 	qb.Where("userid = ?", username)
 
 	// 执行查询
-	result, err := qb.First()
+	member := &Member{}
+	found, err := qb.FirstInto(member)
 	if err != nil {
 		logger.Error("获取会员失败", "username", username, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("member not found: %s", username)
 	}
 
-	// 转换为会员
-	member := &Member{}
-
-	// 处理ID字段
-	if mid, ok := result["mid"].(int64); ok {
-		member.ID = mid
-	} else if mid, ok := result["mid"].([]byte); ok {
-		if idStr := string(mid); idStr != "" {
-			if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-				member.ID = idInt
-			}
-		}
-	}
-
-	member.Username, _ = result["userid"].(string)
-	member.Password, _ = result["pwd"].(string)
-	member.Email, _ = result["email"].(string)
-	member.MType = convertToInt(result["mtype"])
-	member.Sex, _ = result["sex"].(string)
-	member.Face, _ = result["face"].(string)
-	member.Avatar = member.Face // 兼容性
-	member.Score = convertToInt(result["scores"])
-	member.Money = convertToFloat64(result["money"])
-
-	// 处理时间戳字段
-	if jointime, ok := result["jointime"].(int64); ok {
-		member.RegTime = time.Unix(jointime, 0)
-	} else if jointime, ok := result["jointime"].([]byte); ok {
-		if timeStr := string(jointime); timeStr != "" {
-			if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-				member.RegTime = time.Unix(timestamp, 0)
-			}
-		}
-	}
-
-	if logintime, ok := result["logintime"].(int64); ok {
-		member.LastLogin = time.Unix(logintime, 0)
-	} else if logintime, ok := result["logintime"].([]byte); ok {
-		if timeStr := string(logintime); timeStr != "" {
-			if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-				member.LastLogin = time.Unix(timestamp, 0)
-			}
-		}
-	}
-
-	member.RegIP, _ = result["joinip"].(string)
-	member.LastIP, _ = result["loginip"].(string)
-	member.Status = 1 // 默认状态为正常
-
 	return member, nil
 }
 
@@ -371,66 +172,18 @@ func (m *MemberModel) GetByEmail(email string) (*Member, error) {
 	qb.Where("email = ?", email)
 
 	// 执行查询
-	result, err := qb.First()
+	member := &Member{}
+	found, err := qb.FirstInto(member)
 	if err != nil {
 		logger.Error("获取会员失败", "email", email, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("member not found: %s", email)
 	}
 
-	// 转换为会员
-	member := &Member{}
-
-	// 处理ID字段
-	if mid, ok := result["mid"].(int64); ok {
-		member.ID = mid
-	} else if mid, ok := result["mid"].([]byte); ok {
-		if idStr := string(mid); idStr != "" {
-			if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-				member.ID = idInt
-			}
-		}
-	}
-
-	member.Username, _ = result["userid"].(string)
-	member.Password, _ = result["pwd"].(string)
-	member.Email, _ = result["email"].(string)
-	member.MType = convertToInt(result["mtype"])
-	member.Sex, _ = result["sex"].(string)
-	member.Face, _ = result["face"].(string)
-	member.Avatar = member.Face // 兼容性
-	member.Score = convertToInt(result["scores"])
-	member.Money = convertToFloat64(result["money"])
-
-	// 处理时间戳字段
-	if jointime, ok := result["jointime"].(int64); ok {
-		member.RegTime = time.Unix(jointime, 0)
-	} else if jointime, ok := result["jointime"].([]byte); ok {
-		if timeStr := string(jointime); timeStr != "" {
-			if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-				member.RegTime = time.Unix(timestamp, 0)
-			}
-		}
-	}
-
-	if logintime, ok := result["logintime"].(int64); ok {
-		member.LastLogin = time.Unix(logintime, 0)
-	} else if logintime, ok := result["logintime"].([]byte); ok {
-		if timeStr := string(logintime); timeStr != "" {
-			if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-				member.LastLogin = time.Unix(timestamp, 0)
-			}
-		}
-	}
-
-	member.RegIP, _ = result["joinip"].(string)
-	member.LastIP, _ = result["loginip"].(string)
-	member.Status = 1 // 默认状态为正常
-
 	return member, nil
 }
 
@@ -443,8 +196,8 @@ func (m *MemberModel) GetAll(page, pageSize int) ([]*Member, int, error) {
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	members := make([]*Member, 0)
+	if err := qb.GetInto(&members); err != nil {
 		logger.Error("获取所有会员失败", "error", err)
 		return nil, 0, err
 	}
@@ -456,60 +209,6 @@ func (m *MemberModel) GetAll(page, pageSize int) ([]*Member, int, error) {
 		return nil, 0, err
 	}
 
-	// 转换为会员列表
-	members := make([]*Member, 0, len(results))
-	for _, result := range results {
-		member := &Member{}
-
-		// 处理ID字段
-		if mid, ok := result["mid"].(int64); ok {
-			member.ID = mid
-		} else if mid, ok := result["mid"].([]byte); ok {
-			if idStr := string(mid); idStr != "" {
-				if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-					member.ID = idInt
-				}
-			}
-		}
-
-		member.Username, _ = result["userid"].(string)
-		member.Password, _ = result["pwd"].(string)
-		member.Email, _ = result["email"].(string)
-		member.MType = convertToInt(result["mtype"])
-		member.Sex, _ = result["sex"].(string)
-		member.Face, _ = result["face"].(string)
-		member.Avatar = member.Face // 兼容性
-		member.Score = convertToInt(result["scores"])
-		member.Money = convertToFloat64(result["money"])
-
-		// 处理时间戳字段
-		if jointime, ok := result["jointime"].(int64); ok {
-			member.RegTime = time.Unix(jointime, 0)
-		} else if jointime, ok := result["jointime"].([]byte); ok {
-			if timeStr := string(jointime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.RegTime = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		if logintime, ok := result["logintime"].(int64); ok {
-			member.LastLogin = time.Unix(logintime, 0)
-		} else if logintime, ok := result["logintime"].([]byte); ok {
-			if timeStr := string(logintime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.LastLogin = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		member.RegIP, _ = result["joinip"].(string)
-		member.LastIP, _ = result["loginip"].(string)
-		member.Status = 1 // 默认状态为正常
-
-		members = append(members, member)
-	}
-
 	return members, total, nil
 }
 
@@ -637,7 +336,9 @@ func (m *MemberModel) GetTotalCount() (int, error) {
 func (m *MemberModel) GetTodayCount() (int, error) {
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "member")
-	qb.Where("DATE(FROM_UNIXTIME(jointime)) = CURDATE()")
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	qb.Where("jointime >= ?", today.Unix())
 
 	// 执行查询
 	count, err := qb.Count()
@@ -653,7 +354,7 @@ func (m *MemberModel) GetTodayCount() (int, error) {
 func (m *MemberModel) GetActiveCount(days int) (int, error) {
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "member")
-	qb.Where("logintime >= UNIX_TIMESTAMP(DATE_SUB(NOW(), INTERVAL ? DAY))", days)
+	qb.Where("logintime >= ?", time.Now().AddDate(0, 0, -days).Unix())
 
 	// 执行查询
 	count, err := qb.Count()
@@ -681,65 +382,12 @@ func (m *MemberModel) GetLatest(limit int) ([]*Member, error) {
 	qb.Limit(limit)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	members := make([]*Member, 0)
+	if err := qb.GetInto(&members); err != nil {
 		logger.Error("获取最新会员失败", "limit", limit, "error", err)
 		return nil, err
 	}
 
-	// 转换为会员对象
-	members := make([]*Member, 0, len(results))
-	for _, result := range results {
-		member := &Member{}
-
-		// 处理ID字段
-		if mid, ok := result["mid"].(int64); ok {
-			member.ID = mid
-		} else if mid, ok := result["mid"].([]byte); ok {
-			if idStr := string(mid); idStr != "" {
-				if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-					member.ID = idInt
-				}
-			}
-		}
-
-		member.Username, _ = result["userid"].(string)
-		member.Email, _ = result["email"].(string)
-		member.MType = convertToInt(result["mtype"])
-		member.Sex, _ = result["sex"].(string)
-		member.Face, _ = result["face"].(string)
-		member.Avatar = member.Face // 兼容性
-		member.Score = convertToInt(result["scores"])
-		member.Money = convertToFloat64(result["money"])
-
-		// 处理时间戳字段
-		if jointime, ok := result["jointime"].(int64); ok {
-			member.RegTime = time.Unix(jointime, 0)
-		} else if jointime, ok := result["jointime"].([]byte); ok {
-			if timeStr := string(jointime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.RegTime = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		if logintime, ok := result["logintime"].(int64); ok {
-			member.LastLogin = time.Unix(logintime, 0)
-		} else if logintime, ok := result["logintime"].([]byte); ok {
-			if timeStr := string(logintime); timeStr != "" {
-				if timestamp, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
-					member.LastLogin = time.Unix(timestamp, 0)
-				}
-			}
-		}
-
-		member.RegIP, _ = result["joinip"].(string)
-		member.LastIP, _ = result["loginip"].(string)
-		member.Status = 1 // 默认状态为正常
-
-		members = append(members, member)
-	}
-
 	return members, nil
 }
 
@@ -789,30 +437,3 @@ func (m *MemberModel) GetTypeStats() ([]map[string]interface{}, error) {
 
 	return stats, nil
 }
-
-// convertToFloat64 将interface{}转换为float64，支持多种类型
-func convertToFloat64(value interface{}) float64 {
-	switch v := value.(type) {
-	case float64:
-		return v
-	case float32:
-		return float64(v)
-	case int:
-		return float64(v)
-	case int64:
-		return float64(v)
-	case int32:
-		return float64(v)
-	case []byte:
-		if str := string(v); str != "" {
-			if f, err := strconv.ParseFloat(str, 64); err == nil {
-				return f
-			}
-		}
-	case string:
-		if f, err := strconv.ParseFloat(v, 64); err == nil {
-			return f
-		}
-	}
-	return 0.0
-}
@@ -1,21 +1,19 @@
 package model
 
 import (
-	"strconv"
-
 	"aq3cms/pkg/database"
 	"aq3cms/pkg/logger"
 )
 
 // MemberType 会员类型模型
 type MemberType struct {
-	ID          int64   `json:"id"`
-	TypeName    string  `json:"typename"`
-	Description string  `json:"description"`
-	Rank        int     `json:"rank"`
-	Money       float64 `json:"money"`
-	Scores      int     `json:"scores"`
-	Purviews    string  `json:"purviews"`
+	ID          int64   `json:"id" db:"id"`
+	TypeName    string  `json:"typename" db:"typename"`
+	Description string  `json:"description" db:"description"`
+	Rank        int     `json:"rank" db:"rank"`
+	Money       float64 `json:"money" db:"money"`
+	Scores      int     `json:"scores" db:"scores"`
+	Purviews    string  `json:"purviews" db:"purviews"`
 }
 
 // MemberTypeModel 会员类型模型操作
@@ -38,37 +36,17 @@ func (m *MemberTypeModel) GetByID(id int64) (*MemberType, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	memberType := &MemberType{}
+	found, err := qb.FirstInto(memberType)
 	if err != nil {
 		logger.Error("查询会员类型失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, nil
 	}
 
-	// 转换为会员类型对象
-	memberType := &MemberType{}
-
-	// 处理ID字段
-	if mid, ok := result["id"].(int64); ok {
-		memberType.ID = mid
-	} else if mid, ok := result["id"].([]byte); ok {
-		if idStr := string(mid); idStr != "" {
-			if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-				memberType.ID = idInt
-			}
-		}
-	}
-
-	memberType.TypeName, _ = result["typename"].(string)
-	memberType.Description, _ = result["description"].(string)
-	memberType.Rank = convertToInt(result["rank"])
-	memberType.Money = convertToFloat64(result["money"])
-	memberType.Scores = convertToInt(result["scores"])
-	memberType.Purviews, _ = result["purviews"].(string)
-
 	return memberType, nil
 }
 
@@ -80,37 +58,11 @@ func (m *MemberTypeModel) GetAll() ([]*MemberType, error) {
 	qb.OrderBy("`rank` ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	memberTypes := make([]*MemberType, 0)
+	if err := qb.GetInto(&memberTypes); err != nil {
 		logger.Error("查询所有会员类型失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为会员类型对象
-	memberTypes := make([]*MemberType, 0, len(results))
-	for _, result := range results {
-		memberType := &MemberType{}
-
-		// 处理ID字段
-		if mid, ok := result["id"].(int64); ok {
-			memberType.ID = mid
-		} else if mid, ok := result["id"].([]byte); ok {
-			if idStr := string(mid); idStr != "" {
-				if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-					memberType.ID = idInt
-				}
-			}
-		}
-
-		memberType.TypeName, _ = result["typename"].(string)
-		memberType.Description, _ = result["description"].(string)
-		memberType.Rank = convertToInt(result["rank"])
-		memberType.Money = convertToFloat64(result["money"])
-		memberType.Scores = convertToInt(result["scores"])
-		memberType.Purviews, _ = result["purviews"].(string)
-
-		memberTypes = append(memberTypes, memberType)
-	}
-
 	return memberTypes, nil
 }
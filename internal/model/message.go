@@ -11,18 +11,18 @@ import (
 
 // Message 消息模型
 type Message struct {
-	ID        int64     `json:"id"`
-	FromID    int64     `json:"fromid"`    // 发送者ID
-	ToID      int64     `json:"toid"`      // 接收者ID
-	Title     string    `json:"title"`     // 消息标题
-	Content   string    `json:"content"`   // 消息内容
-	SendTime  time.Time `json:"sendtime"`  // 发送时间
-	ReadTime  time.Time `json:"readtime"`  // 阅读时间
-	IsRead    int       `json:"isread"`    // 是否已读
-	FromDel   int       `json:"fromdel"`   // 发送者是否删除
-	ToDel     int       `json:"todel"`     // 接收者是否删除
-	FromName  string    `json:"fromname"`  // 发送者名称
-	ToName    string    `json:"toname"`    // 接收者名称
+	ID       int64     `json:"id" db:"id"`
+	FromID   int64     `json:"fromid" db:"fromid"`     // 发送者ID
+	ToID     int64     `json:"toid" db:"toid"`         // 接收者ID
+	Title    string    `json:"title" db:"title"`       // 消息标题
+	Content  string    `json:"content" db:"content"`   // 消息内容
+	SendTime time.Time `json:"sendtime" db:"sendtime"` // 发送时间
+	ReadTime time.Time `json:"readtime" db:"readtime"` // 阅读时间
+	IsRead   int       `json:"isread" db:"isread"`     // 是否已读
+	FromDel  int       `json:"fromdel" db:"fromdel"`   // 发送者是否删除
+	ToDel    int       `json:"todel" db:"todel"`       // 接收者是否删除
+	FromName string    `json:"fromname" db:"fromname"` // 发送者名称
+	ToName   string    `json:"toname" db:"toname"`     // 接收者名称
 }
 
 // MessageModel 消息模型操作
@@ -47,46 +47,17 @@ func (m *MessageModel) GetByID(id int64) (*Message, error) {
 	qb.Where("m.id = ?", id)
 	
 	// 执行查询
-	result, err := qb.First()
+	message := &Message{}
+	found, err := qb.FirstInto(message)
 	if err != nil {
 		logger.Error("查询消息失败", "id", id, "error", err)
 		return nil, err
 	}
-	
-	if result == nil {
+
+	if !found {
 		return nil, fmt.Errorf("消息不存在")
 	}
-	
-	// 转换为消息对象
-	message := &Message{}
-	message.ID, _ = result["id"].(int64)
-	message.FromID, _ = result["fromid"].(int64)
-	message.ToID, _ = result["toid"].(int64)
-	message.Title, _ = result["title"].(string)
-	message.Content, _ = result["content"].(string)
-	
-	// 处理日期
-	if sendtime, ok := result["sendtime"].(time.Time); ok {
-		message.SendTime = sendtime
-	}
-	if readtime, ok := result["readtime"].(time.Time); ok {
-		message.ReadTime = readtime
-	}
-	
-	// 处理整数字段
-	if isread, ok := result["isread"].(int64); ok {
-		message.IsRead = int(isread)
-	}
-	if fromdel, ok := result["fromdel"].(int64); ok {
-		message.FromDel = int(fromdel)
-	}
-	if todel, ok := result["todel"].(int64); ok {
-		message.ToDel = int(todel)
-	}
-	
-	message.FromName, _ = result["fromname"].(string)
-	message.ToName, _ = result["toname"].(string)
-	
+
 	return message, nil
 }
 
@@ -113,47 +84,12 @@ func (m *MessageModel) GetInbox(memberID int64, page, pageSize int) ([]*Message,
 	qb.Limit(pageSize, offset)
 	
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	messages := make([]*Message, 0)
+	if err := qb.GetInto(&messages); err != nil {
 		logger.Error("查询收件箱失败", "memberid", memberID, "error", err)
 		return nil, 0, err
 	}
 	
-	// 转换为消息对象
-	messages := make([]*Message, 0, len(results))
-	for _, result := range results {
-		message := &Message{}
-		message.ID, _ = result["id"].(int64)
-		message.FromID, _ = result["fromid"].(int64)
-		message.ToID, _ = result["toid"].(int64)
-		message.Title, _ = result["title"].(string)
-		message.Content, _ = result["content"].(string)
-		
-		// 处理日期
-		if sendtime, ok := result["sendtime"].(time.Time); ok {
-			message.SendTime = sendtime
-		}
-		if readtime, ok := result["readtime"].(time.Time); ok {
-			message.ReadTime = readtime
-		}
-		
-		// 处理整数字段
-		if isread, ok := result["isread"].(int64); ok {
-			message.IsRead = int(isread)
-		}
-		if fromdel, ok := result["fromdel"].(int64); ok {
-			message.FromDel = int(fromdel)
-		}
-		if todel, ok := result["todel"].(int64); ok {
-			message.ToDel = int(todel)
-		}
-		
-		message.FromName, _ = result["fromname"].(string)
-		message.ToName, _ = result["toname"].(string)
-		
-		messages = append(messages, message)
-	}
-	
 	return messages, total, nil
 }
 
@@ -180,47 +116,12 @@ func (m *MessageModel) GetOutbox(memberID int64, page, pageSize int) ([]*Message
 	qb.Limit(pageSize, offset)
 	
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	messages := make([]*Message, 0)
+	if err := qb.GetInto(&messages); err != nil {
 		logger.Error("查询发件箱失败", "memberid", memberID, "error", err)
 		return nil, 0, err
 	}
 	
-	// 转换为消息对象
-	messages := make([]*Message, 0, len(results))
-	for _, result := range results {
-		message := &Message{}
-		message.ID, _ = result["id"].(int64)
-		message.FromID, _ = result["fromid"].(int64)
-		message.ToID, _ = result["toid"].(int64)
-		message.Title, _ = result["title"].(string)
-		message.Content, _ = result["content"].(string)
-		
-		// 处理日期
-		if sendtime, ok := result["sendtime"].(time.Time); ok {
-			message.SendTime = sendtime
-		}
-		if readtime, ok := result["readtime"].(time.Time); ok {
-			message.ReadTime = readtime
-		}
-		
-		// 处理整数字段
-		if isread, ok := result["isread"].(int64); ok {
-			message.IsRead = int(isread)
-		}
-		if fromdel, ok := result["fromdel"].(int64); ok {
-			message.FromDel = int(fromdel)
-		}
-		if todel, ok := result["todel"].(int64); ok {
-			message.ToDel = int(todel)
-		}
-		
-		message.FromName, _ = result["fromname"].(string)
-		message.ToName, _ = result["toname"].(string)
-		
-		messages = append(messages, message)
-	}
-	
 	return messages, total, nil
 }
 
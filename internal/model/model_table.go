@@ -0,0 +1,696 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// 字段索引类型
+const (
+	FieldIndexNone   = ""
+	FieldIndexNormal = "index"
+	FieldIndexUnique = "unique"
+)
+
+// ErrDestructiveChange 数据表变更可能丢失已有内容，需要确认后强制执行
+var ErrDestructiveChange = errors.New("数据表变更可能丢失已有内容，请确认后强制执行")
+
+// identRe 表名和字段名只允许小写字母开头的字母、数字、下划线，长度限制保证索引名不超过64个字符
+var identRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,23}$`)
+
+// reservedFields 附加表的系统字段
+var reservedFields = map[string]bool{"id": true, "aid": true}
+
+// maxVarcharLength 变长字符串的最大长度
+const maxVarcharLength = 255
+
+// TablePlan 内容模型附加表的变更计划
+type TablePlan struct {
+	Table       string   `json:"table"`       // 完整表名
+	Statements  []string `json:"statements"`  // 待执行的DDL
+	Warnings    []string `json:"warnings"`    // 提示信息
+	Destructive bool     `json:"destructive"` // 是否包含可能丢失数据的变更
+}
+
+// AddonTable 内容模型附加表名（不含前缀）
+func AddonTable(tableName string) string {
+	return "addon" + tableName
+}
+
+// ValidateTableName 校验模型表名
+func ValidateTableName(tableName string) error {
+	if !identRe.MatchString(tableName) {
+		return fmt.Errorf("表名只能包含小写字母、数字和下划线，以字母开头且不超过24个字符: %s", tableName)
+	}
+	return nil
+}
+
+// ValidateFields 校验字段定义，并规范化类型、长度和默认值
+func ValidateFields(fields []Field) error {
+	seen := make(map[string]bool)
+	for i := range fields {
+		field := &fields[i]
+		if field.Title == "" {
+			return fmt.Errorf("字段标题不能为空: %s", field.Name)
+		}
+		if !identRe.MatchString(field.Name) {
+			return fmt.Errorf("字段名只能包含小写字母、数字和下划线，以字母开头且不超过24个字符: %s", field.Name)
+		}
+		if reservedFields[field.Name] {
+			return fmt.Errorf("字段名为系统保留字段: %s", field.Name)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("字段名重复: %s", field.Name)
+		}
+		seen[field.Name] = true
+
+		if err := normalizeField(field); err != nil {
+			return err
+		}
+		if field.Type == "varchar" && (field.Length < 1 || field.Length > maxVarcharLength) {
+			return fmt.Errorf("字段 %s 的长度应在1到%d之间", field.Name, maxVarcharLength)
+		}
+
+		switch field.Index {
+		case FieldIndexNone, FieldIndexNormal, FieldIndexUnique:
+		default:
+			return fmt.Errorf("不支持的索引类型: %s", field.Index)
+		}
+		if field.Index != FieldIndexNone && (field.Type == "text" || field.Type == "longtext") {
+			return fmt.Errorf("文本字段不能建立索引: %s", field.Name)
+		}
+	}
+	return nil
+}
+
+// normalizeField 规范化字段的类型、长度和默认值
+func normalizeField(field *Field) error {
+	switch field.Type {
+	case "varchar":
+		if field.Length == 0 {
+			field.Length = maxVarcharLength
+		}
+	case "int", "float", "decimal", "text", "longtext", "datetime", "date":
+		field.Length = 0
+	default:
+		return fmt.Errorf("不支持的字段类型: %s", field.Type)
+	}
+
+	if field.Default != "" {
+		value, err := normalizeDefault(field.Type, field.Default)
+		if err != nil {
+			return fmt.Errorf("字段 %s 的默认值无效: %v", field.Name, err)
+		}
+		field.Default = value
+	}
+
+	return nil
+}
+
+// normalizeDefault 按字段类型校验默认值并返回规范形式
+func normalizeDefault(fieldType, value string) (string, error) {
+	switch fieldType {
+	case "int":
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(n, 10), nil
+	case "float", "decimal":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case "date":
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", err
+		}
+		return t.Format("2006-01-02"), nil
+	case "datetime":
+		t, err := time.Parse("2006-01-02 15:04:05", value)
+		if err != nil {
+			return "", err
+		}
+		return t.Format("2006-01-02 15:04:05"), nil
+	case "text", "longtext":
+		return "", fmt.Errorf("文本字段不支持默认值")
+	}
+	return value, nil
+}
+
+// FieldValue 按字段类型转换表单或导入的值：空值保存为NULL，数字和日期解析后保存，格式不正确时返回错误
+func FieldValue(field Field, value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if field.Required {
+			return nil, fmt.Errorf("%s不能为空", field.Title)
+		}
+		return nil, nil
+	}
+
+	switch field.Type {
+	case "int":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s必须是整数", field.Title)
+		}
+		return n, nil
+	case "float", "decimal":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s必须是数字", field.Title)
+		}
+		return f, nil
+	case "date":
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("%s的日期格式应为2006-01-02", field.Title)
+		}
+		return t.Format("2006-01-02"), nil
+	case "datetime":
+		for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.Format("2006-01-02 15:04:05"), nil
+			}
+		}
+		return nil, fmt.Errorf("%s的时间格式应为2006-01-02 15:04:05", field.Title)
+	}
+	return value, nil
+}
+
+// DecodeFields 解析并校验字段定义
+func DecodeFields(fieldsJSON string) ([]Field, error) {
+	var fields []Field
+	if err := json.Unmarshal([]byte(fieldsJSON), &fields); err != nil {
+		return nil, fmt.Errorf("字段定义格式错误: %v", err)
+	}
+	if err := ValidateFields(fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// encodeFields 序列化规范化后的字段定义
+func encodeFields(fields []Field) string {
+	data, _ := json.Marshal(fields)
+	return string(data)
+}
+
+// parseFields 解析已保存的字段定义，无法识别的类型按旧版建表时的VARCHAR(255)处理
+func parseFields(fieldsJSON string) ([]Field, error) {
+	var fields []Field
+	if fieldsJSON == "" {
+		return fields, nil
+	}
+	if err := json.Unmarshal([]byte(fieldsJSON), &fields); err != nil {
+		return nil, err
+	}
+	for i := range fields {
+		field := &fields[i]
+		switch field.Type {
+		case "varchar", "int", "float", "decimal", "text", "longtext", "datetime", "date":
+		default:
+			field.Type = "varchar"
+			field.Length = maxVarcharLength
+		}
+		// 默认值无法规范化时保留原值
+		def := field.Default
+		if normalizeField(field) != nil {
+			field.Default = def
+		}
+	}
+	return fields, nil
+}
+
+// ContentTable 内容模型实际使用的附加表名（不含前缀），兼容直接以模型表名建立的旧表
+func (m *ContentModelModel) ContentTable(model *ContentModel) string {
+	table := AddonTable(model.TableName)
+	if exists, err := m.db.TableExists(table); err == nil && !exists {
+		if legacy, err := m.db.TableExists(model.TableName); err == nil && legacy {
+			return model.TableName
+		}
+	}
+	return table
+}
+
+// PlanCreate 生成创建附加表的DDL
+func (m *ContentModelModel) PlanCreate(model *ContentModel) (*TablePlan, error) {
+	if err := ValidateTableName(model.TableName); err != nil {
+		return nil, err
+	}
+	fields, err := DecodeFields(model.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	table := AddonTable(model.TableName)
+	exists, err := m.TableExists(table)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("数据表已存在: %s", m.db.TableName(table))
+	}
+
+	plan := &TablePlan{Table: m.db.TableName(table)}
+	plan.Statements = m.createTableSQL(plan.Table, fields)
+	return plan, nil
+}
+
+// PlanUpdate 对比新旧字段定义，生成修改附加表的DDL；附加表不存在时生成建表语句
+func (m *ContentModelModel) PlanUpdate(oldModel, newModel *ContentModel) (*TablePlan, error) {
+	newFields, err := DecodeFields(newModel.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	table := m.ContentTable(oldModel)
+	exists, err := m.TableExists(table)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &TablePlan{Table: m.db.TableName(table)}
+	if !exists {
+		plan.Statements = m.createTableSQL(plan.Table, newFields)
+		plan.Warnings = append(plan.Warnings, "数据表不存在，将重新创建")
+		return plan, nil
+	}
+
+	oldFields, err := m.existingFields(table, oldModel, newFields)
+	if err != nil {
+		return nil, err
+	}
+	oldMap := make(map[string]Field, len(oldFields))
+	for _, field := range oldFields {
+		oldMap[field.Name] = field
+	}
+	newMap := make(map[string]Field, len(newFields))
+	for _, field := range newFields {
+		newMap[field.Name] = field
+	}
+
+	var added, modified, dropped []Field
+	for _, field := range newFields {
+		oldField, ok := oldMap[field.Name]
+		if !ok {
+			added = append(added, field)
+			continue
+		}
+		if columnChanged(oldField, field) {
+			modified = append(modified, field)
+			if reason := lossyChange(m.dialect().Name(), oldField, field); reason != "" {
+				plan.Destructive = true
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("字段 %s %s，可能丢失数据", field.Name, reason))
+			}
+		}
+	}
+	for _, field := range oldFields {
+		if _, ok := newMap[field.Name]; !ok {
+			dropped = append(dropped, field)
+			plan.Destructive = true
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("字段 %s 将被删除，其中的数据无法恢复", field.Name))
+		}
+	}
+
+	// 在执行任何DDL之前检查重复值，避免MySQL中DDL执行到一半失败
+	if err := m.checkUnique(table, oldMap, newFields); err != nil {
+		return nil, err
+	}
+
+	if m.dialect().Name() == "sqlite" && (len(modified) > 0 || len(dropped) > 0) {
+		// SQLite不支持修改字段，通过重建表完成
+		plan.Statements = m.rebuildTableSQL(plan.Table, oldFields, newFields)
+		return plan, nil
+	}
+
+	d := m.dialect()
+	quoted := d.Quote(plan.Table)
+	for _, field := range dropped {
+		if field.Index != FieldIndexNone {
+			plan.Statements = append(plan.Statements, m.dropIndexSQL(plan.Table, field.Name))
+		}
+		plan.Statements = append(plan.Statements, "ALTER TABLE "+quoted+" DROP COLUMN "+d.Quote(field.Name))
+	}
+	for _, field := range modified {
+		plan.Statements = append(plan.Statements, m.modifyColumnSQL(plan.Table, oldMap[field.Name], field)...)
+	}
+	for _, field := range added {
+		plan.Statements = append(plan.Statements, "ALTER TABLE "+quoted+" ADD COLUMN "+m.columnSQL(field))
+	}
+	for _, field := range newFields {
+		oldField, ok := oldMap[field.Name]
+		if ok && oldField.Index == field.Index {
+			continue
+		}
+		if ok && oldField.Index != FieldIndexNone {
+			plan.Statements = append(plan.Statements, m.dropIndexSQL(plan.Table, field.Name))
+		}
+		if field.Index != FieldIndexNone {
+			plan.Statements = append(plan.Statements, m.createIndexSQL(plan.Table, field))
+		}
+	}
+
+	return plan, nil
+}
+
+// existingFields 附加表中实际存在的字段及其索引。MySQL的DDL不能回滚，上次变更执行到一半时
+// 字段定义与数据表不一致，因此以实际的字段和索引为准：已保存定义中的字段沿用其定义，
+// 只存在于新定义中的字段视为已按新定义添加，两者都没有的字段按长文本处理
+func (m *ContentModelModel) existingFields(table string, oldModel *ContentModel, newFields []Field) ([]Field, error) {
+	savedFields, err := parseFields(oldModel.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("原字段定义格式错误: %v", err)
+	}
+	known := make(map[string]Field, len(savedFields)+len(newFields))
+	for _, field := range newFields {
+		known[field.Name] = field
+	}
+	for _, field := range savedFields {
+		known[field.Name] = field
+	}
+
+	columns, err := m.db.TableColumns(table)
+	if err != nil {
+		logger.Error("获取数据表字段失败", "table", table, "error", err)
+		return nil, err
+	}
+	indexes, err := m.db.TableIndexes(table)
+	if err != nil {
+		logger.Error("获取数据表索引失败", "table", table, "error", err)
+		return nil, err
+	}
+	indexed := make(map[string]bool, len(indexes))
+	for _, name := range indexes {
+		indexed[strings.ToLower(name)] = true
+	}
+
+	fields := make([]Field, 0, len(columns))
+	for _, column := range columns {
+		name := strings.ToLower(column)
+		if reservedFields[name] {
+			continue
+		}
+		field, ok := known[name]
+		if !ok {
+			field = Field{Name: name, Title: name, Type: "longtext"}
+		}
+		switch {
+		case !indexed[strings.ToLower(indexName(m.db.TableName(table), name))]:
+			field.Index = FieldIndexNone
+		case field.Index == FieldIndexNone:
+			field.Index = FieldIndexNormal
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// checkUnique 新建唯一索引的字段已有重复值时返回错误
+func (m *ContentModelModel) checkUnique(table string, oldMap map[string]Field, fields []Field) error {
+	d := m.dialect()
+	for _, field := range fields {
+		oldField, ok := oldMap[field.Name]
+		if !ok || field.Index != FieldIndexUnique || oldField.Index == FieldIndexUnique {
+			continue
+		}
+
+		column := d.Quote(field.Name)
+		sql := "SELECT COUNT(*) AS count FROM (SELECT " + column + " FROM " + d.Quote(m.db.TableName(table)) +
+			" WHERE " + column + " IS NOT NULL GROUP BY " + column + " HAVING COUNT(*) > 1) AS dup"
+		var row struct {
+			Count int `db:"count"`
+		}
+		if _, err := m.db.GetOneInto(&row, sql); err != nil {
+			logger.Error("检查字段重复值失败", "table", table, "field", field.Name, "error", err)
+			return err
+		}
+		if row.Count > 0 {
+			return fmt.Errorf("字段 %s 有 %d 个重复的值，无法建立唯一索引", field.Name, row.Count)
+		}
+	}
+	return nil
+}
+
+// ApplyPlan 执行变更计划，包含可能丢失数据的变更时需要force
+func (m *ContentModelModel) ApplyPlan(plan *TablePlan, force bool) error {
+	if plan.Destructive && !force {
+		return ErrDestructiveChange
+	}
+
+	err := m.db.WithTx(func(tx *database.Tx) error {
+		for _, stmt := range plan.Statements {
+			if _, err := tx.DB().Exec(stmt); err != nil {
+				return fmt.Errorf("%v: %s", err, stmt)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("执行数据表变更失败", "table", plan.Table, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// columnChanged 字段的列定义是否变化
+func columnChanged(oldField, newField Field) bool {
+	return oldField.Type != newField.Type || oldField.Length != newField.Length || oldField.Default != newField.Default
+}
+
+// lossyChange 字段变更可能丢失数据的原因，兼容的变更返回空串
+func lossyChange(dialect string, oldField, newField Field) string {
+	if oldField.Type == newField.Type {
+		if newField.Type == "varchar" && newField.Length < oldField.Length {
+			return fmt.Sprintf("长度从%d缩短为%d", oldField.Length, newField.Length)
+		}
+		return ""
+	}
+
+	widening := map[string][]string{
+		"varchar":  {"text", "longtext"},
+		"text":     {"longtext"},
+		"int":      {"float", "decimal", "varchar", "text", "longtext"},
+		"float":    {"varchar", "text", "longtext"},
+		"decimal":  {"float", "varchar", "text", "longtext"},
+		"date":     {"datetime", "varchar", "text", "longtext"},
+		"datetime": {"varchar", "text", "longtext"},
+	}
+	// 只有MySQL的TEXT比LONGTEXT短，其他数据库都使用TEXT
+	if oldField.Type == "longtext" && newField.Type == "text" && dialect != "mysql" {
+		return ""
+	}
+	for _, t := range widening[oldField.Type] {
+		if t == newField.Type {
+			// 转为字符串时长度需容纳原值
+			if t == "varchar" && newField.Length < 32 {
+				return fmt.Sprintf("类型从%s改为长度%d的varchar", oldField.Type, newField.Length)
+			}
+			return ""
+		}
+	}
+	return fmt.Sprintf("类型从%s改为%s", oldField.Type, newField.Type)
+}
+
+// dialect 当前数据库方言
+func (m *ContentModelModel) dialect() database.Dialect {
+	if m.db.Dialect == nil {
+		d, _ := database.NewDialect("mysql")
+		return d
+	}
+	return m.db.Dialect
+}
+
+// columnType 字段类型对应的数据库列类型
+func (m *ContentModelModel) columnType(field Field) string {
+	name := m.dialect().Name()
+	switch field.Type {
+	case "varchar":
+		if name == "sqlite" {
+			return "TEXT"
+		}
+		return fmt.Sprintf("VARCHAR(%d)", field.Length)
+	case "int":
+		if name == "mysql" {
+			return "INT"
+		}
+		return "INTEGER"
+	case "float":
+		switch name {
+		case "postgres":
+			return "DOUBLE PRECISION"
+		case "sqlite":
+			return "REAL"
+		}
+		return "DOUBLE"
+	case "decimal":
+		if name == "sqlite" {
+			return "NUMERIC"
+		}
+		return "DECIMAL(10,2)"
+	case "longtext":
+		if name == "mysql" {
+			return "LONGTEXT"
+		}
+		return "TEXT"
+	case "datetime":
+		return m.dialect().DateTimeType()
+	case "date":
+		return "DATE"
+	}
+	return "TEXT"
+}
+
+// defaultSQL 默认值子句，字符串按方言转义
+func (m *ContentModelModel) defaultSQL(field Field) string {
+	if field.Default == "" {
+		return ""
+	}
+	switch field.Type {
+	case "int", "float", "decimal":
+		return " DEFAULT " + field.Default
+	}
+	value := strings.Replace(field.Default, "'", "''", -1)
+	if m.dialect().Name() == "mysql" {
+		value = strings.Replace(value, `\`, `\\`, -1)
+	}
+	return " DEFAULT '" + value + "'"
+}
+
+// columnSQL 字段的列定义，必填由表单校验，列均允许NULL以便兼容已有数据
+func (m *ContentModelModel) columnSQL(field Field) string {
+	return m.dialect().Quote(field.Name) + " " + m.columnType(field) + m.defaultSQL(field) + " NULL"
+}
+
+// createTableSQL 建表及索引语句
+func (m *ContentModelModel) createTableSQL(table string, fields []Field) []string {
+	d := m.dialect()
+
+	var id, aid string
+	switch d.Name() {
+	case "postgres":
+		id, aid = "BIGSERIAL PRIMARY KEY", "BIGINT NOT NULL DEFAULT 0"
+	case "sqlite":
+		id, aid = "INTEGER PRIMARY KEY AUTOINCREMENT", "INTEGER NOT NULL DEFAULT 0"
+	default:
+		id, aid = "BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY", "BIGINT NOT NULL DEFAULT 0"
+	}
+
+	var sql strings.Builder
+	sql.WriteString("CREATE TABLE " + d.Quote(table) + " (\n")
+	sql.WriteString("  " + d.Quote("id") + " " + id + ",\n")
+	sql.WriteString("  " + d.Quote("aid") + " " + aid)
+	for _, field := range fields {
+		sql.WriteString(",\n  " + m.columnSQL(field))
+	}
+	sql.WriteString("\n)")
+	if d.Name() == "mysql" {
+		sql.WriteString(" ENGINE=InnoDB DEFAULT CHARSET=utf8mb4")
+	}
+
+	statements := []string{sql.String(), m.createIndexSQL(table, Field{Name: "aid", Index: FieldIndexNormal})}
+	for _, field := range fields {
+		if field.Index != FieldIndexNone {
+			statements = append(statements, m.createIndexSQL(table, field))
+		}
+	}
+	return statements
+}
+
+// rebuildTableSQL 重建表：新建临时表、复制保留字段的数据、替换原表
+func (m *ContentModelModel) rebuildTableSQL(table string, oldFields, newFields []Field) []string {
+	d := m.dialect()
+	tmp := table + "_rebuild"
+
+	kept := make(map[string]bool, len(oldFields))
+	for _, field := range oldFields {
+		kept[field.Name] = true
+	}
+	columns := []string{d.Quote("id"), d.Quote("aid")}
+	for _, field := range newFields {
+		if kept[field.Name] {
+			columns = append(columns, d.Quote(field.Name))
+		}
+	}
+	list := strings.Join(columns, ", ")
+
+	create := m.createTableSQL(tmp, newFields)
+	statements := []string{
+		"DROP TABLE IF EXISTS " + d.Quote(tmp),
+		create[0],
+		"INSERT INTO " + d.Quote(tmp) + " (" + list + ") SELECT " + list + " FROM " + d.Quote(table),
+		"DROP TABLE " + d.Quote(table),
+		"ALTER TABLE " + d.Quote(tmp) + " RENAME TO " + d.Quote(table),
+	}
+	// 索引随原表删除，按原表名重新创建
+	statements = append(statements, m.createIndexSQL(table, Field{Name: "aid", Index: FieldIndexNormal}))
+	for _, field := range newFields {
+		if field.Index != FieldIndexNone {
+			statements = append(statements, m.createIndexSQL(table, field))
+		}
+	}
+	return statements
+}
+
+// modifyColumnSQL 修改字段定义的语句
+func (m *ContentModelModel) modifyColumnSQL(table string, oldField, field Field) []string {
+	d := m.dialect()
+	prefix := "ALTER TABLE " + d.Quote(table) + " "
+	if d.Name() != "postgres" {
+		return []string{prefix + "MODIFY COLUMN " + m.columnSQL(field)}
+	}
+
+	column := d.Quote(field.Name)
+	var statements []string
+	typeChanged := oldField.Type != field.Type || oldField.Length != field.Length
+	if typeChanged {
+		// 先去掉默认值，避免旧默认值无法转换为新类型
+		columnType := m.columnType(field)
+		statements = append(statements, prefix+"ALTER COLUMN "+column+" DROP DEFAULT")
+		statements = append(statements, prefix+"ALTER COLUMN "+column+" TYPE "+columnType+" USING "+column+"::"+columnType)
+	}
+	if field.Default == "" {
+		if !typeChanged {
+			statements = append(statements, prefix+"ALTER COLUMN "+column+" DROP DEFAULT")
+		}
+	} else {
+		statements = append(statements, prefix+"ALTER COLUMN "+column+" SET"+m.defaultSQL(field))
+	}
+	return statements
+}
+
+// indexName 索引名，与迁移脚本一致使用“表名_字段名”
+func indexName(table, column string) string {
+	return table + "_" + column
+}
+
+// createIndexSQL 创建字段索引的语句
+func (m *ContentModelModel) createIndexSQL(table string, field Field) string {
+	d := m.dialect()
+	unique := ""
+	if field.Index == FieldIndexUnique {
+		unique = "UNIQUE "
+	}
+	return "CREATE " + unique + "INDEX " + d.Quote(indexName(table, field.Name)) + " ON " + d.Quote(table) + " (" + d.Quote(field.Name) + ")"
+}
+
+// dropIndexSQL 删除字段索引的语句
+func (m *ContentModelModel) dropIndexSQL(table, column string) string {
+	d := m.dialect()
+	sql := "DROP INDEX " + d.Quote(indexName(table, column))
+	if d.Name() == "mysql" {
+		sql += " ON " + d.Quote(table)
+	}
+	return sql
+}
@@ -11,36 +11,36 @@ import (
 
 // PaymentMethod 支付方式
 type PaymentMethod struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`        // 支付方式名称
-	Code        string    `json:"code"`        // 支付方式代码
-	Description string    `json:"description"` // 描述
-	Config      string    `json:"config"`      // 配置，JSON格式
-	Icon        string    `json:"icon"`        // 图标
-	OrderID     int       `json:"orderid"`     // 排序ID
-	Status      int       `json:"status"`      // 状态：0禁用，1启用
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`               // 支付方式名称
+	Code        string    `json:"code" db:"code"`               // 支付方式代码
+	Description string    `json:"description" db:"description"` // 描述
+	Config      string    `json:"config" db:"config"`           // 配置，JSON格式
+	Icon        string    `json:"icon" db:"icon"`               // 图标
+	OrderID     int       `json:"orderid" db:"orderid"`         // 排序ID
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // PaymentOrder 支付订单
 type PaymentOrder struct {
-	ID             int64     `json:"id"`
-	OrderNo        string    `json:"orderno"`        // 订单号
-	MemberID       int64     `json:"memberid"`       // 会员ID
-	Amount         float64   `json:"amount"`         // 金额
-	PaymentMethod  string    `json:"paymentmethod"`  // 支付方式
-	PaymentOrderNo string    `json:"paymentorderno"` // 支付平台订单号
-	Status         int       `json:"status"`         // 状态：0未支付，1已支付，2已取消，3已退款
-	Type           int       `json:"type"`           // 类型：0充值，1购买，2其他
-	RelatedID      int64     `json:"relatedid"`      // 关联ID
-	RelatedType    string    `json:"relatedtype"`    // 关联类型
-	Remark         string    `json:"remark"`         // 备注
-	IP             string    `json:"ip"`             // IP地址
-	CreateTime     time.Time `json:"createtime"`     // 创建时间
-	UpdateTime     time.Time `json:"updatetime"`     // 更新时间
-	PayTime        time.Time `json:"paytime"`        // 支付时间
-	ExtraData      string    `json:"extradata"`      // 额外数据，JSON格式
+	ID             int64     `json:"id" db:"id"`
+	OrderNo        string    `json:"orderno" db:"orderno"`               // 订单号
+	MemberID       int64     `json:"memberid" db:"memberid"`             // 会员ID
+	Amount         float64   `json:"amount" db:"amount"`                 // 金额
+	PaymentMethod  string    `json:"paymentmethod" db:"paymentmethod"`   // 支付方式
+	PaymentOrderNo string    `json:"paymentorderno" db:"paymentorderno"` // 支付平台订单号
+	Status         int       `json:"status" db:"status"`                 // 状态：0未支付，1已支付，2已取消，3已退款
+	Type           int       `json:"type" db:"type"`                     // 类型：0充值，1购买，2其他
+	RelatedID      int64     `json:"relatedid" db:"relatedid"`           // 关联ID
+	RelatedType    string    `json:"relatedtype" db:"relatedtype"`       // 关联类型
+	Remark         string    `json:"remark" db:"remark"`                 // 备注
+	IP             string    `json:"ip" db:"ip"`                         // IP地址
+	CreateTime     time.Time `json:"createtime" db:"createtime"`         // 创建时间
+	UpdateTime     time.Time `json:"updatetime" db:"updatetime"`         // 更新时间
+	PayTime        time.Time `json:"paytime" db:"paytime"`               // 支付时间
+	ExtraData      string    `json:"extradata" db:"extradata"`           // 额外数据，JSON格式
 }
 
 // PaymentMethodModel 支付方式模型
@@ -62,30 +62,18 @@ func (m *PaymentMethodModel) GetByID(id int64) (*PaymentMethod, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	method := &PaymentMethod{}
+	found, err := qb.FirstInto(method)
 	if err != nil {
 		logger.Error("获取支付方式失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("payment method not found: %d", id)
 	}
 
-	// 转换为支付方式
-	method := &PaymentMethod{}
-	method.ID, _ = result["id"].(int64)
-	method.Name, _ = result["name"].(string)
-	method.Code, _ = result["code"].(string)
-	method.Description, _ = result["description"].(string)
-	method.Config, _ = result["config"].(string)
-	method.Icon, _ = result["icon"].(string)
-	method.OrderID, _ = result["orderid"].(int)
-	method.Status, _ = result["status"].(int)
-	method.CreateTime, _ = result["createtime"].(time.Time)
-	method.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return method, nil
 }
 
@@ -96,30 +84,18 @@ func (m *PaymentMethodModel) GetByCode(code string) (*PaymentMethod, error) {
 	qb.Where("code = ?", code)
 
 	// 执行查询
-	result, err := qb.First()
+	method := &PaymentMethod{}
+	found, err := qb.FirstInto(method)
 	if err != nil {
 		logger.Error("获取支付方式失败", "code", code, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("payment method not found: %s", code)
 	}
 
-	// 转换为支付方式
-	method := &PaymentMethod{}
-	method.ID, _ = result["id"].(int64)
-	method.Name, _ = result["name"].(string)
-	method.Code, _ = result["code"].(string)
-	method.Description, _ = result["description"].(string)
-	method.Config, _ = result["config"].(string)
-	method.Icon, _ = result["icon"].(string)
-	method.OrderID, _ = result["orderid"].(int)
-	method.Status, _ = result["status"].(int)
-	method.CreateTime, _ = result["createtime"].(time.Time)
-	method.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return method, nil
 }
 
@@ -133,29 +109,12 @@ func (m *PaymentMethodModel) GetAll(status int) ([]*PaymentMethod, error) {
 	qb.OrderBy("orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	methods := make([]*PaymentMethod, 0)
+	if err := qb.GetInto(&methods); err != nil {
 		logger.Error("获取所有支付方式失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为支付方式列表
-	methods := make([]*PaymentMethod, 0, len(results))
-	for _, result := range results {
-		method := &PaymentMethod{}
-		method.ID, _ = result["id"].(int64)
-		method.Name, _ = result["name"].(string)
-		method.Code, _ = result["code"].(string)
-		method.Description, _ = result["description"].(string)
-		method.Config, _ = result["config"].(string)
-		method.Icon, _ = result["icon"].(string)
-		method.OrderID, _ = result["orderid"].(int)
-		method.Status, _ = result["status"].(int)
-		method.CreateTime, _ = result["createtime"].(time.Time)
-		method.UpdateTime, _ = result["updatetime"].(time.Time)
-		methods = append(methods, method)
-	}
-
 	return methods, nil
 }
 
@@ -293,36 +252,18 @@ func (m *PaymentOrderModel) GetByID(id int64) (*PaymentOrder, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	order := &PaymentOrder{}
+	found, err := qb.FirstInto(order)
 	if err != nil {
 		logger.Error("获取支付订单失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("payment order not found: %d", id)
 	}
 
-	// 转换为支付订单
-	order := &PaymentOrder{}
-	order.ID, _ = result["id"].(int64)
-	order.OrderNo, _ = result["orderno"].(string)
-	order.MemberID, _ = result["memberid"].(int64)
-	order.Amount, _ = result["amount"].(float64)
-	order.PaymentMethod, _ = result["paymentmethod"].(string)
-	order.PaymentOrderNo, _ = result["paymentorderno"].(string)
-	order.Status, _ = result["status"].(int)
-	order.Type, _ = result["type"].(int)
-	order.RelatedID, _ = result["relatedid"].(int64)
-	order.RelatedType, _ = result["relatedtype"].(string)
-	order.Remark, _ = result["remark"].(string)
-	order.IP, _ = result["ip"].(string)
-	order.CreateTime, _ = result["createtime"].(time.Time)
-	order.UpdateTime, _ = result["updatetime"].(time.Time)
-	order.PayTime, _ = result["paytime"].(time.Time)
-	order.ExtraData, _ = result["extradata"].(string)
-
 	return order, nil
 }
 
@@ -333,36 +274,18 @@ func (m *PaymentOrderModel) GetByOrderNo(orderNo string) (*PaymentOrder, error)
 	qb.Where("orderno = ?", orderNo)
 
 	// 执行查询
-	result, err := qb.First()
+	order := &PaymentOrder{}
+	found, err := qb.FirstInto(order)
 	if err != nil {
 		logger.Error("获取支付订单失败", "orderno", orderNo, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("payment order not found: %s", orderNo)
 	}
 
-	// 转换为支付订单
-	order := &PaymentOrder{}
-	order.ID, _ = result["id"].(int64)
-	order.OrderNo, _ = result["orderno"].(string)
-	order.MemberID, _ = result["memberid"].(int64)
-	order.Amount, _ = result["amount"].(float64)
-	order.PaymentMethod, _ = result["paymentmethod"].(string)
-	order.PaymentOrderNo, _ = result["paymentorderno"].(string)
-	order.Status, _ = result["status"].(int)
-	order.Type, _ = result["type"].(int)
-	order.RelatedID, _ = result["relatedid"].(int64)
-	order.RelatedType, _ = result["relatedtype"].(string)
-	order.Remark, _ = result["remark"].(string)
-	order.IP, _ = result["ip"].(string)
-	order.CreateTime, _ = result["createtime"].(time.Time)
-	order.UpdateTime, _ = result["updatetime"].(time.Time)
-	order.PayTime, _ = result["paytime"].(time.Time)
-	order.ExtraData, _ = result["extradata"].(string)
-
 	return order, nil
 }
 
@@ -379,8 +302,8 @@ func (m *PaymentOrderModel) GetByMemberID(memberID int64, status int, page, page
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	orders := make([]*PaymentOrder, 0)
+	if err := qb.GetInto(&orders); err != nil {
 		logger.Error("获取支付订单失败", "memberid", memberID, "error", err)
 		return nil, 0, err
 	}
@@ -392,29 +315,6 @@ func (m *PaymentOrderModel) GetByMemberID(memberID int64, status int, page, page
 		return nil, 0, err
 	}
 
-	// 转换为支付订单列表
-	orders := make([]*PaymentOrder, 0, len(results))
-	for _, result := range results {
-		order := &PaymentOrder{}
-		order.ID, _ = result["id"].(int64)
-		order.OrderNo, _ = result["orderno"].(string)
-		order.MemberID, _ = result["memberid"].(int64)
-		order.Amount, _ = result["amount"].(float64)
-		order.PaymentMethod, _ = result["paymentmethod"].(string)
-		order.PaymentOrderNo, _ = result["paymentorderno"].(string)
-		order.Status, _ = result["status"].(int)
-		order.Type, _ = result["type"].(int)
-		order.RelatedID, _ = result["relatedid"].(int64)
-		order.RelatedType, _ = result["relatedtype"].(string)
-		order.Remark, _ = result["remark"].(string)
-		order.IP, _ = result["ip"].(string)
-		order.CreateTime, _ = result["createtime"].(time.Time)
-		order.UpdateTime, _ = result["updatetime"].(time.Time)
-		order.PayTime, _ = result["paytime"].(time.Time)
-		order.ExtraData, _ = result["extradata"].(string)
-		orders = append(orders, order)
-	}
-
 	return orders, total, nil
 }
 
@@ -430,8 +330,8 @@ func (m *PaymentOrderModel) GetAll(status int, page, pageSize int) ([]*PaymentOr
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	orders := make([]*PaymentOrder, 0)
+	if err := qb.GetInto(&orders); err != nil {
 		logger.Error("获取所有支付订单失败", "error", err)
 		return nil, 0, err
 	}
@@ -443,29 +343,6 @@ func (m *PaymentOrderModel) GetAll(status int, page, pageSize int) ([]*PaymentOr
 		return nil, 0, err
 	}
 
-	// 转换为支付订单列表
-	orders := make([]*PaymentOrder, 0, len(results))
-	for _, result := range results {
-		order := &PaymentOrder{}
-		order.ID, _ = result["id"].(int64)
-		order.OrderNo, _ = result["orderno"].(string)
-		order.MemberID, _ = result["memberid"].(int64)
-		order.Amount, _ = result["amount"].(float64)
-		order.PaymentMethod, _ = result["paymentmethod"].(string)
-		order.PaymentOrderNo, _ = result["paymentorderno"].(string)
-		order.Status, _ = result["status"].(int)
-		order.Type, _ = result["type"].(int)
-		order.RelatedID, _ = result["relatedid"].(int64)
-		order.RelatedType, _ = result["relatedtype"].(string)
-		order.Remark, _ = result["remark"].(string)
-		order.IP, _ = result["ip"].(string)
-		order.CreateTime, _ = result["createtime"].(time.Time)
-		order.UpdateTime, _ = result["updatetime"].(time.Time)
-		order.PayTime, _ = result["paytime"].(time.Time)
-		order.ExtraData, _ = result["extradata"].(string)
-		orders = append(orders, order)
-	}
-
 	return orders, total, nil
 }
 
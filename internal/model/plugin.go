@@ -10,29 +10,29 @@ import (
 
 // Plugin 插件
 type Plugin struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`        // 插件名称
-	Code        string    `json:"code"`        // 插件代码
-	Version     string    `json:"version"`     // 版本
-	Author      string    `json:"author"`      // 作者
-	Description string    `json:"description"` // 描述
-	Config      string    `json:"config"`      // 配置，JSON格式
-	Status      int       `json:"status"`      // 状态：0禁用，1启用
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`               // 插件名称
+	Code        string    `json:"code" db:"code"`               // 插件代码
+	Version     string    `json:"version" db:"version"`         // 版本
+	Author      string    `json:"author" db:"author"`           // 作者
+	Description string    `json:"description" db:"description"` // 描述
+	Config      string    `json:"config" db:"config"`           // 配置，JSON格式
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // PluginHook 插件钩子
 type PluginHook struct {
-	ID         int64     `json:"id"`
-	PluginID   int64     `json:"pluginid"`   // 插件ID
-	Name       string    `json:"name"`       // 钩子名称
-	Code       string    `json:"code"`       // 钩子代码
-	Position   string    `json:"position"`   // 钩子位置
-	OrderID    int       `json:"orderid"`    // 排序ID
-	Status     int       `json:"status"`     // 状态：0禁用，1启用
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	PluginID   int64     `json:"pluginid" db:"pluginid"`     // 插件ID
+	Name       string    `json:"name" db:"name"`             // 钩子名称
+	Code       string    `json:"code" db:"code"`             // 钩子代码
+	Position   string    `json:"position" db:"position"`     // 钩子位置
+	OrderID    int       `json:"orderid" db:"orderid"`       // 排序ID
+	Status     int       `json:"status" db:"status"`         // 状态：0禁用，1启用
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // PluginModel 插件模型
@@ -54,30 +54,18 @@ func (m *PluginModel) GetByID(id int64) (*Plugin, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	plugin := &Plugin{}
+	found, err := qb.FirstInto(plugin)
 	if err != nil {
 		logger.Error("获取插件失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("plugin not found: %d", id)
 	}
 
-	// 转换为插件
-	plugin := &Plugin{}
-	plugin.ID, _ = result["id"].(int64)
-	plugin.Name, _ = result["name"].(string)
-	plugin.Code, _ = result["code"].(string)
-	plugin.Version, _ = result["version"].(string)
-	plugin.Author, _ = result["author"].(string)
-	plugin.Description, _ = result["description"].(string)
-	plugin.Config, _ = result["config"].(string)
-	plugin.Status, _ = result["status"].(int)
-	plugin.CreateTime, _ = result["createtime"].(time.Time)
-	plugin.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return plugin, nil
 }
 
@@ -88,30 +76,18 @@ func (m *PluginModel) GetByCode(code string) (*Plugin, error) {
 	qb.Where("code = ?", code)
 
 	// 执行查询
-	result, err := qb.First()
+	plugin := &Plugin{}
+	found, err := qb.FirstInto(plugin)
 	if err != nil {
 		logger.Error("获取插件失败", "code", code, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("plugin not found: %s", code)
 	}
 
-	// 转换为插件
-	plugin := &Plugin{}
-	plugin.ID, _ = result["id"].(int64)
-	plugin.Name, _ = result["name"].(string)
-	plugin.Code, _ = result["code"].(string)
-	plugin.Version, _ = result["version"].(string)
-	plugin.Author, _ = result["author"].(string)
-	plugin.Description, _ = result["description"].(string)
-	plugin.Config, _ = result["config"].(string)
-	plugin.Status, _ = result["status"].(int)
-	plugin.CreateTime, _ = result["createtime"].(time.Time)
-	plugin.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return plugin, nil
 }
 
@@ -125,29 +101,12 @@ func (m *PluginModel) GetAll(status int) ([]*Plugin, error) {
 	qb.OrderBy("id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	plugins := make([]*Plugin, 0)
+	if err := qb.GetInto(&plugins); err != nil {
 		logger.Error("获取所有插件失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为插件列表
-	plugins := make([]*Plugin, 0, len(results))
-	for _, result := range results {
-		plugin := &Plugin{}
-		plugin.ID, _ = result["id"].(int64)
-		plugin.Name, _ = result["name"].(string)
-		plugin.Code, _ = result["code"].(string)
-		plugin.Version, _ = result["version"].(string)
-		plugin.Author, _ = result["author"].(string)
-		plugin.Description, _ = result["description"].(string)
-		plugin.Config, _ = result["config"].(string)
-		plugin.Status, _ = result["status"].(int)
-		plugin.CreateTime, _ = result["createtime"].(time.Time)
-		plugin.UpdateTime, _ = result["updatetime"].(time.Time)
-		plugins = append(plugins, plugin)
-	}
-
 	return plugins, nil
 }
 
@@ -259,29 +218,18 @@ func (m *PluginHookModel) GetByID(id int64) (*PluginHook, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	hook := &PluginHook{}
+	found, err := qb.FirstInto(hook)
 	if err != nil {
 		logger.Error("获取插件钩子失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("plugin hook not found: %d", id)
 	}
 
-	// 转换为插件钩子
-	hook := &PluginHook{}
-	hook.ID, _ = result["id"].(int64)
-	hook.PluginID, _ = result["pluginid"].(int64)
-	hook.Name, _ = result["name"].(string)
-	hook.Code, _ = result["code"].(string)
-	hook.Position, _ = result["position"].(string)
-	hook.OrderID, _ = result["orderid"].(int)
-	hook.Status, _ = result["status"].(int)
-	hook.CreateTime, _ = result["createtime"].(time.Time)
-	hook.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return hook, nil
 }
 
@@ -292,29 +240,18 @@ func (m *PluginHookModel) GetByCode(code string) (*PluginHook, error) {
 	qb.Where("code = ?", code)
 
 	// 执行查询
-	result, err := qb.First()
+	hook := &PluginHook{}
+	found, err := qb.FirstInto(hook)
 	if err != nil {
 		logger.Error("获取插件钩子失败", "code", code, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("plugin hook not found: %s", code)
 	}
 
-	// 转换为插件钩子
-	hook := &PluginHook{}
-	hook.ID, _ = result["id"].(int64)
-	hook.PluginID, _ = result["pluginid"].(int64)
-	hook.Name, _ = result["name"].(string)
-	hook.Code, _ = result["code"].(string)
-	hook.Position, _ = result["position"].(string)
-	hook.OrderID, _ = result["orderid"].(int)
-	hook.Status, _ = result["status"].(int)
-	hook.CreateTime, _ = result["createtime"].(time.Time)
-	hook.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return hook, nil
 }
 
@@ -326,28 +263,12 @@ func (m *PluginHookModel) GetByPluginID(pluginID int64) ([]*PluginHook, error) {
 	qb.OrderBy("orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	hooks := make([]*PluginHook, 0)
+	if err := qb.GetInto(&hooks); err != nil {
 		logger.Error("获取插件钩子失败", "pluginid", pluginID, "error", err)
 		return nil, err
 	}
 
-	// 转换为插件钩子列表
-	hooks := make([]*PluginHook, 0, len(results))
-	for _, result := range results {
-		hook := &PluginHook{}
-		hook.ID, _ = result["id"].(int64)
-		hook.PluginID, _ = result["pluginid"].(int64)
-		hook.Name, _ = result["name"].(string)
-		hook.Code, _ = result["code"].(string)
-		hook.Position, _ = result["position"].(string)
-		hook.OrderID, _ = result["orderid"].(int)
-		hook.Status, _ = result["status"].(int)
-		hook.CreateTime, _ = result["createtime"].(time.Time)
-		hook.UpdateTime, _ = result["updatetime"].(time.Time)
-		hooks = append(hooks, hook)
-	}
-
 	return hooks, nil
 }
 
@@ -360,28 +281,12 @@ func (m *PluginHookModel) GetByPosition(position string) ([]*PluginHook, error)
 	qb.OrderBy("orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	hooks := make([]*PluginHook, 0)
+	if err := qb.GetInto(&hooks); err != nil {
 		logger.Error("获取插件钩子失败", "position", position, "error", err)
 		return nil, err
 	}
 
-	// 转换为插件钩子列表
-	hooks := make([]*PluginHook, 0, len(results))
-	for _, result := range results {
-		hook := &PluginHook{}
-		hook.ID, _ = result["id"].(int64)
-		hook.PluginID, _ = result["pluginid"].(int64)
-		hook.Name, _ = result["name"].(string)
-		hook.Code, _ = result["code"].(string)
-		hook.Position, _ = result["position"].(string)
-		hook.OrderID, _ = result["orderid"].(int)
-		hook.Status, _ = result["status"].(int)
-		hook.CreateTime, _ = result["createtime"].(time.Time)
-		hook.UpdateTime, _ = result["updatetime"].(time.Time)
-		hooks = append(hooks, hook)
-	}
-
 	return hooks, nil
 }
 
@@ -395,28 +300,12 @@ func (m *PluginHookModel) GetAll(status int) ([]*PluginHook, error) {
 	qb.OrderBy("position ASC, orderid ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	hooks := make([]*PluginHook, 0)
+	if err := qb.GetInto(&hooks); err != nil {
 		logger.Error("获取所有插件钩子失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为插件钩子列表
-	hooks := make([]*PluginHook, 0, len(results))
-	for _, result := range results {
-		hook := &PluginHook{}
-		hook.ID, _ = result["id"].(int64)
-		hook.PluginID, _ = result["pluginid"].(int64)
-		hook.Name, _ = result["name"].(string)
-		hook.Code, _ = result["code"].(string)
-		hook.Position, _ = result["position"].(string)
-		hook.OrderID, _ = result["orderid"].(int)
-		hook.Status, _ = result["status"].(int)
-		hook.CreateTime, _ = result["createtime"].(time.Time)
-		hook.UpdateTime, _ = result["updatetime"].(time.Time)
-		hooks = append(hooks, hook)
-	}
-
 	return hooks, nil
 }
 
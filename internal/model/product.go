@@ -10,39 +10,39 @@ import (
 
 // Product 产品模型
 type Product struct {
-	ID          int64     `json:"id"`
-	TypeID      int64     `json:"typeid"`
-	Title       string    `json:"title"`
-	ShortTitle  string    `json:"shorttitle"`
-	Color       string    `json:"color"`
-	Writer      string    `json:"writer"`
-	Source      string    `json:"source"`
-	LitPic      string    `json:"litpic"`
-	PubDate     time.Time `json:"pubdate"`
-	SendDate    time.Time `json:"senddate"`
-	Keywords    string    `json:"keywords"`
-	Description string    `json:"description"`
-	Filename    string    `json:"filename"`
-	IsTop       int       `json:"istop"`
-	IsRecommend int       `json:"isrecommend"`
-	IsHot       int       `json:"ishot"`
-	ArcRank     int       `json:"arcrank"`
-	Click       int       `json:"click"`
-	Body        string    `json:"body"`
-	TypeName    string    `json:"typename"`
-	TypeDir     string    `json:"typedir"`
+	ID          int64     `json:"id" db:"id"`
+	TypeID      int64     `json:"typeid" db:"typeid"`
+	Title       string    `json:"title" db:"title"`
+	ShortTitle  string    `json:"shorttitle" db:"shorttitle"`
+	Color       string    `json:"color" db:"color"`
+	Writer      string    `json:"writer" db:"writer"`
+	Source      string    `json:"source" db:"source"`
+	LitPic      string    `json:"litpic" db:"litpic"`
+	PubDate     time.Time `json:"pubdate" db:"pubdate"`
+	SendDate    time.Time `json:"senddate" db:"senddate"`
+	Keywords    string    `json:"keywords" db:"keywords"`
+	Description string    `json:"description" db:"description"`
+	Filename    string    `json:"filename" db:"filename"`
+	IsTop       int       `json:"istop" db:"istop"`
+	IsRecommend int       `json:"isrecommend" db:"isrecommend"`
+	IsHot       int       `json:"ishot" db:"ishot"`
+	ArcRank     int       `json:"arcrank" db:"arcrank"`
+	Click       int       `json:"click" db:"click"`
+	Body        string    `json:"body" db:"body"`
+	TypeName    string    `json:"typename" db:"typename"`
+	TypeDir     string    `json:"typedir" db:"typedir"`
 	// 产品特有字段
-	ProductName  string   `json:"productname"`
-	ProductSN    string   `json:"productsn"`
-	Price        float64  `json:"price"`
-	OldPrice     float64  `json:"oldprice"`
-	Units        string   `json:"units"`
-	Weight       float64  `json:"weight"`
-	Specification string  `json:"specification"`
-	Features     string   `json:"features"`
-	Parameters   string   `json:"parameters"`
-	Images       []string `json:"images"`
-	Stock        int      `json:"stock"`
+	ProductName   string   `json:"productname" db:"productname"`
+	ProductSN     string   `json:"productsn" db:"productsn"`
+	Price         float64  `json:"price" db:"price"`
+	OldPrice      float64  `json:"oldprice" db:"oldprice"`
+	Units         string   `json:"units" db:"units"`
+	Weight        float64  `json:"weight" db:"weight"`
+	Specification string   `json:"specification" db:"specification"`
+	Features      string   `json:"features" db:"features"`
+	Parameters    string   `json:"parameters" db:"parameters"`
+	Images        []string `json:"images"`
+	Stock         int      `json:"stock" db:"stock"`
 }
 
 // ProductModel 产品模型操作
@@ -69,97 +69,17 @@ func (m *ProductModel) GetByID(id int64) (*Product, error) {
 	qb.Where("a.channel = 2") // 产品频道ID
 	
 	// 执行查询
-	result, err := qb.First()
+	product := &Product{}
+	found, err := qb.FirstInto(product)
 	if err != nil {
 		logger.Error("查询产品失败", "id", id, "error", err)
 		return nil, err
 	}
-	
-	if result == nil {
+
+	if !found {
 		return nil, fmt.Errorf("产品不存在")
 	}
-	
-	// 转换为产品对象
-	product := &Product{}
-	product.ID, _ = result["id"].(int64)
-	product.TypeID, _ = result["typeid"].(int64)
-	product.Title, _ = result["title"].(string)
-	product.ShortTitle, _ = result["shorttitle"].(string)
-	product.Color, _ = result["color"].(string)
-	product.Writer, _ = result["writer"].(string)
-	product.Source, _ = result["source"].(string)
-	product.LitPic, _ = result["litpic"].(string)
-	
-	// 处理日期
-	if pubdate, ok := result["pubdate"].(time.Time); ok {
-		product.PubDate = pubdate
-	}
-	if senddate, ok := result["senddate"].(time.Time); ok {
-		product.SendDate = senddate
-	}
-	
-	product.Keywords, _ = result["keywords"].(string)
-	product.Description, _ = result["description"].(string)
-	product.Filename, _ = result["filename"].(string)
-	
-	// 处理整数字段
-	if istop, ok := result["istop"].(int64); ok {
-		product.IsTop = int(istop)
-	}
-	if isrecommend, ok := result["isrecommend"].(int64); ok {
-		product.IsRecommend = int(isrecommend)
-	}
-	if ishot, ok := result["ishot"].(int64); ok {
-		product.IsHot = int(ishot)
-	}
-	if arcrank, ok := result["arcrank"].(int64); ok {
-		product.ArcRank = int(arcrank)
-	}
-	if click, ok := result["click"].(int64); ok {
-		product.Click = int(click)
-	}
-	
-	product.Body, _ = result["body"].(string)
-	product.TypeName, _ = result["typename"].(string)
-	product.TypeDir, _ = result["typedir"].(string)
-	
-	// 处理产品特有字段
-	product.ProductName, _ = result["productname"].(string)
-	product.ProductSN, _ = result["productsn"].(string)
-	
-	// 处理价格
-	if price, ok := result["price"].(float64); ok {
-		product.Price = price
-	} else if priceStr, ok := result["price"].(string); ok {
-		fmt.Sscanf(priceStr, "%f", &product.Price)
-	}
-	
-	if oldprice, ok := result["oldprice"].(float64); ok {
-		product.OldPrice = oldprice
-	} else if oldpriceStr, ok := result["oldprice"].(string); ok {
-		fmt.Sscanf(oldpriceStr, "%f", &product.OldPrice)
-	}
-	
-	product.Units, _ = result["units"].(string)
-	
-	// 处理重量
-	if weight, ok := result["weight"].(float64); ok {
-		product.Weight = weight
-	} else if weightStr, ok := result["weight"].(string); ok {
-		fmt.Sscanf(weightStr, "%f", &product.Weight)
-	}
-	
-	product.Specification, _ = result["specification"].(string)
-	product.Features, _ = result["features"].(string)
-	product.Parameters, _ = result["parameters"].(string)
-	
-	// 处理库存
-	if stock, ok := result["stock"].(int64); ok {
-		product.Stock = int(stock)
-	} else if stockStr, ok := result["stock"].(string); ok {
-		fmt.Sscanf(stockStr, "%d", &product.Stock)
-	}
-	
+
 	// 获取产品图片
 	images, err := m.getProductImages(id)
 	if err != nil {
@@ -175,14 +95,21 @@ func (m *ProductModel) GetList(typeid int64, page, pageSize int) ([]*Product, in
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "archives")
 	qb.Select("a.*", "t.typename", "t.typedir", "p.productname", "p.productsn", "p.price", "p.oldprice")
+	qb.From(m.db.TableName("archives") + " AS a")
 	qb.LeftJoin(m.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 	qb.LeftJoin(m.db.TableName("addonproduct")+" AS p", "a.id = p.aid")
 	
 	// 添加条件
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 	qb.Where("a.channel = 2") // 产品频道ID
 	if typeid > 0 {
-		qb.Where("a.typeid = ?", typeid)
+		// 包括发布到副栏目和交叉栏目的产品
+		condition, args, err := NewArticleModel(m.db).CategoryCondition(typeid)
+		if err != nil {
+			return nil, 0, err
+		}
+		qb.Where(condition, args...)
 	}
 	
 	// 获取总数
@@ -198,77 +125,12 @@ func (m *ProductModel) GetList(typeid int64, page, pageSize int) ([]*Product, in
 	qb.Limit(pageSize, offset)
 	
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	products := make([]*Product, 0)
+	if err := qb.GetInto(&products); err != nil {
 		logger.Error("查询产品列表失败", "error", err)
 		return nil, 0, err
 	}
 	
-	// 转换为产品对象
-	products := make([]*Product, 0, len(results))
-	for _, result := range results {
-		product := &Product{}
-		product.ID, _ = result["id"].(int64)
-		product.TypeID, _ = result["typeid"].(int64)
-		product.Title, _ = result["title"].(string)
-		product.ShortTitle, _ = result["shorttitle"].(string)
-		product.Color, _ = result["color"].(string)
-		product.Writer, _ = result["writer"].(string)
-		product.Source, _ = result["source"].(string)
-		product.LitPic, _ = result["litpic"].(string)
-		
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			product.PubDate = pubdate
-		}
-		if senddate, ok := result["senddate"].(time.Time); ok {
-			product.SendDate = senddate
-		}
-		
-		product.Keywords, _ = result["keywords"].(string)
-		product.Description, _ = result["description"].(string)
-		product.Filename, _ = result["filename"].(string)
-		
-		// 处理整数字段
-		if istop, ok := result["istop"].(int64); ok {
-			product.IsTop = int(istop)
-		}
-		if isrecommend, ok := result["isrecommend"].(int64); ok {
-			product.IsRecommend = int(isrecommend)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			product.IsHot = int(ishot)
-		}
-		if arcrank, ok := result["arcrank"].(int64); ok {
-			product.ArcRank = int(arcrank)
-		}
-		if click, ok := result["click"].(int64); ok {
-			product.Click = int(click)
-		}
-		
-		product.TypeName, _ = result["typename"].(string)
-		product.TypeDir, _ = result["typedir"].(string)
-		
-		// 处理产品特有字段
-		product.ProductName, _ = result["productname"].(string)
-		product.ProductSN, _ = result["productsn"].(string)
-		
-		// 处理价格
-		if price, ok := result["price"].(float64); ok {
-			product.Price = price
-		} else if priceStr, ok := result["price"].(string); ok {
-			fmt.Sscanf(priceStr, "%f", &product.Price)
-		}
-		
-		if oldprice, ok := result["oldprice"].(float64); ok {
-			product.OldPrice = oldprice
-		} else if oldpriceStr, ok := result["oldprice"].(string); ok {
-			fmt.Sscanf(oldpriceStr, "%f", &product.OldPrice)
-		}
-		
-		products = append(products, product)
-	}
-	
 	return products, total, nil
 }
 
@@ -480,17 +342,17 @@ func (m *ProductModel) getProductImages(id int64) ([]string, error) {
 	qb.OrderBy("id ASC")
 	
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	var rows []struct {
+		Image string `db:"image"`
+	}
+	if err := qb.GetInto(&rows); err != nil {
 		return nil, err
 	}
 	
 	// 提取图片URL
-	images := make([]string, 0, len(results))
-	for _, result := range results {
-		if image, ok := result["image"].(string); ok {
-			images = append(images, image)
-		}
+	images := make([]string, 0, len(rows))
+	for _, row := range rows {
+		images = append(images, row.Image)
 	}
 	
 	return images, nil
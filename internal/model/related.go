@@ -0,0 +1,88 @@
+package model
+
+import (
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// ArticleRelation 文章相关度，由后台任务根据标题、标签和正文计算
+type ArticleRelation struct {
+	AID      int64   `json:"aid" db:"aid"`           // 文章ID
+	RelID    int64   `json:"relid" db:"relid"`       // 相关文章ID
+	Score    float64 `json:"score" db:"score"`       // 相似度，0到1
+	SortRank int     `json:"sortrank" db:"sortrank"` // 相似度排名，从1开始
+}
+
+// ArticleRelationModel 文章相关度模型
+type ArticleRelationModel struct {
+	db *database.DB
+}
+
+// NewArticleRelationModel 创建文章相关度模型
+func NewArticleRelationModel(db *database.DB) *ArticleRelationModel {
+	return &ArticleRelationModel{
+		db: db,
+	}
+}
+
+// GetByAID 获取文章的相关文章记录，按排名排序
+func (m *ArticleRelationModel) GetByAID(aid int64, limit int) ([]*ArticleRelation, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "arcrelated")
+	qb.Select("aid", "relid", "score", "sortrank")
+	qb.Where("aid = ?", aid)
+	qb.OrderBy("sortrank ASC")
+	if limit > 0 {
+		qb.Limit(limit)
+	}
+
+	// 执行查询
+	relations := make([]*ArticleRelation, 0)
+	if err := qb.GetInto(&relations); err != nil {
+		logger.Error("获取相关文章记录失败", "aid", aid, "error", err)
+		return nil, err
+	}
+
+	return relations, nil
+}
+
+// ReplaceAll 用新的计算结果替换全部相关文章记录
+func (m *ArticleRelationModel) ReplaceAll(relations []*ArticleRelation) error {
+	rows := make([]map[string]interface{}, 0, len(relations))
+	for _, r := range relations {
+		rows = append(rows, map[string]interface{}{
+			"aid":      r.AID,
+			"relid":    r.RelID,
+			"score":    r.Score,
+			"sortrank": r.SortRank,
+		})
+	}
+
+	err := m.db.WithTx(func(tx *database.Tx) error {
+		if _, err := tx.DB().Exec("DELETE FROM " + m.db.TableName("arcrelated")); err != nil {
+			return err
+		}
+		_, err := database.NewQueryBuilder(tx.DB(), "arcrelated").InsertBatch(rows)
+		return err
+	})
+	if err != nil {
+		logger.Error("保存相关文章记录失败", "count", len(relations), "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByAID 删除文章作为主文章或相关文章的全部记录
+func (m *ArticleRelationModel) DeleteByAID(aid int64) error {
+	_, err := m.db.Exec(
+		"DELETE FROM "+m.db.TableName("arcrelated")+" WHERE aid = ? OR relid = ?",
+		aid, aid,
+	)
+	if err != nil {
+		logger.Error("删除相关文章记录失败", "aid", aid, "error", err)
+		return err
+	}
+
+	return nil
+}
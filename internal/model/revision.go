@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// ArticleRevision 文章修订版本
+type ArticleRevision struct {
+	ID        int64     `json:"id" db:"id"`
+	AID       int64     `json:"aid" db:"aid"`             // 文章ID
+	Title     string    `json:"title" db:"title"`         // 标题
+	Body      string    `json:"body" db:"body"`           // 内容，Markdown文章为Markdown原文
+	Format    string    `json:"format" db:"format"`       // 内容格式：html或markdown
+	Keywords  string    `json:"keywords" db:"keywords"`   // 关键词
+	Flag      string    `json:"flag" db:"flag"`           // 属性：c置顶 h推荐 p热门
+	AdminID   int64     `json:"adminid" db:"adminid"`     // 编辑的管理员ID
+	MemberID  int64     `json:"mid" db:"mid"`             // 编辑的会员ID（API修改）
+	AdminName string    `json:"adminname" db:"adminname"` // 管理员用户名
+	Note      string    `json:"note" db:"note"`           // 说明
+	Dateline  time.Time `json:"dateline" db:"dateline"`   // 保存时间
+}
+
+// Flags 解析flag字段，返回置顶、推荐、热门属性
+func (r *ArticleRevision) Flags() (isTop, isRecommend, isHot int) {
+	return parseFlags(r.Flag)
+}
+
+// ArticleRevisionModel 文章修订版本模型
+type ArticleRevisionModel struct {
+	db *database.DB
+}
+
+// NewArticleRevisionModel 创建文章修订版本模型
+func NewArticleRevisionModel(db *database.DB) *ArticleRevisionModel {
+	return &ArticleRevisionModel{
+		db: db,
+	}
+}
+
+// GetByID 根据ID获取修订版本
+func (m *ArticleRevisionModel) GetByID(id int64) (*ArticleRevision, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "arcrevision")
+	qb.Select("r.id", "r.aid", "r.title", "r.body", "r.format", "r.keywords", "r.flag", "r.adminid", "r.mid", "r.note", "r.dateline", "ad.username AS adminname")
+	qb.From(m.db.TableName("arcrevision") + " AS r")
+	qb.LeftJoin(m.db.TableName("admin")+" AS ad", "r.adminid = ad.id")
+	qb.Where("r.id = ?", id)
+
+	// 执行查询
+	revision := &ArticleRevision{}
+	found, err := qb.FirstInto(revision)
+	if err != nil {
+		logger.Error("获取修订版本失败", "id", id, "error", err)
+		return nil, err
+	}
+
+	// 检查结果
+	if !found {
+		return nil, fmt.Errorf("修订版本不存在: %d", id)
+	}
+
+	return revision, nil
+}
+
+// GetListByAID 获取文章的修订版本，按时间倒序，不含内容
+func (m *ArticleRevisionModel) GetListByAID(aid int64) ([]*ArticleRevision, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "arcrevision")
+	qb.Select("r.id", "r.aid", "r.title", "r.format", "r.keywords", "r.flag", "r.adminid", "r.mid", "r.note", "r.dateline", "ad.username AS adminname")
+	qb.From(m.db.TableName("arcrevision") + " AS r")
+	qb.LeftJoin(m.db.TableName("admin")+" AS ad", "r.adminid = ad.id")
+	qb.Where("r.aid = ?", aid)
+	qb.OrderBy("r.id DESC")
+
+	// 执行查询
+	revisions := make([]*ArticleRevision, 0)
+	if err := qb.GetInto(&revisions); err != nil {
+		logger.Error("获取文章修订版本失败", "aid", aid, "error", err)
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// CountByAID 获取文章的修订版本数
+func (m *ArticleRevisionModel) CountByAID(aid int64) (int, error) {
+	qb := database.NewQueryBuilder(m.db, "arcrevision")
+	qb.Where("aid = ?", aid)
+	return qb.Count()
+}
+
+// Create 保存修订版本
+func (m *ArticleRevisionModel) Create(revision *ArticleRevision) (int64, error) {
+	if revision.Dateline.IsZero() {
+		revision.Dateline = time.Now()
+	}
+
+	// 执行插入
+	result, err := m.db.Exec(
+		"INSERT INTO "+m.db.TableName("arcrevision")+" (aid, title, body, format, keywords, flag, adminid, mid, note, dateline) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		revision.AID, revision.Title, revision.Body, NormalizeBodyFormat(revision.Format), revision.Keywords, revision.Flag, revision.AdminID, revision.MemberID, revision.Note, revision.Dateline.Unix(),
+	)
+	if err != nil {
+		logger.Error("保存修订版本失败", "aid", revision.AID, "error", err)
+		return 0, err
+	}
+
+	// 获取插入ID
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("获取插入ID失败", "error", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// DeleteByAID 删除文章的所有修订版本
+func (m *ArticleRevisionModel) DeleteByAID(aid int64) error {
+	_, err := m.db.Exec("DELETE FROM "+m.db.TableName("arcrevision")+" WHERE aid = ?", aid)
+	if err != nil {
+		logger.Error("删除文章修订版本失败", "aid", aid, "error", err)
+		return err
+	}
+
+	return nil
+}
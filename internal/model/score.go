@@ -10,27 +10,27 @@ import (
 
 // ScoreRule 积分规则
 type ScoreRule struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`        // 规则名称
-	Code        string    `json:"code"`        // 规则代码
-	Score       int       `json:"score"`       // 积分值
-	MaxTimes    int       `json:"maxtimes"`    // 最大次数，0表示不限制
-	CycleType   int       `json:"cycletype"`   // 周期类型：0不限，1每天，2每周，3每月，4每年
-	Status      int       `json:"status"`      // 状态：0禁用，1启用
-	Description string    `json:"description"` // 描述
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`               // 规则名称
+	Code        string    `json:"code" db:"code"`               // 规则代码
+	Score       int       `json:"score" db:"score"`             // 积分值
+	MaxTimes    int       `json:"maxtimes" db:"maxtimes"`       // 最大次数，0表示不限制
+	CycleType   int       `json:"cycletype" db:"cycletype"`     // 周期类型：0不限，1每天，2每周，3每月，4每年
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	Description string    `json:"description" db:"description"` // 描述
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // ScoreLog 积分日志
 type ScoreLog struct {
-	ID         int64     `json:"id"`
-	MemberID   int64     `json:"memberid"`   // 会员ID
-	RuleID     int64     `json:"ruleid"`     // 规则ID
-	Score      int       `json:"score"`      // 积分值
-	Remark     string    `json:"remark"`     // 备注
-	IP         string    `json:"ip"`         // IP地址
-	CreateTime time.Time `json:"createtime"` // 创建时间
+	ID         int64     `json:"id" db:"id"`
+	MemberID   int64     `json:"memberid" db:"memberid"`     // 会员ID
+	RuleID     int64     `json:"ruleid" db:"ruleid"`         // 规则ID
+	Score      int       `json:"score" db:"score"`           // 积分值
+	Remark     string    `json:"remark" db:"remark"`         // 备注
+	IP         string    `json:"ip" db:"ip"`                 // IP地址
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
 }
 
 // ScoreRuleModel 积分规则模型
@@ -52,30 +52,18 @@ func (m *ScoreRuleModel) GetByID(id int64) (*ScoreRule, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	rule := &ScoreRule{}
+	found, err := qb.FirstInto(rule)
 	if err != nil {
 		logger.Error("获取积分规则失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("score rule not found: %d", id)
 	}
 
-	// 转换为积分规则
-	rule := &ScoreRule{}
-	rule.ID, _ = result["id"].(int64)
-	rule.Name, _ = result["name"].(string)
-	rule.Code, _ = result["code"].(string)
-	rule.Score, _ = result["score"].(int)
-	rule.MaxTimes, _ = result["maxtimes"].(int)
-	rule.CycleType, _ = result["cycletype"].(int)
-	rule.Status, _ = result["status"].(int)
-	rule.Description, _ = result["description"].(string)
-	rule.CreateTime, _ = result["createtime"].(time.Time)
-	rule.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return rule, nil
 }
 
@@ -86,30 +74,18 @@ func (m *ScoreRuleModel) GetByCode(code string) (*ScoreRule, error) {
 	qb.Where("code = ?", code)
 
 	// 执行查询
-	result, err := qb.First()
+	rule := &ScoreRule{}
+	found, err := qb.FirstInto(rule)
 	if err != nil {
 		logger.Error("获取积分规则失败", "code", code, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("score rule not found: %s", code)
 	}
 
-	// 转换为积分规则
-	rule := &ScoreRule{}
-	rule.ID, _ = result["id"].(int64)
-	rule.Name, _ = result["name"].(string)
-	rule.Code, _ = result["code"].(string)
-	rule.Score, _ = result["score"].(int)
-	rule.MaxTimes, _ = result["maxtimes"].(int)
-	rule.CycleType, _ = result["cycletype"].(int)
-	rule.Status, _ = result["status"].(int)
-	rule.Description, _ = result["description"].(string)
-	rule.CreateTime, _ = result["createtime"].(time.Time)
-	rule.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return rule, nil
 }
 
@@ -123,29 +99,12 @@ func (m *ScoreRuleModel) GetAll(status int) ([]*ScoreRule, error) {
 	qb.OrderBy("id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	rules := make([]*ScoreRule, 0)
+	if err := qb.GetInto(&rules); err != nil {
 		logger.Error("获取所有积分规则失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为积分规则列表
-	rules := make([]*ScoreRule, 0, len(results))
-	for _, result := range results {
-		rule := &ScoreRule{}
-		rule.ID, _ = result["id"].(int64)
-		rule.Name, _ = result["name"].(string)
-		rule.Code, _ = result["code"].(string)
-		rule.Score, _ = result["score"].(int)
-		rule.MaxTimes, _ = result["maxtimes"].(int)
-		rule.CycleType, _ = result["cycletype"].(int)
-		rule.Status, _ = result["status"].(int)
-		rule.Description, _ = result["description"].(string)
-		rule.CreateTime, _ = result["createtime"].(time.Time)
-		rule.UpdateTime, _ = result["updatetime"].(time.Time)
-		rules = append(rules, rule)
-	}
-
 	return rules, nil
 }
 
@@ -240,27 +199,18 @@ func (m *ScoreLogModel) GetByID(id int64) (*ScoreLog, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	log := &ScoreLog{}
+	found, err := qb.FirstInto(log)
 	if err != nil {
 		logger.Error("获取积分日志失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("score log not found: %d", id)
 	}
 
-	// 转换为积分日志
-	log := &ScoreLog{}
-	log.ID, _ = result["id"].(int64)
-	log.MemberID, _ = result["memberid"].(int64)
-	log.RuleID, _ = result["ruleid"].(int64)
-	log.Score, _ = result["score"].(int)
-	log.Remark, _ = result["remark"].(string)
-	log.IP, _ = result["ip"].(string)
-	log.CreateTime, _ = result["createtime"].(time.Time)
-
 	return log, nil
 }
 
@@ -274,8 +224,8 @@ func (m *ScoreLogModel) GetByMemberID(memberID int64, page, pageSize int) ([]*Sc
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	logs := make([]*ScoreLog, 0)
+	if err := qb.GetInto(&logs); err != nil {
 		logger.Error("获取积分日志失败", "memberid", memberID, "error", err)
 		return nil, 0, err
 	}
@@ -287,20 +237,6 @@ func (m *ScoreLogModel) GetByMemberID(memberID int64, page, pageSize int) ([]*Sc
 		return nil, 0, err
 	}
 
-	// 转换为积分日志列表
-	logs := make([]*ScoreLog, 0, len(results))
-	for _, result := range results {
-		log := &ScoreLog{}
-		log.ID, _ = result["id"].(int64)
-		log.MemberID, _ = result["memberid"].(int64)
-		log.RuleID, _ = result["ruleid"].(int64)
-		log.Score, _ = result["score"].(int)
-		log.Remark, _ = result["remark"].(string)
-		log.IP, _ = result["ip"].(string)
-		log.CreateTime, _ = result["createtime"].(time.Time)
-		logs = append(logs, log)
-	}
-
 	return logs, total, nil
 }
 
@@ -314,8 +250,8 @@ func (m *ScoreLogModel) GetByRuleID(ruleID int64, page, pageSize int) ([]*ScoreL
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	logs := make([]*ScoreLog, 0)
+	if err := qb.GetInto(&logs); err != nil {
 		logger.Error("获取积分日志失败", "ruleid", ruleID, "error", err)
 		return nil, 0, err
 	}
@@ -327,20 +263,6 @@ func (m *ScoreLogModel) GetByRuleID(ruleID int64, page, pageSize int) ([]*ScoreL
 		return nil, 0, err
 	}
 
-	// 转换为积分日志列表
-	logs := make([]*ScoreLog, 0, len(results))
-	for _, result := range results {
-		log := &ScoreLog{}
-		log.ID, _ = result["id"].(int64)
-		log.MemberID, _ = result["memberid"].(int64)
-		log.RuleID, _ = result["ruleid"].(int64)
-		log.Score, _ = result["score"].(int)
-		log.Remark, _ = result["remark"].(string)
-		log.IP, _ = result["ip"].(string)
-		log.CreateTime, _ = result["createtime"].(time.Time)
-		logs = append(logs, log)
-	}
-
 	return logs, total, nil
 }
 
@@ -1,7 +1,6 @@
 package model
 
 import (
-	"fmt"
 	"time"
 
 	"aq3cms/pkg/database"
@@ -113,38 +112,15 @@ func (m *SearchLogModel) GetSearchTrend(days int) (map[string]int, error) {
 
 	// 构建查询
 	sql := "SELECT DATE(searchtime) as date, COUNT(*) as count FROM " + m.db.TableName("search_log") + " WHERE searchtime >= ? GROUP BY DATE(searchtime)"
-	results, err := m.db.Query(sql, startDate)
-	if err != nil {
+	var rows []dayCount
+	if err := m.db.QueryInto(&rows, sql, startDate); err != nil {
 		logger.Error("获取搜索趋势失败", "error", err)
 		return trend, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		date, ok := row["date"].(string)
-		if !ok {
-			logger.Error("日期类型转换失败", "date", row["date"])
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
-		trend[date] = int(count)
+	for _, row := range rows {
+		trend[row.Date.Format("2006-01-02")] = row.Count
 	}
 
 	return trend, nil
@@ -10,27 +10,27 @@ import (
 
 // SEORule SEO规则
 type SEORule struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`        // 规则名称
-	Type        int       `json:"type"`        // 类型：0首页，1栏目页，2文章页，3标签页，4自定义
-	Pattern     string    `json:"pattern"`     // 匹配模式
-	Title       string    `json:"title"`       // 标题模板
-	Keywords    string    `json:"keywords"`    // 关键词模板
-	Description string    `json:"description"` // 描述模板
-	Status      int       `json:"status"`      // 状态：0禁用，1启用
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`               // 规则名称
+	Type        int       `json:"type" db:"type"`               // 类型：0首页，1栏目页，2文章页，3标签页，4自定义
+	Pattern     string    `json:"pattern" db:"pattern"`         // 匹配模式
+	Title       string    `json:"title" db:"title"`             // 标题模板
+	Keywords    string    `json:"keywords" db:"keywords"`       // 关键词模板
+	Description string    `json:"description" db:"description"` // 描述模板
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // SEOKeyword SEO关键词
 type SEOKeyword struct {
-	ID         int64     `json:"id"`
-	Keyword    string    `json:"keyword"`    // 关键词
-	URL        string    `json:"url"`        // 链接
-	Weight     int       `json:"weight"`     // 权重
-	Status     int       `json:"status"`     // 状态：0禁用，1启用
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	Keyword    string    `json:"keyword" db:"keyword"`       // 关键词
+	URL        string    `json:"url" db:"url"`               // 链接
+	Weight     int       `json:"weight" db:"weight"`         // 权重
+	Status     int       `json:"status" db:"status"`         // 状态：0禁用，1启用
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // SEORuleModel SEO规则模型
@@ -52,30 +52,18 @@ func (m *SEORuleModel) GetByID(id int64) (*SEORule, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	rule := &SEORule{}
+	found, err := qb.FirstInto(rule)
 	if err != nil {
 		logger.Error("获取SEO规则失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("seo rule not found: %d", id)
 	}
 
-	// 转换为SEO规则
-	rule := &SEORule{}
-	rule.ID, _ = result["id"].(int64)
-	rule.Name, _ = result["name"].(string)
-	rule.Type, _ = result["type"].(int)
-	rule.Pattern, _ = result["pattern"].(string)
-	rule.Title, _ = result["title"].(string)
-	rule.Keywords, _ = result["keywords"].(string)
-	rule.Description, _ = result["description"].(string)
-	rule.Status, _ = result["status"].(int)
-	rule.CreateTime, _ = result["createtime"].(time.Time)
-	rule.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return rule, nil
 }
 
@@ -87,30 +75,18 @@ func (m *SEORuleModel) GetByType(ruleType int) (*SEORule, error) {
 	qb.Where("status = ?", 1)
 
 	// 执行查询
-	result, err := qb.First()
+	rule := &SEORule{}
+	found, err := qb.FirstInto(rule)
 	if err != nil {
 		logger.Error("获取SEO规则失败", "type", ruleType, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("seo rule not found: %d", ruleType)
 	}
 
-	// 转换为SEO规则
-	rule := &SEORule{}
-	rule.ID, _ = result["id"].(int64)
-	rule.Name, _ = result["name"].(string)
-	rule.Type, _ = result["type"].(int)
-	rule.Pattern, _ = result["pattern"].(string)
-	rule.Title, _ = result["title"].(string)
-	rule.Keywords, _ = result["keywords"].(string)
-	rule.Description, _ = result["description"].(string)
-	rule.Status, _ = result["status"].(int)
-	rule.CreateTime, _ = result["createtime"].(time.Time)
-	rule.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return rule, nil
 }
 
@@ -122,30 +98,18 @@ func (m *SEORuleModel) GetByPattern(pattern string) (*SEORule, error) {
 	qb.Where("status = ?", 1)
 
 	// 执行查询
-	result, err := qb.First()
+	rule := &SEORule{}
+	found, err := qb.FirstInto(rule)
 	if err != nil {
 		logger.Error("获取SEO规则失败", "pattern", pattern, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("seo rule not found: %s", pattern)
 	}
 
-	// 转换为SEO规则
-	rule := &SEORule{}
-	rule.ID, _ = result["id"].(int64)
-	rule.Name, _ = result["name"].(string)
-	rule.Type, _ = result["type"].(int)
-	rule.Pattern, _ = result["pattern"].(string)
-	rule.Title, _ = result["title"].(string)
-	rule.Keywords, _ = result["keywords"].(string)
-	rule.Description, _ = result["description"].(string)
-	rule.Status, _ = result["status"].(int)
-	rule.CreateTime, _ = result["createtime"].(time.Time)
-	rule.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return rule, nil
 }
 
@@ -159,29 +123,12 @@ func (m *SEORuleModel) GetAll(status int) ([]*SEORule, error) {
 	qb.OrderBy("type ASC, id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	rules := make([]*SEORule, 0)
+	if err := qb.GetInto(&rules); err != nil {
 		logger.Error("获取所有SEO规则失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为SEO规则列表
-	rules := make([]*SEORule, 0, len(results))
-	for _, result := range results {
-		rule := &SEORule{}
-		rule.ID, _ = result["id"].(int64)
-		rule.Name, _ = result["name"].(string)
-		rule.Type, _ = result["type"].(int)
-		rule.Pattern, _ = result["pattern"].(string)
-		rule.Title, _ = result["title"].(string)
-		rule.Keywords, _ = result["keywords"].(string)
-		rule.Description, _ = result["description"].(string)
-		rule.Status, _ = result["status"].(int)
-		rule.CreateTime, _ = result["createtime"].(time.Time)
-		rule.UpdateTime, _ = result["updatetime"].(time.Time)
-		rules = append(rules, rule)
-	}
-
 	return rules, nil
 }
 
@@ -276,27 +223,18 @@ func (m *SEOKeywordModel) GetByID(id int64) (*SEOKeyword, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	keyword := &SEOKeyword{}
+	found, err := qb.FirstInto(keyword)
 	if err != nil {
 		logger.Error("获取SEO关键词失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("seo keyword not found: %d", id)
 	}
 
-	// 转换为SEO关键词
-	keyword := &SEOKeyword{}
-	keyword.ID, _ = result["id"].(int64)
-	keyword.Keyword, _ = result["keyword"].(string)
-	keyword.URL, _ = result["url"].(string)
-	keyword.Weight, _ = result["weight"].(int)
-	keyword.Status, _ = result["status"].(int)
-	keyword.CreateTime, _ = result["createtime"].(time.Time)
-	keyword.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return keyword, nil
 }
 
@@ -308,27 +246,18 @@ func (m *SEOKeywordModel) GetByKeyword(keyword string) (*SEOKeyword, error) {
 	qb.Where("status = ?", 1)
 
 	// 执行查询
-	result, err := qb.First()
+	seoKeyword := &SEOKeyword{}
+	found, err := qb.FirstInto(seoKeyword)
 	if err != nil {
 		logger.Error("获取SEO关键词失败", "keyword", keyword, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("seo keyword not found: %s", keyword)
 	}
 
-	// 转换为SEO关键词
-	seoKeyword := &SEOKeyword{}
-	seoKeyword.ID, _ = result["id"].(int64)
-	seoKeyword.Keyword, _ = result["keyword"].(string)
-	seoKeyword.URL, _ = result["url"].(string)
-	seoKeyword.Weight, _ = result["weight"].(int)
-	seoKeyword.Status, _ = result["status"].(int)
-	seoKeyword.CreateTime, _ = result["createtime"].(time.Time)
-	seoKeyword.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return seoKeyword, nil
 }
 
@@ -344,8 +273,8 @@ func (m *SEOKeywordModel) GetAll(status int, page, pageSize int) ([]*SEOKeyword,
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	keywords := make([]*SEOKeyword, 0)
+	if err := qb.GetInto(&keywords); err != nil {
 		logger.Error("获取所有SEO关键词失败", "error", err)
 		return nil, 0, err
 	}
@@ -357,20 +286,6 @@ func (m *SEOKeywordModel) GetAll(status int, page, pageSize int) ([]*SEOKeyword,
 		return nil, 0, err
 	}
 
-	// 转换为SEO关键词列表
-	keywords := make([]*SEOKeyword, 0, len(results))
-	for _, result := range results {
-		keyword := &SEOKeyword{}
-		keyword.ID, _ = result["id"].(int64)
-		keyword.Keyword, _ = result["keyword"].(string)
-		keyword.URL, _ = result["url"].(string)
-		keyword.Weight, _ = result["weight"].(int)
-		keyword.Status, _ = result["status"].(int)
-		keyword.CreateTime, _ = result["createtime"].(time.Time)
-		keyword.UpdateTime, _ = result["updatetime"].(time.Time)
-		keywords = append(keywords, keyword)
-	}
-
 	return keywords, total, nil
 }
 
@@ -10,22 +10,22 @@ import (
 
 // Special 专题模型
 type Special struct {
-	ID          int64     `json:"id"`
-	Title       string    `json:"title"`       // 专题标题
-	Typeid      int64     `json:"typeid"`      // 栏目ID
-	Note        string    `json:"note"`        // 专题描述
-	Pic         string    `json:"pic"`         // 专题图片
-	PubDate     time.Time `json:"pubdate"`     // 发布时间
-	LastUpdate  time.Time `json:"lastupdate"`  // 最后更新时间
-	IsHot       int       `json:"ishot"`       // 是否热门
-	Click       int       `json:"click"`       // 点击量
-	Template    string    `json:"template"`    // 专题模板
-	TemplateList string   `json:"templatelist"` // 列表模板
-	Filename    string    `json:"filename"`    // 文件名
-	Status      int       `json:"status"`      // 状态
-	Keywords    string    `json:"keywords"`    // 关键词
-	Description string    `json:"description"` // 描述
-	Content     string    `json:"content"`     // 专题内容
+	ID           int64     `json:"id" db:"id"`
+	Title        string    `json:"title" db:"title"`               // 专题标题
+	Typeid       int64     `json:"typeid" db:"typeid"`             // 栏目ID
+	Note         string    `json:"note" db:"note"`                 // 专题描述
+	Pic          string    `json:"pic" db:"pic"`                   // 专题图片
+	PubDate      time.Time `json:"pubdate" db:"pubdate"`           // 发布时间
+	LastUpdate   time.Time `json:"lastupdate" db:"lastupdate"`     // 最后更新时间
+	IsHot        int       `json:"ishot" db:"ishot"`               // 是否热门
+	Click        int       `json:"click" db:"click"`               // 点击量
+	Template     string    `json:"template" db:"template"`         // 专题模板
+	TemplateList string    `json:"templatelist" db:"templatelist"` // 列表模板
+	Filename     string    `json:"filename" db:"filename"`         // 文件名
+	Status       int       `json:"status" db:"status"`             // 状态
+	Keywords     string    `json:"keywords" db:"keywords"`         // 关键词
+	Description  string    `json:"description" db:"description"`   // 描述
+	Content      string    `json:"content" db:"content"`           // 专题内容
 }
 
 // SpecialModel 专题模型操作
@@ -48,53 +48,17 @@ func (m *SpecialModel) GetByID(id int64) (*Special, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	special := &Special{}
+	found, err := qb.FirstInto(special)
 	if err != nil {
 		logger.Error("查询专题失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("专题不存在")
 	}
 
-	// 转换为专题对象
-	special := &Special{}
-	special.ID, _ = result["id"].(int64)
-	special.Title, _ = result["title"].(string)
-	special.Typeid, _ = result["typeid"].(int64)
-	special.Note, _ = result["note"].(string)
-	special.Pic, _ = result["pic"].(string)
-
-	// 处理日期
-	if pubdate, ok := result["pubdate"].(time.Time); ok {
-		special.PubDate = pubdate
-	}
-	if lastupdate, ok := result["lastupdate"].(time.Time); ok {
-		special.LastUpdate = lastupdate
-	}
-
-	// 处理整数字段
-	if ishot, ok := result["ishot"].(int64); ok {
-		special.IsHot = int(ishot)
-	}
-	if click, ok := result["click"].(int64); ok {
-		special.Click = int(click)
-	}
-
-	special.Template, _ = result["template"].(string)
-	special.TemplateList, _ = result["templatelist"].(string)
-	special.Filename, _ = result["filename"].(string)
-
-	// 处理整数字段
-	if status, ok := result["status"].(int64); ok {
-		special.Status = int(status)
-	}
-
-	special.Keywords, _ = result["keywords"].(string)
-	special.Description, _ = result["description"].(string)
-	special.Content, _ = result["content"].(string)
-
 	return special, nil
 }
 
@@ -106,53 +70,17 @@ func (m *SpecialModel) GetByFilename(filename string) (*Special, error) {
 	qb.Where("filename = ?", filename)
 
 	// 执行查询
-	result, err := qb.First()
+	special := &Special{}
+	found, err := qb.FirstInto(special)
 	if err != nil {
 		logger.Error("查询专题失败", "filename", filename, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("专题不存在")
 	}
 
-	// 转换为专题对象
-	special := &Special{}
-	special.ID, _ = result["id"].(int64)
-	special.Title, _ = result["title"].(string)
-	special.Typeid, _ = result["typeid"].(int64)
-	special.Note, _ = result["note"].(string)
-	special.Pic, _ = result["pic"].(string)
-
-	// 处理日期
-	if pubdate, ok := result["pubdate"].(time.Time); ok {
-		special.PubDate = pubdate
-	}
-	if lastupdate, ok := result["lastupdate"].(time.Time); ok {
-		special.LastUpdate = lastupdate
-	}
-
-	// 处理整数字段
-	if ishot, ok := result["ishot"].(int64); ok {
-		special.IsHot = int(ishot)
-	}
-	if click, ok := result["click"].(int64); ok {
-		special.Click = int(click)
-	}
-
-	special.Template, _ = result["template"].(string)
-	special.TemplateList, _ = result["templatelist"].(string)
-	special.Filename, _ = result["filename"].(string)
-
-	// 处理整数字段
-	if status, ok := result["status"].(int64); ok {
-		special.Status = int(status)
-	}
-
-	special.Keywords, _ = result["keywords"].(string)
-	special.Description, _ = result["description"].(string)
-	special.Content, _ = result["content"].(string)
-
 	return special, nil
 }
 
@@ -164,55 +92,12 @@ func (m *SpecialModel) GetAll() ([]*Special, error) {
 	qb.Where("status = 1")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	specials := make([]*Special, 0)
+	if err := qb.GetInto(&specials); err != nil {
 		logger.Error("查询专题列表失败", "error", err)
 		return nil, err
 	}
 
-
-
-	// 转换为专题对象
-	specials := make([]*Special, 0, len(results))
-	for _, result := range results {
-		special := &Special{}
-		special.ID, _ = result["id"].(int64)
-		special.Title, _ = result["title"].(string)
-		special.Typeid, _ = result["typeid"].(int64)
-		special.Note, _ = result["note"].(string)
-		special.Pic, _ = result["pic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			special.PubDate = pubdate
-		}
-		if lastupdate, ok := result["lastupdate"].(time.Time); ok {
-			special.LastUpdate = lastupdate
-		}
-
-		// 处理整数字段
-		if ishot, ok := result["ishot"].(int64); ok {
-			special.IsHot = int(ishot)
-		}
-		if click, ok := result["click"].(int64); ok {
-			special.Click = int(click)
-		}
-
-		special.Template, _ = result["template"].(string)
-		special.TemplateList, _ = result["templatelist"].(string)
-		special.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if status, ok := result["status"].(int64); ok {
-			special.Status = int(status)
-		}
-
-		special.Keywords, _ = result["keywords"].(string)
-		special.Description, _ = result["description"].(string)
-
-		specials = append(specials, special)
-	}
-
 	return specials, nil
 }
 
@@ -227,53 +112,12 @@ func (m *SpecialModel) GetHotSpecials(limit int) ([]*Special, error) {
 	qb.Limit(limit)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	specials := make([]*Special, 0)
+	if err := qb.GetInto(&specials); err != nil {
 		logger.Error("查询热门专题失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为专题对象
-	specials := make([]*Special, 0, len(results))
-	for _, result := range results {
-		special := &Special{}
-		special.ID, _ = result["id"].(int64)
-		special.Title, _ = result["title"].(string)
-		special.Typeid, _ = result["typeid"].(int64)
-		special.Note, _ = result["note"].(string)
-		special.Pic, _ = result["pic"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			special.PubDate = pubdate
-		}
-		if lastupdate, ok := result["lastupdate"].(time.Time); ok {
-			special.LastUpdate = lastupdate
-		}
-
-		// 处理整数字段
-		if ishot, ok := result["ishot"].(int64); ok {
-			special.IsHot = int(ishot)
-		}
-		if click, ok := result["click"].(int64); ok {
-			special.Click = int(click)
-		}
-
-		special.Template, _ = result["template"].(string)
-		special.TemplateList, _ = result["templatelist"].(string)
-		special.Filename, _ = result["filename"].(string)
-
-		// 处理整数字段
-		if status, ok := result["status"].(int64); ok {
-			special.Status = int(status)
-		}
-
-		special.Keywords, _ = result["keywords"].(string)
-		special.Description, _ = result["description"].(string)
-
-		specials = append(specials, special)
-	}
-
 	return specials, nil
 }
 
@@ -282,7 +126,11 @@ func (m *SpecialModel) GetArticles(specialID int64, page, pageSize int) ([]*Arti
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "special_content")
 	qb.Select("sc.aid")
+	qb.From(m.db.TableName("special_content") + " AS sc")
+	qb.Join(m.db.TableName("archives")+" AS a", "sc.aid = a.id")
 	qb.Where("sc.specialid = ?", specialID)
+	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 
 	// 获取总数
 	total, err := qb.Count()
@@ -297,18 +145,18 @@ func (m *SpecialModel) GetArticles(specialID int64, page, pageSize int) ([]*Arti
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	var rows []struct {
+		AID int64 `db:"aid"`
+	}
+	if err := qb.GetInto(&rows); err != nil {
 		logger.Error("查询专题文章列表失败", "specialid", specialID, "error", err)
 		return nil, 0, err
 	}
 
 	// 提取文章ID
-	aids := make([]int64, 0, len(results))
-	for _, result := range results {
-		if aid, ok := result["aid"].(int64); ok {
-			aids = append(aids, aid)
-		}
+	aids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		aids = append(aids, row.AID)
 	}
 
 	// 如果没有文章，返回空列表
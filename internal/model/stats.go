@@ -11,23 +11,23 @@ import (
 
 // Visit 访问记录
 type Visit struct {
-	ID         int64     `json:"id"`
-	IP         string    `json:"ip"`         // IP地址
-	UserAgent  string    `json:"useragent"`  // 用户代理
-	URL        string    `json:"url"`        // 访问URL
-	Referer    string    `json:"referer"`    // 来源URL
-	MemberID   int64     `json:"memberid"`   // 会员ID，0表示游客
-	SessionID  string    `json:"sessionid"`  // 会话ID
-	CreateTime time.Time `json:"createtime"` // 创建时间
+	ID         int64     `json:"id" db:"id"`
+	IP         string    `json:"ip" db:"ip"`                 // IP地址
+	UserAgent  string    `json:"useragent" db:"useragent"`   // 用户代理
+	URL        string    `json:"url" db:"url"`               // 访问URL
+	Referer    string    `json:"referer" db:"referer"`       // 来源URL
+	MemberID   int64     `json:"memberid" db:"memberid"`     // 会员ID，0表示游客
+	SessionID  string    `json:"sessionid" db:"sessionid"`   // 会话ID
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
 }
 
 // SearchKeyword 搜索关键词
 type SearchKeyword struct {
-	ID         int64     `json:"id"`
-	Keyword    string    `json:"keyword"`    // 关键词
-	Count      int       `json:"count"`      // 搜索次数
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID         int64     `json:"id" db:"id"`
+	Keyword    string    `json:"keyword" db:"keyword"`       // 关键词
+	Count      int       `json:"count" db:"count"`           // 搜索次数
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
+	UpdateTime time.Time `json:"updatetime" db:"updatetime"` // 更新时间
 }
 
 // VisitStats 访问统计
@@ -85,28 +85,18 @@ func (m *VisitModel) GetByID(id int64) (*Visit, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	visit := &Visit{}
+	found, err := qb.FirstInto(visit)
 	if err != nil {
 		logger.Error("获取访问记录失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("visit not found: %d", id)
 	}
 
-	// 转换为访问记录
-	visit := &Visit{}
-	visit.ID, _ = result["id"].(int64)
-	visit.IP, _ = result["ip"].(string)
-	visit.UserAgent, _ = result["useragent"].(string)
-	visit.URL, _ = result["url"].(string)
-	visit.Referer, _ = result["referer"].(string)
-	visit.MemberID, _ = result["memberid"].(int64)
-	visit.SessionID, _ = result["sessionid"].(string)
-	visit.CreateTime, _ = result["createtime"].(time.Time)
-
 	return visit, nil
 }
 
@@ -121,27 +111,12 @@ func (m *VisitModel) GetBySessionID(sessionID string, limit int) ([]*Visit, erro
 	}
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	visits := make([]*Visit, 0)
+	if err := qb.GetInto(&visits); err != nil {
 		logger.Error("获取访问记录失败", "sessionid", sessionID, "error", err)
 		return nil, err
 	}
 
-	// 转换为访问记录列表
-	visits := make([]*Visit, 0, len(results))
-	for _, result := range results {
-		visit := &Visit{}
-		visit.ID, _ = result["id"].(int64)
-		visit.IP, _ = result["ip"].(string)
-		visit.UserAgent, _ = result["useragent"].(string)
-		visit.URL, _ = result["url"].(string)
-		visit.Referer, _ = result["referer"].(string)
-		visit.MemberID, _ = result["memberid"].(int64)
-		visit.SessionID, _ = result["sessionid"].(string)
-		visit.CreateTime, _ = result["createtime"].(time.Time)
-		visits = append(visits, visit)
-	}
-
 	return visits, nil
 }
 
@@ -156,27 +131,12 @@ func (m *VisitModel) GetByMemberID(memberID int64, limit int) ([]*Visit, error)
 	}
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	visits := make([]*Visit, 0)
+	if err := qb.GetInto(&visits); err != nil {
 		logger.Error("获取访问记录失败", "memberid", memberID, "error", err)
 		return nil, err
 	}
 
-	// 转换为访问记录列表
-	visits := make([]*Visit, 0, len(results))
-	for _, result := range results {
-		visit := &Visit{}
-		visit.ID, _ = result["id"].(int64)
-		visit.IP, _ = result["ip"].(string)
-		visit.UserAgent, _ = result["useragent"].(string)
-		visit.URL, _ = result["url"].(string)
-		visit.Referer, _ = result["referer"].(string)
-		visit.MemberID, _ = result["memberid"].(int64)
-		visit.SessionID, _ = result["sessionid"].(string)
-		visit.CreateTime, _ = result["createtime"].(time.Time)
-		visits = append(visits, visit)
-	}
-
 	return visits, nil
 }
 
@@ -191,27 +151,12 @@ func (m *VisitModel) GetByDate(date string, limit int) ([]*Visit, error) {
 	}
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	visits := make([]*Visit, 0)
+	if err := qb.GetInto(&visits); err != nil {
 		logger.Error("获取访问记录失败", "date", date, "error", err)
 		return nil, err
 	}
 
-	// 转换为访问记录列表
-	visits := make([]*Visit, 0, len(results))
-	for _, result := range results {
-		visit := &Visit{}
-		visit.ID, _ = result["id"].(int64)
-		visit.IP, _ = result["ip"].(string)
-		visit.UserAgent, _ = result["useragent"].(string)
-		visit.URL, _ = result["url"].(string)
-		visit.Referer, _ = result["referer"].(string)
-		visit.MemberID, _ = result["memberid"].(int64)
-		visit.SessionID, _ = result["sessionid"].(string)
-		visit.CreateTime, _ = result["createtime"].(time.Time)
-		visits = append(visits, visit)
-	}
-
 	return visits, nil
 }
 
@@ -227,27 +172,12 @@ func (m *VisitModel) GetByDateRange(startDate, endDate string, limit int) ([]*Vi
 	}
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	visits := make([]*Visit, 0)
+	if err := qb.GetInto(&visits); err != nil {
 		logger.Error("获取访问记录失败", "startDate", startDate, "endDate", endDate, "error", err)
 		return nil, err
 	}
 
-	// 转换为访问记录列表
-	visits := make([]*Visit, 0, len(results))
-	for _, result := range results {
-		visit := &Visit{}
-		visit.ID, _ = result["id"].(int64)
-		visit.IP, _ = result["ip"].(string)
-		visit.UserAgent, _ = result["useragent"].(string)
-		visit.URL, _ = result["url"].(string)
-		visit.Referer, _ = result["referer"].(string)
-		visit.MemberID, _ = result["memberid"].(int64)
-		visit.SessionID, _ = result["sessionid"].(string)
-		visit.CreateTime, _ = result["createtime"].(time.Time)
-		visits = append(visits, visit)
-	}
-
 	return visits, nil
 }
 
@@ -341,32 +271,28 @@ func (m *VisitModel) GetStats(date string) (*VisitStats, error) {
 		}
 	}
 
-	// 获取平均访问时长
-	avgTimeQuery := "SELECT AVG(TIMESTAMPDIFF(SECOND, MIN(createtime), MAX(createtime))) AS avgtime FROM " + m.db.TableName("visit") + " WHERE DATE(createtime) = ? GROUP BY sessionid"
-	avgTimeResult, err := m.db.Query(avgTimeQuery, date)
-	if err != nil {
+	// 获取平均访问时长：各会话首末次访问的间隔在Go中计算，不依赖数据库的时间差函数
+	avgTimeQuery := "SELECT sessionid, MIN(createtime) AS firsttime, MAX(createtime) AS lasttime FROM " + m.db.TableName("visit") + " WHERE DATE(createtime) = ? GROUP BY sessionid"
+	var sessions []struct {
+		FirstTime time.Time `db:"firsttime"`
+		LastTime  time.Time `db:"lasttime"`
+	}
+	if err := m.db.QueryInto(&sessions, avgTimeQuery, date); err != nil {
 		logger.Error("获取平均访问时长失败", "error", err)
 		return nil, err
 	}
 
 	var avgTime int
-	if len(avgTimeResult) > 0 {
-		if avgTimeVal, ok := avgTimeResult[0]["avgtime"]; ok {
-			switch v := avgTimeVal.(type) {
-			case int:
-				avgTime = v
-			case int64:
-				avgTime = int(v)
-			case float64:
-				avgTime = int(v)
-			case string:
-				avgTime, _ = strconv.Atoi(v)
-			}
+	if len(sessions) > 0 {
+		var total time.Duration
+		for _, session := range sessions {
+			total += session.LastTime.Sub(session.FirstTime)
 		}
+		avgTime = int(total.Seconds()) / len(sessions)
 	}
 
 	// 获取跳出率
-	bounceRateQuery := "SELECT COUNT(*) * 100 / (SELECT COUNT(DISTINCT sessionid) FROM " + m.db.TableName("visit") + " WHERE DATE(createtime) = ?) AS bouncerate FROM (SELECT sessionid, COUNT(*) AS count FROM " + m.db.TableName("visit") + " WHERE DATE(createtime) = ? GROUP BY sessionid HAVING count = 1) AS t"
+	bounceRateQuery := "SELECT COUNT(*) * 100 / (SELECT COUNT(DISTINCT sessionid) FROM " + m.db.TableName("visit") + " WHERE DATE(createtime) = ?) AS bouncerate FROM (SELECT sessionid, COUNT(*) AS count FROM " + m.db.TableName("visit") + " WHERE DATE(createtime) = ? GROUP BY sessionid HAVING COUNT(*) = 1) AS t"
 	bounceRateResult, err := m.db.Query(bounceRateQuery, date, date)
 	if err != nil {
 		logger.Error("获取跳出率失败", "error", err)
@@ -449,25 +375,18 @@ func (m *SearchKeywordModel) GetByID(id int64) (*SearchKeyword, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	keyword := &SearchKeyword{}
+	found, err := qb.FirstInto(keyword)
 	if err != nil {
 		logger.Error("获取搜索关键词失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("search keyword not found: %d", id)
 	}
 
-	// 转换为搜索关键词
-	keyword := &SearchKeyword{}
-	keyword.ID, _ = result["id"].(int64)
-	keyword.Keyword, _ = result["keyword"].(string)
-	keyword.Count, _ = result["count"].(int)
-	keyword.CreateTime, _ = result["createtime"].(time.Time)
-	keyword.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return keyword, nil
 }
 
@@ -478,25 +397,18 @@ func (m *SearchKeywordModel) GetByKeyword(keyword string) (*SearchKeyword, error
 	qb.Where("keyword = ?", keyword)
 
 	// 执行查询
-	result, err := qb.First()
+	searchKeyword := &SearchKeyword{}
+	found, err := qb.FirstInto(searchKeyword)
 	if err != nil {
 		logger.Error("获取搜索关键词失败", "keyword", keyword, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("search keyword not found: %s", keyword)
 	}
 
-	// 转换为搜索关键词
-	searchKeyword := &SearchKeyword{}
-	searchKeyword.ID, _ = result["id"].(int64)
-	searchKeyword.Keyword, _ = result["keyword"].(string)
-	searchKeyword.Count, _ = result["count"].(int)
-	searchKeyword.CreateTime, _ = result["createtime"].(time.Time)
-	searchKeyword.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return searchKeyword, nil
 }
 
@@ -509,8 +421,8 @@ func (m *SearchKeywordModel) GetAll(page, pageSize int) ([]*SearchKeyword, int,
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	keywords := make([]*SearchKeyword, 0)
+	if err := qb.GetInto(&keywords); err != nil {
 		logger.Error("获取所有搜索关键词失败", "error", err)
 		return nil, 0, err
 	}
@@ -522,18 +434,6 @@ func (m *SearchKeywordModel) GetAll(page, pageSize int) ([]*SearchKeyword, int,
 		return nil, 0, err
 	}
 
-	// 转换为搜索关键词列表
-	keywords := make([]*SearchKeyword, 0, len(results))
-	for _, result := range results {
-		keyword := &SearchKeyword{}
-		keyword.ID, _ = result["id"].(int64)
-		keyword.Keyword, _ = result["keyword"].(string)
-		keyword.Count, _ = result["count"].(int)
-		keyword.CreateTime, _ = result["createtime"].(time.Time)
-		keyword.UpdateTime, _ = result["updatetime"].(time.Time)
-		keywords = append(keywords, keyword)
-	}
-
 	return keywords, total, nil
 }
 
@@ -545,24 +445,12 @@ func (m *SearchKeywordModel) GetTop(limit int) ([]*SearchKeyword, error) {
 	qb.Limit(limit)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	keywords := make([]*SearchKeyword, 0)
+	if err := qb.GetInto(&keywords); err != nil {
 		logger.Error("获取热门搜索关键词失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为搜索关键词列表
-	keywords := make([]*SearchKeyword, 0, len(results))
-	for _, result := range results {
-		keyword := &SearchKeyword{}
-		keyword.ID, _ = result["id"].(int64)
-		keyword.Keyword, _ = result["keyword"].(string)
-		keyword.Count, _ = result["count"].(int)
-		keyword.CreateTime, _ = result["createtime"].(time.Time)
-		keyword.UpdateTime, _ = result["updatetime"].(time.Time)
-		keywords = append(keywords, keyword)
-	}
-
 	return keywords, nil
 }
 
@@ -625,19 +513,21 @@ func (m *SearchKeywordModel) Delete(id int64) error {
 
 // IncrementCount 增加搜索次数
 func (m *SearchKeywordModel) IncrementCount(keyword string) error {
-	// 检查关键词是否存在
-	searchKeyword, err := m.GetByKeyword(keyword)
+	// 关键词不存在时创建，存在时累加次数
+	now := time.Now()
+	_, err := database.NewQueryBuilder(m.db, "search_keyword").Upsert(map[string]interface{}{
+		"keyword":    keyword,
+		"count":      1,
+		"createtime": now,
+		"updatetime": now,
+	}, []string{"keyword"}, map[string]interface{}{
+		"count":      database.Incr(1),
+		"updatetime": now,
+	})
 	if err != nil {
-		// 关键词不存在，创建
-		newKeyword := &SearchKeyword{
-			Keyword: keyword,
-			Count:   1,
-		}
-		_, err = m.Create(newKeyword)
+		logger.Error("更新搜索次数失败", "keyword", keyword, "error", err)
 		return err
 	}
 
-	// 关键词存在，更新
-	searchKeyword.Count++
-	return m.Update(searchKeyword)
+	return nil
 }
@@ -2,7 +2,6 @@ package model
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -12,14 +11,20 @@ import (
 
 // Tag 标签模型
 type Tag struct {
-	ID        int64     `json:"id"`
-	Tag       string    `json:"tag"`       // 标签名
-	Count     int       `json:"count"`     // 使用次数
-	Rank      int       `json:"rank"`      // 排序
-	IsHot     int       `json:"ishot"`     // 是否热门
-	AddTime   time.Time `json:"addtime"`   // 添加时间
-	LastUse   time.Time `json:"lastuse"`   // 最后使用时间
-	TagPinyin string    `json:"tagpinyin"` // 标签拼音
+	ID        int64     `json:"id" db:"id"`
+	Tag       string    `json:"tag" db:"tag"`             // 标签名
+	Count     int       `json:"count" db:"count"`         // 使用次数
+	Rank      int       `json:"rank" db:"rank"`           // 排序
+	IsHot     int       `json:"ishot" db:"ishot"`         // 是否热门
+	AddTime   time.Time `json:"addtime" db:"addtime"`     // 添加时间
+	LastUse   time.Time `json:"lastuse" db:"lastuse"`     // 最后使用时间
+	TagPinyin string    `json:"tagpinyin" db:"tagpinyin"` // 标签拼音
+}
+
+// tagListRow 标签与文章的关联记录
+type tagListRow struct {
+	AID int64  `db:"aid"`
+	Tag string `db:"tag"`
 }
 
 // TagModel 标签模型操作
@@ -42,51 +47,17 @@ func (m *TagModel) GetByID(id int64) (*Tag, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	tag := &Tag{}
+	found, err := qb.FirstInto(tag)
 	if err != nil {
 		logger.Error("查询标签失败", "id", id, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("标签不存在")
 	}
 
-	// 转换为标签对象
-	tag := &Tag{}
-
-	// 处理ID字段 - 支持多种类型
-	if id, ok := result["id"].(int64); ok {
-		tag.ID = id
-	} else if id, ok := result["id"].([]byte); ok {
-		if idStr := string(id); idStr != "" {
-			if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-				tag.ID = idInt
-			}
-		}
-	} else if id, ok := result["id"].(string); ok {
-		if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-			tag.ID = idInt
-		}
-	}
-
-	tag.Tag, _ = result["tag"].(string)
-
-	// 处理整数字段 - 支持多种类型
-	tag.Count = convertToInt(result["count"])
-	tag.Rank = convertToInt(result["rank"])
-	tag.IsHot = convertToInt(result["ishot"])
-
-	// 处理日期
-	if addTime, ok := result["addtime"].(time.Time); ok {
-		tag.AddTime = addTime
-	}
-	if lastUse, ok := result["lastuse"].(time.Time); ok {
-		tag.LastUse = lastUse
-	}
-
-	tag.TagPinyin, _ = result["tagpinyin"].(string)
-
 	return tag, nil
 }
 
@@ -98,51 +69,17 @@ func (m *TagModel) GetByName(name string) (*Tag, error) {
 	qb.Where("tag = ?", name)
 
 	// 执行查询
-	result, err := qb.First()
+	tag := &Tag{}
+	found, err := qb.FirstInto(tag)
 	if err != nil {
 		logger.Error("查询标签失败", "name", name, "error", err)
 		return nil, err
 	}
 
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("标签不存在")
 	}
 
-	// 转换为标签对象
-	tag := &Tag{}
-
-	// 处理ID字段 - 支持多种类型
-	if id, ok := result["id"].(int64); ok {
-		tag.ID = id
-	} else if id, ok := result["id"].([]byte); ok {
-		if idStr := string(id); idStr != "" {
-			if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-				tag.ID = idInt
-			}
-		}
-	} else if id, ok := result["id"].(string); ok {
-		if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-			tag.ID = idInt
-		}
-	}
-
-	tag.Tag, _ = result["tag"].(string)
-
-	// 处理整数字段 - 支持多种类型
-	tag.Count = convertToInt(result["count"])
-	tag.Rank = convertToInt(result["rank"])
-	tag.IsHot = convertToInt(result["ishot"])
-
-	// 处理日期
-	if addTime, ok := result["addtime"].(time.Time); ok {
-		tag.AddTime = addTime
-	}
-	if lastUse, ok := result["lastuse"].(time.Time); ok {
-		tag.LastUse = lastUse
-	}
-
-	tag.TagPinyin, _ = result["tagpinyin"].(string)
-
 	return tag, nil
 }
 
@@ -165,52 +102,12 @@ func (m *TagModel) GetList(page, pageSize int) ([]*Tag, int, error) {
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	tags := make([]*Tag, 0)
+	if err := qb.GetInto(&tags); err != nil {
 		logger.Error("查询标签列表失败", "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为标签对象
-	tags := make([]*Tag, 0, len(results))
-	for _, result := range results {
-		tag := &Tag{}
-
-		// 处理ID字段 - 支持多种类型
-		if id, ok := result["id"].(int64); ok {
-			tag.ID = id
-		} else if id, ok := result["id"].([]byte); ok {
-			if idStr := string(id); idStr != "" {
-				if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-					tag.ID = idInt
-				}
-			}
-		} else if id, ok := result["id"].(string); ok {
-			if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-				tag.ID = idInt
-			}
-		}
-
-		tag.Tag, _ = result["tag"].(string)
-
-		// 处理整数字段 - 支持多种类型
-		tag.Count = convertToInt(result["count"])
-		tag.Rank = convertToInt(result["rank"])
-		tag.IsHot = convertToInt(result["ishot"])
-
-		// 处理日期
-		if addTime, ok := result["addtime"].(time.Time); ok {
-			tag.AddTime = addTime
-		}
-		if lastUse, ok := result["lastuse"].(time.Time); ok {
-			tag.LastUse = lastUse
-		}
-
-		tag.TagPinyin, _ = result["tagpinyin"].(string)
-
-		tags = append(tags, tag)
-	}
-
 	return tags, total, nil
 }
 
@@ -224,52 +121,12 @@ func (m *TagModel) GetHotTags(limit int) ([]*Tag, error) {
 	qb.Limit(limit)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	tags := make([]*Tag, 0)
+	if err := qb.GetInto(&tags); err != nil {
 		logger.Error("查询热门标签失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为标签对象
-	tags := make([]*Tag, 0, len(results))
-	for _, result := range results {
-		tag := &Tag{}
-
-		// 处理ID字段 - 支持多种类型
-		if id, ok := result["id"].(int64); ok {
-			tag.ID = id
-		} else if id, ok := result["id"].([]byte); ok {
-			if idStr := string(id); idStr != "" {
-				if idInt, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-					tag.ID = idInt
-				}
-			}
-		} else if id, ok := result["id"].(string); ok {
-			if idInt, err := strconv.ParseInt(id, 10, 64); err == nil {
-				tag.ID = idInt
-			}
-		}
-
-		tag.Tag, _ = result["tag"].(string)
-
-		// 处理整数字段 - 支持多种类型
-		tag.Count = convertToInt(result["count"])
-		tag.Rank = convertToInt(result["rank"])
-		tag.IsHot = convertToInt(result["ishot"])
-
-		// 处理日期
-		if addTime, ok := result["addtime"].(time.Time); ok {
-			tag.AddTime = addTime
-		}
-		if lastUse, ok := result["lastuse"].(time.Time); ok {
-			tag.LastUse = lastUse
-		}
-
-		tag.TagPinyin, _ = result["tagpinyin"].(string)
-
-		tags = append(tags, tag)
-	}
-
 	return tags, nil
 }
 
@@ -278,7 +135,11 @@ func (m *TagModel) GetArticlesByTag(tagName string, page, pageSize int) ([]*Arti
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "taglist")
 	qb.Select("tl.aid")
+	qb.From(m.db.TableName("taglist") + " AS tl")
+	qb.Join(m.db.TableName("archives")+" AS a", "tl.aid = a.id")
 	qb.Where("tl.tag = ?", tagName)
+	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 
 	// 获取总数
 	total, err := qb.Count()
@@ -293,18 +154,16 @@ func (m *TagModel) GetArticlesByTag(tagName string, page, pageSize int) ([]*Arti
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	var rows []tagListRow
+	if err := qb.GetInto(&rows); err != nil {
 		logger.Error("查询标签文章列表失败", "tag", tagName, "error", err)
 		return nil, 0, err
 	}
 
 	// 提取文章ID
-	aids := make([]int64, 0, len(results))
-	for _, result := range results {
-		if aid, ok := result["aid"].(int64); ok {
-			aids = append(aids, aid)
-		}
+	aids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		aids = append(aids, row.AID)
 	}
 
 	// 如果没有文章，返回空列表
@@ -456,67 +315,24 @@ func (m *TagModel) Delete(id int64) error {
 
 // AddArticleTag 添加文章标签
 func (m *TagModel) AddArticleTag(aid int64, tagName string) error {
-	// 检查标签是否已存在
-	tag, err := m.GetByName(tagName)
-	if err != nil {
-		// 标签不存在，创建新标签
-		tag = &Tag{
-			Tag:     tagName,
-			Count:   1,
-			Rank:    0,
-			IsHot:   0,
-			AddTime: time.Now(),
-			LastUse: time.Now(),
-		}
-
-		_, err = m.Create(tag)
+	return m.db.WithTx(func(tx *database.Tx) error {
+		// 添加文章标签关联，已存在时忽略
+		added, err := tx.NewQueryBuilder("taglist").Upsert(map[string]interface{}{
+			"aid": aid,
+			"tag": tagName,
+		}, []string{"aid", "tag"}, map[string]interface{}{})
 		if err != nil {
-			logger.Error("创建标签失败", "tag", tagName, "error", err)
+			logger.Error("添加文章标签关联失败", "aid", aid, "tag", tagName, "error", err)
 			return err
 		}
-	} else {
-		// 标签已存在，更新使用次数
-		tag.Count++
-		tag.LastUse = time.Now()
 
-		err = m.Update(tag)
-		if err != nil {
-			logger.Error("更新标签失败", "tag", tagName, "error", err)
-			return err
+		// 关联已存在，不需要重复计数
+		if added == 0 {
+			return nil
 		}
-	}
-
-	// 检查文章标签关联是否已存在
-	qb := database.NewQueryBuilder(m.db, "taglist")
-	qb.Select("*")
-	qb.Where("aid = ?", aid)
-	qb.Where("tag = ?", tagName)
-
-	result, err := qb.First()
-	if err != nil {
-		logger.Error("查询文章标签关联失败", "aid", aid, "tag", tagName, "error", err)
-		return err
-	}
-
-	if result != nil {
-		// 关联已存在，不需要重复添加
-		return nil
-	}
-
-	// 添加文章标签关联
-	data := map[string]interface{}{
-		"aid": aid,
-		"tag": tagName,
-	}
-
-	qb = database.NewQueryBuilder(m.db, "taglist")
-	_, err = qb.Insert(data)
-	if err != nil {
-		logger.Error("添加文章标签关联失败", "aid", aid, "tag", tagName, "error", err)
-		return err
-	}
 
-	return nil
+		return touchTag(tx.DB(), tagName)
+	})
 }
 
 // GetByAID 根据文章ID获取标签列表
@@ -527,18 +343,16 @@ func (m *TagModel) GetByAID(aid int64) ([]*Tag, error) {
 	qb.Where("aid = ?", aid)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	var rows []tagListRow
+	if err := qb.GetInto(&rows); err != nil {
 		logger.Error("查询文章标签失败", "aid", aid, "error", err)
 		return nil, err
 	}
 
 	// 提取标签名
-	tagNames := make([]string, 0, len(results))
-	for _, result := range results {
-		if tagName, ok := result["tag"].(string); ok {
-			tagNames = append(tagNames, tagName)
-		}
+	tagNames := make([]string, 0, len(rows))
+	for _, row := range rows {
+		tagNames = append(tagNames, row.Tag)
 	}
 
 	// 如果没有标签，返回空列表
@@ -595,89 +409,82 @@ func (m *TagModel) RemoveArticleTag(aid int64, tagName string) error {
 	return nil
 }
 
-// UpdateArticleTags 更新文章标签
-func (m *TagModel) UpdateArticleTags(aid int64, tags string) error {
-	// 开始事务
-	tx, err := m.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// 删除文章所有标签关联
-	_, err = tx.Exec(
-		"DELETE FROM "+m.db.TableName("taglist")+" WHERE aid = ?",
-		aid,
+// ChangeArticleTagCount 调整文章所有标签的使用次数，文章移入或移出回收站时标签关联保留，只调整次数
+func (m *TagModel) ChangeArticleTagCount(aid int64, delta int) error {
+	_, err := m.db.Exec(
+		"UPDATE "+m.db.TableName("tagindex")+" SET count = CASE WHEN count + ? < 0 THEN 0 ELSE count + ? END"+
+			" WHERE tag IN (SELECT tag FROM "+m.db.TableName("taglist")+" WHERE aid = ?)",
+		delta, delta, aid,
 	)
 	if err != nil {
-		logger.Error("删除文章标签关联失败", "aid", aid, "error", err)
+		logger.Error("更新标签使用次数失败", "aid", aid, "error", err)
 		return err
 	}
 
-	// 如果标签为空，直接提交事务
-	if tags == "" {
-		if err := tx.Commit(); err != nil {
-			logger.Error("提交事务失败", "error", err)
-			return err
-		}
-		return nil
-	}
-
-	// 分割标签
-	tagList := strings.Split(tags, ",")
+	return nil
+}
 
-	// 添加新标签
-	for _, tagName := range tagList {
-		tagName = strings.TrimSpace(tagName)
-		if tagName == "" {
-			continue
+// UpdateArticleTags 更新文章标签
+func (m *TagModel) UpdateArticleTags(aid int64, tags string) error {
+	return m.db.WithTx(func(tx *database.Tx) error {
+		// 删除文章所有标签关联
+		_, err := tx.Exec(
+			"DELETE FROM "+m.db.TableName("taglist")+" WHERE aid = ?",
+			aid,
+		)
+		if err != nil {
+			logger.Error("删除文章标签关联失败", "aid", aid, "error", err)
+			return err
 		}
 
-		// 检查标签是否已存在
-		var tagID int64
-		err = tx.QueryRow(
-			"SELECT id FROM "+m.db.TableName("tagindex")+" WHERE tag = ?",
-			tagName,
-		).Scan(&tagID)
-
-		if err != nil {
-			// 标签不存在，创建新标签
-			now := time.Now().Format("2006-01-02 15:04:05")
-			_, err = tx.Exec(
-				"INSERT INTO "+m.db.TableName("tagindex")+" (tag, count, `rank`, ishot, addtime, lastuse) VALUES (?, ?, ?, ?, ?, ?)",
-				tagName, 1, 0, 0, now, now,
-			)
-			if err != nil {
-				logger.Error("创建标签失败", "tag", tagName, "error", err)
-				return err
+		// 分割标签并去重
+		seen := make(map[string]bool)
+		rows := make([]map[string]interface{}, 0)
+		for _, tagName := range strings.Split(tags, ",") {
+			tagName = strings.TrimSpace(tagName)
+			if tagName == "" || seen[tagName] {
+				continue
 			}
-		} else {
-			// 标签已存在，更新使用次数
-			_, err = tx.Exec(
-				"UPDATE "+m.db.TableName("tagindex")+" SET count = count + 1, lastuse = ? WHERE id = ?",
-				time.Now().Format("2006-01-02 15:04:05"), tagID,
-			)
-			if err != nil {
-				logger.Error("更新标签失败", "tag", tagName, "error", err)
+			seen[tagName] = true
+
+			// 创建标签或更新使用次数
+			if err := touchTag(tx.DB(), tagName); err != nil {
 				return err
 			}
+
+			rows = append(rows, map[string]interface{}{
+				"aid": aid,
+				"tag": tagName,
+			})
 		}
 
 		// 添加文章标签关联
-		_, err = tx.Exec(
-			"INSERT INTO "+m.db.TableName("taglist")+" (aid, tag) VALUES (?, ?)",
-			aid, tagName,
-		)
+		_, err = tx.NewQueryBuilder("taglist").InsertBatch(rows)
 		if err != nil {
-			logger.Error("添加文章标签关联失败", "aid", aid, "tag", tagName, "error", err)
+			logger.Error("添加文章标签关联失败", "aid", aid, "error", err)
 			return err
 		}
-	}
 
-	// 提交事务
-	if err := tx.Commit(); err != nil {
-		logger.Error("提交事务失败", "error", err)
+		return nil
+	})
+}
+
+// touchTag 标签不存在时创建，已存在时增加使用次数
+func touchTag(db *database.DB, tagName string) error {
+	now := time.Now().Format("2006-01-02 15:04:05")
+	_, err := database.NewQueryBuilder(db, "tagindex").Upsert(map[string]interface{}{
+		"tag":     tagName,
+		"count":   1,
+		"rank":    0,
+		"ishot":   0,
+		"addtime": now,
+		"lastuse": now,
+	}, []string{"tag"}, map[string]interface{}{
+		"count":   database.Incr(1),
+		"lastuse": now,
+	})
+	if err != nil {
+		logger.Error("更新标签失败", "tag", tagName, "error", err)
 		return err
 	}
 
@@ -713,18 +520,16 @@ func (m *TagModel) GetArticleTags(aid int64) ([]string, error) {
 	qb.Where("aid = ?", aid)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	var rows []tagListRow
+	if err := qb.GetInto(&rows); err != nil {
 		logger.Error("查询文章标签失败", "aid", aid, "error", err)
 		return nil, err
 	}
 
 	// 提取标签
-	tags := make([]string, 0, len(results))
-	for _, result := range results {
-		if tag, ok := result["tag"].(string); ok {
-			tags = append(tags, tag)
-		}
+	tags := make([]string, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, row.Tag)
 	}
 
 	return tags, nil
@@ -739,43 +544,12 @@ func (m *TagModel) GetNewTags(limit int) ([]*Tag, error) {
 	qb.Limit(limit, 0)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	tags := make([]*Tag, 0)
+	if err := qb.GetInto(&tags); err != nil {
 		logger.Error("获取最新标签失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为标签对象
-	tags := make([]*Tag, 0, len(results))
-	for _, result := range results {
-		tag := &Tag{}
-		tag.ID, _ = result["id"].(int64)
-		tag.Tag, _ = result["tag"].(string)
-
-		// 处理整数字段
-		if count, ok := result["count"].(int64); ok {
-			tag.Count = int(count)
-		}
-		if rank, ok := result["rank"].(int64); ok {
-			tag.Rank = int(rank)
-		}
-		if ishot, ok := result["ishot"].(int64); ok {
-			tag.IsHot = int(ishot)
-		}
-
-		// 处理日期
-		if addtime, ok := result["addtime"].(time.Time); ok {
-			tag.AddTime = addtime
-		}
-		if lastuse, ok := result["lastuse"].(time.Time); ok {
-			tag.LastUse = lastuse
-		}
-
-		tag.TagPinyin, _ = result["tagpinyin"].(string)
-
-		tags = append(tags, tag)
-	}
-
 	return tags, nil
 }
 
@@ -784,7 +558,11 @@ func (m *TagModel) GetArticles(tagName string, page, pageSize int) ([]*Article,
 	// 构建查询
 	qb := database.NewQueryBuilder(m.db, "taglist")
 	qb.Select("tl.aid")
+	qb.From(m.db.TableName("taglist") + " AS tl")
+	qb.Join(m.db.TableName("archives")+" AS a", "tl.aid = a.id")
 	qb.Where("tl.tag = ?", tagName)
+	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", ArticleStatePublished)
 
 	// 获取总数
 	total, err := qb.Count()
@@ -799,18 +577,16 @@ func (m *TagModel) GetArticles(tagName string, page, pageSize int) ([]*Article,
 	qb.Limit(pageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	var rows []tagListRow
+	if err := qb.GetInto(&rows); err != nil {
 		logger.Error("获取标签文章列表失败", "tag", tagName, "error", err)
 		return nil, 0, err
 	}
 
 	// 提取文章ID
-	aids := make([]int64, 0, len(results))
-	for _, result := range results {
-		if aid, ok := result["aid"].(int64); ok {
-			aids = append(aids, aid)
-		}
+	aids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		aids = append(aids, row.AID)
 	}
 
 	// 如果没有文章，返回空列表
@@ -840,26 +616,3 @@ func generatePinyin(text string) string {
 	// 简单实现，实际应使用拼音库
 	return strings.ToLower(text)
 }
-
-// convertToInt 将interface{}转换为int，支持多种类型
-func convertToInt(value interface{}) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case int32:
-		return int(v)
-	case []byte:
-		if str := string(v); str != "" {
-			if i, err := strconv.Atoi(str); err == nil {
-				return i
-			}
-		}
-	case string:
-		if i, err := strconv.Atoi(v); err == nil {
-			return i
-		}
-	}
-	return 0
-}
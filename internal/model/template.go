@@ -14,14 +14,14 @@ import (
 
 // Template 模板
 type Template struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`        // 模板名称
-	Path        string    `json:"path"`        // 模板路径
-	Type        int       `json:"type"`        // 类型：0系统，1用户
-	Description string    `json:"description"` // 描述
-	Status      int       `json:"status"`      // 状态：0禁用，1启用
-	CreateTime  time.Time `json:"createtime"`  // 创建时间
-	UpdateTime  time.Time `json:"updatetime"`  // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`               // 模板名称
+	Path        string    `json:"path" db:"path"`               // 模板路径
+	Type        int       `json:"type" db:"type"`               // 类型：0系统，1用户
+	Description string    `json:"description" db:"description"` // 描述
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // TemplateFile 模板文件
@@ -55,28 +55,18 @@ func (m *TemplateModel) GetByID(id int64) (*Template, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	template := &Template{}
+	found, err := qb.FirstInto(template)
 	if err != nil {
 		logger.Error("获取模板失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("template not found: %d", id)
 	}
 
-	// 转换为模板
-	template := &Template{}
-	template.ID, _ = result["id"].(int64)
-	template.Name, _ = result["name"].(string)
-	template.Path, _ = result["path"].(string)
-	template.Type, _ = result["type"].(int)
-	template.Description, _ = result["description"].(string)
-	template.Status, _ = result["status"].(int)
-	template.CreateTime, _ = result["createtime"].(time.Time)
-	template.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return template, nil
 }
 
@@ -87,28 +77,18 @@ func (m *TemplateModel) GetByPath(path string) (*Template, error) {
 	qb.Where("path = ?", path)
 
 	// 执行查询
-	result, err := qb.First()
+	template := &Template{}
+	found, err := qb.FirstInto(template)
 	if err != nil {
 		logger.Error("获取模板失败", "path", path, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("template not found: %s", path)
 	}
 
-	// 转换为模板
-	template := &Template{}
-	template.ID, _ = result["id"].(int64)
-	template.Name, _ = result["name"].(string)
-	template.Path, _ = result["path"].(string)
-	template.Type, _ = result["type"].(int)
-	template.Description, _ = result["description"].(string)
-	template.Status, _ = result["status"].(int)
-	template.CreateTime, _ = result["createtime"].(time.Time)
-	template.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return template, nil
 }
 
@@ -122,27 +102,12 @@ func (m *TemplateModel) GetAll(status int) ([]*Template, error) {
 	qb.OrderBy("id ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	templates := make([]*Template, 0)
+	if err := qb.GetInto(&templates); err != nil {
 		logger.Error("获取所有模板失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为模板列表
-	templates := make([]*Template, 0, len(results))
-	for _, result := range results {
-		template := &Template{}
-		template.ID, _ = result["id"].(int64)
-		template.Name, _ = result["name"].(string)
-		template.Path, _ = result["path"].(string)
-		template.Type, _ = result["type"].(int)
-		template.Description, _ = result["description"].(string)
-		template.Status, _ = result["status"].(int)
-		template.CreateTime, _ = result["createtime"].(time.Time)
-		template.UpdateTime, _ = result["updatetime"].(time.Time)
-		templates = append(templates, template)
-	}
-
 	return templates, nil
 }
 
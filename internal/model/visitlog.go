@@ -2,7 +2,6 @@ package model
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 
 	"aq3cms/pkg/database"
@@ -177,6 +176,18 @@ func (m *VisitLogModel) GetCategoryVisits(categoryID int64) (int, error) {
 	return count, nil
 }
 
+// visitCount 按日期、来源等分组的访问量
+type visitCount struct {
+	Name  string `db:"name"`
+	Count int    `db:"count"`
+}
+
+// dayCount 按日期分组的数量，DATE()在各数据库中可能返回字符串或时间
+type dayCount struct {
+	Date  time.Time `db:"date"`
+	Count int       `db:"count"`
+}
+
 // GetVisitTrend 获取访问趋势
 func (m *VisitLogModel) GetVisitTrend(days int) (map[string]int, error) {
 	// 初始化结果
@@ -195,38 +206,15 @@ func (m *VisitLogModel) GetVisitTrend(days int) (map[string]int, error) {
 
 	// 构建查询
 	sql := "SELECT DATE(visittime) as date, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " WHERE visittime >= ? GROUP BY DATE(visittime)"
-	results, err := m.db.Query(sql, startDate)
-	if err != nil {
+	var rows []dayCount
+	if err := m.db.QueryInto(&rows, sql, startDate); err != nil {
 		logger.Error("获取访问趋势失败", "error", err)
 		return trend, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		date, ok := row["date"].(string)
-		if !ok {
-			logger.Error("日期类型转换失败", "date", row["date"])
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
-		trend[date] = int(count)
+	for _, row := range rows {
+		trend[row.Date.Format("2006-01-02")] = row.Count
 	}
 
 	return trend, nil
@@ -238,42 +226,20 @@ func (m *VisitLogModel) GetVisitSources() (map[string]int, error) {
 	sources := make(map[string]int)
 
 	// 构建查询
-	sql := "SELECT referer, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY referer ORDER BY count DESC LIMIT 10"
-	results, err := m.db.Query(sql)
-	if err != nil {
+	sql := "SELECT referer as name, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY referer ORDER BY count DESC LIMIT 10"
+	var rows []visitCount
+	if err := m.db.QueryInto(&rows, sql); err != nil {
 		logger.Error("获取访问来源失败", "error", err)
 		return sources, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		referer, ok := row["referer"].(string)
-		if !ok {
-			logger.Error("来源类型转换失败", "referer", row["referer"])
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
+	for _, row := range rows {
+		referer := row.Name
 		if referer == "" {
 			referer = "直接访问"
 		}
-		sources[referer] = int(count)
+		sources[referer] = row.Count
 	}
 
 	return sources, nil
@@ -285,39 +251,16 @@ func (m *VisitLogModel) GetVisitDevices() (map[string]int, error) {
 	devices := make(map[string]int)
 
 	// 构建查询
-	sql := "SELECT device, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY device ORDER BY count DESC"
-	results, err := m.db.Query(sql)
-	if err != nil {
+	sql := "SELECT device as name, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY device ORDER BY count DESC"
+	var rows []visitCount
+	if err := m.db.QueryInto(&rows, sql); err != nil {
 		logger.Error("获取访问设备失败", "error", err)
 		return devices, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		device, ok := row["device"].(string)
-		if !ok {
-			logger.Error("设备类型转换失败", "device", row["device"])
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
-		devices[device] = int(count)
+	for _, row := range rows {
+		devices[row.Name] = row.Count
 	}
 
 	return devices, nil
@@ -329,39 +272,16 @@ func (m *VisitLogModel) GetVisitBrowsers() (map[string]int, error) {
 	browsers := make(map[string]int)
 
 	// 构建查询
-	sql := "SELECT browser, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY browser ORDER BY count DESC"
-	results, err := m.db.Query(sql)
-	if err != nil {
+	sql := "SELECT browser as name, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY browser ORDER BY count DESC"
+	var rows []visitCount
+	if err := m.db.QueryInto(&rows, sql); err != nil {
 		logger.Error("获取访问浏览器失败", "error", err)
 		return browsers, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		browser, ok := row["browser"].(string)
-		if !ok {
-			logger.Error("浏览器类型转换失败", "browser", row["browser"])
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
-		browsers[browser] = int(count)
+	for _, row := range rows {
+		browsers[row.Name] = row.Count
 	}
 
 	return browsers, nil
@@ -373,39 +293,16 @@ func (m *VisitLogModel) GetVisitOS() (map[string]int, error) {
 	os := make(map[string]int)
 
 	// 构建查询
-	sql := "SELECT os, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY os ORDER BY count DESC"
-	results, err := m.db.Query(sql)
-	if err != nil {
+	sql := "SELECT os as name, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY os ORDER BY count DESC"
+	var rows []visitCount
+	if err := m.db.QueryInto(&rows, sql); err != nil {
 		logger.Error("获取访问操作系统失败", "error", err)
 		return os, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		osName, ok := row["os"].(string)
-		if !ok {
-			logger.Error("操作系统类型转换失败", "os", row["os"])
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
-		os[osName] = int(count)
+	for _, row := range rows {
+		os[row.Name] = row.Count
 	}
 
 	return os, nil
@@ -417,39 +314,16 @@ func (m *VisitLogModel) GetVisitRegions() (map[string]int, error) {
 	regions := make(map[string]int)
 
 	// 构建查询
-	sql := "SELECT region, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY region ORDER BY count DESC"
-	results, err := m.db.Query(sql)
-	if err != nil {
+	sql := "SELECT region as name, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY region ORDER BY count DESC"
+	var rows []visitCount
+	if err := m.db.QueryInto(&rows, sql); err != nil {
 		logger.Error("获取访问地区失败", "error", err)
 		return regions, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		region, ok := row["region"].(string)
-		if !ok {
-			logger.Error("地区类型转换失败", "region", row["region"])
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
-		regions[region] = int(count)
+	for _, row := range rows {
+		regions[row.Name] = row.Count
 	}
 
 	return regions, nil
@@ -465,58 +339,18 @@ func (m *VisitLogModel) GetVisitHours() (map[string]int, error) {
 
 	// 构建查询
 	sql := "SELECT HOUR(visittime) as hour, COUNT(*) as count FROM " + m.db.TableName("visit_log") + " GROUP BY HOUR(visittime) ORDER BY hour"
-	results, err := m.db.Query(sql)
-	if err != nil {
+	var rows []struct {
+		Hour  int `db:"hour"`
+		Count int `db:"count"`
+	}
+	if err := m.db.QueryInto(&rows, sql); err != nil {
 		logger.Error("获取访问时段失败", "error", err)
 		return hours, err
 	}
 
 	// 处理结果
-	for _, row := range results {
-		var hour int
-		hourVal, ok := row["hour"]
-		if !ok {
-			logger.Error("小时字段不存在", "row", row)
-			continue
-		}
-
-		switch h := hourVal.(type) {
-		case int:
-			hour = h
-		case int64:
-			hour = int(h)
-		case float64:
-			hour = int(h)
-		case string:
-			var err error
-			hour, err = strconv.Atoi(h)
-			if err != nil {
-				logger.Error("小时类型转换失败", "hour", h, "error", err)
-				continue
-			}
-		default:
-			logger.Error("小时类型转换失败", "hour", hourVal)
-			continue
-		}
-
-		count, ok := row["count"].(int64)
-		if !ok {
-			// 尝试其他类型转换
-			if countStr, ok := row["count"].(string); ok {
-				var countInt int
-				_, err := fmt.Sscanf(countStr, "%d", &countInt)
-				if err != nil {
-					logger.Error("计数转换失败", "count", row["count"])
-					continue
-				}
-				count = int64(countInt)
-			} else {
-				logger.Error("计数类型转换失败", "count", row["count"])
-				continue
-			}
-		}
-
-		hours[fmt.Sprintf("%02d", hour)] = int(count)
+	for _, row := range rows {
+		hours[fmt.Sprintf("%02d", row.Hour)] = row.Count
 	}
 
 	return hours, nil
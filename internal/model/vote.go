@@ -10,36 +10,36 @@ import (
 
 // Vote 投票
 type Vote struct {
-	ID         int64     `json:"id"`
-	Title      string    `json:"title"`      // 投票标题
-	Description string   `json:"description"` // 投票描述
-	StartTime  time.Time `json:"starttime"`  // 开始时间
-	EndTime    time.Time `json:"endtime"`    // 结束时间
-	IsMulti    int       `json:"ismulti"`    // 是否多选：0否，1是
-	MaxChoices int       `json:"maxchoices"` // 最多可选几项
-	Status     int       `json:"status"`     // 状态：0禁用，1启用
-	TotalCount int       `json:"totalcount"` // 总投票数
-	CreateTime time.Time `json:"createtime"` // 创建时间
-	UpdateTime time.Time `json:"updatetime"` // 更新时间
+	ID          int64     `json:"id" db:"id"`
+	Title       string    `json:"title" db:"title"`             // 投票标题
+	Description string    `json:"description" db:"description"` // 投票描述
+	StartTime   time.Time `json:"starttime" db:"starttime"`     // 开始时间
+	EndTime     time.Time `json:"endtime" db:"endtime"`         // 结束时间
+	IsMulti     int       `json:"ismulti" db:"ismulti"`         // 是否多选：0否，1是
+	MaxChoices  int       `json:"maxchoices" db:"maxchoices"`   // 最多可选几项
+	Status      int       `json:"status" db:"status"`           // 状态：0禁用，1启用
+	TotalCount  int       `json:"totalcount" db:"totalcount"`   // 总投票数
+	CreateTime  time.Time `json:"createtime" db:"createtime"`   // 创建时间
+	UpdateTime  time.Time `json:"updatetime" db:"updatetime"`   // 更新时间
 }
 
 // VoteOption 投票选项
 type VoteOption struct {
-	ID      int64  `json:"id"`
-	VoteID  int64  `json:"voteid"`  // 投票ID
-	Title   string `json:"title"`   // 选项标题
-	Count   int    `json:"count"`   // 投票数
-	OrderID int    `json:"orderid"` // 排序ID
+	ID      int64  `json:"id" db:"id"`
+	VoteID  int64  `json:"voteid" db:"voteid"`   // 投票ID
+	Title   string `json:"title" db:"title"`     // 选项标题
+	Count   int    `json:"count" db:"count"`     // 投票数
+	OrderID int    `json:"orderid" db:"orderid"` // 排序ID
 }
 
 // VoteLog 投票日志
 type VoteLog struct {
-	ID         int64     `json:"id"`
-	VoteID     int64     `json:"voteid"`     // 投票ID
-	OptionID   int64     `json:"optionid"`   // 选项ID
-	MemberID   int64     `json:"memberid"`   // 会员ID
-	IP         string    `json:"ip"`         // IP地址
-	CreateTime time.Time `json:"createtime"` // 创建时间
+	ID         int64     `json:"id" db:"id"`
+	VoteID     int64     `json:"voteid" db:"voteid"`         // 投票ID
+	OptionID   int64     `json:"optionid" db:"optionid"`     // 选项ID
+	MemberID   int64     `json:"memberid" db:"memberid"`     // 会员ID
+	IP         string    `json:"ip" db:"ip"`                 // IP地址
+	CreateTime time.Time `json:"createtime" db:"createtime"` // 创建时间
 }
 
 // VoteModel 投票模型
@@ -61,31 +61,18 @@ func (m *VoteModel) GetByID(id int64) (*Vote, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	vote := &Vote{}
+	found, err := qb.FirstInto(vote)
 	if err != nil {
 		logger.Error("获取投票失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("vote not found: %d", id)
 	}
 
-	// 转换为投票
-	vote := &Vote{}
-	vote.ID, _ = result["id"].(int64)
-	vote.Title, _ = result["title"].(string)
-	vote.Description, _ = result["description"].(string)
-	vote.StartTime, _ = result["starttime"].(time.Time)
-	vote.EndTime, _ = result["endtime"].(time.Time)
-	vote.IsMulti, _ = result["ismulti"].(int)
-	vote.MaxChoices, _ = result["maxchoices"].(int)
-	vote.Status, _ = result["status"].(int)
-	vote.TotalCount, _ = result["totalcount"].(int)
-	vote.CreateTime, _ = result["createtime"].(time.Time)
-	vote.UpdateTime, _ = result["updatetime"].(time.Time)
-
 	return vote, nil
 }
 
@@ -98,8 +85,8 @@ func (m *VoteModel) GetAll(page, pageSize int) ([]*Vote, int, error) {
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	votes := make([]*Vote, 0)
+	if err := qb.GetInto(&votes); err != nil {
 		logger.Error("获取所有投票失败", "error", err)
 		return nil, 0, err
 	}
@@ -111,24 +98,6 @@ func (m *VoteModel) GetAll(page, pageSize int) ([]*Vote, int, error) {
 		return nil, 0, err
 	}
 
-	// 转换为投票列表
-	votes := make([]*Vote, 0, len(results))
-	for _, result := range results {
-		vote := &Vote{}
-		vote.ID, _ = result["id"].(int64)
-		vote.Title, _ = result["title"].(string)
-		vote.Description, _ = result["description"].(string)
-		vote.StartTime, _ = result["starttime"].(time.Time)
-		vote.EndTime, _ = result["endtime"].(time.Time)
-		vote.IsMulti, _ = result["ismulti"].(int)
-		vote.MaxChoices, _ = result["maxchoices"].(int)
-		vote.Status, _ = result["status"].(int)
-		vote.TotalCount, _ = result["totalcount"].(int)
-		vote.CreateTime, _ = result["createtime"].(time.Time)
-		vote.UpdateTime, _ = result["updatetime"].(time.Time)
-		votes = append(votes, vote)
-	}
-
 	return votes, total, nil
 }
 
@@ -223,25 +192,18 @@ func (m *VoteOptionModel) GetByID(id int64) (*VoteOption, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	option := &VoteOption{}
+	found, err := qb.FirstInto(option)
 	if err != nil {
 		logger.Error("获取投票选项失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("vote option not found: %d", id)
 	}
 
-	// 转换为投票选项
-	option := &VoteOption{}
-	option.ID, _ = result["id"].(int64)
-	option.VoteID, _ = result["voteid"].(int64)
-	option.Title, _ = result["title"].(string)
-	option.Count, _ = result["count"].(int)
-	option.OrderID, _ = result["orderid"].(int)
-
 	return option, nil
 }
 
@@ -253,24 +215,12 @@ func (m *VoteOptionModel) GetByVoteID(voteID int64) ([]*VoteOption, error) {
 	qb.OrderBy("orderid ASC")
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	options := make([]*VoteOption, 0)
+	if err := qb.GetInto(&options); err != nil {
 		logger.Error("获取投票选项失败", "voteid", voteID, "error", err)
 		return nil, err
 	}
 
-	// 转换为投票选项列表
-	options := make([]*VoteOption, 0, len(results))
-	for _, result := range results {
-		option := &VoteOption{}
-		option.ID, _ = result["id"].(int64)
-		option.VoteID, _ = result["voteid"].(int64)
-		option.Title, _ = result["title"].(string)
-		option.Count, _ = result["count"].(int)
-		option.OrderID, _ = result["orderid"].(int)
-		options = append(options, option)
-	}
-
 	return options, nil
 }
 
@@ -369,26 +319,18 @@ func (m *VoteLogModel) GetByID(id int64) (*VoteLog, error) {
 	qb.Where("id = ?", id)
 
 	// 执行查询
-	result, err := qb.First()
+	log := &VoteLog{}
+	found, err := qb.FirstInto(log)
 	if err != nil {
 		logger.Error("获取投票日志失败", "id", id, "error", err)
 		return nil, err
 	}
 
 	// 检查结果
-	if result == nil {
+	if !found {
 		return nil, fmt.Errorf("vote log not found: %d", id)
 	}
 
-	// 转换为投票日志
-	log := &VoteLog{}
-	log.ID, _ = result["id"].(int64)
-	log.VoteID, _ = result["voteid"].(int64)
-	log.OptionID, _ = result["optionid"].(int64)
-	log.MemberID, _ = result["memberid"].(int64)
-	log.IP, _ = result["ip"].(string)
-	log.CreateTime, _ = result["createtime"].(time.Time)
-
 	return log, nil
 }
 
@@ -402,8 +344,8 @@ func (m *VoteLogModel) GetByVoteID(voteID int64, page, pageSize int) ([]*VoteLog
 	qb.Offset((page - 1) * pageSize)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	logs := make([]*VoteLog, 0)
+	if err := qb.GetInto(&logs); err != nil {
 		logger.Error("获取投票日志失败", "voteid", voteID, "error", err)
 		return nil, 0, err
 	}
@@ -415,19 +357,6 @@ func (m *VoteLogModel) GetByVoteID(voteID int64, page, pageSize int) ([]*VoteLog
 		return nil, 0, err
 	}
 
-	// 转换为投票日志列表
-	logs := make([]*VoteLog, 0, len(results))
-	for _, result := range results {
-		log := &VoteLog{}
-		log.ID, _ = result["id"].(int64)
-		log.VoteID, _ = result["voteid"].(int64)
-		log.OptionID, _ = result["optionid"].(int64)
-		log.MemberID, _ = result["memberid"].(int64)
-		log.IP, _ = result["ip"].(string)
-		log.CreateTime, _ = result["createtime"].(time.Time)
-		logs = append(logs, log)
-	}
-
 	return logs, total, nil
 }
 
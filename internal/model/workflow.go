@@ -0,0 +1,164 @@
+package model
+
+import (
+	"time"
+
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// 文章审核流程状态
+const (
+	ArticleStateDraft     = 0 // 草稿
+	ArticleStatePending   = 1 // 待审核
+	ArticleStateApproved  = 2 // 已审核
+	ArticleStatePublished = 3 // 已发布
+	ArticleStateArchived  = 4 // 已归档
+	ArticleStateScheduled = 5 // 定时发布，发布时间到达后自动发布
+)
+
+// ArticleStateNames 文章状态名称
+var ArticleStateNames = map[int]string{
+	ArticleStateDraft:     "草稿",
+	ArticleStatePending:   "待审核",
+	ArticleStateApproved:  "已审核",
+	ArticleStatePublished: "已发布",
+	ArticleStateArchived:  "已归档",
+	ArticleStateScheduled: "定时发布",
+}
+
+// 审核、发布和归档需要的管理员等级
+const AdminRankReviewer = 5
+
+// WorkflowTransition 文章状态流转
+type WorkflowTransition struct {
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+	Name        string `json:"name"`         // 操作名称
+	Rank        int    `json:"rank"`         // 需要的管理员等级
+	NeedComment bool   `json:"need_comment"` // 是否必须填写审核意见
+}
+
+// articleTransitions 允许的状态流转
+var articleTransitions = []WorkflowTransition{
+	{From: ArticleStateDraft, To: ArticleStatePending, Name: "提交审核", Rank: 1},
+	{From: ArticleStatePending, To: ArticleStateApproved, Name: "审核通过", Rank: AdminRankReviewer},
+	{From: ArticleStatePending, To: ArticleStateDraft, Name: "退回修改", Rank: AdminRankReviewer, NeedComment: true},
+	{From: ArticleStateApproved, To: ArticleStatePublished, Name: "发布", Rank: AdminRankReviewer},
+	{From: ArticleStateApproved, To: ArticleStateDraft, Name: "退回修改", Rank: AdminRankReviewer, NeedComment: true},
+	{From: ArticleStatePublished, To: ArticleStateArchived, Name: "归档", Rank: AdminRankReviewer},
+	{From: ArticleStateArchived, To: ArticleStatePublished, Name: "重新发布", Rank: AdminRankReviewer},
+	{From: ArticleStateScheduled, To: ArticleStateApproved, Name: "取消定时", Rank: AdminRankReviewer},
+}
+
+// FindArticleTransition 查找从from到to的状态流转
+func FindArticleTransition(from, to int) (WorkflowTransition, bool) {
+	for _, t := range articleTransitions {
+		if t.From == from && t.To == to {
+			return t, true
+		}
+	}
+	return WorkflowTransition{}, false
+}
+
+// ArticleTransitions 获取指定等级的管理员在from状态下可以执行的流转
+func ArticleTransitions(from, rank int) []WorkflowTransition {
+	list := make([]WorkflowTransition, 0)
+	for _, t := range articleTransitions {
+		if t.From == from && rank >= t.Rank {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+// ArticleWorkflowLog 文章审核记录
+type ArticleWorkflowLog struct {
+	ID        int64     `json:"id" db:"id"`
+	AID       int64     `json:"aid" db:"aid"`             // 文章ID
+	FromState int       `json:"fromstate" db:"fromstate"` // 原状态
+	ToState   int       `json:"tostate" db:"tostate"`     // 新状态
+	AdminID   int64     `json:"adminid" db:"adminid"`     // 操作的管理员ID
+	AdminName string    `json:"adminname" db:"adminname"` // 管理员用户名
+	Comment   string    `json:"comment" db:"comment"`     // 审核意见
+	Dateline  time.Time `json:"dateline" db:"dateline"`   // 操作时间
+}
+
+// FromName 原状态名称
+func (l *ArticleWorkflowLog) FromName() string {
+	return ArticleStateNames[l.FromState]
+}
+
+// ToName 新状态名称
+func (l *ArticleWorkflowLog) ToName() string {
+	return ArticleStateNames[l.ToState]
+}
+
+// ArticleWorkflowModel 文章审核记录模型
+type ArticleWorkflowModel struct {
+	db *database.DB
+}
+
+// NewArticleWorkflowModel 创建文章审核记录模型
+func NewArticleWorkflowModel(db *database.DB) *ArticleWorkflowModel {
+	return &ArticleWorkflowModel{
+		db: db,
+	}
+}
+
+// GetListByAID 获取文章的审核记录，按时间倒序
+func (m *ArticleWorkflowModel) GetListByAID(aid int64) ([]*ArticleWorkflowLog, error) {
+	// 构建查询
+	qb := database.NewQueryBuilder(m.db, "arcworkflow")
+	qb.Select("w.id", "w.aid", "w.fromstate", "w.tostate", "w.adminid", "w.comment", "w.dateline", "ad.username AS adminname")
+	qb.From(m.db.TableName("arcworkflow") + " AS w")
+	qb.LeftJoin(m.db.TableName("admin")+" AS ad", "w.adminid = ad.id")
+	qb.Where("w.aid = ?", aid)
+	qb.OrderBy("w.id DESC")
+
+	// 执行查询
+	logs := make([]*ArticleWorkflowLog, 0)
+	if err := qb.GetInto(&logs); err != nil {
+		logger.Error("获取文章审核记录失败", "aid", aid, "error", err)
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// Create 保存审核记录
+func (m *ArticleWorkflowModel) Create(log *ArticleWorkflowLog) (int64, error) {
+	if log.Dateline.IsZero() {
+		log.Dateline = time.Now()
+	}
+
+	// 执行插入
+	result, err := m.db.Exec(
+		"INSERT INTO "+m.db.TableName("arcworkflow")+" (aid, fromstate, tostate, adminid, comment, dateline) VALUES (?, ?, ?, ?, ?, ?)",
+		log.AID, log.FromState, log.ToState, log.AdminID, log.Comment, log.Dateline.Unix(),
+	)
+	if err != nil {
+		logger.Error("保存审核记录失败", "aid", log.AID, "error", err)
+		return 0, err
+	}
+
+	// 获取插入ID
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("获取插入ID失败", "error", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// DeleteByAID 删除文章的所有审核记录
+func (m *ArticleWorkflowModel) DeleteByAID(aid int64) error {
+	_, err := m.db.Exec("DELETE FROM "+m.db.TableName("arcworkflow")+" WHERE aid = ?", aid)
+	if err != nil {
+		logger.Error("删除文章审核记录失败", "aid", aid, "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"time"
 
 	"aq3cms/config"
@@ -35,7 +36,7 @@ func NewArticleService(db *database.DB, cache cache.Cache, config *config.Config
 // GetArticleByID 根据ID获取文章
 func (s *ArticleService) GetArticleByID(id int64) (*model.Article, error) {
 	// 缓存键
-	cacheKey := "article:" + string(id)
+	cacheKey := "article:" + fmt.Sprint(id)
 
 	// 检查缓存
 	if s.config.Cache.EnableArticleCache {
@@ -64,7 +65,7 @@ func (s *ArticleService) GetArticleByID(id int64) (*model.Article, error) {
 // GetArticles 获取文章列表
 func (s *ArticleService) GetArticles(page, pageSize int, orderBy string) ([]*model.Article, int, error) {
 	// 缓存键
-	cacheKey := "articles:" + string(page) + ":" + string(pageSize) + ":" + orderBy
+	cacheKey := "articles:" + fmt.Sprint(page) + ":" + fmt.Sprint(pageSize) + ":" + orderBy
 
 	// 检查缓存
 	if s.config.Cache.EnableListCache {
@@ -99,7 +100,7 @@ func (s *ArticleService) GetArticles(page, pageSize int, orderBy string) ([]*mod
 // GetArticlesByCategoryID 根据栏目ID获取文章列表
 func (s *ArticleService) GetArticlesByCategoryID(categoryID int64, page, pageSize int) ([]*model.Article, int, error) {
 	// 缓存键
-	cacheKey := "category_articles:" + string(categoryID) + ":" + string(page) + ":" + string(pageSize)
+	cacheKey := "category_articles:" + fmt.Sprint(categoryID) + ":" + fmt.Sprint(page) + ":" + fmt.Sprint(pageSize)
 
 	// 检查缓存
 	if s.config.Cache.EnableListCache {
@@ -134,7 +135,7 @@ func (s *ArticleService) GetArticlesByCategoryID(categoryID int64, page, pageSiz
 // GetLatestArticles 获取最新文章
 func (s *ArticleService) GetLatestArticles(limit int) ([]*model.Article, error) {
 	// 缓存键
-	cacheKey := "latest_articles:" + string(limit)
+	cacheKey := "latest_articles:" + fmt.Sprint(limit)
 
 	// 检查缓存
 	if s.config.Cache.EnableListCache {
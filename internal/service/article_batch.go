@@ -0,0 +1,401 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// batchMaxArticles 一次批量操作最多处理的文章数
+const batchMaxArticles = 1000
+
+// 文章批量操作
+const (
+	BatchMove       = "move"       // 移动到其他栏目
+	BatchSetFlag    = "setflag"    // 设置置顶、推荐、热门属性
+	BatchClearFlag  = "clearflag"  // 取消置顶、推荐、热门属性
+	BatchArcRank    = "arcrank"    // 修改阅读权限
+	BatchAddTags    = "addtags"    // 添加标签
+	BatchRemoveTags = "removetags" // 移除标签
+	BatchDelete     = "delete"     // 移入回收站
+	BatchMakeHTML   = "makehtml"   // 重新生成静态页面
+)
+
+// batchFlags 可以批量设置的属性：c置顶 h推荐 p热门
+var batchFlags = map[string]bool{"c": true, "h": true, "p": true}
+
+// BatchFilter 按后台文章列表的条件选择文章
+type BatchFilter struct {
+	TypeID  int64  `json:"typeid"`
+	State   *int   `json:"state"` // 为空时不限状态
+	Keyword string `json:"keyword"`
+}
+
+// BatchRequest 文章批量操作请求，指定ids时按ids选择文章，否则按filter选择
+type BatchRequest struct {
+	Action  string       `json:"action"`
+	IDs     []int64      `json:"ids"`
+	Filter  *BatchFilter `json:"filter"`
+	TypeID  int64        `json:"typeid"`  // move的目标栏目
+	Flags   string       `json:"flags"`   // setflag和clearflag的属性，逗号分隔
+	ArcRank int          `json:"arcrank"` // arcrank的阅读权限
+	Tags    string       `json:"tags"`    // addtags和removetags的标签，逗号分隔
+}
+
+// BatchItemResult 批量操作中单篇文章的结果
+type BatchItemResult struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title,omitempty"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// BatchResult 批量操作的结果
+type BatchResult struct {
+	Action    string             `json:"action"`
+	Total     int                `json:"total"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Items     []*BatchItemResult `json:"items"`
+}
+
+// ArticleBatchService 文章批量操作服务，数据库的修改在一个事务中完成，
+// 任何一篇文章保存失败时整批回滚；文章不存在等无法处理的文章跳过并在结果中说明
+type ArticleBatchService struct {
+	db            *database.DB
+	cache         cache.Cache
+	config        *config.Config
+	articleModel  *model.ArticleModel
+	categoryModel *model.CategoryModel
+	htmlService   *HtmlService
+}
+
+// NewArticleBatchService 创建文章批量操作服务
+func NewArticleBatchService(db *database.DB, cache cache.Cache, config *config.Config) *ArticleBatchService {
+	return &ArticleBatchService{
+		db:            db,
+		cache:         cache,
+		config:        config,
+		articleModel:  model.NewArticleModel(db),
+		categoryModel: model.NewCategoryModel(db),
+		htmlService:   NewHtmlService(db, cache, config),
+	}
+}
+
+// batchChange 单篇文章在事务中的修改，返回是否有改动和说明
+type batchChange func(tx *database.Tx, article *model.Article) (changed bool, message string, err error)
+
+// Run 执行批量操作，返回每篇文章的结果；整批回滚时返回错误，结果中的文章均为失败
+func (s *ArticleBatchService) Run(req *BatchRequest) (*BatchResult, error) {
+	change, err := s.change(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.selectIDs(req)
+	if err != nil {
+		return nil, err
+	}
+	articles, err := s.articleModel.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*model.Article, len(articles))
+	for _, article := range articles {
+		byID[article.ID] = article
+	}
+
+	result := &BatchResult{Action: req.Action, Total: len(ids), Items: make([]*BatchItemResult, 0, len(ids))}
+	items := make(map[int64]*BatchItemResult, len(ids))
+	valid := make([]*model.Article, 0, len(articles))
+	for _, id := range ids {
+		item := &BatchItemResult{ID: id}
+		result.Items = append(result.Items, item)
+		items[id] = item
+
+		article, ok := byID[id]
+		switch {
+		case !ok:
+			item.Message = "文章不存在"
+		case article.ArcRank == model.ArcRankRecycled:
+			item.Title = article.Title
+			item.Message = "文章在回收站中"
+		default:
+			item.Title = article.Title
+			valid = append(valid, article)
+		}
+	}
+
+	// 重新生成静态页面不修改数据库
+	if req.Action == BatchMakeHTML {
+		s.makeHTML(valid, items)
+		result.count()
+		return result, nil
+	}
+
+	// 在一个事务中修改
+	changed := make([]*model.Article, 0, len(valid))
+	err = s.db.WithTx(func(tx *database.Tx) error {
+		for _, article := range valid {
+			item := items[article.ID]
+			ok, message, err := change(tx, article)
+			if err != nil {
+				item.Message = err.Error()
+				return err
+			}
+			item.Success = true
+			item.Message = message
+			if ok {
+				changed = append(changed, article)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("文章批量操作失败，已回滚", "action", req.Action, "error", err)
+		for _, item := range result.Items {
+			if item.Success {
+				item.Success = false
+				item.Message = "已回滚"
+			}
+		}
+		result.count()
+		return result, fmt.Errorf("批量操作失败，已回滚: %w", err)
+	}
+
+	s.refresh(req, changed)
+	result.count()
+	return result, nil
+}
+
+// count 统计成功和失败的文章数
+func (r *BatchResult) count() {
+	r.Succeeded, r.Failed = 0, 0
+	for _, item := range r.Items {
+		if item.Success {
+			r.Succeeded++
+		} else {
+			r.Failed++
+		}
+	}
+}
+
+// selectIDs 确定要处理的文章
+func (s *ArticleBatchService) selectIDs(req *BatchRequest) ([]int64, error) {
+	if len(req.IDs) > 0 {
+		ids := make([]int64, 0, len(req.IDs))
+		seen := make(map[int64]bool)
+		for _, id := range req.IDs {
+			if id > 0 && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > batchMaxArticles {
+			return nil, fmt.Errorf("一次最多处理%d篇文章", batchMaxArticles)
+		}
+		return ids, nil
+	}
+
+	if req.Filter == nil {
+		return nil, fmt.Errorf("请选择文章")
+	}
+	state := -1
+	if req.Filter.State != nil {
+		state = *req.Filter.State
+	}
+	ids, err := s.articleModel.GetAdminIDs(req.Filter.TypeID, state, strings.TrimSpace(req.Filter.Keyword), batchMaxArticles+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > batchMaxArticles {
+		return nil, fmt.Errorf("符合条件的文章超过%d篇，请缩小范围", batchMaxArticles)
+	}
+	return ids, nil
+}
+
+// change 检查参数并返回对单篇文章的修改
+func (s *ArticleBatchService) change(req *BatchRequest) (batchChange, error) {
+	switch req.Action {
+	case BatchMove:
+		category, err := s.categoryModel.GetByID(req.TypeID)
+		if err != nil || category == nil {
+			return nil, fmt.Errorf("目标栏目不存在")
+		}
+		return func(tx *database.Tx, article *model.Article) (bool, string, error) {
+			if article.TypeID == req.TypeID {
+				return false, "已在该栏目中", nil
+			}
+			return true, "", model.NewArticleModel(tx.DB()).SetTypeID(article.ID, req.TypeID)
+		}, nil
+
+	case BatchSetFlag, BatchClearFlag:
+		flags := splitList(req.Flags)
+		if len(flags) == 0 {
+			return nil, fmt.Errorf("请选择属性")
+		}
+		for _, flag := range flags {
+			if !batchFlags[flag] {
+				return nil, fmt.Errorf("不支持的属性: %s", flag)
+			}
+		}
+		return func(tx *database.Tx, article *model.Article) (bool, string, error) {
+			flagStr := model.ChangeFlags(article.Flag, flags, nil)
+			if req.Action == BatchClearFlag {
+				flagStr = model.ChangeFlags(article.Flag, nil, flags)
+			}
+			if flagStr == article.Flag {
+				return false, "属性未改变", nil
+			}
+			return true, "", model.NewArticleModel(tx.DB()).SetFlag(article.ID, flagStr)
+		}, nil
+
+	case BatchArcRank:
+		if req.ArcRank < -1 {
+			return nil, fmt.Errorf("无效的阅读权限: %d", req.ArcRank)
+		}
+		return func(tx *database.Tx, article *model.Article) (bool, string, error) {
+			if article.ArcRank == req.ArcRank {
+				return false, "阅读权限未改变", nil
+			}
+			return true, "", model.NewArticleModel(tx.DB()).SetArcRank(article.ID, req.ArcRank)
+		}, nil
+
+	case BatchAddTags, BatchRemoveTags:
+		tags := splitList(req.Tags)
+		if len(tags) == 0 {
+			return nil, fmt.Errorf("请填写标签")
+		}
+		return func(tx *database.Tx, article *model.Article) (bool, string, error) {
+			tagModel := model.NewTagModel(tx.DB())
+			current, err := tagModel.GetArticleTags(article.ID)
+			if err != nil {
+				return false, "", err
+			}
+			has := make(map[string]bool, len(current))
+			for _, tag := range current {
+				has[tag] = true
+			}
+
+			n := 0
+			for _, tag := range tags {
+				if req.Action == BatchAddTags && !has[tag] {
+					err = tagModel.AddArticleTag(article.ID, tag)
+				} else if req.Action == BatchRemoveTags && has[tag] {
+					err = tagModel.RemoveArticleTag(article.ID, tag)
+				} else {
+					continue
+				}
+				if err != nil {
+					return false, "", err
+				}
+				n++
+			}
+			if n == 0 {
+				return false, "标签未改变", nil
+			}
+			return true, "", nil
+		}, nil
+
+	case BatchDelete:
+		return func(tx *database.Tx, article *model.Article) (bool, string, error) {
+			return true, "", NewRecycleService(tx.DB(), s.cache, s.config).trash(article.ID)
+		}, nil
+
+	case BatchMakeHTML:
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("不支持的批量操作: %s", req.Action)
+}
+
+// makeHTML 重新生成文章的静态页面
+func (s *ArticleBatchService) makeHTML(articles []*model.Article, items map[int64]*BatchItemResult) {
+	typeids := make(map[int64]bool)
+	for _, article := range articles {
+		item := items[article.ID]
+		if !article.IsPublished() {
+			item.Message = "文章未发布"
+			continue
+		}
+		if err := s.htmlService.GenerateArticle(article.ID); err != nil {
+			item.Message = err.Error()
+			continue
+		}
+		item.Success = true
+		s.listed(typeids, article.ID, article.TypeID)
+	}
+	s.refreshLists(typeids)
+}
+
+// refresh 清除修改过的文章的缓存并更新静态页面，移动栏目时更新原栏目和目标栏目的列表页
+func (s *ArticleBatchService) refresh(req *BatchRequest, articles []*model.Article) {
+	typeids := make(map[int64]bool)
+	for _, article := range articles {
+		s.cache.Delete("article:" + fmt.Sprint(article.ID))
+		s.listed(typeids, article.ID, article.TypeID)
+		if req.Action == BatchMove {
+			s.listed(typeids, article.ID, req.TypeID)
+		}
+
+		if req.Action == BatchDelete {
+			if err := s.htmlService.RemoveArticle(article.ID); err != nil {
+				logger.Error("删除文章静态页失败", "id", article.ID, "error", err)
+			}
+		} else if s.config.Site.StaticArticle {
+			if err := s.htmlService.GenerateArticle(article.ID); err != nil {
+				logger.Error("重新生成文章静态页失败", "id", article.ID, "error", err)
+			}
+		}
+	}
+	s.refreshLists(typeids)
+}
+
+// listed 记录列表中包含该文章的栏目，包括副栏目和交叉栏目
+func (s *ArticleBatchService) listed(typeids map[int64]bool, aid, typeid int64) {
+	if !s.config.Site.StaticList {
+		return
+	}
+	ids, err := s.articleModel.ListedTypeIDs(aid, typeid)
+	if err != nil {
+		typeids[typeid] = true
+		return
+	}
+	for _, id := range ids {
+		typeids[id] = true
+	}
+}
+
+// refreshLists 重新生成栏目的静态列表页
+func (s *ArticleBatchService) refreshLists(typeids map[int64]bool) {
+	if !s.config.Site.StaticList {
+		return
+	}
+	for typeid := range typeids {
+		if typeid <= 0 {
+			continue
+		}
+		if err := s.htmlService.GenerateList(typeid); err != nil {
+			logger.Error("重新生成栏目列表页失败", "typeid", typeid, "error", err)
+		}
+	}
+}
+
+// splitList 分割逗号分隔的列表，去掉空白和重复项，兼容中文逗号
+func splitList(value string) []string {
+	list := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, v := range strings.Split(strings.ReplaceAll(value, "，", ","), ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			list = append(list, v)
+		}
+	}
+	return list
+}
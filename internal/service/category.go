@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"time"
 
 	"aq3cms/config"
@@ -36,7 +37,7 @@ func (s *CategoryService) GetChildCategories(parentID int64) ([]*model.Category,
 // GetCategoryPath 获取栏目路径
 func (s *CategoryService) GetCategoryPath(categoryID int64) ([]*model.Category, error) {
 	// 缓存键
-	cacheKey := "category_path_" + string(categoryID)
+	cacheKey := "category_path_" + fmt.Sprint(categoryID)
 
 	// 检查缓存
 	if cached, ok := s.cache.Get(cacheKey); ok {
@@ -100,7 +101,7 @@ func (s *CategoryService) GetTopCategories() ([]*model.Category, error) {
 // GetCategoryByID 根据ID获取栏目
 func (s *CategoryService) GetCategoryByID(id int64) (*model.Category, error) {
 	// 缓存键
-	cacheKey := "category_" + string(id)
+	cacheKey := "category_" + fmt.Sprint(id)
 
 	// 检查缓存
 	if cached, ok := s.cache.Get(cacheKey); ok {
@@ -150,7 +151,7 @@ func (s *CategoryService) GetCategoryByDir(dir string) (*model.Category, error)
 // GetSubCategories 获取子栏目
 func (s *CategoryService) GetSubCategories(parentID int64) ([]*model.Category, error) {
 	// 缓存键
-	cacheKey := "sub_categories_" + string(parentID)
+	cacheKey := "sub_categories_" + fmt.Sprint(parentID)
 
 	// 检查缓存
 	if cached, ok := s.cache.Get(cacheKey); ok {
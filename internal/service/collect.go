@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"aq3cms/pkg/cache"
 	"aq3cms/pkg/database"
 	"aq3cms/pkg/logger"
+	"aq3cms/pkg/similarity"
 )
 
 // CollectService 采集服务
@@ -27,6 +29,8 @@ type CollectService struct {
 	articleModel     *model.ArticleModel
 	categoryModel    *model.CategoryModel
 	contentModel     *model.ContentModelModel
+	imageService     *ImageService
+	duplicateService *DuplicateService
 }
 
 // NewCollectService 创建采集服务
@@ -40,6 +44,8 @@ func NewCollectService(db *database.DB, cache cache.Cache, config *config.Config
 		articleModel:     model.NewArticleModel(db),
 		categoryModel:    model.NewCategoryModel(db),
 		contentModel:     model.NewContentModelModel(db),
+		imageService:     NewImageService(db, cache, config),
+		duplicateService: NewDuplicateService(db, cache, config),
 	}
 }
 
@@ -199,8 +205,8 @@ func (s *CollectService) collectRSS(rule *model.CollectRule) (int, error) {
 		// 获取标题
 		title := selection.Find("title").Text()
 
-		// 获取内容
-		content := selection.Find("description").Text()
+		// 获取内容并下载其中的远程图片
+		content, _ := s.imageService.LocalizeHTML(selection.Find("description").Text(), link)
 
 		// 创建采集项目
 		item := &model.CollectItem{
@@ -275,6 +281,9 @@ func (s *CollectService) collectContent(rule *model.CollectRule, link string) er
 		content, _ = doc.Find(rule.ContentRule).Html()
 	}
 
+	// 下载内容中的远程图片，相对地址按页面地址解析
+	content, _ = s.imageService.LocalizeHTML(content, link)
+
 	// 获取字段数据
 	fieldData := make(map[string]interface{})
 	if rule.FieldRules != "" {
@@ -338,7 +347,7 @@ func (s *CollectService) collectContent(rule *model.CollectRule, link string) er
 	return nil
 }
 
-// PublishItem 发布采集项目
+// PublishItem 发布采集项目，内容与已有文档重复时按配置跳过或照常发布并标记，跳过时返回ErrDuplicateContent
 func (s *CollectService) PublishItem(itemID int64) error {
 	// 获取采集项目
 	item, err := s.collectItemModel.GetByID(itemID)
@@ -346,16 +355,57 @@ func (s *CollectService) PublishItem(itemID int64) error {
 		return err
 	}
 
+	// 已有文档的指纹
+	index, err := s.duplicateService.ArchiveIndex()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.publishItem(item, index)
+	return err
+}
+
+// ForcePublishItem 发布采集项目，不检查重复内容
+func (s *CollectService) ForcePublishItem(itemID int64) error {
+	// 获取采集项目
+	item, err := s.collectItemModel.GetByID(itemID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.publishItem(item, nil)
+	return err
+}
+
+// publishItem 发布采集项目，index为已有文档的指纹，为nil时不检查重复内容，返回文章ID
+func (s *CollectService) publishItem(item *model.CollectItem, index *similarity.Index) (int64, error) {
+	itemID := item.ID
+
+	// 检查重复内容
+	var dupAID int64
+	if index != nil {
+		if item.SimHash == 0 {
+			item.SimHash = model.ContentSimHash(item.Title, item.Content)
+		}
+		dupAID = s.duplicateService.Check(index, item.SimHash)
+		if dupAID > 0 && s.duplicateService.Action() == DuplicateActionSkip {
+			if err := s.collectItemModel.MarkDuplicate(itemID, model.CollectItemStatusDuplicate, dupAID); err != nil {
+				return 0, err
+			}
+			return 0, duplicateError(dupAID)
+		}
+	}
+
 	// 获取采集规则
 	rule, err := s.collectRuleModel.GetByID(item.RuleID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// 获取字段数据
 	fieldData, err := s.collectItemModel.GetFieldData(itemID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// 创建文章
@@ -366,7 +416,7 @@ func (s *CollectService) PublishItem(itemID int64) error {
 		Color:       "",
 		Source:      "采集",
 		Writer:      "采集",
-		LitPic:      "",
+		LitPic:      s.imageService.FirstImage(item.Content),
 		Keywords:    "",
 		Description: "",
 		Body:        item.Content,
@@ -377,13 +427,14 @@ func (s *CollectService) PublishItem(itemID int64) error {
 		IsRecommend: 0,
 		IsHot:       0,
 		ArcRank:     0,
+		State:       model.ArticleStatePublished,
 	}
 
 	// 保存文章
 	articleID, err := s.articleModel.Create(article)
 	if err != nil {
 		logger.Error("保存文章失败", "error", err)
-		return err
+		return 0, err
 	}
 
 	// 保存扩展模型内容
@@ -391,40 +442,59 @@ func (s *CollectService) PublishItem(itemID int64) error {
 		err = s.contentModel.SaveContent(rule.ModelID, articleID, fieldData)
 		if err != nil {
 			logger.Error("保存扩展模型内容失败", "error", err)
-			return err
+			return 0, err
 		}
 	}
 
-	// 更新采集项目状态
-	err = s.collectItemModel.UpdateStatus(itemID, 2)
+	// 更新采集项目状态，照常发布的重复内容同时记录重复的文档
+	if dupAID > 0 {
+		err = s.collectItemModel.MarkDuplicate(itemID, 2, dupAID)
+	} else {
+		err = s.collectItemModel.UpdateStatus(itemID, 2)
+	}
 	if err != nil {
 		logger.Error("更新采集项目状态失败", "error", err)
-		return err
+		return 0, err
 	}
 
-	return nil
+	// 同一批次中后发布的项目也要与之比较
+	if index != nil {
+		index.Add(articleID, uint64(item.SimHash))
+	}
+
+	return articleID, nil
 }
 
-// BatchPublish 批量发布采集项目
-func (s *CollectService) BatchPublish(ruleID int64) (int, error) {
+// BatchPublish 批量发布采集项目，返回发布数和因内容重复跳过的数量
+func (s *CollectService) BatchPublish(ruleID int64) (int, int, error) {
 	// 获取采集规则
 	_, err := s.collectRuleModel.GetByID(ruleID)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	// 获取未处理的采集项目
 	items, _, err := s.collectItemModel.GetByRuleID(ruleID, 0, 1, 100)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
+	}
+
+	// 已有文档的指纹
+	index, err := s.duplicateService.ArchiveIndex()
+	if err != nil {
+		return 0, 0, err
 	}
 
 	// 发布计数
-	count := 0
+	count, skipped := 0, 0
 
 	// 批量发布
 	for _, item := range items {
-		err = s.PublishItem(item.ID)
+		_, err = s.publishItem(item, index)
+		if errors.Is(err, ErrDuplicateContent) {
+			skipped++
+			continue
+		}
 		if err != nil {
 			logger.Error("发布采集项目失败", "id", item.ID, "error", err)
 			continue
@@ -432,7 +502,7 @@ func (s *CollectService) BatchPublish(ruleID int64) (int, error) {
 		count++
 	}
 
-	return count, nil
+	return count, skipped, nil
 }
 
 // DeleteRule 删除采集规则
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+)
+
+// WithContext 返回绑定ctx的广告服务
+func (s *AdService) WithContext(ctx context.Context) *AdService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.adModel = s.adModel.WithContext(ctx)
+	c.adPositionModel = s.adPositionModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的管理员服务
+func (s *AdminService) WithContext(ctx context.Context) *AdminService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.adminModel = s.adminModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的API服务
+func (s *APIService) WithContext(ctx context.Context) *APIService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	c.memberModel = s.memberModel.WithContext(ctx)
+	c.formService = s.formService.WithContext(ctx)
+	c.commentModel = s.commentModel.WithContext(ctx)
+	c.articleService = s.articleService.WithContext(ctx)
+	c.memberService = s.memberService.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的文章服务
+func (s *ArticleService) WithContext(ctx context.Context) *ArticleService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	c.tagModel = s.tagModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的文章批量操作服务
+func (s *ArticleBatchService) WithContext(ctx context.Context) *ArticleBatchService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的栏目服务
+func (s *CategoryService) WithContext(ctx context.Context) *CategoryService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的采集服务
+func (s *CollectService) WithContext(ctx context.Context) *CollectService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.collectRuleModel = s.collectRuleModel.WithContext(ctx)
+	c.collectItemModel = s.collectItemModel.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	c.contentModel = s.contentModel.WithContext(ctx)
+	c.imageService = s.imageService.WithContext(ctx)
+	c.duplicateService = s.duplicateService.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的重复内容服务
+func (s *DuplicateService) WithContext(ctx context.Context) *DuplicateService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.collectItemModel = s.collectItemModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的内容导出服务
+func (s *ExportService) WithContext(ctx context.Context) *ExportService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	c.tagModel = s.tagModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的自定义表单服务
+func (s *FormService) WithContext(ctx context.Context) *FormService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.formModel = s.formModel.WithContext(ctx)
+	c.formDataModel = s.formDataModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的远程图片本地化服务
+func (s *ImageService) WithContext(ctx context.Context) *ImageService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的数据导入服务
+func (s *ImportService) WithContext(ctx context.Context) *ImportService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	c.commentModel = s.commentModel.WithContext(ctx)
+	c.tagModel = s.tagModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的关键词和摘要提取服务
+func (s *KeywordService) WithContext(ctx context.Context) *KeywordService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的友情链接服务
+func (s *LinkService) WithContext(ctx context.Context) *LinkService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.linkModel = s.linkModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的会员服务
+func (s *MemberService) WithContext(ctx context.Context) *MemberService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.memberModel = s.memberModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的支付服务
+func (s *PaymentService) WithContext(ctx context.Context) *PaymentService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.paymentMethodModel = s.paymentMethodModel.WithContext(ctx)
+	c.paymentOrderModel = s.paymentOrderModel.WithContext(ctx)
+	c.memberModel = s.memberModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的插件服务
+func (s *PluginService) WithContext(ctx context.Context) *PluginService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.pluginModel = s.pluginModel.WithContext(ctx)
+	c.pluginHookModel = s.pluginHookModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的回收站服务
+func (s *RecycleService) WithContext(ctx context.Context) *RecycleService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的相关文章服务
+func (s *RelatedService) WithContext(ctx context.Context) *RelatedService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.relationModel = s.relationModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的文章修订历史服务
+func (s *RevisionService) WithContext(ctx context.Context) *RevisionService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.revisionModel = s.revisionModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的积分服务
+func (s *ScoreService) WithContext(ctx context.Context) *ScoreService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.scoreRuleModel = s.scoreRuleModel.WithContext(ctx)
+	c.scoreLogModel = s.scoreLogModel.WithContext(ctx)
+	c.memberModel = s.memberModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的搜索服务
+func (s *SearchService) WithContext(ctx context.Context) *SearchService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.productModel = s.productModel.WithContext(ctx)
+	c.downloadModel = s.downloadModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的SEO服务
+func (s *SEOService) WithContext(ctx context.Context) *SEOService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	c.tagModel = s.tagModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的统计服务
+func (s *StatsService) WithContext(ctx context.Context) *StatsService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.categoryModel = s.categoryModel.WithContext(ctx)
+	c.memberModel = s.memberModel.WithContext(ctx)
+	c.commentModel = s.commentModel.WithContext(ctx)
+	c.visitLogModel = s.visitLogModel.WithContext(ctx)
+	c.searchLogModel = s.searchLogModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的投票服务
+func (s *VoteService) WithContext(ctx context.Context) *VoteService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.voteModel = s.voteModel.WithContext(ctx)
+	c.voteOptionModel = s.voteOptionModel.WithContext(ctx)
+	c.voteLogModel = s.voteLogModel.WithContext(ctx)
+	return &c
+}
+
+// WithContext 返回绑定ctx的文章审核流程服务
+func (s *WorkflowService) WithContext(ctx context.Context) *WorkflowService {
+	c := *s
+	c.db = s.db.WithContext(ctx)
+	c.articleModel = s.articleModel.WithContext(ctx)
+	c.workflowModel = s.workflowModel.WithContext(ctx)
+	return &c
+}
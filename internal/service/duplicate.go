@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/pkg/similarity"
+)
+
+// 重复内容检查参数
+const (
+	duplicateDefaultDistance = 8   // 默认汉明距离，约相当于92%的片段相同
+	duplicateBatch           = 500 // 补算指纹时每批读取的记录数
+	duplicateMaxClusters     = 200 // 报告中最多列出的重复类数
+
+	duplicateBackfillInterval = 10 * time.Minute // 后台补算指纹的间隔
+	duplicateIndexTTL         = 5 * time.Minute  // 文档指纹索引重建的间隔
+)
+
+// 发布重复的采集内容时的处理方式
+const (
+	DuplicateActionSkip = "skip" // 不发布，采集项目标记为重复
+	DuplicateActionFlag = "flag" // 照常发布，采集项目记录重复的文档
+)
+
+// ErrDuplicateContent 采集内容与已有文档重复
+var ErrDuplicateContent = errors.New("内容与已有文档重复")
+
+// DuplicateMember 重复类中的文档或采集项目
+type DuplicateMember struct {
+	Kind     string    // archive为文档，collect为未发布的采集项目
+	ID       int64     // 文档ID或采集项目ID
+	Title    string    // 标题
+	Category string    // 文档的栏目名称
+	State    string    // 文档的审核状态
+	RuleID   int64     // 采集项目的规则ID
+	URL      string    // 采集项目的来源地址
+	Time     time.Time // 文档的发布时间或采集时间
+	Distance int       // 与类中第一个成员的汉明距离
+}
+
+// DuplicateCluster 内容互相重复的一组文档和采集项目
+type DuplicateCluster struct {
+	Members []*DuplicateMember
+}
+
+// fingerprint 内容指纹
+type fingerprint struct {
+	ID      int64 `db:"id"`
+	SimHash int64 `db:"simhash"`
+}
+
+// archiveIndex 缓存的文档指纹索引
+type archiveIndex struct {
+	index   *similarity.Index
+	lastID  int64     // 索引中最大的文档ID
+	builtAt time.Time // 建立时间
+}
+
+// 文档指纹索引缓存，按文档表名区分
+var (
+	archiveIndexMu sync.Mutex
+	archiveIndexes = make(map[string]*archiveIndex)
+)
+
+// DuplicateService 重复内容服务，根据标题和正文的SimHash指纹发现不同来源的相同内容
+type DuplicateService struct {
+	db               *database.DB
+	cache            cache.Cache
+	config           *config.Config
+	articleModel     *model.ArticleModel
+	collectItemModel *model.CollectItemModel
+}
+
+// NewDuplicateService 创建重复内容服务
+func NewDuplicateService(db *database.DB, cache cache.Cache, config *config.Config) *DuplicateService {
+	return &DuplicateService{
+		db:               db,
+		cache:            cache,
+		config:           config,
+		articleModel:     model.NewArticleModel(db),
+		collectItemModel: model.NewCollectItemModel(db),
+	}
+}
+
+// Distance 判定重复的最大汉明距离，小于0表示不检查
+func (s *DuplicateService) Distance() int {
+	if s.config.Site.DuplicateDistance == 0 {
+		return duplicateDefaultDistance
+	}
+	return s.config.Site.DuplicateDistance
+}
+
+// Action 发布重复的采集内容时的处理方式
+func (s *DuplicateService) Action() string {
+	if s.config.Site.DuplicateAction == DuplicateActionFlag {
+		return DuplicateActionFlag
+	}
+	return DuplicateActionSkip
+}
+
+// Backfill 为还没有指纹的文档和采集项目计算指纹，返回计算的数量
+func (s *DuplicateService) Backfill() (int, error) {
+	count := 0
+
+	// 文档
+	var lastID int64
+	for {
+		qb := database.NewQueryBuilder(s.db, "archives")
+		qb.Select("a.id", "a.title", "ad.body")
+		qb.From(s.db.TableName("archives") + " AS a")
+		qb.LeftJoin(s.db.TableName("addonarticle")+" AS ad", "a.id = ad.aid")
+		qb.Where("a.simhash = 0")
+		qb.Where("a.id > ?", lastID)
+		qb.OrderBy("a.id ASC")
+		qb.Limit(duplicateBatch)
+
+		var rows []struct {
+			ID    int64  `db:"id"`
+			Title string `db:"title"`
+			Body  string `db:"body"`
+		}
+		if err := qb.GetInto(&rows); err != nil {
+			logger.Error("读取文档内容失败", "error", err)
+			return count, err
+		}
+		for _, row := range rows {
+			lastID = row.ID
+			// 没有文字的文档指纹为0，下次仍会读取
+			if hash := model.ContentSimHash(row.Title, row.Body); hash != 0 {
+				if err := s.articleModel.SetSimHash(row.ID, hash); err != nil {
+					return count, err
+				}
+				count++
+			}
+		}
+		if len(rows) < duplicateBatch {
+			break
+		}
+	}
+
+	// 采集项目
+	lastID = 0
+	for {
+		qb := database.NewQueryBuilder(s.db, "collect_item")
+		qb.Select("id", "title", "content")
+		qb.Where("simhash = 0")
+		qb.Where("id > ?", lastID)
+		qb.OrderBy("id ASC")
+		qb.Limit(duplicateBatch)
+
+		var rows []struct {
+			ID      int64  `db:"id"`
+			Title   string `db:"title"`
+			Content string `db:"content"`
+		}
+		if err := qb.GetInto(&rows); err != nil {
+			logger.Error("读取采集项目内容失败", "error", err)
+			return count, err
+		}
+		for _, row := range rows {
+			lastID = row.ID
+			if hash := model.ContentSimHash(row.Title, row.Content); hash != 0 {
+				if err := s.collectItemModel.SetSimHash(row.ID, hash); err != nil {
+					return count, err
+				}
+				count++
+			}
+		}
+		if len(rows) < duplicateBatch {
+			break
+		}
+	}
+
+	return count, nil
+}
+
+// RunBackfill 定时为导入等途径写入的旧文档和采集项目补算指纹，直到ctx取消；
+// 新建和修改文档时已计算指纹，发布采集内容时不再逐次补算
+func (s *DuplicateService) RunBackfill(ctx context.Context) {
+	if s.Distance() < 0 {
+		return
+	}
+
+	ticker := time.NewTicker(duplicateBackfillInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := s.WithContext(ctx).Backfill()
+		if err != nil {
+			logger.Error("补算内容指纹失败", "error", err)
+		} else if count > 0 {
+			logger.Info("补算内容指纹完成", "count", count)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ArchiveIndex 获取回收站以外的文档指纹索引，不检查重复时返回nil。
+// 索引在进程内缓存，每次只读取新增的文档，每duplicateIndexTTL重建一次，
+// 因此修改、移入回收站和由RunBackfill补算指纹的文档最多延迟duplicateIndexTTL生效
+func (s *DuplicateService) ArchiveIndex() (*similarity.Index, error) {
+	distance := s.Distance()
+	if distance < 0 {
+		return nil, nil
+	}
+
+	archiveIndexMu.Lock()
+	defer archiveIndexMu.Unlock()
+
+	key := s.db.TableName("archives")
+	cached := archiveIndexes[key]
+	if cached == nil || cached.index.Distance() != distance || time.Since(cached.builtAt) > duplicateIndexTTL {
+		cached = &archiveIndex{index: similarity.NewIndex(distance), builtAt: time.Now()}
+	}
+
+	archives, err := s.archiveFingerprints(cached.lastID)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range archives {
+		cached.index.Add(f.ID, uint64(f.SimHash))
+		cached.lastID = f.ID
+	}
+	archiveIndexes[key] = cached
+	return cached.index, nil
+}
+
+// Check 查找与指纹重复的文档，返回距离最近的文档ID，没有重复或index为nil时返回0
+func (s *DuplicateService) Check(index *similarity.Index, hash int64) int64 {
+	if index == nil {
+		return 0
+	}
+	if near := index.Find(uint64(hash)); len(near) > 0 {
+		return near[0].ID
+	}
+	return 0
+}
+
+// Clusters 列出内容重复的文档和未发布的采集项目，返回前duplicateMaxClusters个类和类的总数；
+// 还没有指纹的文档和采集项目由RunBackfill在后台补算，补算前不出现在报告中
+func (s *DuplicateService) Clusters() ([]*DuplicateCluster, int, error) {
+	distance := s.Distance()
+	if distance < 0 {
+		distance = duplicateDefaultDistance
+	}
+
+	archives, err := s.archiveFingerprints(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	qb := database.NewQueryBuilder(s.db, "collect_item")
+	qb.Select("id", "simhash")
+	qb.Where("status <> ?", 2)
+	qb.Where("simhash <> 0")
+	qb.OrderBy("id ASC")
+	var items []fingerprint
+	if err := qb.GetInto(&items); err != nil {
+		logger.Error("读取采集项目指纹失败", "error", err)
+		return nil, 0, err
+	}
+
+	// 采集项目在索引中使用负数ID
+	index := similarity.NewIndex(distance)
+	hashes := make(map[int64]uint64, len(archives)+len(items))
+	for _, f := range archives {
+		index.Add(f.ID, uint64(f.SimHash))
+		hashes[f.ID] = uint64(f.SimHash)
+	}
+	for _, f := range items {
+		index.Add(-f.ID, uint64(f.SimHash))
+		hashes[-f.ID] = uint64(f.SimHash)
+	}
+
+	groups := index.Clusters()
+	total := len(groups)
+	if len(groups) > duplicateMaxClusters {
+		groups = groups[:duplicateMaxClusters]
+	}
+
+	// 读取成员的标题等信息
+	var articleIDs, itemIDs []int64
+	for _, group := range groups {
+		for _, id := range group {
+			if id > 0 {
+				articleIDs = append(articleIDs, id)
+			} else {
+				itemIDs = append(itemIDs, -id)
+			}
+		}
+	}
+	articles, err := s.articleModel.GetByIDs(articleIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	collectItems, err := s.collectItemModel.GetByIDs(itemIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members := make(map[int64]*DuplicateMember, len(articles)+len(collectItems))
+	for _, article := range articles {
+		members[article.ID] = &DuplicateMember{
+			Kind:     "archive",
+			ID:       article.ID,
+			Title:    article.Title,
+			Category: article.TypeName,
+			State:    article.StateName(),
+			Time:     article.PubDate,
+		}
+	}
+	for _, item := range collectItems {
+		members[-item.ID] = &DuplicateMember{
+			Kind:   "collect",
+			ID:     item.ID,
+			Title:  item.Title,
+			RuleID: item.RuleID,
+			URL:    item.URL,
+			Time:   item.CreateTime,
+		}
+	}
+
+	clusters := make([]*DuplicateCluster, 0, len(groups))
+	for _, group := range groups {
+		cluster := &DuplicateCluster{}
+		for _, id := range group {
+			member, ok := members[id]
+			if !ok {
+				continue
+			}
+			member.Distance = similarity.Hamming(hashes[group[0]], hashes[id])
+			cluster.Members = append(cluster.Members, member)
+		}
+		if len(cluster.Members) > 1 {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	return clusters, total, nil
+}
+
+// archiveFingerprints 读取ID大于afterID、回收站以外的文档指纹
+func (s *DuplicateService) archiveFingerprints(afterID int64) ([]fingerprint, error) {
+	qb := database.NewQueryBuilder(s.db, "archives")
+	qb.Select("id", "simhash")
+	qb.Where("id > ?", afterID)
+	qb.Where("arcrank <> ?", model.ArcRankRecycled)
+	qb.Where("simhash <> 0")
+	qb.OrderBy("id ASC")
+
+	var list []fingerprint
+	if err := qb.GetInto(&list); err != nil {
+		logger.Error("读取文档指纹失败", "error", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// duplicateError 包装重复内容错误，附带重复的文档ID
+func duplicateError(aid int64) error {
+	return fmt.Errorf("%w: 文档%d", ErrDuplicateContent, aid)
+}
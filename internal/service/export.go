@@ -0,0 +1,765 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/pkg/markdown"
+)
+
+// BundleFormat 导出包格式标识
+const BundleFormat = "aq3cms-export"
+
+// BundleVersion 导出包格式版本，格式有不兼容的修改时增加
+const BundleVersion = 1
+
+// DefaultExportDir 导出包的默认保存目录
+const DefaultExportDir = "data/export"
+
+// 导出时每次读取的记录数
+const exportBatchSize = 200
+
+// 导出包中的文件
+const (
+	bundleManifestFile   = "manifest.json"
+	bundleCategoriesFile = "categories.json"
+	bundleArticlesFile   = "articles.json"
+	bundleTagsFile       = "tags.json"
+	bundleSpecialsFile   = "specials.json"
+	bundleCommentsFile   = "comments.json"
+	bundleUploadsFile    = "uploads.json"
+)
+
+// BundleManifest 导出包说明，记录格式版本和各类内容的数量
+type BundleManifest struct {
+	Format     string         `json:"format"`
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	SiteName   string         `json:"site_name"`
+	SiteURL    string         `json:"site_url"`
+	Counts     map[string]int `json:"counts"` // categories、articles、tags、specials、comments、uploads、files
+}
+
+// BundleCategory 导出的栏目
+type BundleCategory struct {
+	ID              int64   `json:"id"`
+	ParentID        int64   `json:"parent_id"` // 顶级栏目为0
+	Name            string  `json:"name"`
+	Dir             string  `json:"dir"`
+	ChannelType     int     `json:"channel_type"`
+	SortRank        int     `json:"sort_rank"`
+	Hidden          bool    `json:"hidden"`
+	Description     string  `json:"description"`
+	Keywords        string  `json:"keywords"`
+	ListTemplate    string  `json:"list_template"`
+	ArticleTemplate string  `json:"article_template"`
+	CrossIDs        []int64 `json:"cross_ids"` // 交叉栏目ID
+}
+
+// BundleArticle 导出的文章，正文保存在HTMLFile和MarkdownFile中
+type BundleArticle struct {
+	ID             int64           `json:"id"`
+	CategoryID     int64           `json:"category_id"`
+	Title          string          `json:"title"`
+	ShortTitle     string          `json:"short_title"`
+	Color          string          `json:"color"`
+	Writer         string          `json:"writer"`
+	Source         string          `json:"source"`
+	Thumbnail      string          `json:"thumbnail"`
+	PubDate        time.Time       `json:"pub_date"`
+	SendDate       time.Time       `json:"send_date"`
+	ExpireDate     *time.Time      `json:"expire_date,omitempty"`
+	Keywords       string          `json:"keywords"`
+	Description    string          `json:"description"`
+	Filename       string          `json:"filename"`
+	Flag           string          `json:"flag"`      // c置顶 h推荐 p热门
+	State          int             `json:"state"`     // 审核流程状态，见 model.ArticleStateNames
+	ArcRank        int             `json:"arc_rank"`  // -2表示在回收站中
+	Click          int             `json:"click"`     // 浏览次数
+	Format         string          `json:"format"`    // 正文原始格式：html或markdown
+	Tags           []string        `json:"tags"`      // 文章标签
+	CrossIDs       []int64         `json:"cross_ids"` // 副栏目ID
+	HTMLFile       string          `json:"html_file"` // 正文HTML文件
+	MarkdownFile   string          `json:"markdown_file"`
+	DeletedAt      *time.Time      `json:"deleted_at,omitempty"` // 移入回收站的时间
+	DeletedArcRank int             `json:"deleted_arcrank"`      // 移入回收站前的arc_rank，恢复时使用
+	Channel        int             `json:"channel"`              // 1文章 2产品 3下载
+	Download       *BundleDownload `json:"download,omitempty"`   // 下载频道的附加信息
+	Product        *BundleProduct  `json:"product,omitempty"`    // 产品频道的附加信息
+	// 自定义内容模型的附加字段，按模型表名分组，值为字段名到文本值的映射
+	Fields map[string]map[string]string `json:"fields,omitempty"`
+}
+
+// BundleDownload 下载频道文章的附加信息
+type BundleDownload struct {
+	SoftName      string   `json:"soft_name" db:"softname"`
+	SoftVersion   string   `json:"soft_version" db:"softversion"`
+	SoftLanguage  string   `json:"soft_language" db:"softlanguage"`
+	SoftType      string   `json:"soft_type" db:"softtype"`
+	SoftSize      string   `json:"soft_size" db:"softsize"`
+	SoftOS        string   `json:"soft_os" db:"softos"`
+	SoftDeveloper string   `json:"soft_developer" db:"softdeveloper"`
+	SoftLicense   string   `json:"soft_license" db:"softlicense"`
+	SoftScore     float64  `json:"soft_score" db:"softscore"`
+	SoftURL       string   `json:"soft_url" db:"softurl"`
+	SoftMirrURL   string   `json:"soft_mirr_url" db:"softmirrurl"` // 镜像地址，每行一个
+	DownCount     int      `json:"down_count" db:"downcount"`
+	Screenshots   []string `json:"screenshots"`
+}
+
+// BundleProduct 产品频道文章的附加信息
+type BundleProduct struct {
+	ProductName   string   `json:"product_name" db:"productname"`
+	ProductSN     string   `json:"product_sn" db:"productsn"`
+	Price         float64  `json:"price" db:"price"`
+	OldPrice      float64  `json:"old_price" db:"oldprice"`
+	Units         string   `json:"units" db:"units"`
+	Weight        float64  `json:"weight" db:"weight"`
+	Specification string   `json:"specification" db:"specification"`
+	Features      string   `json:"features" db:"features"`
+	Parameters    string   `json:"parameters" db:"parameters"`
+	Stock         int      `json:"stock" db:"stock"`
+	Images        []string `json:"images"`
+}
+
+// bundleArticleRow 导出时读取的文章记录
+type bundleArticleRow struct {
+	model.Article
+	Channel        int `db:"channel"`
+	DeletedArcRank int `db:"deleted_arcrank"`
+}
+
+// BundleFrontMatter Markdown文件的头部信息
+type BundleFrontMatter struct {
+	ID       int64     `yaml:"id"`
+	Title    string    `yaml:"title"`
+	Category int64     `yaml:"category"`
+	Date     time.Time `yaml:"date"`
+	Writer   string    `yaml:"writer,omitempty"`
+	Tags     []string  `yaml:"tags,omitempty"`
+	Format   string    `yaml:"format"`
+}
+
+// BundleTag 导出的标签
+type BundleTag struct {
+	Name  string `json:"name"`
+	Rank  int    `json:"rank"`
+	Hot   bool   `json:"hot"`
+	Count int    `json:"count"`
+}
+
+// BundleSpecial 导出的专题
+type BundleSpecial struct {
+	ID           int64                  `json:"id"`
+	CategoryID   int64                  `json:"category_id"`
+	Title        string                 `json:"title"`
+	Note         string                 `json:"note"`
+	Pic          string                 `json:"pic"`
+	PubDate      time.Time              `json:"pub_date"`
+	Hot          bool                   `json:"hot"`
+	Click        int                    `json:"click"`
+	Template     string                 `json:"template"`
+	TemplateList string                 `json:"template_list"`
+	Filename     string                 `json:"filename"`
+	Status       int                    `json:"status"`
+	Keywords     string                 `json:"keywords"`
+	Description  string                 `json:"description"`
+	Content      string                 `json:"content"`
+	Articles     []BundleSpecialArticle `json:"articles"`
+}
+
+// BundleSpecialArticle 专题中的文章
+type BundleSpecialArticle struct {
+	ArticleID int64 `json:"article_id" db:"aid"`
+	SortRank  int   `json:"sort_rank" db:"sortrank"`
+}
+
+// BundleComment 导出的评论，内容为纯文本
+type BundleComment struct {
+	ID        int64     `json:"id"`
+	ArticleID int64     `json:"article_id"`
+	ParentID  int64     `json:"parent_id"` // 回复的评论ID，0表示不是回复
+	Username  string    `json:"username"`
+	Content   string    `json:"content"`
+	Time      time.Time `json:"time"`
+	Approved  bool      `json:"approved"`
+	IP        string    `json:"ip"`
+	Score     int       `json:"score"`
+	Good      int       `json:"good"`
+	Bad       int       `json:"bad"`
+	UserFace  string    `json:"user_face"`
+}
+
+// BundleUpload 内容中引用的上传文件
+type BundleUpload struct {
+	URL  string `json:"url"`  // 内容中的地址，如 /uploads/2024/01/a.jpg
+	File string `json:"file"` // 导出包中的文件，未打包或文件不存在时为空
+	Size int64  `json:"size"`
+}
+
+// ExportOptions 导出选项
+type ExportOptions struct {
+	IncludeFiles bool // 将引用的上传文件打包到导出包中
+}
+
+// ExportService 内容导出服务
+type ExportService struct {
+	db            *database.DB
+	cache         cache.Cache
+	config        *config.Config
+	articleModel  *model.ArticleModel
+	categoryModel *model.CategoryModel
+	tagModel      *model.TagModel
+}
+
+// NewExportService 创建内容导出服务
+func NewExportService(db *database.DB, cache cache.Cache, config *config.Config) *ExportService {
+	return &ExportService{
+		db:            db,
+		cache:         cache,
+		config:        config,
+		articleModel:  model.NewArticleModel(db),
+		categoryModel: model.NewCategoryModel(db),
+		tagModel:      model.NewTagModel(db),
+	}
+}
+
+// bundleExport 一次导出的状态
+type bundleExport struct {
+	*ExportService
+	zw            *zip.Writer
+	opts          ExportOptions
+	uploads       map[string]bool // 内容中引用的上传文件地址
+	uploadPattern *regexp.Regexp  // 匹配内容中的上传文件地址
+	contentModels []bundleContentModel
+	now           time.Time
+}
+
+// bundleContentModel 导出时使用的自定义内容模型
+type bundleContentModel struct {
+	tableName string // 模型表名，导入时据此查找模型
+	table     string // 附加表名
+	fields    []model.Field
+}
+
+// Export 将栏目、文章、标签、专题、评论和引用的上传文件导出为zip包写入w，格式见README
+func (s *ExportService) Export(w io.Writer, opts ExportOptions) (*BundleManifest, error) {
+	e := &bundleExport{
+		ExportService: s,
+		zw:            zip.NewWriter(w),
+		opts:          opts,
+		uploads:       make(map[string]bool),
+		uploadPattern: regexp.MustCompile(regexp.QuoteMeta(uploadURLPrefix(s.config)) + `[^"'\s()<>?#]+`),
+		now:           time.Now(),
+	}
+	manifest := &BundleManifest{
+		Format:     BundleFormat,
+		Version:    BundleVersion,
+		ExportedAt: e.now,
+		SiteName:   s.config.Site.Name,
+		SiteURL:    s.config.Site.URL,
+		Counts:     make(map[string]int),
+	}
+
+	steps := []struct {
+		name string
+		fn   func() (int, error)
+	}{
+		{"categories", e.categories},
+		{"articles", e.articles},
+		{"tags", e.tags},
+		{"specials", e.specials},
+		{"comments", e.comments},
+	}
+	for _, step := range steps {
+		count, err := step.fn()
+		if err != nil {
+			logger.Error("导出内容失败", "type", step.name, "error", err)
+			return nil, err
+		}
+		manifest.Counts[step.name] = count
+	}
+
+	// 上传文件在最后导出，此时已收集全部引用
+	uploads, files, err := e.files()
+	if err != nil {
+		logger.Error("导出上传文件失败", "error", err)
+		return nil, err
+	}
+	manifest.Counts["uploads"] = uploads
+	manifest.Counts["files"] = files
+
+	if err := e.writeJSON(bundleManifestFile, manifest); err != nil {
+		return nil, err
+	}
+	if err := e.zw.Close(); err != nil {
+		return nil, err
+	}
+
+	logger.Info("导出内容完成", "counts", manifest.Counts)
+	return manifest, nil
+}
+
+// create 在导出包中创建文件，修改时间为导出时间
+func (e *bundleExport) create(name string) (io.Writer, error) {
+	return e.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: e.now,
+	})
+}
+
+// writeJSON 将v以JSON格式写入导出包
+func (e *bundleExport) writeJSON(name string, v interface{}) error {
+	f, err := e.create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeFile 将内容写入导出包
+func (e *bundleExport) writeFile(name, content string) error {
+	f, err := e.create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, content)
+	return err
+}
+
+// categories 导出栏目
+func (e *bundleExport) categories() (int, error) {
+	categories, err := e.categoryModel.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	list := make([]BundleCategory, 0, len(categories))
+	for _, c := range categories {
+		list = append(list, BundleCategory{
+			ID:              c.ID,
+			ParentID:        c.ParentID,
+			Name:            c.TypeName,
+			Dir:             c.TypeDir,
+			ChannelType:     c.ChannelType,
+			SortRank:        c.SortRank,
+			Hidden:          c.IsHidden == 1,
+			Description:     c.Description,
+			Keywords:        c.Keywords,
+			ListTemplate:    c.ListTpl,
+			ArticleTemplate: c.ArticleTpl,
+			CrossIDs:        c.CrossTypeIDs(),
+		})
+	}
+	return len(list), e.writeJSON(bundleCategoriesFile, list)
+}
+
+// articles 按ID分批导出全部文章，包括未发布和回收站中的文章
+func (e *bundleExport) articles() (int, error) {
+	if err := e.loadContentModels(); err != nil {
+		return 0, err
+	}
+
+	list := make([]BundleArticle, 0)
+	last := int64(0)
+	for {
+		qb := database.NewQueryBuilder(e.db, "archives")
+		qb.Select("a.id", "a.typeid", "a.title", "a.shorttitle", "a.color", "a.writer", "a.source", "a.litpic", "a.pubdate", "a.senddate", "a.expiredate", "a.keywords", "a.description", "a.filename", "a.flag", "a.arcrank", "a.state", "a.click", "a.deleted_at", "a.deleted_arcrank", "a.channel", "ad.body", "ad.format", "ad.markdown")
+		qb.From(e.db.TableName("archives") + " AS a")
+		qb.LeftJoin(e.db.TableName("addonarticle")+" AS ad", "a.id = ad.aid")
+		qb.Where("a.id > ?", last)
+		qb.OrderBy("a.id ASC")
+		qb.Limit(exportBatchSize)
+
+		articles := make([]*bundleArticleRow, 0)
+		if err := qb.GetInto(&articles); err != nil {
+			return 0, err
+		}
+		for _, a := range articles {
+			last = a.ID
+			item, err := e.article(a)
+			if err != nil {
+				return 0, fmt.Errorf("文章 %d: %v", a.ID, err)
+			}
+			list = append(list, item)
+		}
+		if len(articles) < exportBatchSize {
+			break
+		}
+	}
+	return len(list), e.writeJSON(bundleArticlesFile, list)
+}
+
+// article 写入文章的HTML和带头部信息的Markdown文件
+func (e *bundleExport) article(row *bundleArticleRow) (BundleArticle, error) {
+	a := &row.Article
+	tags, err := e.tagModel.GetArticleTags(a.ID)
+	if err != nil {
+		return BundleArticle{}, err
+	}
+	sort.Strings(tags)
+	crossIDs, err := e.articleModel.GetCrossTypeIDs(a.ID)
+	if err != nil {
+		return BundleArticle{}, err
+	}
+
+	// Markdown文章导出原文，HTML文章转换为Markdown
+	format := model.NormalizeBodyFormat(a.Format)
+	source := a.Markdown
+	if !a.IsMarkdown() {
+		if source, err = markdown.FromHTML(a.Body); err != nil {
+			return BundleArticle{}, err
+		}
+	}
+
+	item := BundleArticle{
+		ID:           a.ID,
+		CategoryID:   a.TypeID,
+		Title:        a.Title,
+		ShortTitle:   a.ShortTitle,
+		Color:        a.Color,
+		Writer:       a.Writer,
+		Source:       a.Source,
+		Thumbnail:    a.LitPic,
+		PubDate:      a.PubDate,
+		SendDate:     a.SendDate,
+		Keywords:     a.Keywords,
+		Description:  a.Description,
+		Filename:     a.Filename,
+		Flag:         a.Flag,
+		State:        a.State,
+		ArcRank:      a.ArcRank,
+		Click:        a.Click,
+		Format:       format,
+		Tags:         tags,
+		CrossIDs:     crossIDs,
+		HTMLFile:     fmt.Sprintf("articles/%d.html", a.ID),
+		MarkdownFile: fmt.Sprintf("articles/%d.md", a.ID),
+		Channel:      row.Channel,
+	}
+	if err := e.articleAddon(&item); err != nil {
+		return BundleArticle{}, err
+	}
+	if !a.ExpireDate.IsZero() {
+		item.ExpireDate = &a.ExpireDate
+	}
+	if a.ArcRank == model.ArcRankRecycled {
+		item.DeletedArcRank = row.DeletedArcRank
+		if a.DeletedAt.Unix() > 0 {
+			item.DeletedAt = &a.DeletedAt
+		}
+	}
+
+	frontMatter, err := yaml.Marshal(BundleFrontMatter{
+		ID:       a.ID,
+		Title:    a.Title,
+		Category: a.TypeID,
+		Date:     a.PubDate,
+		Writer:   a.Writer,
+		Tags:     tags,
+		Format:   format,
+	})
+	if err != nil {
+		return BundleArticle{}, err
+	}
+
+	if err := e.writeFile(item.HTMLFile, a.Body); err != nil {
+		return BundleArticle{}, err
+	}
+	if err := e.writeFile(item.MarkdownFile, "---\n"+string(frontMatter)+"---\n\n"+source); err != nil {
+		return BundleArticle{}, err
+	}
+
+	e.collectUploads(a.LitPic, a.Body, a.Markdown)
+	return item, nil
+}
+
+// loadContentModels 读取自定义内容模型的字段定义，字段定义无效的模型不导出
+func (e *bundleExport) loadContentModels() error {
+	contentModel := model.NewContentModelModel(e.db)
+	models, err := contentModel.GetAll()
+	if err != nil {
+		return err
+	}
+	e.contentModels = e.contentModels[:0]
+	for _, m := range models {
+		fields, err := model.DecodeFields(m.Fields)
+		if err != nil {
+			logger.Error("内容模型字段定义无效，不导出附加字段", "model", m.TableName, "error", err)
+			continue
+		}
+		e.contentModels = append(e.contentModels, bundleContentModel{
+			tableName: m.TableName,
+			table:     contentModel.ContentTable(m),
+			fields:    fields,
+		})
+	}
+	return nil
+}
+
+// articleAddon 读取下载和产品频道文章以及自定义内容模型的附加信息
+func (e *bundleExport) articleAddon(item *BundleArticle) error {
+	switch item.Channel {
+	case 3:
+		download := &BundleDownload{}
+		found, err := database.NewQueryBuilder(e.db, "addondownload").Where("aid = ?", item.ID).FirstInto(download)
+		if err != nil || !found {
+			return err
+		}
+		if download.Screenshots, err = e.addonImages("download_screenshots", "screenshot", item.ID); err != nil {
+			return err
+		}
+		item.Download = download
+		e.collectUploads(download.Screenshots...)
+	case 2:
+		product := &BundleProduct{}
+		found, err := database.NewQueryBuilder(e.db, "addonproduct").Where("aid = ?", item.ID).FirstInto(product)
+		if err != nil || !found {
+			return err
+		}
+		if product.Images, err = e.addonImages("product_images", "image", item.ID); err != nil {
+			return err
+		}
+		item.Product = product
+		e.collectUploads(product.Images...)
+		e.collectUploads(product.Specification, product.Features, product.Parameters)
+	}
+	return e.contentFields(item)
+}
+
+// contentFields 读取文章在各自定义内容模型中的附加字段
+func (e *bundleExport) contentFields(item *BundleArticle) error {
+	for _, m := range e.contentModels {
+		row, err := database.NewQueryBuilder(e.db, m.table).Where("aid = ?", item.ID).First()
+		if err != nil {
+			return fmt.Errorf("内容模型 %s: %v", m.tableName, err)
+		}
+		if row == nil {
+			continue
+		}
+		values := make(map[string]string, len(m.fields))
+		for _, field := range m.fields {
+			value, ok := row[field.Name]
+			if !ok || value == nil {
+				continue
+			}
+			text := database.ValueString(value)
+			if t, ok := value.(time.Time); ok && field.Type == "date" {
+				text = t.Format("2006-01-02")
+			}
+			values[field.Name] = text
+			e.collectUploads(text)
+		}
+		if item.Fields == nil {
+			item.Fields = make(map[string]map[string]string)
+		}
+		item.Fields[m.tableName] = values
+	}
+	return nil
+}
+
+// addonImages 读取文章的截图或产品图片地址
+func (e *bundleExport) addonImages(table, column string, aid int64) ([]string, error) {
+	var rows []struct {
+		URL string `db:"url"`
+	}
+	err := database.NewQueryBuilder(e.db, table).
+		Select(column+" AS url").
+		Where("aid = ?", aid).
+		OrderBy("id ASC").
+		GetInto(&rows)
+	if err != nil {
+		return nil, err
+	}
+	images := make([]string, 0, len(rows))
+	for _, row := range rows {
+		images = append(images, row.URL)
+	}
+	return images, nil
+}
+
+// tags 导出标签
+func (e *bundleExport) tags() (int, error) {
+	qb := database.NewQueryBuilder(e.db, "tagindex")
+	qb.OrderBy("id ASC")
+
+	tags := make([]*model.Tag, 0)
+	if err := qb.GetInto(&tags); err != nil {
+		return 0, err
+	}
+
+	list := make([]BundleTag, 0, len(tags))
+	for _, t := range tags {
+		list = append(list, BundleTag{
+			Name:  t.Tag,
+			Rank:  t.Rank,
+			Hot:   t.IsHot == 1,
+			Count: t.Count,
+		})
+	}
+	return len(list), e.writeJSON(bundleTagsFile, list)
+}
+
+// specials 导出专题及专题中的文章，包括未启用的专题
+func (e *bundleExport) specials() (int, error) {
+	qb := database.NewQueryBuilder(e.db, "special")
+	qb.OrderBy("id ASC")
+
+	specials := make([]*model.Special, 0)
+	if err := qb.GetInto(&specials); err != nil {
+		return 0, err
+	}
+
+	list := make([]BundleSpecial, 0, len(specials))
+	for _, s := range specials {
+		articles := make([]BundleSpecialArticle, 0)
+		err := database.NewQueryBuilder(e.db, "special_content").
+			Select("aid", "sortrank").
+			Where("specialid = ?", s.ID).
+			OrderBy("sortrank ASC, id ASC").
+			GetInto(&articles)
+		if err != nil {
+			return 0, err
+		}
+
+		list = append(list, BundleSpecial{
+			ID:           s.ID,
+			CategoryID:   s.Typeid,
+			Title:        s.Title,
+			Note:         s.Note,
+			Pic:          s.Pic,
+			PubDate:      s.PubDate,
+			Hot:          s.IsHot == 1,
+			Click:        s.Click,
+			Template:     s.Template,
+			TemplateList: s.TemplateList,
+			Filename:     s.Filename,
+			Status:       s.Status,
+			Keywords:     s.Keywords,
+			Description:  s.Description,
+			Content:      s.Content,
+			Articles:     articles,
+		})
+		e.collectUploads(s.Pic, s.Content)
+	}
+	return len(list), e.writeJSON(bundleSpecialsFile, list)
+}
+
+// comments 按ID分批导出评论，评论内容还原为纯文本
+func (e *bundleExport) comments() (int, error) {
+	list := make([]BundleComment, 0)
+	last := int64(0)
+	for {
+		qb := database.NewQueryBuilder(e.db, "feedback")
+		qb.Where("id > ?", last)
+		qb.OrderBy("id ASC")
+		qb.Limit(exportBatchSize)
+
+		comments := make([]*model.Comment, 0)
+		if err := qb.GetInto(&comments); err != nil {
+			return 0, err
+		}
+		for _, c := range comments {
+			last = c.ID
+			list = append(list, BundleComment{
+				ID:        c.ID,
+				ArticleID: c.AID,
+				ParentID:  c.ParentID,
+				Username:  c.Username,
+				Content:   html.UnescapeString(c.Content),
+				Time:      c.Dtime,
+				Approved:  c.IsCheck == 1,
+				IP:        c.IP,
+				Score:     c.Score,
+				Good:      c.GoodCount,
+				Bad:       c.BadCount,
+				UserFace:  c.UserFace,
+			})
+		}
+		if len(comments) < exportBatchSize {
+			break
+		}
+	}
+	return len(list), e.writeJSON(bundleCommentsFile, list)
+}
+
+// uploadURLPrefix 上传文件的访问地址前缀
+func uploadURLPrefix(cfg *config.Config) string {
+	return cfg.Upload.URLPrefix()
+}
+
+// collectUploads 收集内容中引用的上传文件地址
+func (e *bundleExport) collectUploads(texts ...string) {
+	for _, text := range texts {
+		for _, u := range e.uploadPattern.FindAllString(text, -1) {
+			e.uploads[u] = true
+		}
+	}
+}
+
+// files 写入上传文件清单，IncludeFiles时同时打包文件，返回引用数和打包的文件数
+func (e *bundleExport) files() (int, int, error) {
+	urls := make([]string, 0, len(e.uploads))
+	for u := range e.uploads {
+		urls = append(urls, u)
+	}
+	sort.Strings(urls)
+
+	prefix := uploadURLPrefix(e.config)
+	list := make([]BundleUpload, 0, len(urls))
+	files := 0
+	for _, u := range urls {
+		item := BundleUpload{URL: u}
+		rel := path.Clean(strings.TrimPrefix(u, prefix))
+		local := filepath.Join(e.config.Upload.LocalDir(), filepath.FromSlash(rel))
+		info, err := os.Stat(local)
+		if err == nil && info.Mode().IsRegular() && !strings.HasPrefix(rel, "../") {
+			item.Size = info.Size()
+			if e.opts.IncludeFiles {
+				item.File = "uploads/" + rel
+				if err := e.copyFile(item.File, local); err != nil {
+					return 0, 0, err
+				}
+				files++
+			}
+		}
+		list = append(list, item)
+	}
+	return len(list), files, e.writeJSON(bundleUploadsFile, list)
+}
+
+// copyFile 将本地文件写入导出包
+func (e *bundleExport) copyFile(name, local string) error {
+	src, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	f, err := e.create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, src)
+	return err
+}
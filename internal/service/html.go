@@ -248,6 +248,22 @@ func (s *HtmlService) GenerateList(typeid int64) error {
 	return nil
 }
 
+// GenerateArticleLists 重新生成列表中包含该文章的栏目列表页：主栏目、副栏目以及交叉栏目包含它们的栏目
+func (s *HtmlService) GenerateArticleLists(aid, typeid int64) error {
+	typeids, err := s.articleModel.ListedTypeIDs(aid, typeid)
+	if err != nil {
+		logger.Error("获取文章所在栏目失败", "id", aid, "error", err)
+		return err
+	}
+
+	for _, id := range typeids {
+		if err := s.GenerateList(id); err != nil {
+			logger.Error("重新生成栏目列表页失败", "typeid", id, "error", err)
+		}
+	}
+	return nil
+}
+
 // GenerateArticle 生成文章页
 func (s *HtmlService) GenerateArticle(id int64) error {
 	logger.Info("开始生成文章页", "id", id)
@@ -265,6 +281,12 @@ func (s *HtmlService) GenerateArticle(id int64) error {
 		return nil
 	}
 
+	// 未发布或在回收站中的文章不生成，并删除已有的静态页
+	if !article.IsPublished() {
+		logger.Info("文章未发布，跳过生成", "id", id)
+		return s.RemoveArticle(id)
+	}
+
 	// 获取栏目信息
 	category, err := s.categoryModel.GetByID(article.TypeID)
 	if err != nil {
@@ -272,13 +294,13 @@ func (s *HtmlService) GenerateArticle(id int64) error {
 	}
 
 	// 获取上一篇文章
-	prevArticle, err := s.articleModel.GetByID(id - 1)
+	prevArticle, err := s.articleModel.GetPrevArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取上一篇文章失败", "id", id, "error", err)
 	}
 
 	// 获取下一篇文章
-	nextArticle, err := s.articleModel.GetByID(id + 1)
+	nextArticle, err := s.articleModel.GetNextArticle(id, article.TypeID)
 	if err != nil {
 		logger.Error("获取下一篇文章失败", "id", id, "error", err)
 	}
@@ -303,6 +325,12 @@ func (s *HtmlService) GenerateArticle(id int64) error {
 		"PageTitle":       article.Title + " - " + s.config.Site.Name,
 		"Keywords":        article.Keywords,
 		"Description":     article.Description,
+		"Title":           article.Title + " - " + s.config.Site.Name,
+		"SiteName":        s.config.Site.Name,
+		"CurrentTime":     time.Now().Format("2006-01-02 15:04:05"),
+		"Version":         "1.0.0",
+		"SiteURL":         s.config.Site.URL,
+		"CopyRight":       "© " + time.Now().Format("2006") + " " + s.config.Site.Name + ". All rights reserved.",
 	}
 
 	// 确定模板文件
@@ -313,36 +341,48 @@ func (s *HtmlService) GenerateArticle(id int64) error {
 		tplFile = s.config.Template.DefaultTpl + "/article.htm"
 	}
 
-	// 生成静态页面
-	staticPath := fmt.Sprintf("a/%d.html", id)
-
-	// 确保目录存在
-	dir := filepath.Dir(staticPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		logger.Error("创建目录失败", "dir", dir, "error", err)
+	// 删除旧的分页，分页数减少时不留下多余的页面
+	if err := s.removeArticlePages(id); err != nil {
 		return err
 	}
 
-	// 生成静态页面
-	err = s.templateService.GenerateStaticPage(tplFile, data, staticPath)
-	if err != nil {
-		logger.Error("生成静态文章页失败", "id", id, "error", err)
-		return err
-	}
-
-	// 生成移动端文章页
+	// 按分页符拆分内容，每页生成一个静态页面
+	pages := model.SplitPages(article.Body, s.config.Site.PageBreak)
 	mobileTplFile := s.config.Template.DefaultTpl + "/mobile/article.htm"
-	if fileExists(filepath.Join(s.config.Template.Dir, mobileTplFile)) {
-		mobileStaticPath := fmt.Sprintf("m/a/%d.html", id)
+	hasMobile := fileExists(filepath.Join(s.config.Template.Dir, mobileTplFile))
+	for page := 1; page <= len(pages); page++ {
+		pageData := ArticlePageData(data, article, pages, page, ArticlePageURL(id, true))
+
+		// 生成静态页面
+		staticPath := ArticleStaticPath(id, page)
 
 		// 确保目录存在
-		mobileDir := filepath.Dir(mobileStaticPath)
-		if err := os.MkdirAll(mobileDir, 0755); err != nil {
-			logger.Error("创建移动端目录失败", "dir", mobileDir, "error", err)
-		} else {
-			err = s.templateService.GenerateStaticPage(mobileTplFile, data, mobileStaticPath)
-			if err != nil {
-				logger.Error("生成移动端静态文章页失败", "id", id, "error", err)
+		dir := filepath.Dir(staticPath)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			logger.Error("创建目录失败", "dir", dir, "error", err)
+			return err
+		}
+
+		err = s.templateService.GenerateStaticPage(tplFile, pageData, staticPath)
+		if err != nil {
+			logger.Error("生成静态文章页失败", "id", id, "page", page, "error", err)
+			return err
+		}
+
+		// 生成移动端文章页
+		if hasMobile {
+			mobileStaticPath := "m/" + staticPath
+
+			// 确保目录存在
+			mobileDir := filepath.Dir(mobileStaticPath)
+			if err := os.MkdirAll(mobileDir, 0755); err != nil {
+				logger.Error("创建移动端目录失败", "dir", mobileDir, "error", err)
+			} else {
+				mobileData := ArticlePageData(data, article, pages, page, "/m"+ArticlePageURL(id, true))
+				err = s.templateService.GenerateStaticPage(mobileTplFile, mobileData, mobileStaticPath)
+				if err != nil {
+					logger.Error("生成移动端静态文章页失败", "id", id, "page", page, "error", err)
+				}
 			}
 		}
 	}
@@ -351,6 +391,86 @@ func (s *HtmlService) GenerateArticle(id int64) error {
 	return nil
 }
 
+// RemoveArticle 删除文章的静态页面，包括所有分页
+func (s *HtmlService) RemoveArticle(id int64) error {
+	for _, path := range []string{fmt.Sprintf("a/%d.html", id), fmt.Sprintf("m/a/%d.html", id)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Error("删除静态文章页失败", "path", path, "error", err)
+			return err
+		}
+	}
+	if err := s.removeArticlePages(id); err != nil {
+		return err
+	}
+
+	logger.Info("文章静态页已删除", "id", id)
+	return nil
+}
+
+// removeArticlePages 删除文章第2页及以后的静态页面
+func (s *HtmlService) removeArticlePages(id int64) error {
+	for _, pattern := range []string{fmt.Sprintf("a/%d_*.html", id), fmt.Sprintf("m/a/%d_*.html", id)} {
+		paths, _ := filepath.Glob(pattern)
+		for _, path := range paths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				logger.Error("删除静态文章分页失败", "path", path, "error", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ArticleStaticPath 文章第page页的静态文件路径，第1页为a/{id}.html，其余为a/{id}_{page}.html
+func ArticleStaticPath(id int64, page int) string {
+	if page <= 1 {
+		return fmt.Sprintf("a/%d.html", id)
+	}
+	return fmt.Sprintf("a/%d_%d.html", id, page)
+}
+
+// ArticlePageURL 文章第1页的地址，分页地址在其后加_{page}；静态化时为静态文件地址
+func ArticlePageURL(id int64, static bool) string {
+	if static {
+		return "/" + ArticleStaticPath(id, 1)
+	}
+	return fmt.Sprintf("/article/%d.html", id)
+}
+
+// ArticlePageData 复制模板数据并替换为文章第page页的内容，ArticlePagination供pagebreak标签使用，
+// pageURL为第1页的地址
+func ArticlePageData(data map[string]interface{}, article *model.Article, pages []model.ArticlePage, page int, pageURL string) map[string]interface{} {
+	pageData := make(map[string]interface{}, len(data)+3)
+	for k, v := range data {
+		pageData[k] = v
+	}
+
+	// 复制文章，不修改原文章的内容
+	current := *article
+	current.Body = pages[page-1].Body
+	current.Content = current.Body
+
+	titles := make([]string, len(pages))
+	for i, p := range pages {
+		titles[i] = p.Title
+	}
+
+	pageData["Article"] = &current
+	pageData["ArticlePage"] = pages[page-1]
+	pageData["ArticlePagination"] = map[string]interface{}{
+		"CurrentPage": page,
+		"TotalPages":  len(pages),
+		"HasPrev":     page > 1,
+		"HasNext":     page < len(pages),
+		"PrevPage":    page - 1,
+		"NextPage":    page + 1,
+		"PageUrl":     pageURL,
+		"Titles":      titles,
+	}
+
+	return pageData
+}
+
 // GenerateProduct 生成产品页
 func (s *HtmlService) GenerateProduct(id int64) error {
 	logger.Info("开始生成产品页", "id", id)
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/pkg/security"
+)
+
+// 远程图片下载参数
+const (
+	remoteImageTimeout      = 30 * time.Second
+	remoteImageMaxCount     = 50 // 每篇内容最多下载的图片数
+	remoteImageMaxRedirects = 5  // 最多跟随的重定向次数
+)
+
+// errForbiddenAddress 远程地址解析到内网、回环或链路本地地址
+var errForbiddenAddress = errors.New("不允许访问内网地址")
+
+// carrierNAT 运营商级NAT地址段，net.IP.IsPrivate不包含
+var carrierNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// imgSrcPattern 匹配img标签的src属性，分组依次为src之前的部分、双引号、单引号和无引号的值
+var imgSrcPattern = regexp.MustCompile(`(?i)(<img\b[^>]*?\ssrc\s*=\s*)(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+// imageTypes 允许下载的图片类型及保存的扩展名
+var imageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+}
+
+// ImageService 远程图片本地化服务，将内容中引用的外站图片下载到上传目录
+type ImageService struct {
+	db     *database.DB
+	cache  cache.Cache
+	config *config.Config
+	client *http.Client
+}
+
+// NewImageService 创建远程图片本地化服务
+func NewImageService(db *database.DB, cache cache.Cache, config *config.Config) *ImageService {
+	return &ImageService{
+		db:     db,
+		cache:  cache,
+		config: config,
+		client: newRemoteClient(),
+	}
+}
+
+// newRemoteClient 创建下载远程图片的HTTP客户端，连接时按解析后的IP拒绝内网地址，重定向的每一跳都会重新检查
+func newRemoteClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !publicIP(net.ParseIP(host)) {
+				return fmt.Errorf("%w: %s", errForbiddenAddress, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: remoteImageTimeout,
+		Transport: &http.Transport{
+			// 不使用环境变量中的代理，否则检查的是代理地址而不是目标地址
+			Proxy: nil,
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, addr)
+			},
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: remoteImageTimeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= remoteImageMaxRedirects {
+				return fmt.Errorf("重定向次数超过%d次", remoteImageMaxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("不支持的重定向地址: %s", req.URL)
+			}
+			return nil
+		},
+	}
+}
+
+// publicIP 是否为可访问的公网地址
+func publicIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if ip4[0] == 0 || carrierNAT.Contains(ip4) {
+			return false
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// LocalizeHTML 下载HTML中的远程图片并改写为本地地址，baseURL用于解析相对地址（采集的内容），
+// 返回改写后的内容和远程地址到本地地址的映射；下载失败的图片保留原地址
+func (s *ImageService) LocalizeHTML(body, baseURL string) (string, map[string]string) {
+	var base *url.URL
+	if baseURL != "" {
+		base, _ = url.Parse(baseURL)
+	}
+
+	replaced := make(map[string]string)
+	failed := make(map[string]bool)
+	body = imgSrcPattern.ReplaceAllStringFunc(body, func(tag string) string {
+		m := imgSrcPattern.FindStringSubmatch(tag)
+		raw := m[2] + m[3] + m[4]
+		src := strings.TrimSpace(html.UnescapeString(raw))
+
+		remote := s.remoteURL(src, base)
+		if remote == "" {
+			return tag
+		}
+
+		local, ok := replaced[src]
+		if !ok && !failed[src] && len(replaced) < remoteImageMaxCount {
+			var err error
+			local, err = s.download(remote, baseURL)
+			if err != nil {
+				logger.Error("下载远程图片失败", "url", remote, "error", err)
+				failed[src] = true
+			} else {
+				replaced[src] = local
+			}
+		}
+		if local == "" {
+			// 采集内容中的相对地址改为绝对地址，避免在本站失效
+			if remote == src {
+				return tag
+			}
+			local = remote
+		}
+
+		return m[1] + `"` + html.EscapeString(local) + `"`
+	})
+
+	return body, replaced
+}
+
+// LocalizeArticle 下载文章内容中的远程图片并改写为本地地址，Markdown文章同时改写原文；
+// 没有缩略图时使用内容中的第一张本地图片，返回下载的图片数
+func (s *ImageService) LocalizeArticle(article *model.Article) int {
+	body, replaced := s.LocalizeHTML(article.Body, "")
+	article.Body = body
+	article.Content = body
+
+	if article.IsMarkdown() {
+		for remote, local := range replaced {
+			article.Markdown = strings.ReplaceAll(article.Markdown, remote, local)
+		}
+	}
+
+	if article.LitPic == "" {
+		article.LitPic = s.FirstImage(article.Body)
+	}
+
+	return len(replaced)
+}
+
+// FirstImage 返回HTML中第一张本站图片的地址
+func (s *ImageService) FirstImage(body string) string {
+	for _, m := range imgSrcPattern.FindAllStringSubmatch(body, -1) {
+		src := strings.TrimSpace(html.UnescapeString(m[2] + m[3] + m[4]))
+		if src != "" && !strings.HasPrefix(src, "data:") && s.remoteURL(src, nil) == "" {
+			return src
+		}
+	}
+	return ""
+}
+
+// remoteURL 返回需要下载的图片的绝对地址，本站图片和无法识别的地址返回空字符串
+func (s *ImageService) remoteURL(src string, base *url.URL) string {
+	if src == "" || strings.HasPrefix(src, "data:") {
+		return ""
+	}
+	// 上传文件使用CDN等外部地址时同样视为本站图片
+	if prefix := s.config.Upload.URLPrefix(); strings.Contains(prefix, "://") && strings.HasPrefix(src, prefix) {
+		return ""
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case u.IsAbs():
+	case base != nil:
+		u = base.ResolveReference(u)
+	case u.Host != "":
+		// 省略协议的地址
+		u.Scheme = "https"
+	default:
+		return ""
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	// 本站地址不需要下载
+	if site, err := url.Parse(s.config.Site.URL); err == nil && site.Host != "" && strings.EqualFold(site.Host, u.Host) {
+		return ""
+	}
+
+	return u.String()
+}
+
+// download 下载图片到上传目录的images/年月目录，检查大小和图片类型，返回本地地址
+func (s *ImageService) download(remote, referer string) (string, error) {
+	req, err := http.NewRequestWithContext(s.db.Context(), http.MethodGet, remote, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; aq3cms)")
+	if referer != "" {
+		req.Header.Set("Referer", referer)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP状态码 %d", resp.StatusCode)
+	}
+
+	// 检查大小，未配置时限制为10MB
+	limit := s.config.Upload.MaxSize
+	if limit <= 0 {
+		limit = 10
+	}
+	maxSize := int64(limit) * 1024 * 1024
+	if resp.ContentLength > maxSize {
+		return "", fmt.Errorf("图片超过%dMB", limit)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > maxSize {
+		return "", fmt.Errorf("图片超过%dMB", limit)
+	}
+
+	// 按内容判断图片类型，不信任地址和响应头
+	ext, ok := imageTypes[http.DetectContentType(data)]
+	if !ok {
+		return "", fmt.Errorf("不支持的图片类型: %s", http.DetectContentType(data))
+	}
+	if !s.allowedExt(ext) {
+		return "", fmt.Errorf("不允许上传的扩展名: %s", ext)
+	}
+
+	// 保存文件
+	now := time.Now()
+	rel := filepath.Join("images", now.Format("200601"), fmt.Sprintf("%d_%s%s", now.Unix(), security.RandomString(8), ext))
+	path := filepath.Join(s.config.Upload.LocalDir(), rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+
+	// 地址由访问前缀和相对上传目录的路径组成，与文件在磁盘上的位置无关
+	return s.config.Upload.URLPrefix() + filepath.ToSlash(rel), nil
+}
+
+// allowedExt 扩展名是否在允许上传的列表中，没有配置时允许所有图片类型
+func (s *ImageService) allowedExt(ext string) bool {
+	if strings.TrimSpace(s.config.Upload.AllowedExts) == "" {
+		return true
+	}
+	for _, allowed := range strings.Split(s.config.Upload.AllowedExts, ",") {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == ext || (ext == ".jpg" && allowed == ".jpeg") {
+			return true
+		}
+	}
+	return false
+}
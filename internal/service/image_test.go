@@ -0,0 +1,215 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "aq3cms-logs")
+	if err != nil {
+		panic(err)
+	}
+	logger.Init("error", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// testPNG 1x1的PNG图片
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newTestImageService 创建保存到临时目录的服务，测试服务器在本机，需要替换掉拒绝内网地址的客户端
+func newTestImageService(t *testing.T, srv *httptest.Server) (*ImageService, string) {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Upload.Dir = dir
+	cfg.Upload.URL = "/uploads"
+	cfg.Upload.MaxSize = 1
+	cfg.Upload.AllowedExts = ".jpg,.png,.gif"
+
+	s := NewImageService(&database.DB{}, nil, cfg)
+	s.client = srv.Client()
+	return s, dir
+}
+
+// newImageServer 提供测试图片的服务器
+func newImageServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	pic := testPNG(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/img/a.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(pic)
+	})
+	mux.HandleFunc("/post/b.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(pic)
+	})
+	mux.HandleFunc("/big.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(append(pic, make([]byte, 2*1024*1024)...))
+	})
+	mux.HandleFunc("/page.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("<html><body>not an image</body></html>"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+var localSrcPattern = regexp.MustCompile(`src="(/uploads/images/\d{6}/[^"]+\.png)"`)
+
+// assertLocalImage 检查内容中的图片已改写为本地地址且文件存在，返回本地地址
+func assertLocalImage(t *testing.T, body, dir string) string {
+	t.Helper()
+	m := localSrcPattern.FindStringSubmatch(body)
+	if m == nil {
+		t.Fatalf("图片地址未改写: %s", body)
+	}
+	local := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(m[1], "/uploads/")))
+	if _, err := os.Stat(local); err != nil {
+		t.Fatalf("图片文件不存在: %v", err)
+	}
+	return m[1]
+}
+
+func TestLocalizeHTMLRewritesSrc(t *testing.T) {
+	srv := newImageServer(t)
+	s, dir := newTestImageService(t, srv)
+
+	remote := srv.URL + "/img/a.png"
+	body, replaced := s.LocalizeHTML(`<p><img alt="a" src='`+remote+`'><img src="`+remote+`"></p>`, "")
+
+	local := assertLocalImage(t, body, dir)
+	if replaced[remote] != local {
+		t.Errorf("映射 = %v, 期望 %s", replaced, local)
+	}
+	if strings.Contains(body, remote) || strings.Count(body, local) != 2 {
+		t.Errorf("重复的图片应只下载一次并全部改写: %s", body)
+	}
+	if strings.HasPrefix(local, "//") || strings.Contains(local, dir) {
+		t.Errorf("本地地址不应包含磁盘路径: %s", local)
+	}
+}
+
+func TestLocalizeHTMLRejectsInvalidImages(t *testing.T) {
+	srv := newImageServer(t)
+	s, _ := newTestImageService(t, srv)
+
+	for _, name := range []string{"/big.png", "/page.png", "/missing.png"} {
+		src := `<img src="` + srv.URL + name + `">`
+		body, replaced := s.LocalizeHTML(src, "")
+		if body != src || len(replaced) != 0 {
+			t.Errorf("%s 应保留原地址: %s %v", name, body, replaced)
+		}
+	}
+}
+
+func TestLocalizeHTMLResolvesRelativeURL(t *testing.T) {
+	srv := newImageServer(t)
+	s, dir := newTestImageService(t, srv)
+
+	baseURL := srv.URL + "/post/1.html"
+	for _, src := range []string{"/img/a.png", "b.png"} {
+		body, _ := s.LocalizeHTML(`<img src="`+src+`">`, baseURL)
+		assertLocalImage(t, body, dir)
+	}
+
+	// 没有baseURL时相对地址视为本站地址
+	body, replaced := s.LocalizeHTML(`<img src="/img/a.png">`, "")
+	if body != `<img src="/img/a.png">` || len(replaced) != 0 {
+		t.Errorf("本站相对地址不应下载: %s", body)
+	}
+}
+
+func TestLocalizeArticleLitPicFallback(t *testing.T) {
+	srv := newImageServer(t)
+	s, dir := newTestImageService(t, srv)
+
+	article := &model.Article{Body: `<p>text</p><img src="` + srv.URL + `/img/a.png">`}
+	if n := s.LocalizeArticle(article); n != 1 {
+		t.Fatalf("下载数 = %d, 期望 1", n)
+	}
+	local := assertLocalImage(t, article.Body, dir)
+	if article.LitPic != local {
+		t.Errorf("LitPic = %q, 期望 %q", article.LitPic, local)
+	}
+
+	// 已有缩略图时保持不变
+	article = &model.Article{Body: `<img src="` + srv.URL + `/img/a.png">`, LitPic: "/uploads/thumb.jpg"}
+	s.LocalizeArticle(article)
+	if article.LitPic != "/uploads/thumb.jpg" {
+		t.Errorf("LitPic被覆盖: %q", article.LitPic)
+	}
+}
+
+func TestRemoteClientRejectsPrivateAddress(t *testing.T) {
+	srv := newImageServer(t)
+	s, _ := newTestImageService(t, srv)
+	s.client = newRemoteClient()
+
+	src := `<img src="` + srv.URL + `/img/a.png">`
+	if body, replaced := s.LocalizeHTML(src, ""); body != src || len(replaced) != 0 {
+		t.Errorf("不应下载本机地址的图片: %s", body)
+	}
+
+}
+
+func TestRemoteClientLimitsRedirects(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL+r.URL.Path+"x", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	// 只替换连接方式，保留重定向检查
+	client := newRemoteClient()
+	client.Transport = srv.Client().Transport
+	if _, err := client.Get(srv.URL + "/a"); err == nil || !strings.Contains(err.Error(), "重定向次数") {
+		t.Errorf("重定向次数应受限制: %v", err)
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"::ffff:10.0.0.1": false,
+	}
+	for addr, want := range cases {
+		if got := publicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("publicIP(%s) = %v, 期望 %v", addr, got, want)
+		}
+	}
+}
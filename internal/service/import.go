@@ -0,0 +1,660 @@
+package service
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/pkg/security"
+)
+
+// DefaultImportDir 导入时生成的地址映射文件目录
+const DefaultImportDir = "data/import"
+
+// DefaultWXRUploadURL WordPress附件的新地址前缀，需要把wp-content/uploads目录复制到uploads/wordpress
+const DefaultWXRUploadURL = "/uploads/wordpress"
+
+// ImportOptions 导入选项
+type ImportOptions struct {
+	DefaultTypeID int64  // 没有分类的文章导入到该栏目
+	UploadURL     string // 附件的新地址前缀
+}
+
+// URLMapping 旧地址到新地址的映射，用于设置旧地址跳转
+type URLMapping struct {
+	Type string `json:"type"` // post、category、tag、attachment
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// ImportResult 导入结果
+type ImportResult struct {
+	Categories  int          `json:"categories"`  // 新建的栏目数
+	Articles    int          `json:"articles"`    // 导入的文章数
+	Comments    int          `json:"comments"`    // 导入的评论数
+	Members     int          `json:"members"`     // 导入的会员数
+	Links       int          `json:"links"`       // 导入的友情链接数
+	Specials    int          `json:"specials"`    // 导入的专题数
+	Files       int          `json:"files"`       // 还原的上传文件数
+	Attachments int          `json:"attachments"` // 改写地址的附件数
+	Skipped     int          `json:"skipped"`     // 跳过的文章和评论数
+	Errors      []string     `json:"errors"`      // 导入失败的记录
+	URLMap      []URLMapping `json:"url_map"`     // 旧地址映射
+}
+
+// WriteURLMap 将地址映射写入JSON文件
+func (r *ImportResult) WriteURLMap(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r.URLMap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// addError 记录导入失败的记录
+func (r *ImportResult) addError(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// addURL 记录地址映射，跳过空地址和相同地址
+func (r *ImportResult) addURL(typ, oldURL, newURL string) {
+	if oldURL == "" || oldURL == newURL {
+		return
+	}
+	r.URLMap = append(r.URLMap, URLMapping{Type: typ, Old: oldURL, New: newURL})
+}
+
+// ImportService 外部系统数据导入服务
+type ImportService struct {
+	db            *database.DB
+	cache         cache.Cache
+	config        *config.Config
+	articleModel  *model.ArticleModel
+	categoryModel *model.CategoryModel
+	commentModel  *model.CommentModel
+	tagModel      *model.TagModel
+}
+
+// NewImportService 创建数据导入服务
+func NewImportService(db *database.DB, cache cache.Cache, config *config.Config) *ImportService {
+	return &ImportService{
+		db:            db,
+		cache:         cache,
+		config:        config,
+		articleModel:  model.NewArticleModel(db),
+		categoryModel: model.NewCategoryModel(db),
+		commentModel:  model.NewCommentModel(db),
+		tagModel:      model.NewTagModel(db),
+	}
+}
+
+// wxrFile WordPress导出文件（WXR）
+type wxrFile struct {
+	Channel wxrChannel `xml:"channel"`
+}
+
+// wxrChannel 站点信息，wp命名空间的版本号随WordPress版本变化，因此只按本地名匹配
+type wxrChannel struct {
+	Link        string        `xml:"link"`
+	BaseSiteURL string        `xml:"base_site_url"`
+	BaseBlogURL string        `xml:"base_blog_url"`
+	Authors     []wxrAuthor   `xml:"author"`
+	Categories  []wxrCategory `xml:"category"`
+	Tags        []wxrTagDef   `xml:"tag"`
+	Items       []wxrItem     `xml:"item"`
+}
+
+// wxrAuthor 作者
+type wxrAuthor struct {
+	Login       string `xml:"author_login"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+// wxrCategory 分类
+type wxrCategory struct {
+	Slug        string `xml:"category_nicename"`
+	Parent      string `xml:"category_parent"`
+	Name        string `xml:"cat_name"`
+	Description string `xml:"category_description"`
+}
+
+// wxrTagDef 标签
+type wxrTagDef struct {
+	Slug string `xml:"tag_slug"`
+	Name string `xml:"tag_name"`
+}
+
+// wxrItem 文章、页面或附件
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	Link          string        `xml:"link"`
+	GUID          string        `xml:"guid"`
+	Creator       string        `xml:"creator"`
+	Encoded       []wxrEncoded  `xml:"encoded"`
+	PostID        int64         `xml:"post_id"`
+	PostDate      string        `xml:"post_date"`
+	PostDateGMT   string        `xml:"post_date_gmt"`
+	Status        string        `xml:"status"`
+	PostType      string        `xml:"post_type"`
+	AttachmentURL string        `xml:"attachment_url"`
+	Terms         []wxrTerm     `xml:"category"`
+	Meta          []wxrPostMeta `xml:"postmeta"`
+	Comments      []wxrComment  `xml:"comment"`
+}
+
+// wxrEncoded content:encoded为正文，excerpt:encoded为摘要
+type wxrEncoded struct {
+	XMLName xml.Name
+	Text    string `xml:",chardata"`
+}
+
+// wxrTerm 文章所属的分类或标签
+type wxrTerm struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+// wxrPostMeta 文章自定义字段
+type wxrPostMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// wxrComment 评论
+type wxrComment struct {
+	ID       int64  `xml:"comment_id"`
+	Author   string `xml:"comment_author"`
+	IP       string `xml:"comment_author_IP"`
+	Date     string `xml:"comment_date"`
+	DateGMT  string `xml:"comment_date_gmt"`
+	Content  string `xml:"comment_content"`
+	Approved string `xml:"comment_approved"`
+	Type     string `xml:"comment_type"`
+	Parent   int64  `xml:"comment_parent"`
+}
+
+// content 正文和摘要
+func (item *wxrItem) content() (body, excerpt string) {
+	for _, e := range item.Encoded {
+		switch {
+		case strings.Contains(e.XMLName.Space, "excerpt"):
+			excerpt = e.Text
+		case strings.Contains(e.XMLName.Space, "content"):
+			body = e.Text
+		}
+	}
+	return body, excerpt
+}
+
+// meta 获取自定义字段
+func (item *wxrItem) meta(key string) string {
+	for _, m := range item.Meta {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+// wxrImport 一次WXR导入的状态
+type wxrImport struct {
+	*ImportService
+	opts       ImportOptions
+	result     *ImportResult
+	channel    *wxrChannel
+	categories map[string]*wxrCategory // 分类别名到分类定义
+	typeIDs    map[string]int64        // 分类别名到栏目ID
+	uploads    []string                // 旧附件目录地址
+	imported   map[string]string       // 之前导入时记录的文章旧地址到新地址
+}
+
+// ImportWXR 导入WordPress导出文件：分类导入为栏目，文章保留原发布时间和作者，评论保留回复关系，
+// 正文中的附件地址改写为opts.UploadURL下的地址，旧地址和新地址的对应关系记录在结果的URLMap中。
+// 重复导入时，GUID已在之前的地址映射文件中且对应文章仍存在的文章跳过
+func (s *ImportService) ImportWXR(r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	var file wxrFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("解析WXR文件失败: %v", err)
+	}
+	if opts.UploadURL == "" {
+		opts.UploadURL = DefaultWXRUploadURL
+	}
+	opts.UploadURL = strings.TrimSuffix(opts.UploadURL, "/")
+
+	imp := &wxrImport{
+		ImportService: s,
+		opts:          opts,
+		result:        &ImportResult{},
+		channel:       &file.Channel,
+		categories:    make(map[string]*wxrCategory),
+		typeIDs:       make(map[string]int64),
+		imported:      importedPosts(filepath.Join(DefaultImportDir, "wxr_*.json")),
+	}
+	imp.run()
+
+	// 批量导入后栏目、列表和标签的缓存都已失效
+	s.cache.Clear()
+
+	logger.Info("WXR导入完成", "categories", imp.result.Categories, "articles", imp.result.Articles, "comments", imp.result.Comments, "errors", len(imp.result.Errors))
+	return imp.result, nil
+}
+
+// run 依次导入分类、附件地址和文章
+func (imp *wxrImport) run() {
+	ch := imp.channel
+	for i := range ch.Categories {
+		c := &ch.Categories[i]
+		imp.categories[c.Slug] = c
+	}
+	for _, c := range ch.Categories {
+		imp.category(c.Slug, nil)
+	}
+
+	// 附件目录的旧地址，兼容http和https
+	for _, base := range []string{ch.BaseSiteURL, ch.BaseBlogURL, ch.Link} {
+		base = strings.TrimSuffix(base, "/")
+		if base == "" {
+			continue
+		}
+		for _, u := range []string{base, swapScheme(base)} {
+			dir := u + "/wp-content/uploads/"
+			if !containsString(imp.uploads, dir) {
+				imp.uploads = append(imp.uploads, dir)
+			}
+		}
+	}
+
+	// 附件地址，文章缩略图通过_thumbnail_id引用附件
+	attachments := make(map[int64]string)
+	for _, item := range ch.Items {
+		if item.PostType != "attachment" || item.AttachmentURL == "" {
+			continue
+		}
+		newURL := imp.rewriteUploads(item.AttachmentURL)
+		attachments[item.PostID] = newURL
+		imp.result.Attachments++
+		imp.result.addURL("attachment", item.AttachmentURL, newURL)
+	}
+
+	authors := make(map[string]string)
+	for _, a := range ch.Authors {
+		authors[a.Login] = a.DisplayName
+	}
+
+	for i := range ch.Items {
+		item := &ch.Items[i]
+		if item.PostType != "post" {
+			continue
+		}
+		imp.article(item, authors, attachments)
+	}
+
+	// 标签地址
+	for _, t := range ch.Tags {
+		if t.Slug == "" || ch.Link == "" {
+			continue
+		}
+		imp.result.addURL("tag", strings.TrimSuffix(ch.Link, "/")+"/tag/"+t.Slug+"/", "/tag/"+url.PathEscape(html.UnescapeString(t.Name)))
+	}
+}
+
+// category 获取分类对应的栏目ID，不存在时按分类层级创建栏目，visiting用于防止父分类循环引用
+func (imp *wxrImport) category(slug string, visiting map[string]bool) int64 {
+	if slug == "" {
+		return 0
+	}
+	if id, ok := imp.typeIDs[slug]; ok {
+		return id
+	}
+	if visiting[slug] {
+		return 0
+	}
+	if visiting == nil {
+		visiting = make(map[string]bool)
+	}
+	visiting[slug] = true
+
+	def, ok := imp.categories[slug]
+	if !ok {
+		def = &wxrCategory{Slug: slug, Name: slug}
+	}
+
+	dir := security.SanitizeFilename(unescapeSlug(slug))
+	existing, err := imp.categoryModel.GetByDir(dir)
+	if err != nil {
+		imp.result.addError("分类 %s: %v", slug, err)
+		return 0
+	}
+
+	id := int64(0)
+	if existing != nil {
+		id = existing.ID
+	} else {
+		category := &model.Category{
+			ParentID:    imp.category(def.Parent, visiting),
+			TypeName:    html.UnescapeString(def.Name),
+			TypeDir:     dir,
+			ChannelType: 1,
+			Description: html.UnescapeString(def.Description),
+		}
+		id, err = imp.categoryModel.Create(category)
+		if err != nil {
+			imp.result.addError("分类 %s: %v", slug, err)
+			return 0
+		}
+		imp.result.Categories++
+	}
+
+	imp.typeIDs[slug] = id
+	if link := imp.categoryLink(slug); link != "" {
+		imp.result.addURL("category", link, fmt.Sprintf("/list/%d.html", id))
+	}
+	return id
+}
+
+// categoryLink WordPress默认的分类地址，子分类包含父分类路径
+func (imp *wxrImport) categoryLink(slug string) string {
+	if imp.channel.Link == "" {
+		return ""
+	}
+	path := []string{slug}
+	seen := map[string]bool{slug: true}
+	for c := imp.categories[slug]; c != nil && c.Parent != "" && !seen[c.Parent]; c = imp.categories[c.Parent] {
+		seen[c.Parent] = true
+		path = append([]string{c.Parent}, path...)
+	}
+	return strings.TrimSuffix(imp.channel.Link, "/") + "/category/" + strings.Join(path, "/") + "/"
+}
+
+// article 导入一篇文章及其标签和评论
+func (imp *wxrImport) article(item *wxrItem, authors map[string]string, attachments map[int64]string) {
+	state, ok := wxrState(item.Status)
+	if !ok {
+		imp.result.Skipped++
+		return
+	}
+
+	typeID := int64(0)
+	var tags []string
+	for _, term := range item.Terms {
+		switch term.Domain {
+		case "category":
+			if typeID == 0 {
+				if _, ok := imp.categories[term.Nicename]; !ok {
+					imp.categories[term.Nicename] = &wxrCategory{Slug: term.Nicename, Name: term.Name}
+				}
+				typeID = imp.category(term.Nicename, nil)
+			}
+		case "post_tag":
+			if name := strings.TrimSpace(html.UnescapeString(term.Name)); name != "" {
+				tags = append(tags, name)
+			}
+		}
+	}
+	if typeID == 0 {
+		typeID = imp.opts.DefaultTypeID
+	}
+	title := truncateRunes(html.UnescapeString(item.Title), 60)
+	if imp.skipImported(item) {
+		return
+	}
+	if typeID == 0 {
+		imp.result.addError("文章 %s: 没有分类，请指定默认栏目", title)
+		return
+	}
+
+	writer := authors[item.Creator]
+	if writer == "" {
+		writer = item.Creator
+	}
+	pubDate := wxrTime(item.PostDateGMT, item.PostDate)
+	body, excerpt := item.content()
+
+	article := &model.Article{
+		TypeID:      typeID,
+		Title:       title,
+		Writer:      truncateRunes(writer, 30),
+		PubDate:     pubDate,
+		SendDate:    pubDate,
+		Keywords:    truncateRunes(strings.Join(tags, ","), 60),
+		Description: truncateRunes(strings.TrimSpace(html.UnescapeString(security.StripTags(excerpt))), 250),
+		State:       state,
+		Body:        security.CleanHTML(imp.rewriteUploads(wpautop(body))),
+	}
+	if id, err := strconv.ParseInt(item.meta("_thumbnail_id"), 10, 64); err == nil {
+		article.LitPic = attachments[id]
+	}
+
+	id, err := imp.articleModel.Create(article)
+	if err != nil {
+		imp.result.addError("文章 %s: %v", title, err)
+		return
+	}
+	imp.result.Articles++
+
+	if len(tags) > 0 {
+		if err := imp.tagModel.UpdateArticleTags(id, strings.Join(tags, ",")); err != nil {
+			imp.result.addError("文章 %s 的标签: %v", title, err)
+		}
+	}
+
+	newURL := fmt.Sprintf("/article/%d.html", id)
+	imp.result.addURL("post", item.Link, newURL)
+	if item.GUID != item.Link {
+		imp.result.addURL("post", item.GUID, newURL)
+	}
+
+	imp.comments(id, typeID, item.Comments)
+}
+
+// skipImported 文章的GUID已在之前的地址映射中且对应文章仍存在时跳过，并沿用之前的地址映射
+func (imp *wxrImport) skipImported(item *wxrItem) bool {
+	newURL, ok := imp.imported[item.GUID]
+	if !ok {
+		return false
+	}
+	var id int64
+	if _, err := fmt.Sscanf(newURL, "/article/%d.html", &id); err != nil {
+		return false
+	}
+	if _, err := imp.articleModel.GetByID(id); err != nil {
+		return false
+	}
+
+	imp.result.Skipped++
+	imp.result.addURL("post", item.Link, newURL)
+	if item.GUID != item.Link {
+		imp.result.addURL("post", item.GUID, newURL)
+	}
+	return true
+}
+
+// importedPosts 读取之前导入生成的地址映射文件，返回文章旧地址到新地址的映射
+func importedPosts(pattern string) map[string]string {
+	posts := make(map[string]string)
+	files, _ := filepath.Glob(pattern)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var urlMap []URLMapping
+		if err := json.Unmarshal(data, &urlMap); err != nil {
+			logger.Warn("读取地址映射失败", "file", file, "error", err)
+			continue
+		}
+		for _, m := range urlMap {
+			if m.Type == "post" {
+				posts[m.Old] = m.New
+			}
+		}
+	}
+	return posts
+}
+
+// comments 导入文章评论，按原评论ID顺序导入，保证父评论先于回复创建
+func (imp *wxrImport) comments(aid, typeID int64, comments []wxrComment) {
+	sort.Slice(comments, func(i, j int) bool { return comments[i].ID < comments[j].ID })
+
+	ids := make(map[int64]int64)
+	for _, c := range comments {
+		// 跳过垃圾评论和引用通告
+		if c.Approved == "spam" || c.Approved == "trash" || (c.Type != "" && c.Type != "comment") {
+			imp.result.Skipped++
+			continue
+		}
+
+		isCheck := 0
+		if c.Approved == "1" {
+			isCheck = 1
+		}
+		ip := c.IP
+		if len(ip) > 15 {
+			ip = ""
+		}
+
+		id, err := imp.commentModel.Create(&model.Comment{
+			AID:         aid,
+			TypeID:      typeID,
+			Username:    truncateRunes(html.UnescapeString(c.Author), 50),
+			IP:          ip,
+			IsCheck:     isCheck,
+			Dtime:       wxrTime(c.DateGMT, c.Date),
+			Content:     strings.TrimSpace(html.UnescapeString(security.StripTags(c.Content))),
+			ParentID:    ids[c.Parent],
+			ChannelType: 1,
+		})
+		if err != nil {
+			imp.result.addError("文章 %d 的评论 %d: %v", aid, c.ID, err)
+			continue
+		}
+		ids[c.ID] = id
+		imp.result.Comments++
+	}
+}
+
+// rewriteUploads 将WordPress附件目录的地址改写为新地址
+func (imp *wxrImport) rewriteUploads(text string) string {
+	for _, dir := range imp.uploads {
+		text = strings.ReplaceAll(text, dir, imp.opts.UploadURL+"/")
+	}
+	return text
+}
+
+// wxrState WordPress文章状态对应的审核流程状态，回收站、自动草稿等不导入
+func wxrState(status string) (int, bool) {
+	switch status {
+	case "publish":
+		return model.ArticleStatePublished, true
+	case "future":
+		return model.ArticleStateScheduled, true
+	case "pending":
+		return model.ArticleStatePending, true
+	case "draft", "private":
+		return model.ArticleStateDraft, true
+	}
+	return 0, false
+}
+
+// wxrTime 解析WordPress时间，优先使用GMT时间，草稿的GMT时间为零值时按本地时间解析
+func wxrTime(gmt, local string) time.Time {
+	const layout = "2006-01-02 15:04:05"
+	if t, err := time.Parse(layout, gmt); err == nil && t.Year() > 1970 {
+		return t.Local()
+	}
+	if t, err := time.ParseInLocation(layout, local, time.Local); err == nil && t.Year() > 1970 {
+		return t
+	}
+	return time.Now()
+}
+
+// wxrParagraph 已经包含段落标签或区块编辑器标记的正文不需要自动分段
+var wxrParagraph = regexp.MustCompile(`(?i)<p[\s>]|<!-- wp:`)
+
+// wxrBlockTag 以块级标签开头的段落不包裹<p>
+var wxrBlockTag = regexp.MustCompile(`(?i)^<(/?(p|div|h[1-6]|ul|ol|li|dl|table|thead|tbody|tr|blockquote|pre|figure|hr|section|iframe|form)[\s>/]|!--)`)
+
+// wxrBlankLine 段落之间的空行
+var wxrBlankLine = regexp.MustCompile(`\n\s*\n`)
+
+// wpautop 按WordPress经典编辑器的规则，将空行分隔的文本转换为段落，段内换行转换为<br />
+func wpautop(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if strings.TrimSpace(text) == "" || wxrParagraph.MatchString(text) {
+		return text
+	}
+
+	var b strings.Builder
+	pre := 0
+	for _, chunk := range wxrBlankLine.Split(strings.TrimSpace(text), -1) {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		lower := strings.ToLower(chunk)
+		if pre > 0 || wxrBlockTag.MatchString(strings.TrimSpace(chunk)) {
+			b.WriteString(chunk)
+		} else {
+			b.WriteString("<p>" + strings.ReplaceAll(strings.TrimSpace(chunk), "\n", "<br />\n") + "</p>")
+		}
+		// <pre>中的空行属于同一段
+		pre += strings.Count(lower, "<pre") - strings.Count(lower, "</pre>")
+	}
+	return b.String()
+}
+
+// unescapeSlug 还原URL编码的分类别名
+func unescapeSlug(slug string) string {
+	if s, err := url.PathUnescape(slug); err == nil {
+		return s
+	}
+	return slug
+}
+
+// swapScheme 在http和https之间切换地址的协议
+func swapScheme(u string) string {
+	switch {
+	case strings.HasPrefix(u, "https://"):
+		return "http://" + strings.TrimPrefix(u, "https://")
+	case strings.HasPrefix(u, "http://"):
+		return "https://" + strings.TrimPrefix(u, "http://")
+	}
+	return u
+}
+
+// containsString 判断切片是否包含字符串
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateRunes 按字符截断字符串
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
@@ -0,0 +1,612 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"aq3cms/internal/model"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// bundleImport 一次导出包导入的状态，旧ID到新ID的对应关系保存在map中
+type bundleImport struct {
+	*ImportService
+	files      map[string]*zip.File
+	result     *ImportResult
+	categories map[int64]BundleCategory
+	typeIDs    map[int64]int64
+	aids       map[int64]int64
+	aidTypes   map[int64]int64               // 新文章ID到新栏目ID
+	models     map[string]*bundleImportModel // 模型表名到本站的自定义内容模型
+}
+
+// bundleImportModel 导入时使用的自定义内容模型
+type bundleImportModel struct {
+	id     int64
+	fields []model.Field
+}
+
+// ImportBundle 导入aq3cms导出包，用于将内容迁移到新的站点，r为导出包zip文件。
+// 导入的记录使用新ID，旧地址与新地址的对应关系记录在结果的URLMap中
+func (s *ImportService) ImportBundle(r io.ReaderAt, size int64) (*ImportResult, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("无法读取导出包: %v", err)
+	}
+
+	imp := &bundleImport{
+		ImportService: s,
+		files:         make(map[string]*zip.File),
+		result:        &ImportResult{},
+		categories:    make(map[int64]BundleCategory),
+		typeIDs:       make(map[int64]int64),
+		aids:          make(map[int64]int64),
+		aidTypes:      make(map[int64]int64),
+	}
+	for _, f := range zr.File {
+		imp.files[f.Name] = f
+	}
+
+	var manifest BundleManifest
+	if err := imp.readJSON(bundleManifestFile, &manifest); err != nil {
+		return nil, fmt.Errorf("不是有效的导出包: %v", err)
+	}
+	if manifest.Format != BundleFormat {
+		return nil, fmt.Errorf("不是有效的导出包: 格式为 %q", manifest.Format)
+	}
+	if manifest.Version > BundleVersion {
+		return nil, fmt.Errorf("导出包版本 %d 高于支持的版本 %d，请先升级", manifest.Version, BundleVersion)
+	}
+
+	// 栏目是其他数据的基础，读取失败时不再继续
+	if err := imp.importCategories(); err != nil {
+		return nil, err
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"文章", imp.importArticles},
+		{"标签", imp.importTags},
+		{"专题", imp.importSpecials},
+		{"评论", imp.importComments},
+		{"上传文件", imp.importUploads},
+	}
+	for _, step := range steps {
+		if err := step.fn(); err != nil {
+			imp.result.addError("导入%s失败: %v", step.name, err)
+		}
+	}
+
+	// 批量导入后栏目、列表和标签的缓存都已失效
+	s.cache.Clear()
+
+	logger.Info("导出包导入完成", "categories", imp.result.Categories, "articles", imp.result.Articles, "specials", imp.result.Specials, "comments", imp.result.Comments, "files", imp.result.Files, "errors", len(imp.result.Errors))
+	return imp.result, nil
+}
+
+// open 打开导出包中的文件
+func (imp *bundleImport) open(name string) (io.ReadCloser, error) {
+	f, ok := imp.files[name]
+	if !ok {
+		return nil, fmt.Errorf("缺少 %s", name)
+	}
+	return f.Open()
+}
+
+// readJSON 读取导出包中的JSON文件
+func (imp *bundleImport) readJSON(name string, v interface{}) error {
+	rc, err := imp.open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// readText 读取导出包中的文本文件
+func (imp *bundleImport) readText(name string) (string, error) {
+	rc, err := imp.open(name)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	return string(data), err
+}
+
+// importCategories 导入栏目，父栏目先于子栏目创建
+func (imp *bundleImport) importCategories() error {
+	var list []BundleCategory
+	if err := imp.readJSON(bundleCategoriesFile, &list); err != nil {
+		return err
+	}
+	for _, c := range list {
+		imp.categories[c.ID] = c
+	}
+	for _, c := range list {
+		imp.category(c.ID, nil)
+	}
+
+	// 交叉栏目在全部栏目创建后设置
+	for _, c := range list {
+		id, ok := imp.typeIDs[c.ID]
+		if !ok || len(c.CrossIDs) == 0 {
+			continue
+		}
+		if err := imp.categoryModel.SetCrossID(id, imp.mapTypeIDs(c.CrossIDs)); err != nil {
+			imp.result.addError("栏目 %s 的交叉栏目: %v", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// mapTypeIDs 将导出包中的栏目ID转换为新栏目ID，忽略没有导入的栏目
+func (imp *bundleImport) mapTypeIDs(oldIDs []int64) []int64 {
+	ids := make([]int64, 0, len(oldIDs))
+	for _, oldID := range oldIDs {
+		if id, ok := imp.typeIDs[oldID]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// category 创建栏目并返回新栏目ID，visiting用于防止父栏目循环引用
+func (imp *bundleImport) category(oldID int64, visiting map[int64]bool) int64 {
+	if id, ok := imp.typeIDs[oldID]; ok {
+		return id
+	}
+	c, ok := imp.categories[oldID]
+	if !ok || visiting[oldID] {
+		return 0
+	}
+	if visiting == nil {
+		visiting = make(map[int64]bool)
+	}
+	visiting[oldID] = true
+
+	hidden := 0
+	if c.Hidden {
+		hidden = 1
+	}
+	id, err := imp.categoryModel.Create(&model.Category{
+		ParentID:    imp.category(c.ParentID, visiting),
+		TypeName:    c.Name,
+		TypeDir:     c.Dir,
+		IsHidden:    hidden,
+		ChannelType: c.ChannelType,
+		Description: c.Description,
+		Keywords:    c.Keywords,
+		SortRank:    c.SortRank,
+		ListTpl:     c.ListTemplate,
+		ArticleTpl:  c.ArticleTemplate,
+	})
+	if err != nil {
+		imp.result.addError("栏目 %s: %v", c.Name, err)
+		return 0
+	}
+	imp.typeIDs[oldID] = id
+	imp.result.Categories++
+	imp.result.addURL("category", fmt.Sprintf("/list/%d.html", oldID), fmt.Sprintf("/list/%d.html", id))
+	return id
+}
+
+// importArticles 导入文章及其标签，保留审核状态和回收站状态
+func (imp *bundleImport) importArticles() error {
+	var list []BundleArticle
+	if err := imp.readJSON(bundleArticlesFile, &list); err != nil {
+		return err
+	}
+	if err := imp.loadContentModels(); err != nil {
+		return err
+	}
+	for i := range list {
+		imp.article(&list[i])
+	}
+	return nil
+}
+
+// article 导入一篇文章，Markdown文章同时导入原文
+func (imp *bundleImport) article(a *BundleArticle) {
+	typeID, ok := imp.typeIDs[a.CategoryID]
+	if !ok {
+		imp.result.addError("文章 %d %s: 栏目 %d 不存在", a.ID, a.Title, a.CategoryID)
+		imp.result.Skipped++
+		return
+	}
+
+	body, err := imp.readText(a.HTMLFile)
+	if err != nil {
+		imp.result.addError("文章 %d %s: %v", a.ID, a.Title, err)
+		return
+	}
+	format := model.NormalizeBodyFormat(a.Format)
+	var source interface{}
+	if format == model.BodyFormatMarkdown {
+		text, err := imp.readText(a.MarkdownFile)
+		if err != nil {
+			imp.result.addError("文章 %d %s: %v", a.ID, a.Title, err)
+			return
+		}
+		source = stripFrontMatter(text)
+	}
+
+	expireDate, deletedAt := int64(0), int64(0)
+	if a.ExpireDate != nil {
+		expireDate = a.ExpireDate.Unix()
+	}
+	if a.DeletedAt != nil {
+		deletedAt = a.DeletedAt.Unix()
+	}
+	// 旧版导出包没有频道，按普通文章导入
+	channel := a.Channel
+	if channel == 0 {
+		channel = 1
+	}
+
+	var id int64
+	err = imp.db.WithTx(func(tx *database.Tx) error {
+		var err error
+		id, err = database.NewQueryBuilder(tx.DB(), "archives").Insert(map[string]interface{}{
+			"typeid":          typeID,
+			"channel":         channel,
+			"title":           a.Title,
+			"shorttitle":      a.ShortTitle,
+			"color":           a.Color,
+			"writer":          a.Writer,
+			"source":          a.Source,
+			"litpic":          a.Thumbnail,
+			"pubdate":         a.PubDate.Unix(),
+			"senddate":        a.SendDate.Unix(),
+			"expiredate":      expireDate,
+			"keywords":        a.Keywords,
+			"description":     a.Description,
+			"filename":        a.Filename,
+			"flag":            a.Flag,
+			"arcrank":         a.ArcRank,
+			"state":           a.State,
+			"click":           a.Click,
+			"deleted_at":      deletedAt,
+			"deleted_arcrank": a.DeletedArcRank,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = database.NewQueryBuilder(tx.DB(), "addonarticle").Insert(map[string]interface{}{
+			"aid":      id,
+			"typeid":   typeID,
+			"body":     body,
+			"format":   format,
+			"markdown": source,
+		})
+		if err != nil {
+			return err
+		}
+		return imp.articleAddon(tx, id, a)
+	})
+	if err != nil {
+		imp.result.addError("文章 %d %s: %v", a.ID, a.Title, err)
+		return
+	}
+	imp.aids[a.ID] = id
+	imp.aidTypes[id] = typeID
+	imp.result.Articles++
+	imp.result.addURL("post", fmt.Sprintf("/article/%d.html", a.ID), fmt.Sprintf("/article/%d.html", id))
+
+	if len(a.Tags) > 0 {
+		if err := imp.tagModel.UpdateArticleTags(id, strings.Join(a.Tags, ",")); err != nil {
+			imp.result.addError("文章 %d %s 的标签: %v", a.ID, a.Title, err)
+		}
+	}
+	if len(a.CrossIDs) > 0 {
+		if err := imp.articleModel.SetCrossTypeIDs(id, typeID, imp.mapTypeIDs(a.CrossIDs)); err != nil {
+			imp.result.addError("文章 %d %s 的副栏目: %v", a.ID, a.Title, err)
+		}
+	}
+}
+
+// loadContentModels 读取本站的自定义内容模型，导出包中的附加字段按模型表名导入
+func (imp *bundleImport) loadContentModels() error {
+	models, err := model.NewContentModelModel(imp.db).GetAll()
+	if err != nil {
+		return err
+	}
+	imp.models = make(map[string]*bundleImportModel, len(models))
+	for _, m := range models {
+		fields, err := model.DecodeFields(m.Fields)
+		if err != nil {
+			continue
+		}
+		imp.models[m.TableName] = &bundleImportModel{id: m.ID, fields: fields}
+	}
+	return nil
+}
+
+// contentFields 导入文章在自定义内容模型中的附加字段，本站没有的模型和字段跳过
+func (imp *bundleImport) contentFields(tx *database.Tx, id int64, a *BundleArticle) error {
+	for tableName, values := range a.Fields {
+		m, ok := imp.models[tableName]
+		if !ok {
+			imp.result.addError("文章 %d %s: 内容模型 %s 不存在，附加字段未导入", a.ID, a.Title, tableName)
+			continue
+		}
+		data := make(map[string]interface{}, len(m.fields))
+		var invalid error
+		for _, field := range m.fields {
+			value, err := model.FieldValue(field, values[field.Name])
+			if err != nil {
+				invalid = err
+				break
+			}
+			data[field.Name] = value
+		}
+		if invalid != nil {
+			imp.result.addError("文章 %d %s: 内容模型 %s 的附加字段未导入: %v", a.ID, a.Title, tableName, invalid)
+			continue
+		}
+		if err := model.NewContentModelModel(tx.DB()).SaveContent(m.id, id, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// articleAddon 导入下载和产品频道文章以及自定义内容模型的附加信息
+func (imp *bundleImport) articleAddon(tx *database.Tx, id int64, a *BundleArticle) error {
+	if d := a.Download; d != nil {
+		_, err := database.NewQueryBuilder(tx.DB(), "addondownload").Insert(map[string]interface{}{
+			"aid":           id,
+			"softname":      d.SoftName,
+			"softversion":   d.SoftVersion,
+			"softlanguage":  d.SoftLanguage,
+			"softtype":      d.SoftType,
+			"softsize":      d.SoftSize,
+			"softos":        d.SoftOS,
+			"softdeveloper": d.SoftDeveloper,
+			"softlicense":   d.SoftLicense,
+			"softscore":     d.SoftScore,
+			"softurl":       d.SoftURL,
+			"softmirrurl":   d.SoftMirrURL,
+			"downcount":     d.DownCount,
+		})
+		if err != nil {
+			return err
+		}
+		for _, screenshot := range d.Screenshots {
+			_, err := database.NewQueryBuilder(tx.DB(), "download_screenshots").Insert(map[string]interface{}{
+				"aid":        id,
+				"screenshot": screenshot,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if p := a.Product; p != nil {
+		_, err := database.NewQueryBuilder(tx.DB(), "addonproduct").Insert(map[string]interface{}{
+			"aid":           id,
+			"productname":   p.ProductName,
+			"productsn":     p.ProductSN,
+			"price":         p.Price,
+			"oldprice":      p.OldPrice,
+			"units":         p.Units,
+			"weight":        p.Weight,
+			"specification": p.Specification,
+			"features":      p.Features,
+			"parameters":    p.Parameters,
+			"stock":         p.Stock,
+		})
+		if err != nil {
+			return err
+		}
+		for _, image := range p.Images {
+			_, err := database.NewQueryBuilder(tx.DB(), "product_images").Insert(map[string]interface{}{
+				"aid":   id,
+				"image": image,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return imp.contentFields(tx, id, a)
+}
+
+// stripFrontMatter 去掉Markdown文件的头部信息
+func stripFrontMatter(text string) string {
+	if !strings.HasPrefix(text, "---\n") {
+		return text
+	}
+	end := strings.Index(text[4:], "\n---\n")
+	if end < 0 {
+		return text
+	}
+	return strings.TrimPrefix(text[4+end+5:], "\n")
+}
+
+// importTags 导入标签的排序和热门属性，没有文章使用的标签同时创建
+func (imp *bundleImport) importTags() error {
+	var list []BundleTag
+	if err := imp.readJSON(bundleTagsFile, &list); err != nil {
+		return err
+	}
+	for _, t := range list {
+		hot := 0
+		if t.Hot {
+			hot = 1
+		}
+
+		tag, err := imp.tagModel.GetByName(t.Name)
+		if err != nil || tag == nil {
+			_, err = imp.tagModel.Create(&model.Tag{Tag: t.Name, Rank: t.Rank, IsHot: hot})
+		} else if tag.Rank != t.Rank || tag.IsHot != hot {
+			tag.Rank, tag.IsHot = t.Rank, hot
+			err = imp.tagModel.Update(tag)
+		}
+		if err != nil {
+			imp.result.addError("标签 %s: %v", t.Name, err)
+			continue
+		}
+	}
+	return nil
+}
+
+// importSpecials 导入专题及专题中的文章
+func (imp *bundleImport) importSpecials() error {
+	var list []BundleSpecial
+	if err := imp.readJSON(bundleSpecialsFile, &list); err != nil {
+		return err
+	}
+
+	specialModel := model.NewSpecialModel(imp.db)
+	for _, s := range list {
+		hot := 0
+		if s.Hot {
+			hot = 1
+		}
+		id, err := specialModel.Create(&model.Special{
+			Title:        s.Title,
+			Typeid:       imp.typeIDs[s.CategoryID],
+			Note:         s.Note,
+			Pic:          s.Pic,
+			PubDate:      s.PubDate,
+			IsHot:        hot,
+			Click:        s.Click,
+			Template:     s.Template,
+			TemplateList: s.TemplateList,
+			Filename:     s.Filename,
+			Status:       s.Status,
+			Keywords:     s.Keywords,
+			Description:  s.Description,
+			Content:      s.Content,
+		})
+		if err != nil {
+			imp.result.addError("专题 %s: %v", s.Title, err)
+			continue
+		}
+		imp.result.Specials++
+
+		for _, a := range s.Articles {
+			aid, ok := imp.aids[a.ArticleID]
+			if !ok {
+				continue
+			}
+			if err := specialModel.AddArticle(id, aid, a.SortRank); err != nil {
+				imp.result.addError("专题 %s 的文章 %d: %v", s.Title, a.ArticleID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// importComments 导入评论，按原评论ID顺序导入，保证父评论先于回复创建
+func (imp *bundleImport) importComments() error {
+	var list []BundleComment
+	if err := imp.readJSON(bundleCommentsFile, &list); err != nil {
+		return err
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	ids := make(map[int64]int64)
+	for _, c := range list {
+		aid, ok := imp.aids[c.ArticleID]
+		if !ok {
+			imp.result.Skipped++
+			continue
+		}
+
+		isCheck := 0
+		if c.Approved {
+			isCheck = 1
+		}
+		id, err := imp.commentModel.Create(&model.Comment{
+			AID:         aid,
+			TypeID:      imp.aidTypes[aid],
+			Username:    c.Username,
+			IP:          c.IP,
+			IsCheck:     isCheck,
+			Dtime:       c.Time,
+			Content:     c.Content,
+			ParentID:    ids[c.ParentID],
+			Score:       c.Score,
+			GoodCount:   c.Good,
+			BadCount:    c.Bad,
+			UserFace:    c.UserFace,
+			ChannelType: 1,
+		})
+		if err != nil {
+			imp.result.addError("评论 %d: %v", c.ID, err)
+			continue
+		}
+		ids[c.ID] = id
+		imp.result.Comments++
+	}
+	return nil
+}
+
+// importUploads 还原导出包中的上传文件，不覆盖已存在的文件
+func (imp *bundleImport) importUploads() error {
+	var list []BundleUpload
+	if err := imp.readJSON(bundleUploadsFile, &list); err != nil {
+		return err
+	}
+
+	prefix := uploadURLPrefix(imp.config)
+	for _, u := range list {
+		if u.File == "" {
+			continue
+		}
+		rel := path.Clean(strings.TrimPrefix(u.URL, prefix))
+		if !strings.HasPrefix(u.URL, prefix) || rel == "." || strings.HasPrefix(rel, "../") {
+			imp.result.addError("上传文件 %s: 地址无效", u.URL)
+			continue
+		}
+		local := filepath.Join(imp.config.Upload.LocalDir(), filepath.FromSlash(rel))
+		if _, err := os.Stat(local); err == nil {
+			imp.result.Skipped++
+			continue
+		}
+		if err := imp.extract(u.File, local); err != nil {
+			imp.result.addError("上传文件 %s: %v", u.URL, err)
+			continue
+		}
+		imp.result.Files++
+	}
+	return nil
+}
+
+// extract 将导出包中的文件写入本地
+func (imp *bundleImport) extract(name, local string) error {
+	rc, err := imp.open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+		return err
+	}
+	dst, err := os.Create(local)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, rc); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
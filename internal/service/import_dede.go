@@ -0,0 +1,735 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+
+	"aq3cms/internal/model"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/pkg/security"
+)
+
+// 每次从DedeCMS数据库读取的记录数
+const dedeBatchSize = 500
+
+// DedeCMS默认的文档命名规则
+const dedeDefaultNameRule = "{typedir}/{Y}{M}{D}/{aid}.html"
+
+// DedeImportOptions DedeCMS导入选项
+type DedeImportOptions struct {
+	CMSPath string // DedeCMS的安装目录，用于还原旧地址，安装在根目录时为空
+	SiteURL string // 旧站点地址，为空时地址映射只记录路径
+}
+
+// dedeImport 一次DedeCMS导入的状态，旧ID到新ID的对应关系保存在map中
+type dedeImport struct {
+	*ImportService
+	src           *database.DB
+	opts          DedeImportOptions
+	result        *ImportResult
+	addTables     map[int64]string // 频道ID到附加表
+	channelNames  map[int64]string
+	fieldsets     map[int64]string
+	contentModels map[int64]*dedeContentModel // 自定义频道ID到内容模型
+	dropped       map[string]int              // 未导入的附加表字段及涉及的文档数
+	types         map[int64]map[string]interface{}
+	typeIDs       map[int64]int64
+	memberIDs     map[int64]int64
+	aids          map[int64]int64
+	tags          map[string]bool
+}
+
+// ImportDede 从DedeCMS 5.7数据库导入栏目、各频道文档及附加表、会员、评论、标签和友情链接，src为DedeCMS数据库连接。
+// 导入的记录使用新ID，DedeCMS的静态和动态地址与新地址的对应关系记录在结果的URLMap中
+func (s *ImportService) ImportDede(src *database.DB, opts DedeImportOptions) (*ImportResult, error) {
+	opts.SiteURL = strings.TrimSuffix(opts.SiteURL, "/")
+	opts.CMSPath = strings.TrimSuffix(opts.CMSPath, "/")
+
+	imp := &dedeImport{
+		ImportService: s,
+		src:           src,
+		opts:          opts,
+		result:        &ImportResult{},
+		addTables:     make(map[int64]string),
+		channelNames:  make(map[int64]string),
+		fieldsets:     make(map[int64]string),
+		contentModels: make(map[int64]*dedeContentModel),
+		dropped:       make(map[string]int),
+		types:         make(map[int64]map[string]interface{}),
+		typeIDs:       make(map[int64]int64),
+		memberIDs:     make(map[int64]int64),
+		aids:          make(map[int64]int64),
+		tags:          make(map[string]bool),
+	}
+
+	// 栏目和频道是其他数据的基础，读取失败时不再继续
+	if err := imp.channels(); err != nil {
+		return nil, fmt.Errorf("读取DedeCMS频道失败: %v", err)
+	}
+	if err := imp.categories(); err != nil {
+		return nil, fmt.Errorf("读取DedeCMS栏目失败: %v", err)
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"会员", imp.members},
+		{"文档", imp.archives},
+		{"评论", imp.comments},
+		{"友情链接", imp.links},
+	}
+	for _, step := range steps {
+		if err := step.fn(); err != nil {
+			imp.result.addError("读取DedeCMS%s失败: %v", step.name, err)
+		}
+	}
+
+	// 批量导入后栏目、列表和标签的缓存都已失效
+	s.cache.Clear()
+
+	logger.Info("DedeCMS导入完成", "categories", imp.result.Categories, "articles", imp.result.Articles, "members", imp.result.Members, "comments", imp.result.Comments, "links", imp.result.Links, "errors", len(imp.result.Errors))
+	return imp.result, nil
+}
+
+// each 按主键分批读取DedeCMS的表，逐行调用fn
+func (imp *dedeImport) each(table, key string, fn func(row map[string]interface{})) error {
+	last := int64(0)
+	for {
+		rows, err := imp.src.Query(
+			fmt.Sprintf("SELECT * FROM %s WHERE %s > ? ORDER BY %s LIMIT %d", imp.src.TableName(table), key, key, dedeBatchSize),
+			last,
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			last = dedeInt(row, key)
+			fn(row)
+		}
+		if len(rows) < dedeBatchSize {
+			return nil
+		}
+	}
+}
+
+// channels 读取频道的名称、附加表和字段配置
+func (imp *dedeImport) channels() error {
+	rows, err := imp.src.Query("SELECT id, typename, addtable, fieldset FROM " + imp.src.TableName("channeltype"))
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		id := dedeInt(row, "id")
+		table := strings.Replace(dedeString(row, "addtable"), "#@__", imp.src.TableName(""), 1)
+		if dedeTableName.MatchString(table) {
+			imp.addTables[id] = table
+		}
+		imp.channelNames[id] = dedeString(row, "typename")
+		imp.fieldsets[id] = dedeString(row, "fieldset")
+	}
+	return nil
+}
+
+// categories 导入栏目，父栏目先于子栏目创建
+func (imp *dedeImport) categories() error {
+	rows, err := imp.src.Query("SELECT * FROM " + imp.src.TableName("arctype") + " ORDER BY id")
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		imp.types[dedeInt(row, "id")] = row
+	}
+	for _, row := range rows {
+		imp.category(dedeInt(row, "id"), nil)
+	}
+	return nil
+}
+
+// category 创建栏目并返回新栏目ID，visiting用于防止父栏目循环引用
+func (imp *dedeImport) category(oldID int64, visiting map[int64]bool) int64 {
+	if id, ok := imp.typeIDs[oldID]; ok {
+		return id
+	}
+	row, ok := imp.types[oldID]
+	if !ok || visiting[oldID] {
+		return 0
+	}
+	if visiting == nil {
+		visiting = make(map[int64]bool)
+	}
+	visiting[oldID] = true
+
+	parentID := imp.category(dedeInt(row, "reid"), visiting)
+	topID := imp.category(dedeInt(row, "topid"), visiting)
+	typeDir := imp.typeDir(oldID)
+
+	id, err := database.NewQueryBuilder(imp.db, "arctype").Insert(map[string]interface{}{
+		"reid":        parentID,
+		"topid":       topID,
+		"sortrank":    dedeInt(row, "sortrank"),
+		"typename":    dedeString(row, "typename"),
+		"typedir":     path.Base("/" + typeDir),
+		"isdefault":   dedeInt(row, "isdefault"),
+		"defaultname": dedeString(row, "defaultname"),
+		"issend":      dedeInt(row, "issend"),
+		"channeltype": dedeChannel(dedeInt(row, "channeltype")),
+		"maxpage":     dedeInt(row, "maxpage"),
+		"ispart":      dedeInt(row, "ispart"),
+		"corank":      dedeInt(row, "corank"),
+		"tempindex":   dedeTemplet(dedeString(row, "tempindex")),
+		"templist":    dedeTemplet(dedeString(row, "templist")),
+		"temparticle": dedeTemplet(dedeString(row, "temparticle")),
+		"namerule":    dedeString(row, "namerule"),
+		"namerule2":   dedeString(row, "namerule2"),
+		"modname":     dedeString(row, "modname"),
+		"description": dedeString(row, "description"),
+		"keywords":    dedeString(row, "keywords"),
+		"seotitle":    dedeString(row, "seotitle"),
+		"ishidden":    dedeInt(row, "ishidden"),
+		"content":     dedeString(row, "content"),
+	})
+	if err != nil {
+		imp.result.addError("栏目 %s: %v", dedeString(row, "typename"), err)
+		return 0
+	}
+	imp.typeIDs[oldID] = id
+	imp.result.Categories++
+
+	newURL := fmt.Sprintf("/list/%d.html", id)
+	imp.result.addURL("category", imp.oldURL(typeDir+"/"), newURL)
+	imp.result.addURL("category", imp.oldURL(fmt.Sprintf("/plus/list.php?tid=%d", oldID)), newURL)
+	return id
+}
+
+// typeDir 栏目在DedeCMS中的目录，替换{cmspath}
+func (imp *dedeImport) typeDir(oldID int64) string {
+	dir := strings.ReplaceAll(dedeString(imp.types[oldID], "typedir"), "{cmspath}", imp.opts.CMSPath)
+	return "/" + strings.Trim(dir, "/")
+}
+
+// members 导入会员，保留密码哈希，已存在的用户名不重复导入
+func (imp *dedeImport) members() error {
+	memberModel := model.NewMemberModel(imp.db)
+	return imp.each("member", "mid", func(row map[string]interface{}) {
+		oldID := dedeInt(row, "mid")
+		userID := dedeString(row, "userid")
+		if existing, err := memberModel.GetByUsername(userID); err == nil {
+			imp.memberIDs[oldID] = existing.ID
+			imp.result.Skipped++
+			return
+		}
+
+		sex := dedeString(row, "sex")
+		if sex != "男" && sex != "女" {
+			sex = "保密"
+		}
+
+		// DedeCMS的mtype为“个人”“企业”，与会员类型ID不同，不导入
+		id, err := database.NewQueryBuilder(imp.db, "member").Insert(map[string]interface{}{
+			"userid":       userID,
+			"pwd":          dedeString(row, "pwd"),
+			"uname":        dedeString(row, "uname"),
+			"sex":          sex,
+			"rank":         dedeInt(row, "rank"),
+			"money":        dedeFloat(row, "money"),
+			"email":        dedeString(row, "email"),
+			"scores":       dedeInt(row, "scores"),
+			"matt":         dedeInt(row, "matt"),
+			"spacesta":     dedeInt(row, "spacesta"),
+			"face":         dedeString(row, "face"),
+			"safequestion": dedeInt(row, "safequestion"),
+			"safeanswer":   dedeString(row, "safeanswer"),
+			"jointime":     dedeInt(row, "jointime"),
+			"joinip":       dedeString(row, "joinip"),
+			"logintime":    dedeInt(row, "logintime"),
+			"loginip":      dedeString(row, "loginip"),
+		})
+		if err != nil {
+			imp.result.addError("会员 %s: %v", userID, err)
+			return
+		}
+		imp.memberIDs[oldID] = id
+		imp.result.Members++
+	})
+}
+
+// archives 导入所有频道的文档及附加表内容
+func (imp *dedeImport) archives() error {
+	err := imp.each("archives", "id", imp.archive)
+	imp.reportDropped()
+	return err
+}
+
+// archive 导入一篇文档：图集的图片追加到正文，软件和商品同时写入下载和产品附加表，
+// 自定义频道和专题的附加字段写入对应的内容模型
+func (imp *dedeImport) archive(row map[string]interface{}) {
+	oldID := dedeInt(row, "id")
+	title := dedeString(row, "title")
+	oldTypeID := dedeInt(row, "typeid")
+	typeID, ok := imp.typeIDs[oldTypeID]
+	if !ok {
+		imp.result.addError("文档 %d %s: 栏目 %d 不存在", oldID, title, oldTypeID)
+		return
+	}
+
+	channel := dedeInt(row, "channel")
+	addon, err := imp.addon(channel, oldID)
+	if err != nil {
+		imp.result.addError("文档 %d %s 的附加表: %v", oldID, title, err)
+		return
+	}
+	contentModel, err := imp.dedeChannelModel(channel)
+	if err != nil {
+		imp.result.addError("频道 %d 的内容模型: %v，附加字段未导入", channel, err)
+	}
+	imp.trackDropped(channel, addon)
+
+	body := dedeString(addon, "body")
+	switch channel {
+	case 2:
+		body = dedeImages(dedeString(addon, "imgurls")) + body
+	case 3:
+		body = dedeString(addon, "introduce")
+	}
+
+	// arcrank为-1的文档未审核，-2的文档在回收站中
+	arcRank := dedeInt(row, "arcrank")
+	state := model.ArticleStatePublished
+	deletedAt := int64(0)
+	switch arcRank {
+	case -1:
+		state = model.ArticleStatePending
+	case model.ArcRankRecycled:
+		deletedAt = time.Now().Unix()
+	}
+
+	var id int64
+	var invalid []string
+	err = imp.db.WithTx(func(tx *database.Tx) error {
+		var err error
+		id, err = database.NewQueryBuilder(tx.DB(), "archives").Insert(map[string]interface{}{
+			"typeid":      typeID,
+			"sortrank":    dedeInt(row, "sortrank"),
+			"flag":        dedeString(row, "flag"),
+			"channel":     dedeChannel(channel),
+			"arcrank":     arcRank,
+			"state":       state,
+			"click":       dedeInt(row, "click"),
+			"money":       dedeInt(row, "money"),
+			"title":       title,
+			"shorttitle":  dedeString(row, "shorttitle"),
+			"color":       dedeString(row, "color"),
+			"writer":      dedeString(row, "writer"),
+			"source":      dedeString(row, "source"),
+			"litpic":      dedeString(row, "litpic"),
+			"pubdate":     dedeInt(row, "pubdate"),
+			"senddate":    dedeInt(row, "senddate"),
+			"mid":         imp.memberIDs[dedeInt(row, "mid")],
+			"keywords":    dedeString(row, "keywords"),
+			"lastpost":    dedeInt(row, "lastpost"),
+			"scores":      dedeInt(row, "scores"),
+			"goodpost":    dedeInt(row, "goodpost"),
+			"badpost":     dedeInt(row, "badpost"),
+			"notpost":     dedeInt(row, "notpost"),
+			"description": dedeString(row, "description"),
+			"filename":    dedeString(row, "filename"),
+			"weight":      dedeInt(row, "weight"),
+			"deleted_at":  deletedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = database.NewQueryBuilder(tx.DB(), "addonarticle").Insert(map[string]interface{}{
+			"aid":         id,
+			"typeid":      typeID,
+			"body":        body,
+			"format":      model.BodyFormatHTML,
+			"redirecturl": dedeString(addon, "redirecturl"),
+			"templet":     dedeTemplet(dedeString(addon, "templet")),
+			"userip":      dedeString(addon, "userip"),
+		})
+		if err != nil {
+			return err
+		}
+
+		switch channel {
+		case 3:
+			links := dedeSoftLinks(dedeString(addon, "softlinks"))
+			softURL := ""
+			if len(links) > 0 {
+				softURL, links = links[0], links[1:]
+			}
+			_, err = database.NewQueryBuilder(tx.DB(), "addondownload").Insert(map[string]interface{}{
+				"aid":           id,
+				"softname":      title,
+				"softversion":   "",
+				"softlanguage":  dedeString(addon, "language"),
+				"softtype":      dedeString(addon, "softtype"),
+				"softsize":      dedeString(addon, "softsize"),
+				"softos":        dedeString(addon, "os"),
+				"softdeveloper": "",
+				"softlicense":   dedeString(addon, "accredit"),
+				"softscore":     dedeFloat(addon, "softrank"),
+				"softurl":       softURL,
+				"softmirrurl":   strings.Join(links, "\n"),
+				"downcount":     0,
+			})
+		case 6:
+			price := dedeFloat(addon, "trueprice")
+			if price == 0 {
+				price = dedeFloat(addon, "price")
+			}
+			_, err = database.NewQueryBuilder(tx.DB(), "addonproduct").Insert(map[string]interface{}{
+				"aid":           id,
+				"productname":   title,
+				"productsn":     "",
+				"price":         price,
+				"oldprice":      dedeFloat(addon, "price"),
+				"units":         dedeString(addon, "units"),
+				"weight":        0,
+				"specification": dedeString(addon, "brand"),
+				"features":      "",
+				"parameters":    "",
+				"stock":         0,
+			})
+		}
+		if err != nil || contentModel == nil {
+			return err
+		}
+		invalid, err = contentModel.save(tx, id, addon)
+		return err
+	})
+	if err != nil {
+		imp.result.addError("文档 %d %s: %v", oldID, title, err)
+		return
+	}
+	if len(invalid) > 0 {
+		imp.result.addError("文档 %d %s 的附加字段 %s 格式不正确，未导入", oldID, title, strings.Join(invalid, "、"))
+	}
+	imp.aids[oldID] = id
+	imp.result.Articles++
+
+	imp.archiveTags(oldID, id, title)
+
+	newURL := fmt.Sprintf("/article/%d.html", id)
+	imp.result.addURL("post", imp.oldURL(fmt.Sprintf("/plus/view.php?aid=%d", oldID)), newURL)
+	if p := imp.archivePath(row, oldTypeID); p != "" {
+		imp.result.addURL("post", imp.oldURL(p), newURL)
+	}
+}
+
+// addon 读取文档的附加表记录，没有附加表时返回nil
+func (imp *dedeImport) addon(channel, aid int64) (map[string]interface{}, error) {
+	table, ok := imp.addTables[channel]
+	if !ok {
+		return nil, nil
+	}
+	row, err := imp.src.GetOne("SELECT * FROM "+table+" WHERE aid = ?", aid)
+	if err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// archiveTags 导入文档的标签
+func (imp *dedeImport) archiveTags(oldID, id int64, title string) {
+	rows, err := imp.src.Query("SELECT tag FROM "+imp.src.TableName("taglist")+" WHERE aid = ?", oldID)
+	if err != nil {
+		imp.result.addError("文档 %d %s 的标签: %v", oldID, title, err)
+		return
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	tags := make([]string, 0, len(rows))
+	for _, row := range rows {
+		tag := strings.TrimSpace(dedeString(row, "tag"))
+		if tag == "" {
+			continue
+		}
+		tags = append(tags, tag)
+		if !imp.tags[tag] {
+			imp.tags[tag] = true
+			imp.result.addURL("tag", imp.oldURL("/tags.php?/"+tag+"/"), "/tag/"+tag)
+		}
+	}
+	if err := imp.tagModel.UpdateArticleTags(id, strings.Join(tags, ",")); err != nil {
+		imp.result.addError("文档 %d %s 的标签: %v", oldID, title, err)
+	}
+}
+
+// archivePath 按栏目的文档命名规则还原DedeCMS生成的静态地址，规则中有无法还原的变量时返回空
+func (imp *dedeImport) archivePath(row map[string]interface{}, oldTypeID int64) string {
+	rule := dedeString(imp.types[oldTypeID], "namerule")
+	if rule == "" {
+		rule = dedeDefaultNameRule
+	}
+
+	id := strconv.FormatInt(dedeInt(row, "id"), 10)
+	sendDate := dedeInt(row, "senddate")
+	t := time.Unix(sendDate, 0)
+	p := strings.NewReplacer(
+		"{typedir}", imp.typeDir(oldTypeID),
+		"{cmspath}", imp.opts.CMSPath,
+		"{Y}", t.Format("2006"),
+		"{M}", t.Format("01"),
+		"{D}", t.Format("02"),
+		"{timestamp}", strconv.FormatInt(sendDate, 10),
+		"{aid}", id,
+	).Replace(rule)
+
+	// 自定义文件名替换规则中的文件名部分
+	if filename := dedeString(row, "filename"); filename != "" {
+		if path.Ext(filename) == "" {
+			filename += ".html"
+		}
+		p = path.Dir(p) + "/" + filename
+	}
+	if strings.Contains(p, "{") {
+		return ""
+	}
+	return "/" + strings.TrimLeft(p, "/")
+}
+
+// comments 导入评论，跳过文档未导入的评论
+func (imp *dedeImport) comments() error {
+	return imp.each("feedback", "id", func(row map[string]interface{}) {
+		aid, ok := imp.aids[dedeInt(row, "aid")]
+		if !ok {
+			imp.result.Skipped++
+			return
+		}
+
+		ip := dedeString(row, "ip")
+		if len(ip) > 15 {
+			ip = ""
+		}
+
+		// DedeCMS保存的是转义后的内容，创建评论时会重新转义
+		_, err := imp.commentModel.Create(&model.Comment{
+			AID:         aid,
+			TypeID:      imp.typeIDs[dedeInt(row, "typeid")],
+			Username:    truncateRunes(dedeString(row, "username"), 50),
+			MID:         imp.memberIDs[dedeInt(row, "mid")],
+			IP:          ip,
+			IsCheck:     int(dedeInt(row, "ischeck")),
+			Dtime:       time.Unix(dedeInt(row, "dtime"), 0),
+			Content:     strings.TrimSpace(html.UnescapeString(security.StripTags(dedeString(row, "msg")))),
+			GoodCount:   int(dedeInt(row, "good")),
+			BadCount:    int(dedeInt(row, "bad")),
+			UserFace:    dedeString(row, "face"),
+			ChannelType: 1,
+		})
+		if err != nil {
+			imp.result.addError("评论 %d: %v", dedeInt(row, "id"), err)
+			return
+		}
+		imp.result.Comments++
+	})
+}
+
+// links 导入友情链接分类和友情链接
+func (imp *dedeImport) links() error {
+	typeIDs := make(map[int64]int64)
+	linkTypeModel := model.NewLinkTypeModel(imp.db)
+	types, err := imp.src.Query("SELECT * FROM " + imp.src.TableName("flinktype") + " ORDER BY id")
+	if err != nil {
+		return err
+	}
+	for _, row := range types {
+		id, err := linkTypeModel.Create(&model.LinkType{
+			Name:    dedeString(row, "typename"),
+			OrderID: int(dedeInt(row, "id")),
+		})
+		if err != nil {
+			imp.result.addError("友情链接分类 %s: %v", dedeString(row, "typename"), err)
+			continue
+		}
+		typeIDs[dedeInt(row, "id")] = id
+	}
+
+	return imp.each("flink", "id", func(row map[string]interface{}) {
+		_, err := database.NewQueryBuilder(imp.db, "flink").Insert(map[string]interface{}{
+			"sortrank": dedeInt(row, "sortrank"),
+			"url":      dedeString(row, "url"),
+			"webname":  dedeString(row, "webname"),
+			"msg":      dedeString(row, "msg"),
+			"email":    dedeString(row, "email"),
+			"typeid":   typeIDs[dedeInt(row, "typeid")],
+			"logo":     dedeString(row, "logo"),
+			"ischeck":  dedeInt(row, "ischeck"),
+			"dtime":    dedeInt(row, "dtime"),
+		})
+		if err != nil {
+			imp.result.addError("友情链接 %s: %v", dedeString(row, "webname"), err)
+			return
+		}
+		imp.result.Links++
+	})
+}
+
+// oldURL 旧站点的完整地址
+func (imp *dedeImport) oldURL(p string) string {
+	return imp.opts.SiteURL + p
+}
+
+// dedeTableName 附加表名只允许字母、数字和下划线
+var dedeTableName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// dedeChannel DedeCMS频道对应的频道：软件为下载，商品为产品，其他频道按文章导入
+func dedeChannel(channel int64) int64 {
+	switch channel {
+	case 3:
+		return 3
+	case 6:
+		return 2
+	}
+	return 1
+}
+
+// dedeTemplet 去掉模板路径中的{style}目录
+func dedeTemplet(name string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(name, "{style}"), "/")
+}
+
+// dedeImgPattern 图集附加表中的图片
+var dedeImgPattern = regexp.MustCompile(`\{dede:img([^}]*)\}([\s\S]*?)\{/dede:img\}`)
+
+// dedeTextAttr 图片说明
+var dedeTextAttr = regexp.MustCompile(`text=['"]([^'"]*)['"]`)
+
+// dedeImages 将图集的图片转换为HTML
+func dedeImages(imgurls string) string {
+	var b strings.Builder
+	for _, m := range dedeImgPattern.FindAllStringSubmatch(imgurls, -1) {
+		src := strings.TrimSpace(m[2])
+		if src == "" {
+			continue
+		}
+		alt := ""
+		if t := dedeTextAttr.FindStringSubmatch(m[1]); t != nil {
+			alt = t[1]
+		}
+		b.WriteString(fmt.Sprintf("<p><img src=\"%s\" alt=\"%s\"></p>\n", html.EscapeString(src), html.EscapeString(alt)))
+	}
+	return b.String()
+}
+
+// dedeLinkPattern 软件附加表中的下载地址
+var dedeLinkPattern = regexp.MustCompile(`\{dede:link[^}]*\}([\s\S]*?)\{/dede:link\}`)
+
+// dedeSoftLinks 解析软件的下载地址
+func dedeSoftLinks(softlinks string) []string {
+	links := make([]string, 0)
+	for _, m := range dedeLinkPattern.FindAllStringSubmatch(softlinks, -1) {
+		if link := strings.TrimSpace(m[1]); link != "" {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// dedeString 读取字符串字段
+func dedeString(row map[string]interface{}, key string) string {
+	switch v := row[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// dedeInt 读取整数字段
+func dedeInt(row map[string]interface{}, key string) int64 {
+	switch v := row[key].(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case nil:
+		return 0
+	default:
+		n, _ := strconv.ParseFloat(strings.TrimSpace(dedeString(row, key)), 64)
+		return int64(n)
+	}
+}
+
+// dedeFloat 读取小数字段
+func dedeFloat(row map[string]interface{}, key string) float64 {
+	switch v := row[key].(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case nil:
+		return 0
+	default:
+		n, _ := strconv.ParseFloat(strings.TrimSpace(dedeString(row, key)), 64)
+		return n
+	}
+}
+
+// dedeCharset 模板中的GBK编码声明
+var dedeCharset = regexp.MustCompile(`(?i)(charset=["']?)(gb2312|gbk|gb18030)`)
+
+// ConvertDedeTemplate 将DedeCMS模板标签{dede:...}转换为{aq3cms:...}
+func ConvertDedeTemplate(content string) string {
+	content = strings.ReplaceAll(content, "{dede:", "{aq3cms:")
+	content = strings.ReplaceAll(content, "{/dede:", "{/aq3cms:")
+	return dedeCharset.ReplaceAllString(content, "${1}utf-8")
+}
+
+// ConvertDedeTemplates 复制DedeCMS模板目录到dst，转换.htm和.html模板中的标签，GBK编码的模板转换为UTF-8，返回转换的模板数
+func ConvertDedeTemplates(src, dst string) (int, error) {
+	count := 0
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".htm", ".html":
+			if !utf8.Valid(data) {
+				if decoded, err := simplifiedchinese.GB18030.NewDecoder().Bytes(data); err == nil {
+					data = decoded
+				}
+			}
+			data = []byte(ConvertDedeTemplate(string(data)))
+			count++
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+	return count, err
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"aq3cms/internal/model"
+	"aq3cms/pkg/database"
+)
+
+// dedeArchiveColumns 附加表中已写入文档和正文的字段，以及独立模型附加表中与文档表重复的字段
+var dedeArchiveColumns = map[string]bool{
+	"aid": true, "typeid": true, "body": true, "redirecturl": true, "templet": true, "userip": true,
+	"arcrank": true, "mid": true, "click": true, "title": true, "senddate": true, "flag": true,
+	"litpic": true, "lastpost": true, "scores": true, "goodpost": true, "badpost": true, "channel": true,
+}
+
+// dedeChannelColumns 内置频道附加表中已导入的字段
+var dedeChannelColumns = map[int64]map[string]bool{
+	1: {},
+	2: {"imgurls": true},
+	3: {"introduce": true, "softlinks": true, "language": true, "softtype": true, "softsize": true, "os": true, "accredit": true, "softrank": true},
+	6: {"trueprice": true, "price": true, "units": true, "brand": true},
+}
+
+// dedeFieldTitle 频道字段配置中的字段名和标题
+var dedeFieldTitle = regexp.MustCompile(`<field:([A-Za-z0-9_]+)\s[^>]*?itemname=["']([^"']*)["']`)
+
+// dedeContentModel 自定义频道对应的内容模型
+type dedeContentModel struct {
+	id     int64
+	fields []model.Field
+}
+
+// dedeChannelModel 返回自定义频道（包括专题）对应的内容模型，第一次使用时按附加表的字段创建；
+// 内置频道返回nil
+func (imp *dedeImport) dedeChannelModel(channel int64) (*dedeContentModel, error) {
+	if _, ok := dedeChannelColumns[channel]; ok {
+		return nil, nil
+	}
+	if cm, ok := imp.contentModels[channel]; ok {
+		return cm, nil
+	}
+	table, ok := imp.addTables[channel]
+	if !ok {
+		return nil, nil
+	}
+
+	// 创建失败时同一频道不再重试
+	imp.contentModels[channel] = nil
+
+	tableName := "dede" + strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(table), strings.ToLower(imp.src.TableName(""))), "addon")
+	if model.ValidateTableName(tableName) != nil {
+		if channel < 0 {
+			tableName = fmt.Sprintf("dedechannel_%d", -channel)
+		} else {
+			tableName = fmt.Sprintf("dedechannel%d", channel)
+		}
+	}
+
+	fields, err := imp.dedeAddonFields(channel, table)
+	if err != nil {
+		return nil, err
+	}
+	cm := &dedeContentModel{fields: fields}
+
+	// 重复导入时沿用已创建的模型
+	contentModel := model.NewContentModelModel(imp.db)
+	models, err := contentModel.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range models {
+		if m.TableName == tableName {
+			cm.id = m.ID
+			if cm.fields, err = model.DecodeFields(m.Fields); err != nil {
+				return nil, err
+			}
+			imp.contentModels[channel] = cm
+			return cm, nil
+		}
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	name := imp.channelNames[channel]
+	if name == "" {
+		name = tableName
+	}
+	cm.id, err = contentModel.Create(&model.ContentModel{
+		Name:        name,
+		TableName:   tableName,
+		Description: "从DedeCMS频道 " + table + " 导入",
+		State:       1,
+		Fields:      string(data),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	imp.contentModels[channel] = cm
+	return cm, nil
+}
+
+// dedeAddonFields 读取附加表的字段定义，跳过已写入文档的字段，字段名不合法时记录为未导入
+func (imp *dedeImport) dedeAddonFields(channel int64, table string) ([]model.Field, error) {
+	rows, err := imp.src.DB.Query("SELECT * FROM " + table + " WHERE 1 = 0")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string]string)
+	for _, m := range dedeFieldTitle.FindAllStringSubmatch(imp.fieldsets[channel], -1) {
+		titles[m[1]] = html.UnescapeString(m[2])
+	}
+
+	fields := make([]model.Field, 0, len(columns))
+	for _, column := range columns {
+		name := column.Name()
+		if dedeArchiveColumns[strings.ToLower(name)] {
+			continue
+		}
+		title := titles[name]
+		if title == "" {
+			title = name
+		}
+		field := model.Field{Name: name, Title: title, Type: dedeFieldType(column.DatabaseTypeName())}
+		if model.ValidateFields([]model.Field{field}) != nil {
+			imp.result.addError("频道 %d 的附加表字段 %s: 字段名不合法，未导入", channel, name)
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// dedeFieldType 附加表字段类型对应的内容模型字段类型，无法确定长度的字符串和大文本都按能容纳原值的类型处理
+func dedeFieldType(dbType string) string {
+	dbType = strings.ToUpper(dbType)
+	switch {
+	case dbType == "BIGINT" || strings.HasPrefix(dbType, "UNSIGNED BIG"):
+		return "varchar"
+	case strings.Contains(dbType, "INT"):
+		return "int"
+	case strings.Contains(dbType, "FLOAT") || strings.Contains(dbType, "DOUBLE") || strings.Contains(dbType, "REAL") ||
+		strings.Contains(dbType, "DECIMAL") || strings.Contains(dbType, "NUMERIC"):
+		return "float"
+	case strings.Contains(dbType, "CHAR"):
+		return "varchar"
+	case dbType == "DATE":
+		return "date"
+	case dbType == "DATETIME" || dbType == "TIMESTAMP":
+		return "datetime"
+	}
+	return "longtext"
+}
+
+// save 将附加表记录按字段类型转换后写入内容模型的附加表，返回格式不正确而未导入的字段
+func (cm *dedeContentModel) save(tx *database.Tx, aid int64, addon map[string]interface{}) ([]string, error) {
+	if len(cm.fields) == 0 || addon == nil {
+		return nil, nil
+	}
+	var invalid []string
+	data := make(map[string]interface{}, len(cm.fields))
+	for _, field := range cm.fields {
+		v := dedeString(addon, field.Name)
+		// DedeCMS用全零日期表示未填写
+		if strings.HasPrefix(v, "0000-00-00") {
+			v = ""
+		}
+		value, err := model.FieldValue(field, v)
+		if err != nil {
+			invalid = append(invalid, field.Name)
+			continue
+		}
+		data[field.Name] = value
+	}
+	return invalid, model.NewContentModelModel(tx.DB()).SaveContent(cm.id, aid, data)
+}
+
+// trackDropped 统计附加表中有值但没有导入的字段
+func (imp *dedeImport) trackDropped(channel int64, addon map[string]interface{}) {
+	// 自定义频道只在内容模型创建失败时统计
+	known, ok := dedeChannelColumns[channel]
+	if !ok && imp.contentModels[channel] != nil {
+		return
+	}
+	for key := range addon {
+		if dedeArchiveColumns[strings.ToLower(key)] || known[strings.ToLower(key)] {
+			continue
+		}
+		if v := dedeString(addon, key); v != "" && v != "0" {
+			imp.dropped[fmt.Sprintf("频道 %d 的附加表字段 %s", channel, key)]++
+		}
+	}
+}
+
+// reportDropped 将未导入的附加表字段记录到导入结果
+func (imp *dedeImport) reportDropped() {
+	keys := make([]string, 0, len(imp.dropped))
+	for key := range imp.dropped {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		imp.result.addError("%s: 没有对应字段，%d 篇文档的该字段未导入", key, imp.dropped[key])
+	}
+}
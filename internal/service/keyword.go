@@ -0,0 +1,162 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/extract"
+	"aq3cms/pkg/logger"
+)
+
+// 关键词和摘要提取参数
+const (
+	keywordCorpusDocs    = 1000      // 统计文档频率的最近文章数
+	keywordCorpusTags    = 5000      // 作为分词词典的标签数
+	keywordCorpusBodyLen = 500       // 统计文档频率的正文长度，单位字符
+	keywordCorpusTTL     = time.Hour // 语料库的有效期
+	keywordCount         = 5         // 提取的关键词数
+	keywordMaxLen        = 60        // 关键词字段的长度
+	descriptionMaxLen    = 200       // 摘要的长度
+)
+
+// keywordCorpus 站点语料库，各服务实例共用
+var keywordCorpus struct {
+	sync.Mutex
+	corpus *extract.Corpus
+	built  time.Time
+}
+
+// KeywordService 关键词和摘要提取服务
+type KeywordService struct {
+	db     *database.DB
+	cache  cache.Cache
+	config *config.Config
+}
+
+// NewKeywordService 创建关键词和摘要提取服务
+func NewKeywordService(db *database.DB, cache cache.Cache, config *config.Config) *KeywordService {
+	return &KeywordService{
+		db:     db,
+		cache:  cache,
+		config: config,
+	}
+}
+
+// Suggest 根据文章标题和内容生成关键词和摘要，关键词用逗号分隔
+func (s *KeywordService) Suggest(article *model.Article) (keywords, description string, err error) {
+	pages := model.SplitPages(article.Body, s.config.Site.PageBreak)
+	parts := make([]string, 0, len(pages))
+	for _, page := range pages {
+		parts = append(parts, page.Body)
+	}
+	body := strings.Join(parts, "\n")
+
+	description = extract.Summary(body, descriptionMaxLen)
+
+	corpus, err := s.corpus()
+	if err != nil {
+		return "", description, err
+	}
+	words := corpus.Keywords(article.Title, strings.Join(extract.Paragraphs(body), "\n"), keywordCount)
+
+	// 不超过关键词字段的长度
+	for _, word := range words {
+		next := word
+		if keywords != "" {
+			next = keywords + "," + word
+		}
+		if utf8.RuneCountInString(next) > keywordMaxLen {
+			break
+		}
+		keywords = next
+	}
+
+	return keywords, description, nil
+}
+
+// Fill 为没有填写关键词或摘要的文章自动生成
+func (s *KeywordService) Fill(article *model.Article) {
+	if strings.TrimSpace(article.Keywords) != "" && strings.TrimSpace(article.Description) != "" {
+		return
+	}
+
+	keywords, description, err := s.Suggest(article)
+	if err != nil {
+		logger.Error("提取文章关键词失败", "title", article.Title, "error", err)
+	}
+	if strings.TrimSpace(article.Keywords) == "" {
+		article.Keywords = keywords
+	}
+	if strings.TrimSpace(article.Description) == "" {
+		article.Description = description
+	}
+}
+
+// corpus 获取站点语料库，过期后用最近发布的文章和标签重新统计
+func (s *KeywordService) corpus() (*extract.Corpus, error) {
+	keywordCorpus.Lock()
+	defer keywordCorpus.Unlock()
+
+	if keywordCorpus.corpus != nil && time.Since(keywordCorpus.built) < keywordCorpusTTL {
+		return keywordCorpus.corpus, nil
+	}
+
+	corpus := extract.NewCorpus()
+
+	// 标签作为分词词典
+	qb := database.NewQueryBuilder(s.db, "tagindex")
+	qb.Select("tag")
+	qb.OrderBy("count DESC")
+	qb.Limit(keywordCorpusTags)
+	var tags []struct {
+		Tag string `db:"tag"`
+	}
+	if err := qb.GetInto(&tags); err != nil {
+		logger.Error("读取标签词典失败", "error", err)
+		return nil, err
+	}
+	for _, tag := range tags {
+		corpus.AddWord(tag.Tag)
+	}
+
+	// 最近发布的文章统计文档频率，文章的关键词也加入词典
+	qb = database.NewQueryBuilder(s.db, "archives")
+	qb.Select("a.title", "a.keywords", "ad.body")
+	qb.From(s.db.TableName("archives") + " AS a")
+	qb.LeftJoin(s.db.TableName("addonarticle")+" AS ad", "a.id = ad.aid")
+	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", model.ArticleStatePublished)
+	qb.OrderBy("a.id DESC")
+	qb.Limit(keywordCorpusDocs)
+	var docs []struct {
+		Title    string `db:"title"`
+		Keywords string `db:"keywords"`
+		Body     string `db:"body"`
+	}
+	if err := qb.GetInto(&docs); err != nil {
+		logger.Error("读取文章语料失败", "error", err)
+		return nil, err
+	}
+	for _, doc := range docs {
+		for _, keyword := range strings.Split(doc.Keywords, ",") {
+			corpus.AddWord(keyword)
+		}
+	}
+	for _, doc := range docs {
+		body := []rune(strings.Join(extract.Paragraphs(doc.Body), "\n"))
+		if len(body) > keywordCorpusBodyLen {
+			body = body[:keywordCorpusBodyLen]
+		}
+		corpus.AddDocument(doc.Title + "\n" + string(body))
+	}
+
+	keywordCorpus.corpus = corpus
+	keywordCorpus.built = time.Now()
+	return corpus, nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"time"
 
 	"aq3cms/config"
@@ -31,7 +32,7 @@ func NewLinkService(db *database.DB, cache cache.Cache, config *config.Config) *
 // GetLinks 获取友情链接
 func (s *LinkService) GetLinks(limit int) ([]*model.Link, error) {
 	// 缓存键
-	cacheKey := "links_" + string(limit)
+	cacheKey := "links_" + fmt.Sprint(limit)
 
 	// 检查缓存
 	if cached, ok := s.cache.Get(cacheKey); ok {
@@ -56,7 +57,7 @@ func (s *LinkService) GetLinks(limit int) ([]*model.Link, error) {
 // GetLinkByID 根据ID获取友情链接
 func (s *LinkService) GetLinkByID(id int64) (*model.Link, error) {
 	// 缓存键
-	cacheKey := "link_" + string(id)
+	cacheKey := "link_" + fmt.Sprint(id)
 
 	// 检查缓存
 	if cached, ok := s.cache.Get(cacheKey); ok {
@@ -81,7 +82,7 @@ func (s *LinkService) GetLinkByID(id int64) (*model.Link, error) {
 // GetLinksByType 根据类型获取友情链接
 func (s *LinkService) GetLinksByType(typeID int64, limit int) ([]*model.Link, error) {
 	// 缓存键
-	cacheKey := "links_type_" + string(typeID) + "_" + string(limit)
+	cacheKey := "links_type_" + fmt.Sprint(typeID) + "_" + fmt.Sprint(limit)
 
 	// 检查缓存
 	if cached, ok := s.cache.Get(cacheKey); ok {
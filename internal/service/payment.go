@@ -34,6 +34,13 @@ func NewPaymentService(db *database.DB, cache cache.Cache, config *config.Config
 	}
 }
 
+// withTx 在事务中执行fn，fn收到的服务实例中的模型都绑定到该事务
+func (s *PaymentService) withTx(fn func(s *PaymentService) error) error {
+	return s.db.WithTx(func(tx *database.Tx) error {
+		return fn(NewPaymentService(tx.DB(), s.cache, s.config))
+	})
+}
+
 // GetPaymentMethods 获取支付方式
 func (s *PaymentService) GetPaymentMethods() ([]*model.PaymentMethod, error) {
 	// 从缓存获取
@@ -173,45 +180,32 @@ func (s *PaymentService) UpdateOrderPaid(orderNo string, paymentOrderNo string)
 		return fmt.Errorf("order status is not unpaid")
 	}
 
-	// 开始事务
-	tx, err := s.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// 更新订单为已支付
-	err = s.paymentOrderModel.UpdatePaid(order.ID, paymentOrderNo)
-	if err != nil {
-		return err
-	}
-
-	// 处理订单类型
-	switch order.Type {
-	case 0: // 充值
-		// 更新会员余额
-		err = s.memberModel.UpdateMoney(order.MemberID, order.Amount)
+	// 在事务中更新订单和会员余额
+	return s.withTx(func(s *PaymentService) error {
+		// 更新订单为已支付
+		err := s.paymentOrderModel.UpdatePaid(order.ID, paymentOrderNo)
 		if err != nil {
 			return err
 		}
-	case 1: // 购买
-		// 处理购买逻辑
-		// 这里需要根据RelatedType和RelatedID处理不同的购买逻辑
-		// 暂时不实现
-	case 2: // 其他
-		// 处理其他逻辑
-		// 暂时不实现
-	}
-
-	// 提交事务
-	err = tx.Commit()
-	if err != nil {
-		logger.Error("提交事务失败", "error", err)
-		return err
-	}
 
-	return nil
+		// 处理订单类型
+		switch order.Type {
+		case 0: // 充值
+			// 更新会员余额
+			err = s.memberModel.UpdateMoney(order.MemberID, order.Amount)
+			if err != nil {
+				return err
+			}
+		case 1: // 购买
+			// 处理购买逻辑
+			// 这里需要根据RelatedType和RelatedID处理不同的购买逻辑
+			// 暂时不实现
+		case 2: // 其他
+			// 处理其他逻辑
+			// 暂时不实现
+		}
+		return nil
+	})
 }
 
 // CancelOrder 取消订单
@@ -249,45 +243,32 @@ func (s *PaymentService) RefundOrder(orderNo string) error {
 		return fmt.Errorf("order status is not paid")
 	}
 
-	// 开始事务
-	tx, err := s.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// 更新订单状态为已退款
-	err = s.paymentOrderModel.UpdateStatus(order.ID, 3)
-	if err != nil {
-		return err
-	}
-
-	// 处理订单类型
-	switch order.Type {
-	case 0: // 充值
-		// 更新会员余额
-		err = s.memberModel.UpdateMoney(order.MemberID, -order.Amount)
+	// 在事务中更新订单和会员余额
+	return s.withTx(func(s *PaymentService) error {
+		// 更新订单状态为已退款
+		err := s.paymentOrderModel.UpdateStatus(order.ID, 3)
 		if err != nil {
 			return err
 		}
-	case 1: // 购买
-		// 处理退款逻辑
-		// 这里需要根据RelatedType和RelatedID处理不同的退款逻辑
-		// 暂时不实现
-	case 2: // 其他
-		// 处理其他逻辑
-		// 暂时不实现
-	}
-
-	// 提交事务
-	err = tx.Commit()
-	if err != nil {
-		logger.Error("提交事务失败", "error", err)
-		return err
-	}
 
-	return nil
+		// 处理订单类型
+		switch order.Type {
+		case 0: // 充值
+			// 更新会员余额
+			err = s.memberModel.UpdateMoney(order.MemberID, -order.Amount)
+			if err != nil {
+				return err
+			}
+		case 1: // 购买
+			// 处理退款逻辑
+			// 这里需要根据RelatedType和RelatedID处理不同的退款逻辑
+			// 暂时不实现
+		case 2: // 其他
+			// 处理其他逻辑
+			// 暂时不实现
+		}
+		return nil
+	})
 }
 
 // GetPaymentURL 获取支付URL
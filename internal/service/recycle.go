@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// 自动清理回收站的检查间隔和每批清理数量
+const (
+	recyclePurgeInterval = time.Hour
+	recyclePurgeBatch    = 100
+)
+
+// RecycleService 文档回收站服务
+type RecycleService struct {
+	db           *database.DB
+	cache        cache.Cache
+	config       *config.Config
+	articleModel *model.ArticleModel
+	htmlService  *HtmlService
+}
+
+// NewRecycleService 创建回收站服务
+func NewRecycleService(db *database.DB, cache cache.Cache, config *config.Config) *RecycleService {
+	return &RecycleService{
+		db:           db,
+		cache:        cache,
+		config:       config,
+		articleModel: model.NewArticleModel(db),
+		htmlService:  NewHtmlService(db, cache, config),
+	}
+}
+
+// GetList 获取回收站中的文章
+func (s *RecycleService) GetList(page, pageSize int) ([]*model.Article, int, error) {
+	return s.articleModel.GetRecycleList(page, pageSize)
+}
+
+// Trash 将文章移入回收站，标签和评论保留以便恢复，标签的使用次数减少，静态页面立即删除
+func (s *RecycleService) Trash(id int64) error {
+	article, err := s.articleModel.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.trash(id); err != nil {
+		return err
+	}
+
+	s.cache.Delete("article:" + fmt.Sprint(id))
+	if err := s.htmlService.RemoveArticle(id); err != nil {
+		logger.Error("删除文章静态页失败", "id", id, "error", err)
+	}
+	s.refreshList(article)
+
+	logger.Info("文章已移入回收站", "id", id)
+	return nil
+}
+
+// trash 在事务中将文章移入回收站并减少标签的使用次数，不处理缓存和静态页面，供批量操作在其事务中调用
+func (s *RecycleService) trash(id int64) error {
+	return s.db.WithTx(func(tx *database.Tx) error {
+		if err := model.NewArticleModel(tx.DB()).MoveToRecycle(id); err != nil {
+			return err
+		}
+		return model.NewTagModel(tx.DB()).ChangeArticleTagCount(id, -1)
+	})
+}
+
+// Restore 从回收站恢复文章，恢复标签的使用次数并重新生成静态页面
+func (s *RecycleService) Restore(id int64) error {
+	err := s.db.WithTx(func(tx *database.Tx) error {
+		if err := model.NewArticleModel(tx.DB()).Restore(id); err != nil {
+			return err
+		}
+		return model.NewTagModel(tx.DB()).ChangeArticleTagCount(id, 1)
+	})
+	if err != nil {
+		return err
+	}
+
+	article, err := s.articleModel.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	s.cache.Delete("article:" + fmt.Sprint(id))
+	if s.config.Site.StaticArticle {
+		if err := s.htmlService.GenerateArticle(id); err != nil {
+			logger.Error("重新生成文章静态页失败", "id", id, "error", err)
+		}
+	}
+	s.refreshList(article)
+
+	logger.Info("文章已从回收站恢复", "id", id)
+	return nil
+}
+
+// Purge 彻底删除回收站中的文章及其标签、评论、修订历史、审核记录和相关文章记录
+func (s *RecycleService) Purge(id int64) error {
+	article, err := s.articleModel.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if article.ArcRank != model.ArcRankRecycled {
+		return fmt.Errorf("文章不在回收站中，不能彻底删除")
+	}
+
+	err = s.db.WithTx(func(tx *database.Tx) error {
+		// 移除标签关联，使用次数在移入回收站时已减少
+		if err := model.NewTagModel(tx.DB()).DeleteByAID(id); err != nil {
+			return err
+		}
+
+		// 删除评论
+		if err := model.NewCommentModel(tx.DB()).DeleteByAID(id); err != nil {
+			return err
+		}
+
+		// 删除修订历史和审核记录
+		if err := model.NewArticleRevisionModel(tx.DB()).DeleteByAID(id); err != nil {
+			return err
+		}
+		if err := model.NewArticleWorkflowModel(tx.DB()).DeleteByAID(id); err != nil {
+			return err
+		}
+		if err := model.NewArticleRelationModel(tx.DB()).DeleteByAID(id); err != nil {
+			return err
+		}
+
+		return model.NewArticleModel(tx.DB()).Delete(id)
+	})
+	if err != nil {
+		logger.Error("彻底删除文章失败", "id", id, "error", err)
+		return err
+	}
+
+	s.cache.Delete("article:" + fmt.Sprint(id))
+	if err := s.htmlService.RemoveArticle(id); err != nil {
+		logger.Error("删除文章静态页失败", "id", id, "error", err)
+	}
+
+	logger.Info("文章已彻底删除", "id", id)
+	return nil
+}
+
+// PurgeExpired 彻底删除在回收站中超过days天的文章，返回删除数量
+func (s *RecycleService) PurgeExpired(days int) (int, error) {
+	if days <= 0 {
+		return 0, nil
+	}
+
+	before := time.Now().AddDate(0, 0, -days)
+	count := 0
+	for {
+		ids, err := s.articleModel.GetRecycledBefore(before, recyclePurgeBatch)
+		if err != nil {
+			return count, err
+		}
+
+		purged := 0
+		for _, id := range ids {
+			if err := s.Purge(id); err != nil {
+				continue
+			}
+			purged++
+		}
+		count += purged
+
+		// 本批全部失败时停止，避免反复处理同一批
+		if len(ids) < recyclePurgeBatch || purged == 0 {
+			return count, nil
+		}
+	}
+}
+
+// RunAutoPurge 定时清理回收站中过期的文章，直到ctx取消
+func (s *RecycleService) RunAutoPurge(ctx context.Context) {
+	days := s.config.Site.RecycleDays
+	if days <= 0 {
+		return
+	}
+
+	logger.Info("回收站自动清理已启用", "days", days)
+
+	ticker := time.NewTicker(recyclePurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := s.PurgeExpired(days)
+		if err != nil {
+			logger.Error("自动清理回收站失败", "error", err)
+		} else if count > 0 {
+			logger.Info("自动清理回收站完成", "count", count)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshList 重新生成列表中包含该文章的栏目静态列表页
+func (s *RecycleService) refreshList(article *model.Article) {
+	if !s.config.Site.StaticList || article.TypeID <= 0 {
+		return
+	}
+	s.htmlService.GenerateArticleLists(article.ID, article.TypeID)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"html"
+	"strings"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+	"aq3cms/pkg/security"
+	"aq3cms/pkg/similarity"
+)
+
+// 相关文章计算参数
+const (
+	relatedDefaultInterval = 60   // 默认计算间隔，单位分钟
+	relatedBatch           = 500  // 每批读取的文章数
+	relatedTopN            = 20   // 每篇文章保存的相关文章数
+	relatedMinScore        = 0.05 // 最低相似度
+	relatedBodyLength      = 3000 // 参与计算的正文长度，单位字符
+)
+
+// 各字段在词向量中的权重
+const (
+	relatedTitleWeight = 3
+	relatedTagWeight   = 3
+	relatedBodyWeight  = 1
+)
+
+// RelatedService 相关文章服务，根据标题、标签和正文的相似度计算相关文章
+type RelatedService struct {
+	db            *database.DB
+	cache         cache.Cache
+	config        *config.Config
+	relationModel *model.ArticleRelationModel
+}
+
+// NewRelatedService 创建相关文章服务
+func NewRelatedService(db *database.DB, cache cache.Cache, config *config.Config) *RelatedService {
+	return &RelatedService{
+		db:            db,
+		cache:         cache,
+		config:        config,
+		relationModel: model.NewArticleRelationModel(db),
+	}
+}
+
+// relatedSource 参与计算的文章字段
+type relatedSource struct {
+	ID       int64  `db:"id"`
+	Title    string `db:"title"`
+	Keywords string `db:"keywords"`
+	Body     string `db:"body"`
+}
+
+// Rebuild 重新计算所有已发布文章的相关文章，返回有相关文章的文章数
+func (s *RelatedService) Rebuild() (int, error) {
+	corpus := similarity.NewCorpus()
+
+	var lastID int64
+	for {
+		qb := database.NewQueryBuilder(s.db, "archives")
+		qb.Select("a.id", "a.title", "a.keywords", "ad.body")
+		qb.From(s.db.TableName("archives") + " AS a")
+		qb.LeftJoin(s.db.TableName("addonarticle")+" AS ad", "a.id = ad.aid")
+		qb.Where("a.arcrank > -1")
+		qb.Where("a.state = ?", model.ArticleStatePublished)
+		qb.Where("a.id > ?", lastID)
+		qb.OrderBy("a.id ASC")
+		qb.Limit(relatedBatch)
+
+		sources := make([]*relatedSource, 0, relatedBatch)
+		if err := qb.GetInto(&sources); err != nil {
+			logger.Error("读取相关文章计算数据失败", "error", err)
+			return 0, err
+		}
+
+		if len(sources) == 0 {
+			break
+		}
+
+		tags, err := s.batchTags(lastID, sources[len(sources)-1].ID)
+		if err != nil {
+			return 0, err
+		}
+		for _, src := range sources {
+			corpus.Add(src.ID, s.vector(src, tags[src.ID]))
+		}
+
+		lastID = sources[len(sources)-1].ID
+		if len(sources) < relatedBatch {
+			break
+		}
+	}
+
+	matches := corpus.TopN(relatedTopN, relatedMinScore)
+	relations := make([]*model.ArticleRelation, 0, len(matches)*relatedTopN)
+	for aid, list := range matches {
+		for i, m := range list {
+			relations = append(relations, &model.ArticleRelation{
+				AID:      aid,
+				RelID:    m.ID,
+				Score:    m.Score,
+				SortRank: i + 1,
+			})
+		}
+	}
+
+	if err := s.relationModel.ReplaceAll(relations); err != nil {
+		return 0, err
+	}
+
+	return len(matches), nil
+}
+
+// batchTags 获取ID在(from, to]范围内的文章标签
+func (s *RelatedService) batchTags(from, to int64) (map[int64][]string, error) {
+	qb := database.NewQueryBuilder(s.db, "taglist")
+	qb.Select("aid", "tag")
+	qb.Where("aid > ?", from)
+	qb.Where("aid <= ?", to)
+
+	var rows []struct {
+		AID int64  `db:"aid"`
+		Tag string `db:"tag"`
+	}
+	if err := qb.GetInto(&rows); err != nil {
+		logger.Error("读取文章标签失败", "error", err)
+		return nil, err
+	}
+
+	tags := make(map[int64][]string)
+	for _, row := range rows {
+		tags[row.AID] = append(tags[row.AID], row.Tag)
+	}
+	return tags, nil
+}
+
+// vector 生成文章的词向量，没有标签时使用关键词
+func (s *RelatedService) vector(src *relatedSource, tags []string) similarity.Vector {
+	if len(tags) == 0 {
+		tags = strings.Split(src.Keywords, ",")
+	}
+
+	body := []rune(strings.TrimSpace(html.UnescapeString(security.StripTags(src.Body))))
+	if len(body) > relatedBodyLength {
+		body = body[:relatedBodyLength]
+	}
+
+	v := make(similarity.Vector)
+	v.Add(src.Title, relatedTitleWeight)
+	for _, tag := range tags {
+		v.AddTerm(tag, relatedTagWeight)
+	}
+	v.Add(string(body), relatedBodyWeight)
+	return v
+}
+
+// RunBuilder 定时重新计算相关文章，直到ctx取消
+func (s *RelatedService) RunBuilder(ctx context.Context) {
+	interval := s.config.Site.RelatedInterval
+	if interval < 0 {
+		return
+	}
+	if interval == 0 {
+		interval = relatedDefaultInterval
+	}
+
+	logger.Info("相关文章计算已启用", "interval", interval)
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		count, err := s.Rebuild()
+		if err != nil {
+			logger.Error("计算相关文章失败", "error", err)
+		} else {
+			logger.Info("计算相关文章完成", "count", count, "elapsed", time.Since(start).String())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
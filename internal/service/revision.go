@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// 行数乘积超过该值时不再逐行比较，直接显示为整体替换
+const maxDiffCells = 4000000
+
+// 差异行类型
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffLine 差异中的一行
+type DiffLine struct {
+	Type    string `json:"type"`     // equal、insert、delete
+	Text    string `json:"text"`     // 行内容
+	OldLine int    `json:"old_line"` // 在旧版本中的行号，新增的行为0
+	NewLine int    `json:"new_line"` // 在新版本中的行号，删除的行为0
+}
+
+// RevisionService 文章修订历史服务
+type RevisionService struct {
+	db            *database.DB
+	cache         cache.Cache
+	config        *config.Config
+	articleModel  *model.ArticleModel
+	revisionModel *model.ArticleRevisionModel
+	htmlService   *HtmlService
+}
+
+// NewRevisionService 创建文章修订历史服务
+func NewRevisionService(db *database.DB, cache cache.Cache, config *config.Config) *RevisionService {
+	return &RevisionService{
+		db:            db,
+		cache:         cache,
+		config:        config,
+		articleModel:  model.NewArticleModel(db),
+		revisionModel: model.NewArticleRevisionModel(db),
+		htmlService:   NewHtmlService(db, cache, config),
+	}
+}
+
+// UpdateArticle 保存文章并记录修订版本，adminID和memberID为编辑者
+func (s *RevisionService) UpdateArticle(article *model.Article, adminID, memberID int64) error {
+	return s.updateArticle(article, &model.ArticleRevision{
+		AdminID:  adminID,
+		MemberID: memberID,
+	})
+}
+
+// updateArticle 在同一事务中更新文章并保存修订版本
+func (s *RevisionService) updateArticle(article *model.Article, revision *model.ArticleRevision) error {
+	return s.db.WithTx(func(tx *database.Tx) error {
+		articleModel := model.NewArticleModel(tx.DB())
+		revisionModel := model.NewArticleRevisionModel(tx.DB())
+
+		// 第一次修改前先保存原始版本，保证第一次修改也能撤销
+		count, err := revisionModel.CountByAID(article.ID)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			original, err := articleModel.GetByID(article.ID)
+			if err != nil {
+				return err
+			}
+			_, err = revisionModel.Create(&model.ArticleRevision{
+				AID:      original.ID,
+				Title:    original.Title,
+				Body:     original.SourceBody(),
+				Format:   model.NormalizeBodyFormat(original.Format),
+				Keywords: original.Keywords,
+				Flag:     original.Flag,
+				MemberID: original.MemberID,
+				Note:     "原始版本",
+				Dateline: original.SendDate,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := articleModel.Update(article); err != nil {
+			return err
+		}
+
+		revision.AID = article.ID
+		revision.Title = article.Title
+		revision.Body = article.SourceBody()
+		revision.Format = model.NormalizeBodyFormat(article.Format)
+		revision.Keywords = article.Keywords
+		revision.Flag = article.FlagString()
+		_, err = revisionModel.Create(revision)
+		return err
+	})
+}
+
+// GetList 获取文章的修订版本
+func (s *RevisionService) GetList(aid int64) ([]*model.ArticleRevision, error) {
+	return s.revisionModel.GetListByAID(aid)
+}
+
+// GetByID 获取修订版本
+func (s *RevisionService) GetByID(id int64) (*model.ArticleRevision, error) {
+	return s.revisionModel.GetByID(id)
+}
+
+// Diff 比较同一文章的两个修订版本的内容，返回逐行差异
+func (s *RevisionService) Diff(fromID, toID int64) (*model.ArticleRevision, *model.ArticleRevision, []DiffLine, error) {
+	from, err := s.revisionModel.GetByID(fromID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	to, err := s.revisionModel.GetByID(toID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if from.AID != to.AID {
+		return nil, nil, nil, fmt.Errorf("修订版本不属于同一篇文章")
+	}
+
+	return from, to, DiffLines(from.Body, to.Body), nil
+}
+
+// Restore 将文章恢复到指定的修订版本，恢复本身也记录为一个新版本
+func (s *RevisionService) Restore(id, adminID int64) (*model.Article, error) {
+	revision, err := s.revisionModel.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := s.articleModel.GetByID(revision.AID)
+	if err != nil {
+		return nil, err
+	}
+	if article.ArcRank == model.ArcRankRecycled {
+		return nil, fmt.Errorf("文章在回收站中，请先恢复文章")
+	}
+
+	article.Title = revision.Title
+	if err := article.SetBody(revision.Format, revision.Body, s.config.Site.PageBreak); err != nil {
+		logger.Error("转换修订版本内容失败", "id", id, "error", err)
+		return nil, err
+	}
+	article.Keywords = revision.Keywords
+	article.IsTop, article.IsRecommend, article.IsHot = revision.Flags()
+
+	err = s.updateArticle(article, &model.ArticleRevision{
+		AdminID: adminID,
+		Note:    fmt.Sprintf("恢复到版本 #%d", revision.ID),
+	})
+	if err != nil {
+		logger.Error("恢复修订版本失败", "id", id, "aid", revision.AID, "error", err)
+		return nil, err
+	}
+
+	s.cache.Delete("article:" + fmt.Sprint(article.ID))
+	if s.config.Site.StaticArticle {
+		if err := s.htmlService.GenerateArticle(article.ID); err != nil {
+			logger.Error("重新生成文章静态页失败", "id", article.ID, "error", err)
+		}
+	}
+
+	logger.Info("文章已恢复到修订版本", "aid", article.ID, "revision", revision.ID)
+	return article, nil
+}
+
+// DiffLines 按行比较两段文本
+func DiffLines(oldText, newText string) []DiffLine {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// 跳过相同的开头和结尾，减少比较的行数
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		lines = append(lines, DiffLine{Type: DiffEqual, Text: a[i], OldLine: i + 1, NewLine: i + 1})
+	}
+
+	lines = append(lines, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix)...)
+
+	for i := 0; i < suffix; i++ {
+		oi := len(a) - suffix + i
+		ni := len(b) - suffix + i
+		lines = append(lines, DiffLine{Type: DiffEqual, Text: a[oi], OldLine: oi + 1, NewLine: ni + 1})
+	}
+
+	return lines
+}
+
+// diffMiddle 用最长公共子序列比较去掉公共首尾后的部分，offset为已跳过的行数
+func diffMiddle(a, b []string, offset int) []DiffLine {
+	n, m := len(a), len(b)
+	lines := make([]DiffLine, 0, n+m)
+
+	// 行数过多时直接显示为整体替换，避免占用过多内存
+	if n*m > maxDiffCells {
+		for i, line := range a {
+			lines = append(lines, DiffLine{Type: DiffDelete, Text: line, OldLine: offset + i + 1})
+		}
+		for j, line := range b {
+			lines = append(lines, DiffLine{Type: DiffInsert, Text: line, NewLine: offset + j + 1})
+		}
+		return lines
+	}
+
+	// lcs[i][j] 为 a[i:] 和 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			lines = append(lines, DiffLine{Type: DiffEqual, Text: a[i], OldLine: offset + i + 1, NewLine: offset + j + 1})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, DiffLine{Type: DiffDelete, Text: a[i], OldLine: offset + i + 1})
+			i++
+		default:
+			lines = append(lines, DiffLine{Type: DiffInsert, Text: b[j], NewLine: offset + j + 1})
+			j++
+		}
+	}
+
+	return lines
+}
+
+// splitLines 按行拆分文本，统一换行符
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"fmt"
-	"time"
 
 	"aq3cms/config"
 	"aq3cms/internal/model"
@@ -135,35 +134,12 @@ func (s *ScoreService) GetScoreRanking(limit int) ([]*model.Member, error) {
 	qb.Limit(limit)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	members := make([]*model.Member, 0)
+	if err := qb.GetInto(&members); err != nil {
 		logger.Error("获取积分排行失败", "error", err)
 		return nil, err
 	}
 
-	// 转换为会员列表
-	members := make([]*model.Member, 0, len(results))
-	for _, result := range results {
-		member := &model.Member{}
-		member.ID, _ = result["id"].(int64)
-		member.Username, _ = result["username"].(string)
-		member.Email, _ = result["email"].(string)
-		member.Mobile, _ = result["mobile"].(string)
-		member.MType, _ = result["mtype"].(int)
-		member.Sex, _ = result["sex"].(string)
-		member.Avatar, _ = result["avatar"].(string)
-		member.QQ, _ = result["qq"].(string)
-		member.Score, _ = result["score"].(int)
-		member.Money, _ = result["money"].(float64)
-		member.Status, _ = result["status"].(int)
-		member.RegTime, _ = result["regtime"].(time.Time)
-		member.RegIP, _ = result["regip"].(string)
-		member.LastLogin, _ = result["lastlogin"].(time.Time)
-		member.LastIP, _ = result["lastip"].(string)
-		member.LoginCount, _ = result["logincount"].(int)
-		members = append(members, member)
-	}
-
 	return members, nil
 }
 
@@ -24,14 +24,14 @@ type SearchService struct {
 
 // SearchResult 搜索结果
 type SearchResult struct {
-	ID          int64     `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	PubDate     time.Time `json:"pubdate"`
+	ID          int64     `json:"id" db:"id"`
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	PubDate     time.Time `json:"pubdate" db:"pubdate"`
 	URL         string    `json:"url"`
-	ChannelType int       `json:"channeltype"`
-	TypeID      int64     `json:"typeid"`
-	TypeName    string    `json:"typename"`
+	ChannelType int       `json:"channeltype" db:"channel"`
+	TypeID      int64     `json:"typeid" db:"typeid"`
+	TypeName    string    `json:"typename" db:"typename"`
 	Highlight   string    `json:"highlight"`
 }
 
@@ -80,12 +80,14 @@ func (s *SearchService) Search(options *SearchOptions) ([]*SearchResult, int, er
 	// 构建查询
 	qb := database.NewQueryBuilder(s.db, "archives")
 	qb.Select("a.id", "a.title", "a.description", "a.pubdate", "a.typeid", "a.channel", "t.typename")
+	qb.From(s.db.TableName("archives") + " AS a")
 
 	// 添加左连接
 	qb.LeftJoin(s.db.TableName("arctype")+" AS t", "a.typeid = t.id")
 
 	// 添加条件
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", model.ArticleStatePublished)
 
 	// 添加频道类型条件
 	if options.ChannelType > 0 {
@@ -162,33 +164,14 @@ func (s *SearchService) Search(options *SearchOptions) ([]*SearchResult, int, er
 	qb.Limit(options.PageSize, offset)
 
 	// 执行查询
-	results, err := qb.Get()
-	if err != nil {
+	searchResults := make([]*SearchResult, 0)
+	if err := qb.GetInto(&searchResults); err != nil {
 		logger.Error("查询搜索结果失败", "error", err)
 		return nil, 0, err
 	}
 
-	// 转换为搜索结果
-	searchResults := make([]*SearchResult, 0, len(results))
-	for _, result := range results {
-		searchResult := &SearchResult{}
-		searchResult.ID, _ = result["id"].(int64)
-		searchResult.Title, _ = result["title"].(string)
-		searchResult.Description, _ = result["description"].(string)
-
-		// 处理日期
-		if pubdate, ok := result["pubdate"].(time.Time); ok {
-			searchResult.PubDate = pubdate
-		}
-
-		searchResult.TypeID, _ = result["typeid"].(int64)
-		searchResult.TypeName, _ = result["typename"].(string)
-
-		// 处理频道类型
-		if channel, ok := result["channel"].(int64); ok {
-			searchResult.ChannelType = int(channel)
-		}
-
+	// 补充地址和高亮
+	for _, searchResult := range searchResults {
 		// 生成URL
 		switch searchResult.ChannelType {
 		case 1: // 文章
@@ -279,8 +262,6 @@ func (s *SearchService) Search(options *SearchOptions) ([]*SearchResult, int, er
 
 			searchResult.Highlight = highlight
 		}
-
-		searchResults = append(searchResults, searchResult)
 	}
 
 	// 缓存搜索结果
@@ -161,17 +161,18 @@ func (s *TemplateService) loadGlobals() {
 	qb := database.NewQueryBuilder(s.db, "sysconfig")
 	qb.Select("*")
 
-	results, err := qb.Get()
-	if err != nil {
+	var rows []struct {
+		VarName string `db:"varname"`
+		Value   string `db:"value"`
+	}
+	if err := qb.GetInto(&rows); err != nil {
 		logger.Error("加载全局变量失败", "error", err)
 		return
 	}
 
 	// 处理结果
-	for _, result := range results {
-		if varname, ok := result["varname"].(string); ok {
-			s.globals[varname] = result["value"]
-		}
+	for _, row := range rows {
+		s.globals[row.VarName] = row.Value
 	}
 
 	// 添加站点配置
@@ -279,6 +280,11 @@ func (s *TemplateService) registerTagHandlers() {
 		DB: s.db,
 	})
 
+	// 相关文章标签
+	s.engine.RegisterTag("likearticle", &tags.LikeArticleTag{
+		DB: s.db,
+	})
+
 	// 栏目标签
 	s.engine.RegisterTag("channel", &tags.ChannelTag{
 		DB: s.db,
@@ -300,6 +306,9 @@ func (s *TemplateService) registerTagHandlers() {
 	// 分页标签
 	s.engine.RegisterTag("pagelist", &tags.PageListTag{})
 
+	// 文章内分页标签
+	s.engine.RegisterTag("pagebreak", &tags.PageBreakTag{})
+
 	// 友情链接标签
 	s.engine.RegisterTag("flink", &tags.FLinkTag{
 		DB: s.db,
@@ -8,7 +8,6 @@ import (
 	"aq3cms/internal/model"
 	"aq3cms/pkg/cache"
 	"aq3cms/pkg/database"
-	"aq3cms/pkg/logger"
 )
 
 // VoteService 投票服务
@@ -33,6 +32,13 @@ func NewVoteService(db *database.DB, cache cache.Cache, config *config.Config) *
 	}
 }
 
+// withTx 在事务中执行fn，fn收到的服务实例中的模型都绑定到该事务
+func (s *VoteService) withTx(fn func(s *VoteService) error) error {
+	return s.db.WithTx(func(tx *database.Tx) error {
+		return fn(NewVoteService(tx.DB(), s.cache, s.config))
+	})
+}
+
 // GetVote 获取投票
 func (s *VoteService) GetVote(id int64) (*model.Vote, []*model.VoteOption, error) {
 	// 获取投票
@@ -52,34 +58,28 @@ func (s *VoteService) GetVote(id int64) (*model.Vote, []*model.VoteOption, error
 
 // CreateVote 创建投票
 func (s *VoteService) CreateVote(vote *model.Vote, options []*model.VoteOption) (int64, error) {
-	// 开始事务
-	tx, err := s.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return 0, err
-	}
-	defer tx.Rollback()
-
-	// 创建投票
-	voteID, err := s.voteModel.Create(vote)
-	if err != nil {
-		return 0, err
-	}
-
-	// 创建投票选项
-	for i, option := range options {
-		option.VoteID = voteID
-		option.OrderID = i + 1
-		_, err = s.voteOptionModel.Create(option)
+	// 在事务中创建投票和选项
+	var voteID int64
+	err := s.withTx(func(s *VoteService) error {
+		// 创建投票
+		var err error
+		voteID, err = s.voteModel.Create(vote)
 		if err != nil {
-			return 0, err
+			return err
 		}
-	}
 
-	// 提交事务
-	err = tx.Commit()
+		// 创建投票选项
+		for i, option := range options {
+			option.VoteID = voteID
+			option.OrderID = i + 1
+			_, err = s.voteOptionModel.Create(option)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		logger.Error("提交事务失败", "error", err)
 		return 0, err
 	}
 
@@ -88,82 +88,56 @@ func (s *VoteService) CreateVote(vote *model.Vote, options []*model.VoteOption)
 
 // UpdateVote 更新投票
 func (s *VoteService) UpdateVote(vote *model.Vote, options []*model.VoteOption) error {
-	// 开始事务
-	tx, err := s.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// 更新投票
-	err = s.voteModel.Update(vote)
-	if err != nil {
-		return err
-	}
-
-	// 删除原有选项
-	err = s.voteOptionModel.DeleteByVoteID(vote.ID)
-	if err != nil {
-		return err
-	}
-
-	// 创建新选项
-	for i, option := range options {
-		option.VoteID = vote.ID
-		option.OrderID = i + 1
-		_, err = s.voteOptionModel.Create(option)
+	// 在事务中更新投票和选项
+	return s.withTx(func(s *VoteService) error {
+		// 更新投票
+		err := s.voteModel.Update(vote)
 		if err != nil {
 			return err
 		}
-	}
 
-	// 提交事务
-	err = tx.Commit()
-	if err != nil {
-		logger.Error("提交事务失败", "error", err)
-		return err
-	}
+		// 删除原有选项
+		err = s.voteOptionModel.DeleteByVoteID(vote.ID)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		// 创建新选项
+		for i, option := range options {
+			option.VoteID = vote.ID
+			option.OrderID = i + 1
+			_, err = s.voteOptionModel.Create(option)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // DeleteVote 删除投票
 func (s *VoteService) DeleteVote(id int64) error {
-	// 开始事务
-	tx, err := s.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// 删除投票日志
-	err = s.voteLogModel.DeleteByVoteID(id)
-	if err != nil {
-		return err
-	}
-
-	// 删除投票选项
-	err = s.voteOptionModel.DeleteByVoteID(id)
-	if err != nil {
-		return err
-	}
-
-	// 删除投票
-	err = s.voteModel.Delete(id)
-	if err != nil {
-		return err
-	}
+	// 在事务中删除投票、选项和日志
+	return s.withTx(func(s *VoteService) error {
+		// 删除投票日志
+		err := s.voteLogModel.DeleteByVoteID(id)
+		if err != nil {
+			return err
+		}
 
-	// 提交事务
-	err = tx.Commit()
-	if err != nil {
-		logger.Error("提交事务失败", "error", err)
-		return err
-	}
+		// 删除投票选项
+		err = s.voteOptionModel.DeleteByVoteID(id)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		// 删除投票
+		err = s.voteModel.Delete(id)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
 }
 
 // DoVote 执行投票
@@ -208,58 +182,45 @@ func (s *VoteService) DoVote(voteID int64, optionIDs []int64, memberID int64, ip
 		return fmt.Errorf("too many options selected")
 	}
 
-	// 开始事务
-	tx, err := s.db.Begin()
-	if err != nil {
-		logger.Error("开始事务失败", "error", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// 增加选项投票数
-	for _, optionID := range optionIDs {
-		// 检查选项是否存在
-		option, err := s.voteOptionModel.GetByID(optionID)
-		if err != nil {
-			return err
-		}
-		if option.VoteID != voteID {
-			return fmt.Errorf("option not belong to vote")
+	// 在事务中记录投票
+	return s.withTx(func(s *VoteService) error {
+		// 增加选项投票数
+		for _, optionID := range optionIDs {
+			// 检查选项是否存在
+			option, err := s.voteOptionModel.GetByID(optionID)
+			if err != nil {
+				return err
+			}
+			if option.VoteID != voteID {
+				return fmt.Errorf("option not belong to vote")
+			}
+
+			// 增加投票数
+			err = s.voteOptionModel.IncrementCount(optionID)
+			if err != nil {
+				return err
+			}
+
+			// 创建投票日志
+			log := &model.VoteLog{
+				VoteID:   voteID,
+				OptionID: optionID,
+				MemberID: memberID,
+				IP:       ip,
+			}
+			_, err = s.voteLogModel.Create(log)
+			if err != nil {
+				return err
+			}
 		}
 
-		// 增加投票数
-		err = s.voteOptionModel.IncrementCount(optionID)
-		if err != nil {
-			return err
-		}
-
-		// 创建投票日志
-		log := &model.VoteLog{
-			VoteID:   voteID,
-			OptionID: optionID,
-			MemberID: memberID,
-			IP:       ip,
-		}
-		_, err = s.voteLogModel.Create(log)
+		// 增加总投票数
+		err := s.voteModel.IncrementTotalCount(voteID, 1)
 		if err != nil {
 			return err
 		}
-	}
-
-	// 增加总投票数
-	err = s.voteModel.IncrementTotalCount(voteID, 1)
-	if err != nil {
-		return err
-	}
-
-	// 提交事务
-	err = tx.Commit()
-	if err != nil {
-		logger.Error("提交事务失败", "error", err)
-		return err
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // GetVoteResult 获取投票结果
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"aq3cms/config"
+	"aq3cms/internal/model"
+	"aq3cms/pkg/cache"
+	"aq3cms/pkg/database"
+	"aq3cms/pkg/logger"
+)
+
+// 定时发布检查间隔
+const scheduleInterval = time.Minute
+
+// 每次定时任务最多处理的文章数
+const workflowBatchSize = 100
+
+// WorkflowService 文章审核流程服务
+type WorkflowService struct {
+	db            *database.DB
+	cache         cache.Cache
+	config        *config.Config
+	articleModel  *model.ArticleModel
+	workflowModel *model.ArticleWorkflowModel
+	htmlService   *HtmlService
+}
+
+// NewWorkflowService 创建文章审核流程服务
+func NewWorkflowService(db *database.DB, cache cache.Cache, config *config.Config) *WorkflowService {
+	return &WorkflowService{
+		db:            db,
+		cache:         cache,
+		config:        config,
+		articleModel:  model.NewArticleModel(db),
+		workflowModel: model.NewArticleWorkflowModel(db),
+		htmlService:   NewHtmlService(db, cache, config),
+	}
+}
+
+// InitialState 新建文章的状态：可以发布的管理员直接发布，发布时间未到时定时发布，否则按要求保存为草稿或提交审核
+func (s *WorkflowService) InitialState(requested, rank int, pubDate time.Time) int {
+	switch requested {
+	case model.ArticleStateDraft, model.ArticleStatePending:
+		return requested
+	}
+	if rank < model.AdminRankReviewer {
+		return model.ArticleStatePending
+	}
+	if pubDate.After(time.Now()) {
+		return model.ArticleStateScheduled
+	}
+	return model.ArticleStatePublished
+}
+
+// Transition 将文章流转到to状态，rank为操作管理员的等级，退回时必须填写审核意见
+func (s *WorkflowService) Transition(aid int64, to int, adminID int64, rank int, comment string) error {
+	article, err := s.articleModel.GetByID(aid)
+	if err != nil {
+		return err
+	}
+	if article.ArcRank == model.ArcRankRecycled {
+		return fmt.Errorf("文章在回收站中")
+	}
+
+	transition, ok := model.FindArticleTransition(article.State, to)
+	if !ok {
+		return fmt.Errorf("文章不能从%s变为%s", model.ArticleStateNames[article.State], model.ArticleStateNames[to])
+	}
+	if rank < transition.Rank {
+		return fmt.Errorf("没有权限执行“%s”操作", transition.Name)
+	}
+	comment = strings.TrimSpace(comment)
+	if transition.NeedComment && comment == "" {
+		return fmt.Errorf("“%s”需要填写审核意见", transition.Name)
+	}
+
+	// 发布时间未到的文章改为定时发布
+	if to == model.ArticleStatePublished {
+		now := time.Now()
+		if !article.ExpireDate.IsZero() && !article.ExpireDate.After(now) {
+			return fmt.Errorf("文章的下线时间已过，请先修改下线时间")
+		}
+		if article.PubDate.After(now) {
+			to = model.ArticleStateScheduled
+		}
+	}
+
+	return s.apply(article, to, adminID, comment)
+}
+
+// Resubmit 没有审核权限的用户修改已审核的文章后，文章退回待审核状态，重新审核前不再公开
+func (s *WorkflowService) Resubmit(article *model.Article, adminID int64, rank int) error {
+	if rank >= model.AdminRankReviewer {
+		return nil
+	}
+	switch article.State {
+	case model.ArticleStateApproved, model.ArticleStatePublished, model.ArticleStateScheduled, model.ArticleStateArchived:
+		return s.apply(article, model.ArticleStatePending, adminID, "修改后重新提交审核")
+	}
+	return nil
+}
+
+// Reschedule 已发布文章的发布时间改到将来时改为定时发布，与新建文章的规则一致
+func (s *WorkflowService) Reschedule(article *model.Article, adminID int64) error {
+	if article.State != model.ArticleStatePublished || !article.PubDate.After(time.Now()) {
+		return nil
+	}
+	return s.apply(article, model.ArticleStateScheduled, adminID, "发布时间改为"+article.PubDate.Format("2006-01-02 15:04"))
+}
+
+// apply 变更文章状态并记录审核记录，发布或取消发布时更新静态页面和缓存
+func (s *WorkflowService) apply(article *model.Article, to int, adminID int64, comment string) error {
+	aid := article.ID
+	from := article.State
+	err := s.db.WithTx(func(tx *database.Tx) error {
+		if err := model.NewArticleModel(tx.DB()).SetState(aid, from, to); err != nil {
+			return err
+		}
+		_, err := model.NewArticleWorkflowModel(tx.DB()).Create(&model.ArticleWorkflowLog{
+			AID:       aid,
+			FromState: from,
+			ToState:   to,
+			AdminID:   adminID,
+			Comment:   comment,
+		})
+		return err
+	})
+	if err != nil {
+		logger.Error("文章状态流转失败", "id", aid, "from", from, "to", to, "error", err)
+		return err
+	}
+	article.State = to
+
+	s.cache.Delete("article:" + fmt.Sprint(aid))
+	if from == model.ArticleStatePublished || to == model.ArticleStatePublished {
+		s.refreshPublished(article)
+	}
+
+	logger.Info("文章状态已变更", "id", aid, "from", model.ArticleStateNames[from], "to", model.ArticleStateNames[to], "admin", adminID)
+	return nil
+}
+
+// refreshPublished 文章发布或取消发布后更新静态页面，清除站点地图和RSS缓存
+func (s *WorkflowService) refreshPublished(article *model.Article) {
+	if s.config.Site.StaticArticle {
+		if err := s.htmlService.GenerateArticle(article.ID); err != nil {
+			logger.Error("更新文章静态页失败", "id", article.ID, "error", err)
+		}
+	}
+	if s.config.Site.StaticList && article.TypeID > 0 {
+		s.htmlService.GenerateArticleLists(article.ID, article.TypeID)
+	}
+	if s.config.Site.StaticIndex {
+		if err := s.htmlService.GenerateIndex(); err != nil {
+			logger.Error("重新生成首页失败", "error", err)
+		}
+	}
+
+	s.cache.Delete("sitemap")
+	s.cache.Delete("rss:index")
+
+	// 副栏目和交叉栏目的RSS中也有这篇文章
+	typeids, err := s.articleModel.ListedTypeIDs(article.ID, article.TypeID)
+	if err != nil {
+		logger.Error("获取文章所在栏目失败", "id", article.ID, "error", err)
+		typeids = []int64{article.TypeID}
+	}
+	for _, typeid := range typeids {
+		s.cache.Delete(fmt.Sprintf("rss:category:%d", typeid))
+	}
+}
+
+// PublishDue 发布发布时间已到的定时发布文章，返回发布的数量
+func (s *WorkflowService) PublishDue(now time.Time) (int, error) {
+	ids, err := s.articleModel.GetDueScheduled(now, workflowBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return s.applyAll(ids, model.ArticleStateScheduled, model.ArticleStatePublished, "定时发布"), nil
+}
+
+// ExpireDue 将下线时间已到的文章归档，返回下线的数量
+func (s *WorkflowService) ExpireDue(now time.Time) (int, error) {
+	ids, err := s.articleModel.GetDueExpired(now, workflowBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return s.applyAll(ids, model.ArticleStatePublished, model.ArticleStateArchived, "到期自动下线"), nil
+}
+
+// applyAll 由系统将from状态的文章变为to状态，跳过已被修改的文章
+func (s *WorkflowService) applyAll(ids []int64, from, to int, comment string) int {
+	count := 0
+	for _, id := range ids {
+		article, err := s.articleModel.GetByID(id)
+		if err != nil || article.State != from {
+			continue
+		}
+		if err := s.apply(article, to, 0, comment); err != nil {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// RunScheduler 定时发布到期的文章并下线过期的文章，直到ctx结束
+func (s *WorkflowService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if count, err := s.PublishDue(now); err != nil {
+			logger.Error("定时发布文章失败", "error", err)
+		} else if count > 0 {
+			logger.Info("定时发布文章完成", "count", count)
+		}
+		if count, err := s.ExpireDue(now); err != nil {
+			logger.Error("下线过期文章失败", "error", err)
+		} else if count > 0 {
+			logger.Info("下线过期文章完成", "count", count)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// GetTransitions 获取管理员可以对文章执行的流转
+func (s *WorkflowService) GetTransitions(article *model.Article, rank int) []model.WorkflowTransition {
+	if article.ArcRank == model.ArcRankRecycled {
+		return nil
+	}
+	return model.ArticleTransitions(article.State, rank)
+}
+
+// GetLogs 获取文章的审核记录
+func (s *WorkflowService) GetLogs(aid int64) ([]*model.ArticleWorkflowLog, error) {
+	return s.workflowModel.GetListByAID(aid)
+}
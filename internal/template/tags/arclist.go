@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
+	"aq3cms/internal/model"
 	"aq3cms/pkg/database"
 	"aq3cms/pkg/logger"
 )
@@ -46,17 +46,17 @@ func (t *ArcListTag) Handle(attrs map[string]string, content string, data interf
 
 	// 添加条件
 	qb.Where("a.arcrank > -1")
+	qb.Where("a.state = ?", model.ArticleStatePublished)
 
 	if typeid != "" {
-		if strings.Contains(typeid, ",") {
-			// 多个栏目ID
-			qb.Where("a.typeid IN (" + typeid + ")")
-		} else {
-			// 单个栏目ID
-			typeID, err := strconv.Atoi(typeid)
-			if err == nil {
-				qb.Where("a.typeid = ?", typeID)
+		// 一个或多个栏目ID，包括发布到副栏目和交叉栏目的文章
+		if typeIDs := model.ParseIDList(typeid); len(typeIDs) > 0 {
+			condition, args, err := model.NewArticleModel(t.DB).CategoryCondition(typeIDs...)
+			if err != nil {
+				logger.Error("查询栏目条件失败", "typeid", typeid, "error", err)
+				return "", err
 			}
+			qb.Where(condition, args...)
 		}
 	}
 
@@ -90,32 +90,7 @@ func (t *ArcListTag) Handle(attrs map[string]string, content string, data interf
 		fields["typeurl"] = getTypeUrl(article)
 
 		// 处理内容中的字段标签
-		itemContent := content
-		fieldPattern := regexp.MustCompile(`\[field:([a-zA-Z0-9_]+)(?:\s+function="([^"]+)")?\s*/\]`)
-		itemContent = fieldPattern.ReplaceAllStringFunc(itemContent, func(match string) string {
-			matches := fieldPattern.FindStringSubmatch(match)
-			if len(matches) < 2 {
-				return match
-			}
-
-			fieldName := matches[1]
-			if value, ok := fields[fieldName]; ok {
-				// 如果有函数，则应用函数
-				if len(matches) > 2 && matches[2] != "" {
-					funcName := matches[2]
-					// 处理日期格式化函数
-					if fieldName == "pubdate" && funcName == "date('Y-m-d',@me)" {
-						if timestamp, ok := value.(int64); ok {
-							return time.Unix(timestamp, 0).Format("2006-01-02")
-						}
-					}
-					// 其他函数可以在这里添加
-				}
-				return fmt.Sprintf("%v", value)
-			}
-
-			return ""
-		})
+		itemContent := replaceFields(content, fields)
 
 		result.WriteString(itemContent)
 	}
@@ -123,6 +98,37 @@ func (t *ArcListTag) Handle(attrs map[string]string, content string, data interf
 	return result.String(), nil
 }
 
+// fieldPattern 匹配列表内容中的[field:name/]字段标签
+var fieldPattern = regexp.MustCompile(`\[field:([a-zA-Z0-9_]+)(?:\s+function="([^"]+)")?\s*/\]`)
+
+// replaceFields 将内容中的字段标签替换为fields中的值
+func replaceFields(content string, fields map[string]interface{}) string {
+	return fieldPattern.ReplaceAllStringFunc(content, func(match string) string {
+		matches := fieldPattern.FindStringSubmatch(match)
+		if len(matches) < 2 {
+			return match
+		}
+
+		fieldName := matches[1]
+		if value, ok := fields[fieldName]; ok {
+			// 如果有函数，则应用函数
+			if len(matches) > 2 && matches[2] != "" {
+				funcName := matches[2]
+				// 处理日期格式化函数
+				if fieldName == "pubdate" && funcName == "date('Y-m-d',@me)" {
+					if timestamp, ok := value.(int64); ok {
+						return time.Unix(timestamp, 0).Format("2006-01-02")
+					}
+				}
+				// 其他函数可以在这里添加
+			}
+			return fmt.Sprintf("%v", value)
+		}
+
+		return ""
+	})
+}
+
 // getArcUrl 获取文章URL
 func getArcUrl(article map[string]interface{}) string {
 	id, _ := article["id"].(int64)
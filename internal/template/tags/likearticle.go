@@ -0,0 +1,86 @@
+package tags
+
+import (
+	"bytes"
+	"strconv"
+
+	"aq3cms/internal/model"
+	"aq3cms/pkg/database"
+)
+
+// LikeArticleTag 相关文章标签处理器，按内容相似度列出与当前文章相关的文章
+type LikeArticleTag struct {
+	DB *database.DB
+}
+
+// Handle 处理标签，未指定aid时使用模板数据中的当前文章
+func (t *LikeArticleTag) Handle(attrs map[string]string, content string, data interface{}) (string, error) {
+	// 解析属性
+	row := 10
+	if rowStr, ok := attrs["row"]; ok {
+		if r, err := strconv.Atoi(rowStr); err == nil && r > 0 {
+			row = r
+		}
+	}
+	titleLen := 0
+	if lenStr, ok := attrs["titlelen"]; ok {
+		if l, err := strconv.Atoi(lenStr); err == nil && l > 0 {
+			titleLen = l
+		}
+	}
+
+	// 确定当前文章
+	var aid int64
+	var keywords string
+	if aidStr, ok := attrs["aid"]; ok {
+		aid, _ = strconv.ParseInt(aidStr, 10, 64)
+	} else if dataMap, ok := data.(map[string]interface{}); ok {
+		if article, ok := dataMap["Article"].(*model.Article); ok && article != nil {
+			aid = article.ID
+			keywords = article.Keywords
+		}
+	}
+	if aid <= 0 {
+		return "", nil
+	}
+
+	articles, err := model.NewArticleModel(t.DB).GetRelatedArticles(keywords, aid, row)
+	if err != nil {
+		return "", err
+	}
+
+	// 处理每篇文章
+	var result bytes.Buffer
+	for _, article := range articles {
+		title := []rune(article.Title)
+		if titleLen > 0 && len(title) > titleLen {
+			title = title[:titleLen]
+		}
+
+		fields := map[string]interface{}{
+			"id":          article.ID,
+			"typeid":      article.TypeID,
+			"title":       string(title),
+			"fulltitle":   article.Title,
+			"shorttitle":  article.ShortTitle,
+			"color":       article.Color,
+			"writer":      article.Writer,
+			"source":      article.Source,
+			"litpic":      article.LitPic,
+			"pubdate":     article.PubDate.Unix(),
+			"senddate":    article.SendDate.Unix(),
+			"keywords":    article.Keywords,
+			"description": article.Description,
+			"flag":        article.Flag,
+			"click":       article.Click,
+			"typename":    article.TypeName,
+			"typedir":     article.TypeDir,
+		}
+		fields["arcurl"] = getArcUrl(fields)
+		fields["typeurl"] = getTypeUrl(fields)
+
+		result.WriteString(replaceFields(content, fields))
+	}
+
+	return result.String(), nil
+}
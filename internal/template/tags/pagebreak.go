@@ -0,0 +1,102 @@
+package tags
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// PageBreakTag 文章内分页标签处理器，输出上一页、页码和下一页链接
+type PageBreakTag struct{}
+
+// Handle 处理标签，文章只有一页时不输出
+func (t *PageBreakTag) Handle(attrs map[string]string, content string, data interface{}) (string, error) {
+	dataMap, ok := data.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("无法获取模板数据")
+	}
+	pagination, ok := dataMap["ArticlePagination"].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+
+	currentPage, _ := pagination["CurrentPage"].(int)
+	totalPages, _ := pagination["TotalPages"].(int)
+	pageUrl, _ := pagination["PageUrl"].(string)
+	titles, _ := pagination["Titles"].([]string)
+	if totalPages <= 1 {
+		return "", nil
+	}
+
+	// 获取属性
+	listItem := "pre,pageno,next"
+	if item, ok := attrs["listitem"]; ok {
+		listItem = item
+	}
+	listStyle := "pagebreak"
+	if style, ok := attrs["liststyle"]; ok && style != "" {
+		listStyle = style
+	}
+
+	// 页码的显示文字，有分页标题时显示标题
+	pageText := func(page int) string {
+		if page-1 < len(titles) && titles[page-1] != "" {
+			return html.EscapeString(titles[page-1])
+		}
+		return strconv.Itoa(page)
+	}
+
+	var result bytes.Buffer
+	result.WriteString(fmt.Sprintf("<div class=\"%s\">\n", listStyle))
+
+	for _, item := range strings.Split(listItem, ",") {
+		switch strings.TrimSpace(item) {
+		case "index":
+			// 第一页
+			if currentPage > 1 {
+				result.WriteString(fmt.Sprintf("<a href=\"%s\">首页</a>\n", getPageUrl(pageUrl, 1)))
+			} else {
+				result.WriteString("<span class=\"disabled\">首页</span>\n")
+			}
+		case "pre":
+			// 上一页
+			if currentPage > 1 {
+				result.WriteString(fmt.Sprintf("<a href=\"%s\">上一页</a>\n", getPageUrl(pageUrl, currentPage-1)))
+			} else {
+				result.WriteString("<span class=\"disabled\">上一页</span>\n")
+			}
+		case "pageno":
+			// 页码
+			for i := 1; i <= totalPages; i++ {
+				if i == currentPage {
+					result.WriteString(fmt.Sprintf("<span class=\"current\">%s</span>\n", pageText(i)))
+				} else {
+					result.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>\n", getPageUrl(pageUrl, i), pageText(i)))
+				}
+			}
+		case "next":
+			// 下一页
+			if currentPage < totalPages {
+				result.WriteString(fmt.Sprintf("<a href=\"%s\">下一页</a>\n", getPageUrl(pageUrl, currentPage+1)))
+			} else {
+				result.WriteString("<span class=\"disabled\">下一页</span>\n")
+			}
+		case "end":
+			// 最后一页
+			if currentPage < totalPages {
+				result.WriteString(fmt.Sprintf("<a href=\"%s\">末页</a>\n", getPageUrl(pageUrl, totalPages)))
+			} else {
+				result.WriteString("<span class=\"disabled\">末页</span>\n")
+			}
+		case "info":
+			// 信息
+			result.WriteString(fmt.Sprintf("<span class=\"pageinfo\">第 <strong>%d</strong> 页 / 共 <strong>%d</strong> 页</span>\n", currentPage, totalPages))
+		}
+	}
+
+	result.WriteString("</div>\n")
+
+	return result.String(), nil
+}
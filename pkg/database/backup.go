@@ -0,0 +1,652 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"aq3cms/pkg/logger"
+)
+
+// 备份文件头标识
+const backupHeader = "-- aq3cms backup"
+
+// 默认备份目录
+const DefaultBackupDir = "data/backup"
+
+// 恢复时使用的临时表后缀：数据先写入临时表，全部成功后再替换正式表
+const (
+	restoreSuffix  = "__restore"
+	replacedSuffix = "__replaced"
+)
+
+// backupSetStatements 备份文件中允许出现的SET语句
+var backupSetStatements = map[string]bool{
+	"SET NAMES UTF8MB4":        true,
+	"SET FOREIGN_KEY_CHECKS=0": true,
+	"SET FOREIGN_KEY_CHECKS=1": true,
+}
+
+// BackupOptions 备份选项
+type BackupOptions struct {
+	Dir       string // 备份目录
+	Gzip      bool   // 是否gzip压缩
+	ChunkSize int    // 每条INSERT语句包含的行数
+}
+
+// RestoreOptions 恢复选项
+type RestoreOptions struct {
+	DryRun bool // 只校验文件，不执行
+}
+
+// RestoreResult 恢复结果
+type RestoreResult struct {
+	Statements int      // 语句数
+	Tables     []string // 涉及的表
+	Inserts    int      // INSERT语句数
+	DryRun     bool
+}
+
+// Backup 备份数据库到默认目录
+func (db *DB) Backup() (string, error) {
+	return db.BackupWithOptions(BackupOptions{})
+}
+
+// BackupWithOptions 按选项备份数据库，返回备份文件路径
+func (db *DB) BackupWithOptions(opts BackupOptions) (string, error) {
+	if err := db.checkBackupDialect(); err != nil {
+		return "", err
+	}
+	if opts.Dir == "" {
+		opts.Dir = DefaultBackupDir
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = 200
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return "", err
+	}
+
+	// 生成备份文件名
+	name := fmt.Sprintf("backup_%s.sql", time.Now().Format("20060102150405"))
+	if opts.Gzip {
+		name += ".gz"
+	}
+	backupFile := filepath.Join(opts.Dir, name)
+
+	file, err := os.Create(backupFile)
+	if err != nil {
+		return "", err
+	}
+
+	var w io.Writer = file
+	var gz *gzip.Writer
+	if opts.Gzip {
+		gz = gzip.NewWriter(file)
+		w = gz
+	}
+	bw := bufio.NewWriterSize(w, 64*1024)
+
+	err = db.Dump(bw, opts.ChunkSize)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(backupFile)
+		return "", err
+	}
+
+	logger.Info("数据库备份完成", "file", backupFile)
+
+	return backupFile, nil
+}
+
+// Dump 将所有带前缀的表结构和数据写入w，所有表在同一个只读的一致性快照事务中读取
+func (db *DB) Dump(w io.Writer, chunkSize int) error {
+	ctx := db.Context()
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ"); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, "START TRANSACTION WITH CONSISTENT SNAPSHOT, READ ONLY"); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "COMMIT")
+
+	tables, err := prefixedTables(ctx, conn, db.Prefix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "%s\n", backupHeader)
+	fmt.Fprintf(w, "-- prefix: %s\n", db.Prefix)
+	fmt.Fprintf(w, "-- time: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "-- tables: %d\n\n", len(tables))
+	fmt.Fprintf(w, "SET NAMES utf8mb4;\n")
+	fmt.Fprintf(w, "SET FOREIGN_KEY_CHECKS=0;\n\n")
+
+	for _, table := range tables {
+		if err := dumpTable(ctx, conn, w, table, chunkSize); err != nil {
+			return fmt.Errorf("备份表 %s 失败: %v", table, err)
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "SET FOREIGN_KEY_CHECKS=1;\n")
+	return err
+}
+
+// checkBackupDialect 备份文件使用MySQL语法，其他数据库请使用自带的备份工具
+func (db *DB) checkBackupDialect() error {
+	if name := db.dialect().Name(); name != "mysql" {
+		return fmt.Errorf("%s 数据库不支持在线备份和恢复", name)
+	}
+	return nil
+}
+
+// prefixedTables 获取带前缀的表，不包括恢复时留下的临时表
+func prefixedTables(ctx context.Context, conn *sql.Conn, prefix string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, "SHOW TABLES")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(name, prefix) && !isRestoreTable(name) {
+			tables = append(tables, name)
+		}
+	}
+
+	return tables, rows.Err()
+}
+
+// isRestoreTable 是否为恢复时使用的临时表
+func isRestoreTable(table string) bool {
+	return strings.HasSuffix(table, restoreSuffix) || strings.HasSuffix(table, replacedSuffix)
+}
+
+// dumpTable 导出单个表
+func dumpTable(ctx context.Context, conn *sql.Conn, w io.Writer, table string, chunkSize int) error {
+	var name, createSQL string
+	if err := conn.QueryRowContext(ctx, "SHOW CREATE TABLE `"+table+"`").Scan(&name, &createSQL); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "-- Table structure for `%s`\n", table)
+	fmt.Fprintf(w, "DROP TABLE IF EXISTS `%s`;\n", table)
+	fmt.Fprintf(w, "%s;\n\n", createSQL)
+
+	rows, err := conn.QueryContext(ctx, "SELECT * FROM `"+table+"`")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = "`" + col + "`"
+	}
+	insertPrefix := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES\n", table, strings.Join(quoted, ", "))
+
+	values := make([]interface{}, len(columns))
+	valuePtrs := make([]interface{}, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	fmt.Fprintf(w, "-- Data for `%s`\n", table)
+
+	// 单条INSERT语句的大小上限，避免超过 max_allowed_packet
+	const maxStatementSize = 1 << 20
+
+	n, size := 0, 0
+	var line bytes.Buffer
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return err
+		}
+
+		if n == 0 {
+			io.WriteString(w, insertPrefix)
+		} else {
+			io.WriteString(w, ",\n")
+		}
+
+		line.Reset()
+		line.WriteByte('(')
+		for i, v := range values {
+			if i > 0 {
+				line.WriteString(", ")
+			}
+			line.WriteString(sqlLiteral(v))
+		}
+		line.WriteByte(')')
+		if _, err := w.Write(line.Bytes()); err != nil {
+			return err
+		}
+
+		n++
+		size += line.Len()
+		if n >= chunkSize || size >= maxStatementSize {
+			io.WriteString(w, ";\n")
+			n, size = 0, 0
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if n > 0 {
+		io.WriteString(w, ";\n")
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// sqlLiteral 将值转换为SQL字面量
+func sqlLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case uint64:
+		return strconv.FormatUint(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case bool:
+		if val {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		if val.IsZero() {
+			return "'0000-00-00 00:00:00'"
+		}
+		return "'" + val.Format("2006-01-02 15:04:05") + "'"
+	case []byte:
+		return quoteString(string(val))
+	case string:
+		return quoteString(val)
+	default:
+		return quoteString(fmt.Sprintf("%v", val))
+	}
+}
+
+// quoteString 转义字符串，规则与 mysql_real_escape_string 一致
+func quoteString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case 0:
+			b.WriteString(`\0`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '"':
+			b.WriteString(`\"`)
+		case 0x1a:
+			b.WriteString(`\Z`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// Restore 从备份文件恢复数据库
+func (db *DB) Restore(r io.Reader) error {
+	_, err := db.RestoreWithOptions(r, RestoreOptions{})
+	return err
+}
+
+// RestoreWithOptions 按选项恢复数据库
+//
+// 如果r实现了io.Seeker，会先完整校验一遍文件再执行。MySQL的DDL语句会隐式提交事务，
+// 因此备份中的表先恢复到带 __restore 后缀的临时表，全部成功后用一条RENAME TABLE语句
+// 替换正式表；中途失败时删除临时表，正式表保持不变。
+func (db *DB) RestoreWithOptions(r io.Reader, opts RestoreOptions) (*RestoreResult, error) {
+	if err := db.checkBackupDialect(); err != nil {
+		return nil, err
+	}
+	seeker, seekable := r.(io.Seeker)
+
+	if opts.DryRun || seekable {
+		result, err := db.replay(r, nil)
+		if err != nil || opts.DryRun {
+			if result != nil {
+				result.DryRun = opts.DryRun
+			}
+			return result, err
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	// SET语句只对当前连接有效，恢复过程使用同一个连接
+	ctx := db.Context()
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	result, err := db.replay(r, conn)
+	if err == nil {
+		err = swapTables(ctx, conn, result.Tables)
+	}
+	if err != nil {
+		if result != nil {
+			dropTables(conn, result.Tables, restoreSuffix)
+		}
+		return result, err
+	}
+
+	logger.Info("数据库恢复完成", "statements", result.Statements, "tables", len(result.Tables))
+
+	return result, nil
+}
+
+// swapTables 用一条RENAME TABLE语句将临时表替换为正式表，MySQL保证同一语句中的重命名是原子的
+func swapTables(ctx context.Context, conn *sql.Conn, tables []string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	existing := make(map[string]bool)
+	rows, err := conn.QueryContext(ctx, "SHOW TABLES")
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// 清理上次恢复中断时留下的表
+	if err := dropTables(conn, tables, replacedSuffix); err != nil {
+		return err
+	}
+
+	var renames, replaced []string
+	for _, table := range tables {
+		if existing[table] {
+			renames = append(renames, fmt.Sprintf("`%s` TO `%s`", table, table+replacedSuffix))
+			replaced = append(replaced, table)
+		}
+		renames = append(renames, fmt.Sprintf("`%s` TO `%s`", table+restoreSuffix, table))
+	}
+	if _, err := conn.ExecContext(ctx, "RENAME TABLE "+strings.Join(renames, ", ")); err != nil {
+		return err
+	}
+
+	if err := dropTables(conn, replaced, replacedSuffix); err != nil {
+		logger.Error("删除恢复前的旧表失败", "error", err)
+	}
+	return nil
+}
+
+// dropTables 删除表名加上suffix后的表
+func dropTables(conn *sql.Conn, tables []string, suffix string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = "`" + table + suffix + "`"
+	}
+	// 恢复失败时ctx可能已取消，清理不受其影响
+	_, err := conn.ExecContext(context.Background(), "DROP TABLE IF EXISTS "+strings.Join(names, ", "))
+	return err
+}
+
+// replay 逐条解析备份文件中的语句并写入临时表，conn为nil时只校验
+func (db *DB) replay(r io.Reader, conn *sql.Conn) (*RestoreResult, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+
+	// 自动识别gzip
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		br = bufio.NewReaderSize(gz, 64*1024)
+	}
+
+	first, err := br.Peek(len(backupHeader))
+	if err != nil || string(first) != backupHeader {
+		return nil, errors.New("不是有效的aq3cms备份文件")
+	}
+
+	result := &RestoreResult{}
+	seen := make(map[string]bool)
+	reader := newStatementReader(br)
+
+	for {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("解析第 %d 条语句失败: %v", result.Statements+1, err)
+		}
+
+		table, isInsert, err := db.checkStatement(stmt)
+		if err != nil {
+			return result, fmt.Errorf("第 %d 条语句无效: %v", result.Statements+1, err)
+		}
+		if table != "" && !seen[table] {
+			seen[table] = true
+			result.Tables = append(result.Tables, table)
+		}
+		if isInsert {
+			result.Inserts++
+		}
+
+		if conn != nil {
+			// 表名之前只有关键字，第一次出现的表名即语句操作的表
+			if table != "" {
+				stmt = strings.Replace(stmt, table, table+restoreSuffix, 1)
+			}
+			// 备份中的语句已是完整表名，直接在连接上执行，避免数据中的表前缀占位符被替换
+			if _, err := conn.ExecContext(db.Context(), stmt); err != nil {
+				return result, fmt.Errorf("执行第 %d 条语句失败: %v", result.Statements+1, err)
+			}
+		}
+		result.Statements++
+	}
+
+	return result, nil
+}
+
+// checkStatement 校验语句类型和表名，只允许备份文件中会出现的语句
+func (db *DB) checkStatement(stmt string) (table string, isInsert bool, err error) {
+	upper := strings.ToUpper(stmt)
+
+	var rest string
+	switch {
+	case strings.HasPrefix(upper, "SET "):
+		if !backupSetStatements[strings.Join(strings.Fields(upper), " ")] {
+			return "", false, fmt.Errorf("不允许的语句: %.40s", stmt)
+		}
+		return "", false, nil
+	case strings.HasPrefix(upper, "DROP TABLE IF EXISTS "):
+		rest = stmt[len("DROP TABLE IF EXISTS "):]
+	case strings.HasPrefix(upper, "CREATE TABLE "):
+		rest = stmt[len("CREATE TABLE "):]
+	case strings.HasPrefix(upper, "INSERT INTO "):
+		rest = stmt[len("INSERT INTO "):]
+		isInsert = true
+	default:
+		return "", false, fmt.Errorf("不允许的语句: %.40s", stmt)
+	}
+
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(rest, "`") {
+		end := strings.Index(rest[1:], "`")
+		if end < 0 {
+			return "", false, errors.New("表名格式错误")
+		}
+		table = rest[1 : end+1]
+	} else {
+		fields := strings.FieldsFunc(rest, func(r rune) bool { return r == ' ' || r == '(' || r == '\n' })
+		if len(fields) == 0 {
+			return "", false, errors.New("缺少表名")
+		}
+		table = fields[0]
+	}
+
+	if !strings.HasPrefix(table, db.Prefix) {
+		return "", false, fmt.Errorf("表 %s 不属于当前前缀 %s", table, db.Prefix)
+	}
+	if isRestoreTable(table) {
+		return "", false, fmt.Errorf("表 %s 是恢复时使用的临时表", table)
+	}
+
+	return table, isInsert, nil
+}
+
+// statementReader 按分号拆分SQL语句，忽略引号内的分号和注释
+type statementReader struct {
+	r *bufio.Reader
+}
+
+func newStatementReader(r *bufio.Reader) *statementReader {
+	return &statementReader{r: r}
+}
+
+// Next 返回下一条语句（不含结尾分号），没有更多语句时返回io.EOF
+func (s *statementReader) Next() (string, error) {
+	var buf bytes.Buffer
+	var quote byte
+
+	for {
+		c, err := s.r.ReadByte()
+		if err == io.EOF {
+			if quote != 0 {
+				return "", errors.New("引号未闭合")
+			}
+			stmt := strings.TrimSpace(buf.String())
+			if stmt == "" {
+				return "", io.EOF
+			}
+			return stmt, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if quote != 0 {
+			buf.WriteByte(c)
+			if c == '\\' && quote != '`' {
+				next, err := s.r.ReadByte()
+				if err != nil {
+					return "", errors.New("转义序列不完整")
+				}
+				buf.WriteByte(next)
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+			buf.WriteByte(c)
+		case ';':
+			stmt := strings.TrimSpace(buf.String())
+			if stmt != "" {
+				return stmt, nil
+			}
+			buf.Reset()
+		case '-':
+			// MySQL的行注释要求 -- 后跟空白字符
+			next, _ := s.r.Peek(2)
+			if len(next) == 2 && next[0] == '-' && (next[1] == ' ' || next[1] == '\t' || next[1] == '\n' || next[1] == '\r') {
+				if _, err := s.r.ReadString('\n'); err != nil && err != io.EOF {
+					return "", err
+				}
+				continue
+			}
+			buf.WriteByte(c)
+		case '/':
+			next, _ := s.r.Peek(1)
+			if len(next) == 1 && next[0] == '*' {
+				s.r.ReadByte()
+				if err := s.skipBlockComment(); err != nil {
+					return "", err
+				}
+				continue
+			}
+			buf.WriteByte(c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
+
+// skipBlockComment 跳过 /* ... */ 注释
+func (s *statementReader) skipBlockComment() error {
+	var prev byte
+	for {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			return errors.New("注释未闭合")
+		}
+		if prev == '*' && c == '/' {
+			return nil
+		}
+		prev = c
+	}
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 单条语句的最大参数个数，取各数据库中最小的SQLite限制
+const maxBatchParams = 999
+
+// Incr 在Upsert的更新字段中表示在原值上累加
+type Incr int64
+
+// InsertBatch 批量插入记录，字段以第一条记录为准，返回插入的行数；参数过多时分多条语句在同一事务中执行
+func (qb *QueryBuilder) InsertBatch(rows []map[string]interface{}) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	columns := sortedKeys(rows[0])
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("批量插入的记录没有字段")
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return 0, fmt.Errorf("第%d条记录的字段与第一条不一致", i+1)
+		}
+	}
+
+	size := maxBatchParams / len(columns)
+	if size < 1 {
+		size = 1
+	}
+	if len(rows) <= size {
+		return qb.insertRows(qb.db, columns, rows)
+	}
+
+	var total int64
+	err := qb.db.WithTx(func(tx *Tx) error {
+		for start := 0; start < len(rows); start += size {
+			end := start + size
+			if end > len(rows) {
+				end = len(rows)
+			}
+			n, err := qb.insertRows(tx.DB(), columns, rows[start:end])
+			if err != nil {
+				return err
+			}
+			total += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// insertRows 用一条INSERT语句插入多行
+func (qb *QueryBuilder) insertRows(db *DB, columns []string, rows []map[string]interface{}) (int64, error) {
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	placeholders := make([]string, 0, len(rows))
+	values := make([]interface{}, 0, len(rows)*len(columns))
+
+	for i, row := range rows {
+		for _, column := range columns {
+			value, ok := row[column]
+			if !ok {
+				return 0, fmt.Errorf("第%d条记录缺少字段 %s", i+1, column)
+			}
+			values = append(values, value)
+		}
+		placeholders = append(placeholders, placeholder)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		qb.tableName(),
+		strings.Join(qb.quoteAll(columns), ", "),
+		strings.Join(placeholders, ", "))
+
+	result, err := db.Execute(query, values...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Upsert 插入记录，keys对应的唯一键冲突时改为更新update中的字段
+// update中的值为Incr时在原值上累加；update为nil时用待插入的值覆盖keys以外的字段；update为空map时忽略冲突
+func (qb *QueryBuilder) Upsert(data map[string]interface{}, keys []string, update map[string]interface{}) (int64, error) {
+	if len(data) == 0 {
+		return 0, fmt.Errorf("插入的记录没有字段")
+	}
+	if len(keys) == 0 {
+		return 0, fmt.Errorf("Upsert需要指定唯一键字段")
+	}
+
+	dialect := qb.db.dialect()
+	tableName := qb.tableName()
+
+	columns := sortedKeys(data)
+	placeholders := make([]string, len(columns))
+	values := make([]interface{}, 0, len(columns)+len(update))
+	for i, column := range columns {
+		placeholders[i] = "?"
+		values = append(values, data[column])
+	}
+
+	var sets []string
+	if update == nil {
+		isKey := make(map[string]bool, len(keys))
+		for _, key := range keys {
+			isKey[key] = true
+		}
+		for _, column := range columns {
+			if !isKey[column] {
+				sets = append(sets, dialect.Quote(column)+" = "+dialect.Excluded(column))
+			}
+		}
+	} else {
+		for _, column := range sortedKeys(update) {
+			quoted := dialect.Quote(column)
+			if incr, ok := update[column].(Incr); ok {
+				// PostgreSQL中需要用表名限定原值
+				sets = append(sets, fmt.Sprintf("%s = %s.%s + ?", quoted, tableName, quoted))
+				values = append(values, int64(incr))
+				continue
+			}
+			sets = append(sets, quoted+" = ?")
+			values = append(values, update[column])
+		}
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) %s",
+		tableName,
+		strings.Join(qb.quoteAll(columns), ", "),
+		strings.Join(placeholders, ", "),
+		dialect.Upsert(keys, sets))
+
+	result, err := qb.db.Execute(query, values...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// InsertBatchContext 在ctx下批量插入记录
+func (qb *QueryBuilder) InsertBatchContext(ctx context.Context, rows []map[string]interface{}) (int64, error) {
+	return qb.WithContext(ctx).InsertBatch(rows)
+}
+
+// UpsertContext 在ctx下插入或更新记录
+func (qb *QueryBuilder) UpsertContext(ctx context.Context, data map[string]interface{}, keys []string, update map[string]interface{}) (int64, error) {
+	return qb.WithContext(ctx).Upsert(data, keys, update)
+}
+
+// tableName 获取写入的表名
+func (qb *QueryBuilder) tableName() string {
+	if qb.from != "" {
+		return qb.from
+	}
+	return qb.db.TableName(qb.table)
+}
+
+// quoteAll 引用字段名，防止保留字冲突
+func (qb *QueryBuilder) quoteAll(columns []string) []string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = qb.db.dialect().Quote(column)
+	}
+	return quoted
+}
+
+// sortedKeys 按字段名排序，保证生成的SQL稳定
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
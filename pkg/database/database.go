@@ -1,36 +1,43 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"aq3cms/config"
 	"aq3cms/pkg/logger"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 // DB 数据库连接实例
 type DB struct {
 	*sql.DB
-	Prefix string
+	Prefix       string
+	Dialect      Dialect
+	QueryTimeout time.Duration // 默认查询超时，0表示不限制
+
+	ctx       context.Context
+	tx        *Tx // 非空时所有语句在该事务中执行
+	tracer    *queryTracer
+	replicas  *replicaSet
+	idColumns *sync.Map // 表名 => 是否有id字段
 }
 
 // NewConnection 创建新的数据库连接
 func NewConnection(cfg config.DatabaseConfig) (*DB, error) {
-	var dsn string
-
-	switch cfg.Type {
-	case "mysql":
-		dsn = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=true&loc=Local",
-			cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Database, cfg.Charset)
-	default:
-		return nil, fmt.Errorf("不支持的数据库类型: %s", cfg.Type)
+	dialect, err := NewDialect(cfg.Type)
+	if err != nil {
+		return nil, err
 	}
 
-	db, err := sql.Open(cfg.Type, dsn)
+	db, err := sql.Open(dialect.DriverName(), dialect.DSN(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -46,14 +53,54 @@ func NewConnection(cfg config.DatabaseConfig) (*DB, error) {
 		return nil, err
 	}
 
-	logger.Info("数据库连接成功", "type", cfg.Type, "host", cfg.Host)
+	logger.Info("数据库连接成功", "type", dialect.Name(), "host", cfg.Host)
+
+	// 连接只读副本
+	var replicas *replicaSet
+	if len(cfg.Replicas) > 0 {
+		interval := time.Duration(cfg.ReplicaCheckInterval) * time.Second
+		replicas, err = openReplicas(dialect.DriverName(), cfg.Replicas, cfg.MaxIdle, cfg.MaxOpen, interval)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		logger.Info("数据库副本已配置", "count", len(cfg.Replicas))
+	}
 
 	return &DB{
-		DB:     db,
-		Prefix: cfg.Prefix,
+		DB:           db,
+		Prefix:       cfg.Prefix,
+		Dialect:      dialect,
+		QueryTimeout: time.Duration(cfg.QueryTimeout) * time.Second,
+		tracer:       newQueryTracer(time.Duration(cfg.SlowQueryThreshold)*time.Millisecond, cfg.SlowQueryTop),
+		replicas:     replicas,
+		idColumns:    &sync.Map{},
 	}, nil
 }
 
+// WithContext 返回绑定ctx的数据库实例，通过它执行的查询会在ctx取消时中断
+func (db *DB) WithContext(ctx context.Context) *DB {
+	c := *db
+	c.ctx = ctx
+	return &c
+}
+
+// Context 获取绑定的上下文，未绑定时为context.Background()
+func (db *DB) Context() context.Context {
+	if db.ctx == nil {
+		return context.Background()
+	}
+	return db.ctx
+}
+
+// withTimeout 为查询附加默认超时
+func (db *DB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, db.QueryTimeout)
+}
+
 // TableName 获取带前缀的表名
 func (db *DB) TableName(name string) string {
 	return db.Prefix + name
@@ -61,17 +108,27 @@ func (db *DB) TableName(name string) string {
 
 // Query 执行查询并返回结果
 func (db *DB) Query(query string, args ...interface{}) ([]map[string]interface{}, error) {
-	// 替换查询中的表前缀占位符 #@__
-	query = replacePrefix(query, db.Prefix)
+	return db.QueryContext(db.Context(), query, args...)
+}
 
-	rows, err := db.DB.Query(query, args...)
+// QueryContext 在ctx下执行查询并返回结果，配置了只读副本时SELECT语句发往副本
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	query = db.Rewrite(query)
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	done := db.trace(query)
+	rows, err := db.queryRows(ctx, query, args...)
 	if err != nil {
+		done(0, err)
 		return nil, err
 	}
 	defer rows.Close()
 
 	columns, err := rows.Columns()
 	if err != nil {
+		done(0, err)
 		return nil, err
 	}
 
@@ -101,12 +158,19 @@ func (db *DB) Query(query string, args ...interface{}) ([]map[string]interface{}
 		tableData = append(tableData, entry)
 	}
 
-	return tableData, nil
+	err = rows.Err()
+	done(int64(len(tableData)), err)
+	return tableData, err
 }
 
 // GetOne 获取单条记录
 func (db *DB) GetOne(query string, args ...interface{}) (map[string]interface{}, error) {
-	results, err := db.Query(query, args...)
+	return db.GetOneContext(db.Context(), query, args...)
+}
+
+// GetOneContext 在ctx下获取单条记录
+func (db *DB) GetOneContext(ctx context.Context, query string, args ...interface{}) (map[string]interface{}, error) {
+	results, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -120,37 +184,218 @@ func (db *DB) GetOne(query string, args ...interface{}) (map[string]interface{},
 
 // Execute 执行非查询SQL
 func (db *DB) Execute(query string, args ...interface{}) (sql.Result, error) {
-	query = replacePrefix(query, db.Prefix)
-	return db.DB.Exec(query, args...)
+	return db.ExecContext(db.Context(), query, args...)
 }
 
-// 替换SQL中的表前缀占位符
-func replacePrefix(query, prefix string) string {
-	// 替换 #@__ 为实际的表前缀
-	return strings.Replace(query, "#@__", prefix, -1)
+// ExecuteContext 在ctx下执行非查询SQL
+func (db *DB) ExecuteContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.ExecContext(ctx, query, args...)
+}
+
+// Exec 执行非查询SQL，会替换表前缀并改写为当前方言
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.ExecContext(db.Context(), query, args...)
+}
+
+// ExecContext 在ctx下执行非查询SQL，会替换表前缀并改写为当前方言
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if db.tx != nil {
+		return db.exec(ctx, db.tx.Tx.ExecContext, db.tx.Tx.QueryContext, query, args...)
+	}
+	return db.exec(ctx, db.DB.ExecContext, db.DB.QueryContext, query, args...)
+}
+
+// QueryRow 查询单行，会替换表前缀并改写为当前方言，总是在主库执行
+func (db *DB) QueryRow(query string, args ...interface{}) *Row {
+	return db.QueryRowContext(db.Context(), query, args...)
+}
+
+// QueryRowContext 在ctx下查询单行，会替换表前缀并改写为当前方言
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	query = db.Rewrite(query)
+	ctx, cancel := db.withTimeout(ctx)
+	done := db.trace(query)
+	if db.tx != nil {
+		return &Row{Row: db.tx.Tx.QueryRowContext(ctx, query, args...), cancel: cancel, done: done}
+	}
+	return &Row{Row: db.DB.QueryRowContext(ctx, query, args...), cancel: cancel, done: done}
+}
+
+// Row 单行查询结果，Scan后释放查询超时
+type Row struct {
+	*sql.Row
+	cancel context.CancelFunc
+	done   func(rows int64, err error)
+}
+
+// Scan 读取查询结果
+func (r *Row) Scan(dest ...interface{}) error {
+	defer r.cancel()
+	err := r.Row.Scan(dest...)
+	if err == nil {
+		r.done(1, nil)
+	} else if err == sql.ErrNoRows {
+		r.done(0, nil)
+	} else {
+		r.done(0, err)
+	}
+	return err
+}
+
+type (
+	execFunc  func(context.Context, string, ...interface{}) (sql.Result, error)
+	queryFunc func(context.Context, string, ...interface{}) (*sql.Rows, error)
+)
+
+// exec 执行SQL，不支持LastInsertId的数据库对INSERT语句追加RETURNING子句获取自增ID
+func (db *DB) exec(ctx context.Context, exec execFunc, query queryFunc, q string, args ...interface{}) (sql.Result, error) {
+	q = db.Rewrite(q)
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	// 请求中的后续读取走主库
+	markWrite(ctx)
+
+	done := db.trace(q)
+	result, err := db.execReturning(ctx, exec, query, q, args...)
+	if err != nil {
+		done(0, err)
+		return nil, err
+	}
+	affected, _ := result.RowsAffected()
+	done(affected, nil)
+	return result, nil
 }
 
-// Backup 备份数据库
-func (db *DB) Backup() (string, error) {
-	// 生成备份文件名
-	backupFile := fmt.Sprintf("backup_%s.sql", time.Now().Format("20060102150405"))
+// execReturning 执行SQL，需要时通过RETURNING子句获取自增ID
+func (db *DB) execReturning(ctx context.Context, exec execFunc, query queryFunc, q string, args ...interface{}) (sql.Result, error) {
+	returning := db.dialect().Returning("id")
+	if returning == "" {
+		return exec(ctx, q, args...)
+	}
+	if table := insertTable(q); table == "" || !db.hasIDColumn(table) {
+		return exec(ctx, q, args...)
+	}
+
+	rows, err := query(ctx, q+returning, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &insertResult{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		if result.affected == 0 {
+			result.id = id
+		}
+		result.affected++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// hasIDColumn 检查表是否有id字段，结果会被缓存
+func (db *DB) hasIDColumn(table string) bool {
+	if v, ok := db.idColumns.Load(table); ok {
+		return v.(bool)
+	}
 
-	// 获取所有表
-	_, err := db.Query("SHOW TABLES")
+	var count int
+	err := db.DB.QueryRow(db.Rewrite("SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = 'id'"), table).Scan(&count)
 	if err != nil {
-		return "", err
+		logger.Error("查询表字段失败", "table", table, "error", err)
+		return false
 	}
 
-	// 简化实现，实际应该使用 mysqldump 或类似工具
-	logger.Info("数据库备份", "file", backupFile)
+	db.idColumns.Store(table, count > 0)
+	return count > 0
+}
 
-	return backupFile, nil
+// insertTable 返回INSERT语句的目标表名，其他语句返回空串
+func insertTable(query string) string {
+	m := insertTableRe.FindStringSubmatch(query)
+	if m == nil || returningRe.MatchString(query) {
+		return ""
+	}
+	return m[1]
 }
 
-// Restore 恢复数据库
-func (db *DB) Restore(file interface{}) error {
-	// 简化实现，实际应该解析 SQL 文件并执行
-	logger.Info("数据库恢复")
+var (
+	insertTableRe = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+"?([\w.]+)"?`)
+	returningRe   = regexp.MustCompile(`(?i)\sRETURNING\s`)
+)
+
+// insertResult 通过RETURNING获取的插入结果
+type insertResult struct {
+	id       int64
+	affected int64
+}
+
+func (r *insertResult) LastInsertId() (int64, error) { return r.id, nil }
+func (r *insertResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+// Rewrite 替换表前缀占位符并改写为当前方言的SQL
+func (db *DB) Rewrite(query string) string {
+	query = replacePrefix(query, db.Prefix)
+	return db.dialect().Rewrite(query)
+}
 
-	return nil
+// TableExists 检查表是否存在，name为不含前缀的表名
+func (db *DB) TableExists(name string) (bool, error) {
+	var count int
+	err := db.QueryRowContext(db.Context(), db.dialect().TableExistsSQL(), db.TableName(name)).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// TableColumns 获取表的字段名，name为不含前缀的表名
+func (db *DB) TableColumns(name string) ([]string, error) {
+	return db.queryNames(db.dialect().ColumnsSQL(), db.TableName(name))
+}
+
+// TableIndexes 获取表的索引名，name为不含前缀的表名
+func (db *DB) TableIndexes(name string) ([]string, error) {
+	return db.queryNames(db.dialect().IndexesSQL(), db.TableName(name))
+}
+
+// queryNames 执行返回单列名称的元数据查询
+func (db *DB) queryNames(query string, args ...interface{}) ([]string, error) {
+	rows, err := db.DB.QueryContext(db.Context(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// dialect 获取方言，未设置时默认为MySQL
+func (db *DB) dialect() Dialect {
+	if db.Dialect == nil {
+		return mysqlDialect{}
+	}
+	return db.Dialect
+}
+
+// 替换SQL中的表前缀占位符
+func replacePrefix(query, prefix string) string {
+	// 替换 #@__ 为实际的表前缀
+	return strings.Replace(query, "#@__", prefix, -1)
 }
@@ -0,0 +1,350 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"aq3cms/config"
+)
+
+// Dialect SQL方言
+// 业务代码统一按MySQL风格书写SQL，由方言负责改写为目标数据库可执行的语句
+type Dialect interface {
+	// Name 方言名称，同时作为迁移脚本目录名
+	Name() string
+	// DriverName database/sql驱动名
+	DriverName() string
+	// DSN 根据配置生成连接串
+	DSN(cfg config.DatabaseConfig) string
+	// Quote 引用标识符
+	Quote(ident string) string
+	// Limit 生成分页子句，offset为0时省略，limit小于0表示不限制条数
+	Limit(limit, offset int) string
+	// Now 当前时间表达式
+	Now() string
+	// DateTimeType 日期时间字段类型
+	DateTimeType() string
+	// TableExistsSQL 检查表是否存在的查询，参数为完整表名
+	TableExistsSQL() string
+	// ColumnsSQL 查询表的字段名，参数为完整表名，结果列为name
+	ColumnsSQL() string
+	// IndexesSQL 查询表的索引名，参数为完整表名，结果列为name
+	IndexesSQL() string
+	// LockSQL 获取命名锁的查询，参数为锁名，结果为1表示成功；不需要加锁时返回空串
+	LockSQL() string
+	// UnlockSQL 释放命名锁的语句，参数为锁名
+	UnlockSQL() string
+	// Returning INSERT语句返回自增主键的子句，支持LastInsertId的数据库返回空串
+	Returning(column string) string
+	// Upsert 生成唯一键冲突时的更新子句，sets为已拼好的赋值表达式，为空时忽略冲突
+	Upsert(keys []string, sets []string) string
+	// Excluded 引用冲突时待插入行的字段值
+	Excluded(column string) string
+	// Rewrite 改写MySQL风格的SQL
+	Rewrite(query string) string
+}
+
+// NewDialect 根据数据库类型获取方言
+func NewDialect(dbType string) (Dialect, error) {
+	switch dbType {
+	case "mysql", "":
+		return mysqlDialect{}, nil
+	case "sqlite", "sqlite3":
+		return sqliteDialect{}, nil
+	case "postgres", "postgresql":
+		return postgresDialect{}, nil
+	default:
+		return nil, fmt.Errorf("不支持的数据库类型: %s", dbType)
+	}
+}
+
+// mysqlDialect MySQL方言
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string       { return "mysql" }
+func (mysqlDialect) DriverName() string { return "mysql" }
+
+func (mysqlDialect) DSN(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=true&loc=Local",
+		cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Database, cfg.Charset)
+}
+
+func (mysqlDialect) Quote(ident string) string {
+	return "`" + strings.Replace(ident, "`", "``", -1) + "`"
+}
+
+func (mysqlDialect) Limit(limit, offset int) string {
+	if limit < 0 {
+		// MySQL不支持单独的OFFSET，使用最大值表示不限制
+		return fmt.Sprintf("LIMIT 18446744073709551615 OFFSET %d", offset)
+	}
+	if offset > 0 {
+		return fmt.Sprintf("LIMIT %d, %d", offset, limit)
+	}
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+func (mysqlDialect) Now() string          { return "NOW()" }
+func (mysqlDialect) DateTimeType() string { return "DATETIME" }
+
+func (mysqlDialect) TableExistsSQL() string {
+	return "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+}
+
+func (mysqlDialect) ColumnsSQL() string {
+	return "SELECT column_name AS name FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position"
+}
+
+func (mysqlDialect) IndexesSQL() string {
+	return "SELECT DISTINCT index_name AS name FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ?"
+}
+
+func (mysqlDialect) LockSQL() string   { return "SELECT GET_LOCK(?, 600)" }
+func (mysqlDialect) UnlockSQL() string { return "SELECT RELEASE_LOCK(?)" }
+
+func (mysqlDialect) Returning(column string) string { return "" }
+func (mysqlDialect) Rewrite(query string) string    { return query }
+
+func (d mysqlDialect) Upsert(keys []string, sets []string) string {
+	if len(sets) == 0 {
+		// 没有需要更新的字段时原样赋值唯一键，等同于忽略冲突
+		sets = []string{d.Quote(keys[0]) + " = " + d.Quote(keys[0])}
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+
+func (d mysqlDialect) Excluded(column string) string {
+	return "VALUES(" + d.Quote(column) + ")"
+}
+
+// sqliteDialect SQLite方言
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string       { return "sqlite" }
+func (sqliteDialect) DriverName() string { return "sqlite3" }
+
+// DSN 使用Database作为数据库文件路径
+func (sqliteDialect) DSN(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=1&_loc=auto", cfg.Database)
+}
+
+func (sqliteDialect) Quote(ident string) string {
+	return `"` + strings.Replace(ident, `"`, `""`, -1) + `"`
+}
+
+func (sqliteDialect) Limit(limit, offset int) string {
+	if offset > 0 {
+		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+	}
+	if limit < 0 {
+		return "LIMIT -1"
+	}
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+func (sqliteDialect) Now() string          { return "datetime('now', 'localtime')" }
+func (sqliteDialect) DateTimeType() string { return "DATETIME" }
+
+func (sqliteDialect) TableExistsSQL() string {
+	return "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+}
+
+func (sqliteDialect) ColumnsSQL() string {
+	return "SELECT name FROM pragma_table_info(?) ORDER BY cid"
+}
+
+func (sqliteDialect) IndexesSQL() string {
+	return "SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ?"
+}
+
+// LockSQL SQLite同一时间只允许一个写事务，不需要额外加锁
+func (sqliteDialect) LockSQL() string   { return "" }
+func (sqliteDialect) UnlockSQL() string { return "" }
+
+func (sqliteDialect) Returning(column string) string { return "" }
+
+func (d sqliteDialect) Upsert(keys []string, sets []string) string {
+	return onConflict(d, keys, sets)
+}
+
+func (d sqliteDialect) Excluded(column string) string {
+	return "excluded." + d.Quote(column)
+}
+
+// Rewrite SQLite兼容反引号标识符，只需替换MySQL专有函数
+func (d sqliteDialect) Rewrite(query string) string {
+	if strings.Contains(query, "NOW()") {
+		query = strings.Replace(query, "NOW()", d.Now(), -1)
+	}
+	return query
+}
+
+// postgresDialect PostgreSQL方言
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string       { return "postgres" }
+func (postgresDialect) DriverName() string { return "postgres" }
+
+func (postgresDialect) DSN(cfg config.DatabaseConfig) string {
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		pgDSNValue(cfg.Host), cfg.Port, pgDSNValue(cfg.Username), pgDSNValue(cfg.Password), pgDSNValue(cfg.Database), pgDSNValue(sslMode))
+}
+
+// pgDSNValue 引用连接串中的值，值中可以包含空格、引号和反斜杠
+func pgDSNValue(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value) + "'"
+}
+
+func (postgresDialect) Quote(ident string) string {
+	return `"` + strings.Replace(ident, `"`, `""`, -1) + `"`
+}
+
+func (postgresDialect) Limit(limit, offset int) string {
+	if limit < 0 {
+		return fmt.Sprintf("LIMIT ALL OFFSET %d", offset)
+	}
+	if offset > 0 {
+		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+	}
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+func (postgresDialect) Now() string          { return "NOW()" }
+func (postgresDialect) DateTimeType() string { return "TIMESTAMP" }
+
+func (postgresDialect) TableExistsSQL() string {
+	return "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+}
+
+func (postgresDialect) ColumnsSQL() string {
+	return "SELECT column_name AS name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position"
+}
+
+func (postgresDialect) IndexesSQL() string {
+	return "SELECT indexname AS name FROM pg_indexes WHERE schemaname = current_schema() AND tablename = $1"
+}
+
+func (postgresDialect) LockSQL() string {
+	return "SELECT 1 FROM pg_advisory_lock(hashtext($1))"
+}
+
+func (postgresDialect) UnlockSQL() string {
+	return "SELECT pg_advisory_unlock(hashtext($1))"
+}
+
+func (d postgresDialect) Returning(column string) string {
+	return " RETURNING " + d.Quote(column)
+}
+
+func (d postgresDialect) Upsert(keys []string, sets []string) string {
+	return onConflict(d, keys, sets)
+}
+
+func (d postgresDialect) Excluded(column string) string {
+	return "excluded." + d.Quote(column)
+}
+
+// Rewrite 将 ? 占位符改写为 $n，反引号标识符改写为双引号，MySQL字符串中的反斜杠转义改写为标准写法，
+// 字符串常量和注释内的内容保持不变
+func (postgresDialect) Rewrite(query string) string {
+	if !strings.ContainsAny(query, "?`\\") {
+		return query
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(query) + 16)
+
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if quote != 0 {
+			switch {
+			case c == '\\' && quote == '\'' && i+1 < len(query):
+				i++
+				buf.WriteString(mysqlUnescape(query[i]))
+				continue
+			case c == quote:
+				quote = 0
+				if c == '`' {
+					c = '"'
+				}
+			}
+			buf.WriteByte(c)
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"':
+			quote = c
+			buf.WriteByte(c)
+		case c == '`':
+			quote = c
+			buf.WriteByte('"')
+		case c == '?':
+			n++
+			buf.WriteString("$" + strconv.Itoa(n))
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			// 单行注释原样保留
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			buf.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			// 多行注释原样保留
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			buf.WriteString(query[i : i+end])
+			i += end - 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+// mysqlUnescape MySQL字符串中反斜杠转义的字符在PostgreSQL标准字符串中的写法；
+// \%和\_是LIKE的转义，PostgreSQL的LIKE同样使用反斜杠，保留原样；PostgreSQL的文本中不能有\0，直接去掉
+func mysqlUnescape(c byte) string {
+	switch c {
+	case '\'':
+		return "''"
+	case '0':
+		return ""
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case 'Z':
+		return "\x1a"
+	case '%', '_':
+		return "\\" + string(c)
+	}
+	return string(c)
+}
+
+// onConflict 生成SQLite和PostgreSQL的ON CONFLICT子句
+func onConflict(d Dialect, keys []string, sets []string) string {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = d.Quote(key)
+	}
+	clause := "ON CONFLICT (" + strings.Join(quoted, ", ") + ")"
+	if len(sets) == 0 {
+		return clause + " DO NOTHING"
+	}
+	return clause + " DO UPDATE SET " + strings.Join(sets, ", ")
+}
@@ -0,0 +1,396 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aq3cms/pkg/logger"
+)
+
+// 迁移记录表名（不含前缀）
+const migrationTable = "migrations"
+
+// 迁移进度表名（不含前缀），记录执行中断的版本已完成的语句数
+const migrationProgressTable = "migration_progress"
+
+// alterAddRe 添加字段或索引的语句，重新执行时已存在的字段和索引跳过
+var alterAddRe = regexp.MustCompile("(?is)^ALTER\\s+TABLE\\s+[`\"]?#@__(\\w+)[`\"]?\\s+ADD\\s+(COLUMN|KEY|INDEX)\\s+[`\"]?(\\w+)")
+
+// 迁移文件名格式：0001_name.up.sql / 0001_name.down.sql
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration 单个版本的迁移脚本
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus 迁移状态
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator 数据库迁移器
+type Migrator struct {
+	db         *DB
+	migrations []*Migration
+}
+
+// NewMigrator 从文件系统加载迁移脚本并创建迁移器
+func NewMigrator(db *DB, source fs.FS) (*Migrator, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m := migrationFileRe.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+
+		version, _ := strconv.Atoi(m[1])
+		content, err := fs.ReadFile(source, path.Join(".", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		} else if migration.Name != m[2] {
+			return nil, fmt.Errorf("迁移版本 %d 存在多个名称: %s, %s", version, migration.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("迁移版本 %d 缺少up脚本", migration.Version)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations 返回已加载的迁移
+func (m *Migrator) Migrations() []*Migration {
+	return m.migrations
+}
+
+// ensureTable 创建迁移记录表
+func (m *Migrator) ensureTable() error {
+	d := m.db.dialect()
+	_, err := m.db.DB.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ("+
+		"%s INTEGER NOT NULL,"+
+		"%s VARCHAR(255) NOT NULL DEFAULT '',"+
+		"%s "+d.DateTimeType()+" NOT NULL,"+
+		"PRIMARY KEY (%s))",
+		d.Quote(m.db.TableName(migrationTable)), d.Quote("version"), d.Quote("name"), d.Quote("applied_at"), d.Quote("version")))
+	if err != nil {
+		return err
+	}
+	_, err = m.db.DB.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ("+
+		"%s INTEGER NOT NULL,"+
+		"%s INTEGER NOT NULL DEFAULT 0,"+
+		"PRIMARY KEY (%s))",
+		d.Quote(m.db.TableName(migrationProgressTable)), d.Quote("version"), d.Quote("step"), d.Quote("version")))
+	return err
+}
+
+// progress 获取执行中断的版本已完成的语句数
+func (m *Migrator) progress() (map[int]int, error) {
+	rows, err := m.db.DB.Query("SELECT version, step FROM " + m.db.TableName(migrationProgressTable))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	steps := make(map[int]int)
+	for rows.Next() {
+		var version, step int
+		if err := rows.Scan(&version, &step); err != nil {
+			return nil, err
+		}
+		steps[version] = step
+	}
+	return steps, rows.Err()
+}
+
+// lock 获取迁移锁，避免多个进程同时执行迁移，返回释放锁的函数
+func (m *Migrator) lock() (func(), error) {
+	d := m.db.dialect()
+	if d.LockSQL() == "" {
+		return func() {}, nil
+	}
+
+	// 命名锁属于连接，加锁和解锁使用同一个连接
+	ctx := m.db.Context()
+	conn, err := m.db.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := m.db.TableName(migrationTable)
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, d.LockSQL(), name).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if locked.Int64 != 1 {
+		conn.Close()
+		return nil, fmt.Errorf("等待其他进程执行迁移超时")
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), d.UnlockSQL(), name); err != nil {
+			logger.Error("释放迁移锁失败", "error", err)
+		}
+		conn.Close()
+	}, nil
+}
+
+// applied 获取已执行的版本
+func (m *Migrator) applied() (map[int]time.Time, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.DB.Query("SELECT version, applied_at FROM " + m.db.TableName(migrationTable))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = appliedAt
+	}
+	return versions, rows.Err()
+}
+
+// Version 返回当前数据库的最高迁移版本，未执行过任何迁移时返回0
+func (m *Migrator) Version() (int, error) {
+	versions, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	current := 0
+	for version := range versions {
+		if version > current {
+			current = version
+		}
+	}
+	return current, nil
+}
+
+// Status 返回所有迁移的执行状态
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	versions, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := versions[migration.Version]
+		status = append(status, MigrationStatus{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+	return status, nil
+}
+
+// Up 执行所有未执行的迁移，返回执行的数量
+func (m *Migrator) Up() (int, error) {
+	return m.UpTo(0)
+}
+
+// UpTo 执行未执行的迁移直到指定版本（包含），version为0时执行全部
+// 每条语句执行后记录进度，中断后再次执行时从未完成的语句继续
+func (m *Migrator) UpTo(version int) (int, error) {
+	unlock, err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	versions, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	steps, err := m.progress()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if version > 0 && migration.Version > version {
+			break
+		}
+		if _, ok := versions[migration.Version]; ok {
+			continue
+		}
+
+		logger.Info("执行数据库迁移", "version", migration.Version, "name", migration.Name, "step", steps[migration.Version])
+		if err := m.exec(migration.Up, migration.Version, steps[migration.Version]); err != nil {
+			return count, fmt.Errorf("迁移 %04d_%s 执行失败: %w", migration.Version, migration.Name, err)
+		}
+
+		err := m.db.WithTx(func(tx *Tx) error {
+			_, err := tx.DB().Exec("INSERT INTO "+m.db.TableName(migrationTable)+" (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now())
+			if err != nil {
+				return err
+			}
+			_, err = tx.DB().Exec("DELETE FROM "+m.db.TableName(migrationProgressTable)+" WHERE version = ?", migration.Version)
+			return err
+		})
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Down 回滚最近执行的steps个迁移，返回回滚的数量
+func (m *Migrator) Down(steps int) (int, error) {
+	unlock, err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	versions, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := versions[migration.Version]; !ok {
+			continue
+		}
+
+		logger.Info("回滚数据库迁移", "version", migration.Version, "name", migration.Name)
+		if err := m.exec(migration.Down, 0, 0); err != nil {
+			return count, fmt.Errorf("迁移 %04d_%s 回滚失败: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err := m.db.Exec("DELETE FROM "+m.db.TableName(migrationTable)+" WHERE version = ?", migration.Version)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// exec 逐条执行脚本中的语句，跳过前skip条已完成的语句
+// MySQL的DDL会隐式提交事务，因此version大于0时每条语句执行后记录进度；
+// 添加字段或索引的语句在字段或索引已存在时跳过，语句执行后、记录进度前中断也可以重新执行
+func (m *Migrator) exec(script string, version, skip int) error {
+	reader := newStatementReader(bufio.NewReader(strings.NewReader(script)))
+	for step := 1; ; step++ {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if step <= skip {
+			continue
+		}
+
+		done, err := m.alreadyAdded(stmt)
+		if err != nil {
+			return err
+		}
+		if !done {
+			if _, err := m.db.Exec(stmt); err != nil {
+				return fmt.Errorf("第 %d 条语句: %w", step, err)
+			}
+		}
+
+		if version > 0 {
+			_, err := NewQueryBuilder(m.db, migrationProgressTable).Upsert(map[string]interface{}{
+				"version": version,
+				"step":    step,
+			}, []string{"version"}, map[string]interface{}{
+				"step": step,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// alreadyAdded 语句要添加的字段或索引是否已存在
+func (m *Migrator) alreadyAdded(stmt string) (bool, error) {
+	match := alterAddRe.FindStringSubmatch(stmt)
+	if match == nil {
+		return false, nil
+	}
+
+	var names []string
+	var err error
+	if strings.EqualFold(match[2], "COLUMN") {
+		names, err = m.db.TableColumns(match[1])
+	} else {
+		names, err = m.db.TableIndexes(match[1])
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, match[3]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
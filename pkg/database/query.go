@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -14,7 +15,9 @@ type QueryBuilder struct {
 	where      []string
 	orderBy    string
 	groupBy    string
-	limit      string
+	limit      int
+	offset     int
+	hasLimit   bool
 	join       []string
 	args       []interface{}
 	whereCount int
@@ -29,6 +32,12 @@ func NewQueryBuilder(db *DB, table string) *QueryBuilder {
 	}
 }
 
+// WithContext 绑定ctx，之后执行的查询会在ctx取消时中断
+func (qb *QueryBuilder) WithContext(ctx context.Context) *QueryBuilder {
+	qb.db = qb.db.WithContext(ctx)
+	return qb
+}
+
 // Select 设置查询字段
 func (qb *QueryBuilder) Select(fields ...string) *QueryBuilder {
 	qb.fields = fields
@@ -79,32 +88,17 @@ func (qb *QueryBuilder) GroupBy(groupBy string) *QueryBuilder {
 
 // Limit 设置限制
 func (qb *QueryBuilder) Limit(limit int, offset ...int) *QueryBuilder {
+	qb.limit = limit
+	qb.hasLimit = true
 	if len(offset) > 0 {
-		qb.limit = fmt.Sprintf("LIMIT %d, %d", offset[0], limit)
-	} else {
-		qb.limit = fmt.Sprintf("LIMIT %d", limit)
+		qb.offset = offset[0]
 	}
 	return qb
 }
 
 // Offset 设置偏移量
 func (qb *QueryBuilder) Offset(offset int) *QueryBuilder {
-	if qb.limit == "" {
-		qb.limit = fmt.Sprintf("LIMIT 18446744073709551615 OFFSET %d", offset)
-	} else {
-		// 如果已经有LIMIT，则替换
-		parts := strings.Split(qb.limit, "LIMIT ")
-		if len(parts) > 1 {
-			limitParts := strings.Split(parts[1], ",")
-			if len(limitParts) > 1 {
-				// 已经有OFFSET
-				qb.limit = fmt.Sprintf("LIMIT %s OFFSET %d", limitParts[0], offset)
-			} else {
-				// 没有OFFSET
-				qb.limit = fmt.Sprintf("LIMIT %s OFFSET %d", parts[1], offset)
-			}
-		}
-	}
+	qb.offset = offset
 	return qb
 }
 
@@ -187,8 +181,8 @@ func (qb *QueryBuilder) Insert(data map[string]interface{}) (int64, error) {
 	var values []interface{}
 
 	for column, value := range data {
-		// 用反引号包围字段名，防止保留字冲突
-		columns = append(columns, "`"+column+"`")
+		// 引用字段名，防止保留字冲突
+		columns = append(columns, qb.db.dialect().Quote(column))
 		placeholders = append(placeholders, "?")
 		values = append(values, value)
 	}
@@ -219,8 +213,8 @@ func (qb *QueryBuilder) Update(data map[string]interface{}) (int64, error) {
 	var values []interface{}
 
 	for column, value := range data {
-		// 用反引号包围字段名，防止保留字冲突
-		sets = append(sets, "`"+column+"` = ?")
+		// 引用字段名，防止保留字冲突
+		sets = append(sets, qb.db.dialect().Quote(column)+" = ?")
 		values = append(values, value)
 	}
 
@@ -268,6 +262,36 @@ func (qb *QueryBuilder) Delete() (int64, error) {
 	return result.RowsAffected()
 }
 
+// GetContext 在ctx下执行查询并返回结果
+func (qb *QueryBuilder) GetContext(ctx context.Context) ([]map[string]interface{}, error) {
+	return qb.WithContext(ctx).Get()
+}
+
+// FirstContext 在ctx下获取第一条记录
+func (qb *QueryBuilder) FirstContext(ctx context.Context) (map[string]interface{}, error) {
+	return qb.WithContext(ctx).First()
+}
+
+// CountContext 在ctx下获取记录数
+func (qb *QueryBuilder) CountContext(ctx context.Context) (int, error) {
+	return qb.WithContext(ctx).Count()
+}
+
+// InsertContext 在ctx下插入记录
+func (qb *QueryBuilder) InsertContext(ctx context.Context, data map[string]interface{}) (int64, error) {
+	return qb.WithContext(ctx).Insert(data)
+}
+
+// UpdateContext 在ctx下更新记录
+func (qb *QueryBuilder) UpdateContext(ctx context.Context, data map[string]interface{}) (int64, error) {
+	return qb.WithContext(ctx).Update(data)
+}
+
+// DeleteContext 在ctx下删除记录
+func (qb *QueryBuilder) DeleteContext(ctx context.Context) (int64, error) {
+	return qb.WithContext(ctx).Delete()
+}
+
 // 构建查询SQL
 func (qb *QueryBuilder) buildQuery() string {
 	var query string
@@ -298,8 +322,10 @@ func (qb *QueryBuilder) buildQuery() string {
 		query += " " + qb.orderBy
 	}
 
-	if qb.limit != "" {
-		query += " " + qb.limit
+	if qb.hasLimit {
+		query += " " + qb.db.dialect().Limit(qb.limit, qb.offset)
+	} else if qb.offset > 0 {
+		query += " " + qb.db.dialect().Limit(-1, qb.offset)
 	}
 
 	return query
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"aq3cms/pkg/logger"
+)
+
+// 默认副本健康检查间隔
+const defaultReplicaCheckInterval = 10 * time.Second
+
+// 可以发往只读副本的语句
+var (
+	readQueryRe   = regexp.MustCompile(`(?is)^\s*(SELECT|WITH)\s`)
+	lockingReadRe = regexp.MustCompile(`(?i)\s(FOR\s+UPDATE|FOR\s+SHARE|LOCK\s+IN\s+SHARE\s+MODE)\b`)
+)
+
+// replica 只读副本
+type replica struct {
+	index   int
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// ping 检查副本连通性
+func (r *replica) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	return r.db.PingContext(ctx)
+}
+
+// ReplicaStatus 副本状态
+type ReplicaStatus struct {
+	Index   int  `json:"index"`
+	Healthy bool `json:"healthy"`
+}
+
+// replicaSet 只读副本集合，按轮询选择健康的副本
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint32
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// openReplicas 连接只读副本并启动健康检查
+func openReplicas(driver string, dsns []string, maxIdle, maxOpen int, interval time.Duration) (*replicaSet, error) {
+	rs := &replicaSet{stop: make(chan struct{})}
+	for i, dsn := range dsns {
+		db, err := sql.Open(driver, dsn)
+		if err != nil {
+			rs.close()
+			return nil, err
+		}
+		db.SetMaxIdleConns(maxIdle)
+		db.SetMaxOpenConns(maxOpen)
+		db.SetConnMaxLifetime(time.Hour)
+
+		r := &replica{index: i, db: db}
+		if err := r.ping(); err != nil {
+			logger.Error("数据库副本不可用", "index", i, "error", err)
+		} else {
+			r.healthy.Store(true)
+		}
+		rs.replicas = append(rs.replicas, r)
+	}
+
+	if interval <= 0 {
+		interval = defaultReplicaCheckInterval
+	}
+	go rs.run(interval)
+
+	return rs, nil
+}
+
+// pick 轮询选择一个健康的副本，没有可用副本时返回nil
+func (rs *replicaSet) pick() *replica {
+	n := len(rs.replicas)
+	start := int(rs.next.Add(1))
+	for i := 0; i < n; i++ {
+		r := rs.replicas[(start+i)%n]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// check 检查所有副本的连通性
+func (rs *replicaSet) check() {
+	for _, r := range rs.replicas {
+		err := r.ping()
+		healthy := err == nil
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				logger.Info("数据库副本恢复", "index", r.index)
+			} else {
+				logger.Error("数据库副本不可用", "index", r.index, "error", err)
+			}
+		}
+	}
+}
+
+// markDown 查询失败后确认副本是否仍然可用，不可用时返回true
+func (rs *replicaSet) markDown(r *replica, err error) bool {
+	if r.ping() == nil {
+		return false
+	}
+	if r.healthy.Swap(false) {
+		logger.Error("数据库副本不可用", "index", r.index, "error", err)
+	}
+	return true
+}
+
+// run 定时执行健康检查
+func (rs *replicaSet) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			rs.check()
+		case <-rs.stop:
+			return
+		}
+	}
+}
+
+// status 获取副本状态
+func (rs *replicaSet) status() []ReplicaStatus {
+	list := make([]ReplicaStatus, 0, len(rs.replicas))
+	for _, r := range rs.replicas {
+		list = append(list, ReplicaStatus{Index: r.index, Healthy: r.healthy.Load()})
+	}
+	return list
+}
+
+// close 停止健康检查并关闭副本连接
+func (rs *replicaSet) close() {
+	rs.once.Do(func() {
+		close(rs.stop)
+		for _, r := range rs.replicas {
+			r.db.Close()
+		}
+	})
+}
+
+// 请求会话，记录请求中是否执行过写操作
+type sessionKey struct{}
+
+type session struct {
+	wrote atomic.Bool
+}
+
+// WithSession 为请求创建会话，会话中执行写操作后，后续读取都走主库
+func WithSession(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(sessionKey{}).(*session); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+// UsePrimary 返回读取总是走主库的ctx
+func UsePrimary(ctx context.Context) context.Context {
+	s := &session{}
+	s.wrote.Store(true)
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// markWrite 标记会话执行过写操作
+func markWrite(ctx context.Context) {
+	if s, ok := ctx.Value(sessionKey{}).(*session); ok {
+		s.wrote.Store(true)
+	}
+}
+
+// readFromPrimary 判断会话是否需要读主库
+func readFromPrimary(ctx context.Context) bool {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	return ok && s.wrote.Load()
+}
+
+// reader 选择执行读查询的连接
+func (db *DB) reader(ctx context.Context, query string) (*sql.DB, *replica) {
+	if db.replicas == nil || len(db.replicas.replicas) == 0 || readFromPrimary(ctx) {
+		return db.DB, nil
+	}
+	if !readQueryRe.MatchString(query) || lockingReadRe.MatchString(query) {
+		return db.DB, nil
+	}
+	r := db.replicas.pick()
+	if r == nil {
+		return db.DB, nil
+	}
+	return r.db, r
+}
+
+// queryRows 执行读查询，优先使用只读副本，副本不可用时回退到主库
+func (db *DB) queryRows(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if db.tx != nil {
+		return db.tx.Tx.QueryContext(ctx, query, args...)
+	}
+
+	conn, r := db.reader(ctx, query)
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err == nil || r == nil || ctx.Err() != nil {
+		return rows, err
+	}
+
+	if !db.replicas.markDown(r, err) {
+		return nil, err
+	}
+	return db.DB.QueryContext(ctx, query, args...)
+}
+
+// ReplicaStatus 获取只读副本状态
+func (db *DB) ReplicaStatus() []ReplicaStatus {
+	if db.replicas == nil {
+		return nil
+	}
+	return db.replicas.status()
+}
+
+// Close 关闭主库和只读副本连接
+func (db *DB) Close() error {
+	if db.replicas != nil {
+		db.replicas.close()
+	}
+	return db.DB.Close()
+}
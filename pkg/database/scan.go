@@ -0,0 +1,474 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AfterScanner 扫描完成后调用，用于填充由其他字段派生的字段
+type AfterScanner interface {
+	AfterScan()
+}
+
+// 字符串形式的时间格式
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// 结构体字段映射缓存：类型 => 列名 => 字段索引
+var fieldCache sync.Map
+
+// ScanInto 将rows扫描为T类型的切片，T为结构体或结构体指针，字段通过db标签与列名对应
+func ScanInto[T any](rows *sql.Rows) ([]T, error) {
+	var out []T
+	if err := scanRows(rows, reflect.ValueOf(&out).Elem()); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueryInto 执行查询并扫描到dest，dest为结构体切片指针
+func (db *DB) QueryInto(dest interface{}, query string, args ...interface{}) error {
+	return db.QueryIntoContext(db.Context(), dest, query, args...)
+}
+
+// QueryIntoContext 在ctx下执行查询并扫描到dest
+func (db *DB) QueryIntoContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	slice, err := slicePtr(dest)
+	if err != nil {
+		return err
+	}
+
+	query = db.Rewrite(query)
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	done := db.trace(query)
+	rows, err := db.queryRows(ctx, query, args...)
+	if err != nil {
+		done(0, err)
+		return err
+	}
+	defer rows.Close()
+
+	before := slice.Len()
+	err = scanRows(rows, slice)
+	done(int64(slice.Len()-before), err)
+	return err
+}
+
+// GetOneInto 查询单条记录并扫描到dest，dest为结构体指针，没有记录时返回false
+func (db *DB) GetOneInto(dest interface{}, query string, args ...interface{}) (bool, error) {
+	return db.GetOneIntoContext(db.Context(), dest, query, args...)
+}
+
+// GetOneIntoContext 在ctx下查询单条记录并扫描到dest
+func (db *DB) GetOneIntoContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (bool, error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return false, fmt.Errorf("dest必须是结构体指针，实际为 %T", dest)
+	}
+
+	query = db.Rewrite(query)
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	done := db.trace(query)
+	found, err := db.scanOne(ctx, v.Elem(), query, args...)
+	if found {
+		done(1, err)
+	} else {
+		done(0, err)
+	}
+	return found, err
+}
+
+// scanOne 执行查询并将第一行扫描到dest
+func (db *DB) scanOne(ctx context.Context, dest reflect.Value, query string, args ...interface{}) (bool, error) {
+	rows, err := db.queryRows(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return false, err
+	}
+	if !rows.Next() {
+		return false, rows.Err()
+	}
+	if err := scanRow(rows, columns, dest); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetInto 执行查询并扫描到dest，dest为 *[]T 或 *[]*T
+func (qb *QueryBuilder) GetInto(dest interface{}) error {
+	return qb.db.QueryInto(dest, qb.buildQuery(), qb.args...)
+}
+
+// FirstInto 获取第一条记录并扫描到dest，没有记录时返回false
+func (qb *QueryBuilder) FirstInto(dest interface{}) (bool, error) {
+	qb.Limit(1)
+	return qb.db.GetOneInto(dest, qb.buildQuery(), qb.args...)
+}
+
+// GetIntoContext 在ctx下执行查询并扫描到dest
+func (qb *QueryBuilder) GetIntoContext(ctx context.Context, dest interface{}) error {
+	return qb.WithContext(ctx).GetInto(dest)
+}
+
+// FirstIntoContext 在ctx下获取第一条记录并扫描到dest
+func (qb *QueryBuilder) FirstIntoContext(ctx context.Context, dest interface{}) (bool, error) {
+	return qb.WithContext(ctx).FirstInto(dest)
+}
+
+// MapInto 将Query返回的一行扫描到dest，dest为结构体指针，用于列不固定的查询
+func MapInto(row map[string]interface{}, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dest必须是结构体指针，实际为 %T", dest)
+	}
+
+	fields := structFields(v.Elem().Type())
+	for column, value := range row {
+		index, ok := fields[strings.ToLower(column)]
+		if !ok {
+			continue
+		}
+		if err := assignValue(v.Elem().FieldByIndex(index), value); err != nil {
+			return fmt.Errorf("字段 %s: %v", column, err)
+		}
+	}
+
+	if s, ok := dest.(AfterScanner); ok {
+		s.AfterScan()
+	}
+	return nil
+}
+
+// ValueString 将Query返回的列值转换为字符串，NULL为空字符串
+func ValueString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return toString(value)
+}
+
+// slicePtr 校验dest为切片指针并返回切片
+func slicePtr(dest interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("dest必须是切片指针，实际为 %T", dest)
+	}
+	return v.Elem(), nil
+}
+
+// scanRows 将所有行追加到slice
+func scanRows(rows *sql.Rows, slice reflect.Value) error {
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("不支持扫描到 %s", elemType)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		item := reflect.New(structType)
+		if err := scanRow(rows, columns, item.Elem()); err != nil {
+			return err
+		}
+		if isPtr {
+			slice.Set(reflect.Append(slice, item))
+		} else {
+			slice.Set(reflect.Append(slice, item.Elem()))
+		}
+	}
+	return rows.Err()
+}
+
+// scanRow 将当前行扫描到结构体
+func scanRow(rows *sql.Rows, columns []string, dest reflect.Value) error {
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return err
+	}
+
+	fields := structFields(dest.Type())
+	for i, column := range columns {
+		index, ok := fields[strings.ToLower(column)]
+		if !ok {
+			continue
+		}
+		if err := assignValue(dest.FieldByIndex(index), values[i]); err != nil {
+			return fmt.Errorf("字段 %s: %v", column, err)
+		}
+	}
+
+	if s, ok := dest.Addr().Interface().(AfterScanner); ok {
+		s.AfterScan()
+	}
+	return nil
+}
+
+// structFields 获取结构体的列名到字段索引的映射，支持嵌入结构体
+func structFields(t reflect.Type) map[string][]int {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	fields := make(map[string][]int)
+	var walk func(t reflect.Type, prefix []int)
+	walk = func(t reflect.Type, prefix []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			index := append(append([]int{}, prefix...), i)
+
+			tag := f.Tag.Get("db")
+			if tag == "-" {
+				continue
+			}
+			if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct && f.Type != timeType {
+				walk(f.Type, index)
+				continue
+			}
+			if tag == "" || f.PkgPath != "" {
+				continue
+			}
+			name := strings.ToLower(strings.Split(tag, ",")[0])
+			if _, exists := fields[name]; !exists {
+				fields[name] = index
+			}
+		}
+	}
+	walk(t, nil)
+
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// assignValue 将数据库返回的值转换为字段类型并赋值
+func assignValue(field reflect.Value, value interface{}) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	if field.Addr().Type().Implements(scannerType) {
+		return field.Addr().Interface().(sql.Scanner).Scan(value)
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return assignValue(field.Elem(), value)
+	}
+
+	if b, ok := value.([]byte); ok {
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8 {
+			field.SetBytes(append([]byte(nil), b...))
+			return nil
+		}
+		value = string(b)
+	}
+
+	if field.Type() == timeType {
+		t, err := toTime(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(toString(value))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt(value)
+		if err != nil {
+			return err
+		}
+		field.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(value)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		n, err := toInt(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(n != 0)
+	default:
+		v := reflect.ValueOf(value)
+		if !v.Type().ConvertibleTo(field.Type()) {
+			return fmt.Errorf("无法将 %T 转换为 %s", value, field.Type())
+		}
+		field.Set(v.Convert(field.Type()))
+	}
+	return nil
+}
+
+// toString 转换为字符串
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04:05")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toInt 转换为整数，时间转换为Unix时间戳
+func toInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case time.Time:
+		if v.IsZero() {
+			return 0, nil
+		}
+		return v.Unix(), nil
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0, nil
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n, nil
+		}
+		// DECIMAL以字符串返回
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("无法将 %q 转换为整数", v)
+		}
+		return int64(f), nil
+	default:
+		return 0, fmt.Errorf("无法将 %T 转换为整数", value)
+	}
+}
+
+// toFloat 转换为浮点数
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("无法将 %q 转换为浮点数", v)
+		}
+		return f, nil
+	default:
+		n, err := toInt(value)
+		return float64(n), err
+	}
+}
+
+// toTime 转换为时间，整数按Unix时间戳处理
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" || strings.HasPrefix(s, "0000-00-00") {
+			return time.Time{}, nil
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Unix(n, 0), nil
+		}
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("无法将 %q 转换为时间", v)
+	default:
+		n, err := toInt(value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(n, 0), nil
+	}
+}
@@ -0,0 +1,241 @@
+package database
+
+import (
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"aq3cms/pkg/logger"
+)
+
+// 默认保留的最慢SQL条数
+const defaultSlowQueryTop = 20
+
+// 归一化SQL时替换的内容
+var (
+	stringLiteralRe = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'`)
+	numberLiteralRe = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	pgParamRe       = regexp.MustCompile(`\$\d+`)
+	inListRe        = regexp.MustCompile(`(?i)\bIN\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	valuesListRe    = regexp.MustCompile(`(?i)(VALUES\s*\([^()]*\))(?:\s*,\s*\([^()]*\))+`)
+	spaceRe         = regexp.MustCompile(`\s+`)
+)
+
+// QueryStat 归一化SQL的执行统计
+type QueryStat struct {
+	SQL       string        `json:"sql"`        // 归一化后的SQL
+	Count     int64         `json:"count"`      // 执行次数，列表已满后快于列表下限的执行不计入
+	TotalTime time.Duration `json:"total_time"` // 累计耗时
+	MaxTime   time.Duration `json:"max_time"`   // 最长耗时
+	Rows      int64         `json:"rows"`       // 最慢一次返回或影响的行数
+	Caller    string        `json:"caller"`     // 最慢一次的调用位置
+	LastSeen  time.Time     `json:"last_seen"`  // 最近执行时间
+}
+
+// AvgTime 平均耗时
+func (s QueryStat) AvgTime() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.Count)
+}
+
+// queryTracer 记录SQL耗时，超过阈值时写慢查询日志，并保留最慢的若干条归一化SQL
+type queryTracer struct {
+	threshold time.Duration
+	top       int
+
+	mu    sync.Mutex
+	stats map[string]*QueryStat
+	floor atomic.Int64 // 列表已满时最快一条的最长耗时，未满时为0
+}
+
+// newQueryTracer 创建SQL跟踪器，threshold为0时不写慢查询日志
+func newQueryTracer(threshold time.Duration, top int) *queryTracer {
+	if top <= 0 {
+		top = defaultSlowQueryTop
+	}
+	return &queryTracer{
+		threshold: threshold,
+		top:       top,
+		stats:     make(map[string]*QueryStat),
+	}
+}
+
+// record 记录一次SQL执行
+func (t *queryTracer) record(query string, start time.Time, rows int64, err error) {
+	if t == nil {
+		return
+	}
+
+	elapsed := time.Since(start)
+	slow := t.threshold > 0 && elapsed >= t.threshold
+
+	// 不会进入列表、也不需要写日志的SQL直接返回，省去归一化的开销
+	if !slow && elapsed < time.Duration(t.floor.Load()) && !logger.DebugEnabled() {
+		return
+	}
+
+	sql := normalizeSQL(query)
+
+	// 获取调用位置开销较大，只在需要时获取
+	var caller string
+	if slow || logger.DebugEnabled() {
+		caller = queryCaller()
+	}
+
+	logger.Debug("执行SQL", "duration", elapsed, "rows", rows, "caller", caller, "sql", sql)
+	if slow {
+		if err != nil {
+			logger.Warn("慢查询", "duration", elapsed, "rows", rows, "caller", caller, "sql", sql, "error", err)
+		} else {
+			logger.Warn("慢查询", "duration", elapsed, "rows", rows, "caller", caller, "sql", sql)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.updateFloor()
+
+	stat, ok := t.stats[sql]
+	if !ok {
+		// 已满时只替换最快的一条
+		if len(t.stats) >= t.top {
+			fastest := t.fastest()
+			if fastest == nil || fastest.MaxTime >= elapsed {
+				return
+			}
+			delete(t.stats, fastest.SQL)
+		}
+		stat = &QueryStat{SQL: sql}
+		t.stats[sql] = stat
+	}
+
+	stat.Count++
+	stat.TotalTime += elapsed
+	stat.LastSeen = time.Now()
+	if elapsed >= stat.MaxTime {
+		if caller == "" {
+			caller = queryCaller()
+		}
+		stat.MaxTime = elapsed
+		stat.Rows = rows
+		stat.Caller = caller
+	}
+}
+
+// updateFloor 更新列表下限，调用时需持有锁
+func (t *queryTracer) updateFloor() {
+	if len(t.stats) < t.top {
+		t.floor.Store(0)
+		return
+	}
+	t.floor.Store(int64(t.fastest().MaxTime))
+}
+
+// fastest 获取最长耗时最短的一条
+func (t *queryTracer) fastest() *QueryStat {
+	var fastest *QueryStat
+	for _, stat := range t.stats {
+		if fastest == nil || stat.MaxTime < fastest.MaxTime {
+			fastest = stat
+		}
+	}
+	return fastest
+}
+
+// slowest 按最长耗时降序返回统计
+func (t *queryTracer) slowest() []QueryStat {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	list := make([]QueryStat, 0, len(t.stats))
+	for _, stat := range t.stats {
+		list = append(list, *stat)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].MaxTime > list[j].MaxTime
+	})
+	return list
+}
+
+// reset 清空统计
+func (t *queryTracer) reset() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.stats = make(map[string]*QueryStat)
+	t.floor.Store(0)
+	t.mu.Unlock()
+}
+
+// trace 开始记录一次SQL执行，返回执行结束时调用的函数
+func (db *DB) trace(query string) func(rows int64, err error) {
+	if db.tracer == nil {
+		return func(int64, error) {}
+	}
+	start := time.Now()
+	return func(rows int64, err error) {
+		db.tracer.record(query, start, rows, err)
+	}
+}
+
+// SlowQueries 获取最慢的归一化SQL，按最长耗时降序
+func (db *DB) SlowQueries() []QueryStat {
+	return db.tracer.slowest()
+}
+
+// SlowQueryThreshold 获取慢查询阈值
+func (db *DB) SlowQueryThreshold() time.Duration {
+	if db.tracer == nil {
+		return 0
+	}
+	return db.tracer.threshold
+}
+
+// ResetSlowQueries 清空慢查询统计
+func (db *DB) ResetSlowQueries() {
+	db.tracer.reset()
+}
+
+// normalizeSQL 将SQL中的字面量和参数替换为?，合并IN列表和多行VALUES，便于聚合同类查询
+func normalizeSQL(query string) string {
+	query = stringLiteralRe.ReplaceAllString(query, "?")
+	query = pgParamRe.ReplaceAllString(query, "?")
+	query = numberLiteralRe.ReplaceAllString(query, "?")
+	query = inListRe.ReplaceAllString(query, "IN (?)")
+	query = valuesListRe.ReplaceAllString(query, "$1")
+	return strings.TrimSpace(spaceRe.ReplaceAllString(query, " "))
+}
+
+// queryCaller 获取数据库包之外的第一个调用位置
+func queryCaller() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "aq3cms/pkg/database.") {
+			name := frame.Function
+			if i := strings.LastIndex(name, "/"); i >= 0 {
+				name = name[i+1:]
+			}
+			return filepath.Base(filepath.Dir(frame.File)) + "/" + filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line) + " " + name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
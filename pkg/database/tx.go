@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"aq3cms/pkg/logger"
+)
+
+// ErrNestedTx 在事务中再次开启事务
+var ErrNestedTx = errors.New("已在事务中，不能再次开启事务")
+
+// Tx 数据库事务，执行的SQL同样会替换表前缀并改写为当前方言
+type Tx struct {
+	*sql.Tx
+	db  *DB // 绑定到事务的数据库实例
+	ctx context.Context
+}
+
+// Begin 开启事务
+func (db *DB) Begin() (*Tx, error) {
+	return db.BeginTx(db.Context(), nil)
+}
+
+// BeginTx 在ctx下开启事务，ctx取消时事务会被回滚
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	if db.tx != nil {
+		return nil, ErrNestedTx
+	}
+
+	markWrite(ctx)
+
+	sqlTx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Tx{Tx: sqlTx, ctx: ctx}
+	tx.db = db.WithContext(ctx)
+	tx.db.tx = tx
+	return tx, nil
+}
+
+// WithTx 在事务中执行fn，fn返回错误或panic时回滚，否则提交；已在事务中时直接加入当前事务
+func (db *DB) WithTx(fn func(tx *Tx) error) error {
+	return db.WithTxContext(db.Context(), fn)
+}
+
+// WithTxContext 在ctx下开启事务并执行fn
+func (db *DB) WithTxContext(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	if db.tx != nil {
+		return fn(db.tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				logger.Error("回滚事务失败", "error", rbErr)
+			}
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			err = fmt.Errorf("提交事务失败: %w", err)
+		}
+	}()
+
+	return fn(tx)
+}
+
+// DB 获取绑定到事务的数据库实例，用它创建的模型和查询构建器都在事务中执行
+func (tx *Tx) DB() *DB {
+	return tx.db
+}
+
+// NewQueryBuilder 创建在事务中执行的查询构建器
+func (tx *Tx) NewQueryBuilder(table string) *QueryBuilder {
+	return NewQueryBuilder(tx.db, table)
+}
+
+// Exec 在事务中执行非查询SQL
+func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.ExecContext(tx.ctx, query, args...)
+}
+
+// ExecContext 在ctx下于事务中执行非查询SQL
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.db.ExecContext(ctx, query, args...)
+}
+
+// Query 在事务中执行查询
+func (tx *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.QueryContext(tx.ctx, query, args...)
+}
+
+// QueryContext 在ctx下于事务中执行查询
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.db.Rewrite(query), args...)
+}
+
+// QueryRow 在事务中查询单行
+func (tx *Tx) QueryRow(query string, args ...interface{}) *Row {
+	return tx.QueryRowContext(tx.ctx, query, args...)
+}
+
+// QueryRowContext 在ctx下于事务中查询单行
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	return tx.db.QueryRowContext(ctx, query, args...)
+}
@@ -0,0 +1,347 @@
+package extract
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 未登录词的最短和最长字数
+const (
+	minGram = 2
+	maxGram = 4
+)
+
+// 摘要段落的最少字数，更短的段落视为标题、图注等
+const minParagraph = 20
+
+var (
+	// scriptPattern 匹配脚本和样式块
+	scriptPattern = regexp.MustCompile(`(?is)<script\b.*?</script>|<style\b.*?</style>`)
+	// blockPattern 匹配分隔段落的块级标签
+	blockPattern = regexp.MustCompile(`(?i)</?(p|div|br|h[1-6]|li|ul|ol|tr|td|th|table|blockquote|pre|section|article|figure|figcaption|hr)\b[^>]*>`)
+	// tagPattern 匹配其余HTML标签
+	tagPattern = regexp.MustCompile(`<[^>]*>`)
+	// spacePattern 匹配连续空白
+	spacePattern = regexp.MustCompile(`[\s\x{00a0}\x{3000}]+`)
+)
+
+// Paragraphs 将HTML内容转换为纯文本段落，去除空段落
+func Paragraphs(source string) []string {
+	text := scriptPattern.ReplaceAllString(source, "")
+	text = blockPattern.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+
+	paragraphs := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(spacePattern.ReplaceAllString(line, " "))
+		if line != "" {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	return paragraphs
+}
+
+// Summary 从HTML内容中提取摘要：取第一个足够长的段落，超过maxLen个字时在句末截断
+func Summary(source string, maxLen int) string {
+	paragraphs := Paragraphs(source)
+	if len(paragraphs) == 0 {
+		return ""
+	}
+
+	summary := strings.Join(paragraphs, " ")
+	for _, p := range paragraphs {
+		if utf8.RuneCountInString(p) >= minParagraph && hasLetter(p) {
+			summary = p
+			break
+		}
+	}
+
+	runes := []rune(summary)
+	if maxLen <= 0 || len(runes) <= maxLen {
+		return summary
+	}
+
+	// 优先在后半段的句末截断
+	runes = runes[:maxLen]
+	for i := len(runes) - 1; i >= maxLen/2; i-- {
+		if strings.ContainsRune("。！？；!?;", runes[i]) {
+			return string(runes[:i+1])
+		}
+	}
+	return strings.TrimSpace(string(runes[:maxLen-1])) + "…"
+}
+
+// hasLetter 文本中是否含有文字
+func hasLetter(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCJK 文本中是否含有中日韩文字
+func hasCJK(s string) bool {
+	for _, r := range s {
+		if isCJK(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCJK 是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// token 分词结果
+type token struct {
+	key   string // 小写形式，用于统计
+	text  string // 原文
+	known bool   // 是否为词典中的词
+}
+
+// Corpus 关键词提取的语料库，记录词典和文档频率
+type Corpus struct {
+	docs       int
+	df         map[string]int
+	dict       map[string]bool
+	maxWordLen int
+}
+
+// NewCorpus 创建语料库
+func NewCorpus() *Corpus {
+	return &Corpus{
+		df:         make(map[string]int),
+		dict:       make(map[string]bool),
+		maxWordLen: maxGram,
+	}
+}
+
+// AddWord 向词典中添加词，词典中的词优先作为一个整体切分，通常为站点的标签和关键词
+func (c *Corpus) AddWord(word string) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	n := utf8.RuneCountInString(word)
+	if n < 2 || stopWords[word] {
+		return
+	}
+	c.dict[word] = true
+	if n > c.maxWordLen {
+		c.maxWordLen = n
+	}
+}
+
+// AddDocument 将文档计入文档频率
+func (c *Corpus) AddDocument(text string) {
+	c.docs++
+	seen := make(map[string]bool)
+	for _, t := range c.segment(text) {
+		if !seen[t.key] {
+			seen[t.key] = true
+			c.df[t.key]++
+		}
+	}
+}
+
+// Docs 语料库中的文档数
+func (c *Corpus) Docs() int {
+	return c.docs
+}
+
+// Segment 切分文本，英文按单词切分，中日韩文字按词典最长匹配，未登录的部分取2到4字的片段
+func (c *Corpus) Segment(text string) []string {
+	tokens := c.segment(text)
+	words := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		words = append(words, t.text)
+	}
+	return words
+}
+
+// segment 切分文本
+func (c *Corpus) segment(text string) []token {
+	tokens := make([]token, 0)
+	var word, run []rune
+
+	flushWord := func() {
+		if len(word) >= 2 {
+			w := string(word)
+			key := strings.ToLower(w)
+			if !stopWords[key] && hasLetter(w) {
+				tokens = append(tokens, token{key: key, text: w, known: c.dict[key]})
+			}
+		}
+		word = word[:0]
+	}
+	flushRun := func() {
+		tokens = append(tokens, c.segmentCJK(run)...)
+		run = run[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			run = append(run, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushRun()
+			word = append(word, r)
+		case (r == '+' || r == '#') && len(word) > 0:
+			// C++、C#等
+			word = append(word, r)
+		default:
+			flushWord()
+			flushRun()
+		}
+	}
+	flushWord()
+	flushRun()
+	return tokens
+}
+
+// segmentCJK 切分一段连续的中日韩文字
+func (c *Corpus) segmentCJK(run []rune) []token {
+	tokens := make([]token, 0)
+	start := -1 // 未登录部分的起始位置
+
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, grams(run[start:end])...)
+			start = -1
+		}
+	}
+
+	for i := 0; i < len(run); {
+		// 词典最长匹配
+		matched := 0
+		for n := c.maxWordLen; n >= 2; n-- {
+			if i+n <= len(run) && c.dict[string(run[i:i+n])] {
+				matched = n
+				break
+			}
+		}
+		if matched > 0 {
+			flush(i)
+			w := string(run[i : i+matched])
+			tokens = append(tokens, token{key: w, text: w, known: true})
+			i += matched
+			continue
+		}
+
+		// 停用词和虚词处断开
+		stop := 0
+		if stopChars[run[i]] {
+			stop = 1
+		}
+		for n := 3; n >= 2 && stop == 0; n-- {
+			if i+n <= len(run) && stopWords[string(run[i:i+n])] {
+				stop = n
+			}
+		}
+		if stop > 0 {
+			flush(i)
+			i += stop
+			continue
+		}
+
+		if start < 0 {
+			start = i
+		}
+		i++
+	}
+	flush(len(run))
+
+	return tokens
+}
+
+// grams 生成未登录部分中2到4字的片段，片段首尾不能是虚词
+func grams(run []rune) []token {
+	tokens := make([]token, 0)
+	for n := minGram; n <= maxGram; n++ {
+		for i := 0; i+n <= len(run); i++ {
+			if edgeChars[run[i]] || edgeChars[run[i+n-1]] {
+				continue
+			}
+			w := string(run[i : i+n])
+			if !stopWords[w] {
+				tokens = append(tokens, token{key: w, text: w})
+			}
+		}
+	}
+	return tokens
+}
+
+// candidate 关键词候选
+type candidate struct {
+	token
+	tf    float64
+	score float64
+}
+
+// Keywords 按TF-IDF提取关键词，标题中的词权重更高；词典以外的词至少出现两次才作为候选，
+// 互相包含的中文候选只保留得分高的一个
+func (c *Corpus) Keywords(title, text string, n int) []string {
+	candidates := make(map[string]*candidate)
+	add := func(s string, weight float64) {
+		for _, t := range c.segment(s) {
+			if cand, ok := candidates[t.key]; ok {
+				cand.tf += weight
+			} else {
+				candidates[t.key] = &candidate{token: t, tf: weight}
+			}
+		}
+	}
+	add(title, 3)
+	add(text, 1)
+
+	list := make([]*candidate, 0, len(candidates))
+	for _, cand := range candidates {
+		if !cand.known && cand.tf < 2 {
+			continue
+		}
+		idf := math.Log(float64(c.docs+1)/float64(c.df[cand.key]+1)) + 1
+		cand.score = (1 + math.Log(cand.tf)) * idf
+		if c.dict[cand.key] {
+			cand.score *= 1.5
+		}
+		list = append(list, cand)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		if len(list[i].key) != len(list[j].key) {
+			return len(list[i].key) > len(list[j].key)
+		}
+		return list[i].key < list[j].key
+	})
+
+	keywords := make([]string, 0, n)
+	selected := make([]string, 0, n)
+	for _, cand := range list {
+		if len(keywords) >= n {
+			break
+		}
+		overlap := false
+		for _, s := range selected {
+			if s == cand.key || (hasCJK(s) && hasCJK(cand.key) && (strings.Contains(s, cand.key) || strings.Contains(cand.key, s))) {
+				overlap = true
+				break
+			}
+		}
+		if overlap {
+			continue
+		}
+		selected = append(selected, cand.key)
+		keywords = append(keywords, cand.text)
+	}
+	return keywords
+}
@@ -0,0 +1,41 @@
+package extract
+
+// stopChars 基本不出现在实词中的中文虚词，CJK文本在这些字处断开
+var stopChars = map[rune]bool{}
+
+// edgeChars 常见的虚词和副词，也会出现在实词内部，只限制它们不能作为未登录词的首尾
+var edgeChars = map[rune]bool{}
+
+// stopWords 中文和英文停用词，不作为关键词
+var stopWords = map[string]bool{}
+
+func init() {
+	for _, r := range "的了是着吗呢吧啊呀哦么嘛们我你他她它这那也很又都就被把" {
+		stopChars[r] = true
+	}
+	for _, r := range "在和与及或为对从于将之其而并但若则即且该此每各某些有不一个可能会要让使还更最没无如因所等以" {
+		edgeChars[r] = true
+	}
+
+	for _, w := range []string{
+		// 中文
+		"我们", "你们", "他们", "她们", "它们", "自己", "这个", "那个", "这些", "那些", "这样", "那样", "这里", "那里",
+		"什么", "怎么", "为什么", "如何", "哪些", "一个", "一些", "一种", "一样", "一起", "一下", "一直", "已经",
+		"因为", "所以", "但是", "如果", "虽然", "而且", "或者", "并且", "以及", "然后", "还是", "就是", "不是",
+		"可以", "可能", "需要", "应该", "能够", "没有", "通过", "进行", "其中", "之后", "之前", "以后", "以前",
+		"目前", "现在", "今天", "时候", "同时", "非常", "比较", "所有", "其他", "其它", "以上", "以下", "方面",
+		"问题", "情况", "表示", "认为", "觉得", "知道", "出现", "成为", "来说", "对于", "关于", "根据", "由于",
+		"为了", "直接", "只是", "只有", "还有", "即使", "不过", "甚至", "于是", "因此", "另外", "此外", "总之",
+		// 英文
+		"a", "an", "the", "and", "or", "but", "if", "then", "else", "for", "of", "to", "in", "on", "at", "by",
+		"with", "from", "into", "about", "as", "is", "are", "was", "were", "be", "been", "being", "have", "has",
+		"had", "do", "does", "did", "not", "no", "so", "than", "too", "very", "can", "will", "just", "should",
+		"now", "this", "that", "these", "those", "it", "its", "we", "you", "he", "she", "they", "them", "our",
+		"your", "his", "her", "their", "what", "which", "who", "whom", "when", "where", "why", "how", "all",
+		"any", "both", "each", "few", "more", "most", "other", "some", "such", "only", "own", "same", "also",
+		"here", "there", "out", "up", "down", "over", "under", "again", "once", "my", "me", "us", "him", "i",
+		"nbsp", "amp", "quot", "http", "https", "www", "com", "html",
+	} {
+		stopWords[w] = true
+	}
+}
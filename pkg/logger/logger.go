@@ -75,6 +75,11 @@ func Init(level string, path string) error {
 	return nil
 }
 
+// DebugEnabled 是否输出调试日志
+func DebugEnabled() bool {
+	return currentLevel <= LevelDebug
+}
+
 // Debug 调试日志
 func Debug(msg string, keysAndValues ...interface{}) {
 	if currentLevel <= LevelDebug {
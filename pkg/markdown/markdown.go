@@ -0,0 +1,41 @@
+package markdown
+
+import (
+	"bytes"
+
+	"aq3cms/pkg/security"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/JohannesKaufmann/html-to-markdown/plugin"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// 支持表格、删除线、任务列表、自动链接和脚注，代码块输出 language-xxx 类名
+var converter = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		extension.Footnote,
+	),
+	goldmark.WithRendererOptions(
+		// 保留内嵌的HTML，统一由 security.CleanHTML 过滤
+		html.WithUnsafe(),
+	),
+)
+
+// ToHTML 将Markdown转换为经过过滤的HTML
+func ToHTML(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := converter.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return security.CleanHTML(buf.String()), nil
+}
+
+// FromHTML 将HTML转换为Markdown，用于导出HTML格式的文章
+func FromHTML(source string) (string, error) {
+	conv := md.NewConverter("", true, nil)
+	conv.Use(plugin.GitHubFlavored())
+	return conv.ConvertString(source)
+}
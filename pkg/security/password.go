@@ -72,6 +72,11 @@ func CheckPassword(password, encodedHash string) bool {
 		return CheckPasswordMD5(password, encodedHash)
 	}
 
+	// 检查是否是DedeCMS截取保存的MD5哈希 (20个十六进制字符)
+	if len(encodedHash) == 20 && isHexString(encodedHash) {
+		return CheckPasswordMD5Cut(password, encodedHash)
+	}
+
 	// 检查是否是Argon2哈希
 	if strings.HasPrefix(encodedHash, "$argon2id$") {
 		return CheckPasswordArgon2(password, encodedHash)
@@ -88,6 +93,12 @@ func CheckPasswordMD5(password, hash string) bool {
 	return subtle.ConstantTimeCompare([]byte(strings.ToLower(hash)), []byte(strings.ToLower(expectedHash))) == 1
 }
 
+// CheckPasswordMD5Cut 检查DedeCMS截取保存的MD5密码，DedeCMS选择截取方式时只保存MD5的第6到25位
+func CheckPasswordMD5Cut(password, hash string) bool {
+	expectedHash := HashPassword(password)[5:25]
+	return subtle.ConstantTimeCompare([]byte(strings.ToLower(hash)), []byte(expectedHash)) == 1
+}
+
 // isHexString 检查字符串是否为十六进制
 func isHexString(s string) bool {
 	for _, c := range s {
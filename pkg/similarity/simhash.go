@@ -0,0 +1,267 @@
+package similarity
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// shingleSize SimHash特征的片段长度，单位为中日韩单字或拉丁单词
+const shingleSize = 3
+
+// maxDistance 索引支持的最大汉明距离，更大的距离分块过小，几乎所有内容都会成为候选
+const maxDistance = 15
+
+// units 将文本规范化为单元序列：转为小写并去掉标点和空白，中日韩文字每字一个单元，拉丁字母和数字每个单词一个单元
+func units(text string) []string {
+	list := make([]string, 0)
+	var word []rune
+
+	flushWord := func() {
+		if len(word) > 0 {
+			list = append(list, string(word))
+			word = word[:0]
+		}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case isCJK(r):
+			flushWord()
+			list = append(list, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			flushWord()
+		}
+	}
+	flushWord()
+	return list
+}
+
+// SimHash 计算文本的64位SimHash指纹，特征为规范化后相邻3个单元组成的片段，
+// 标点、空白和大小写的差异不影响结果；没有文字时返回0
+func SimHash(text string) uint64 {
+	list := units(text)
+	if len(list) == 0 {
+		return 0
+	}
+
+	// 统计片段出现次数作为权重
+	shingles := make(map[string]int)
+	if len(list) < shingleSize {
+		shingles[strings.Join(list, " ")]++
+	}
+	for i := 0; i+shingleSize <= len(list); i++ {
+		shingles[strings.Join(list[i:i+shingleSize], " ")]++
+	}
+
+	var weights [64]int
+	h := fnv.New64a()
+	for shingle, weight := range shingles {
+		h.Reset()
+		h.Write([]byte(shingle))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				weights[bit] += weight
+			} else {
+				weights[bit] -= weight
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			fingerprint |= 1 << uint(bit)
+		}
+	}
+	return fingerprint
+}
+
+// Hamming 两个指纹的汉明距离，即不同的位数
+func Hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Near 汉明距离在范围内的指纹
+type Near struct {
+	ID       int64
+	Distance int
+}
+
+// block 指纹中的一段
+type block struct {
+	shift uint
+	mask  uint64
+}
+
+// Index 指纹索引，按抽屉原理将64位分为distance+1段，距离不超过distance的两个指纹至少有一段相同，
+// 只需比较有相同分段的候选；可以并发使用
+type Index struct {
+	distance int
+	blocks   []block
+
+	mu     sync.RWMutex
+	tables []map[uint64][]int
+	ids    []int64
+	hashes []uint64
+	added  map[int64]bool
+}
+
+// NewIndex 创建指纹索引，distance为判定相近的最大汉明距离
+func NewIndex(distance int) *Index {
+	if distance < 0 {
+		distance = 0
+	}
+	if distance > maxDistance {
+		distance = maxDistance
+	}
+
+	n := distance + 1
+	x := &Index{
+		distance: distance,
+		blocks:   make([]block, n),
+		tables:   make([]map[uint64][]int, n),
+		added:    make(map[int64]bool),
+	}
+	shift := uint(0)
+	for i := 0; i < n; i++ {
+		// 前64%n段各多1位
+		width := uint(64 / n)
+		if i < 64%n {
+			width++
+		}
+		x.blocks[i] = block{shift: shift, mask: (1<<width - 1) << shift}
+		x.tables[i] = make(map[uint64][]int)
+		shift += width
+	}
+	return x
+}
+
+// Distance 判定相近的最大汉明距离
+func (x *Index) Distance() int {
+	return x.distance
+}
+
+// Len 索引中的指纹数
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.ids)
+}
+
+// Add 添加指纹，0表示没有指纹，已添加过的ID和没有指纹的会被忽略
+func (x *Index) Add(id int64, hash uint64) {
+	if hash == 0 {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.added[id] {
+		return
+	}
+	x.added[id] = true
+	pos := len(x.ids)
+	x.ids = append(x.ids, id)
+	x.hashes = append(x.hashes, hash)
+	for i, b := range x.blocks {
+		key := hash & b.mask
+		x.tables[i][key] = append(x.tables[i][key], pos)
+	}
+}
+
+// candidates 与指纹有相同分段且距离在范围内的位置，调用时需持有锁
+func (x *Index) candidates(hash uint64) map[int]int {
+	found := make(map[int]int)
+	for i, b := range x.blocks {
+		for _, pos := range x.tables[i][hash&b.mask] {
+			if _, ok := found[pos]; ok {
+				continue
+			}
+			if d := Hamming(hash, x.hashes[pos]); d <= x.distance {
+				found[pos] = d
+			}
+		}
+	}
+	return found
+}
+
+// Find 查找距离不超过distance的指纹，按距离从近到远排序
+func (x *Index) Find(hash uint64) []Near {
+	if hash == 0 {
+		return nil
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	found := x.candidates(hash)
+	list := make([]Near, 0, len(found))
+	for pos, d := range found {
+		list = append(list, Near{ID: x.ids[pos], Distance: d})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Distance != list[j].Distance {
+			return list[i].Distance < list[j].Distance
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// Clusters 将相近的指纹聚类（相近关系可传递），返回至少包含两个ID的类，
+// 按类的大小从大到小排序，类中的ID按添加顺序排列
+func (x *Index) Clusters() [][]int64 {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	parent := make([]int, len(x.ids))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for pos, hash := range x.hashes {
+		for other := range x.candidates(hash) {
+			if a, b := find(pos), find(other); a != b {
+				if a < b {
+					parent[b] = a
+				} else {
+					parent[a] = b
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int64)
+	roots := make([]int, 0)
+	for pos, id := range x.ids {
+		root := find(pos)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], id)
+	}
+
+	clusters := make([][]int64, 0)
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			clusters = append(clusters, groups[root])
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i]) > len(clusters[j])
+	})
+	return clusters
+}
@@ -0,0 +1,236 @@
+package similarity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 每篇文档保留权重最高的词数，限制计算量
+const maxDocTerms = 64
+
+// stopWords 常见的英文停用词
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true,
+	"you": true, "all": true, "any": true, "can": true, "had": true, "her": true,
+	"was": true, "one": true, "our": true, "out": true, "has": true, "have": true,
+	"this": true, "that": true, "with": true, "from": true, "they": true, "will": true,
+	"what": true, "when": true, "which": true, "there": true, "their": true, "been": true,
+	"were": true, "into": true, "than": true, "then": true, "them": true, "these": true,
+	"its": true, "his": true, "she": true, "him": true, "how": true, "who": true,
+	"nbsp": true, "amp": true, "quot": true,
+}
+
+// isCJK 是否为中日韩文字，这些文字之间没有空格分词
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// Tokenize 将文本切分为词：拉丁字母和数字按单词切分并转为小写，中日韩文字按相邻两字（bigram）切分
+func Tokenize(text string) []string {
+	tokens := make([]string, 0)
+	var word []rune
+	var cjk []rune
+
+	flushWord := func() {
+		if len(word) >= 2 {
+			w := strings.ToLower(string(word))
+			if !stopWords[w] {
+				tokens = append(tokens, w)
+			}
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		if len(cjk) == 1 {
+			tokens = append(tokens, string(cjk))
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, string(cjk[i:i+2]))
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// Vector 词频或词权重向量
+type Vector map[string]float64
+
+// Add 将文本切分后按weight累加到向量
+func (v Vector) Add(text string, weight float64) {
+	for _, token := range Tokenize(text) {
+		v[token] += weight
+	}
+}
+
+// AddTerm 将整个词累加到向量，用于标签等不需要切分的词
+func (v Vector) AddTerm(term string, weight float64) {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term != "" {
+		v[term] += weight
+	}
+}
+
+// Match 相似文档
+type Match struct {
+	ID    int64
+	Score float64 // 余弦相似度，0到1
+}
+
+// Corpus 文档集合，计算TF-IDF向量之间的余弦相似度
+type Corpus struct {
+	ids  []int64
+	tfs  []Vector
+	df   map[string]int
+	seen map[int64]bool
+}
+
+// NewCorpus 创建文档集合
+func NewCorpus() *Corpus {
+	return &Corpus{
+		df:   make(map[string]int),
+		seen: make(map[int64]bool),
+	}
+}
+
+// Add 添加文档，tf为按字段加权后的词频，重复的ID会被忽略
+func (c *Corpus) Add(id int64, tf Vector) {
+	if c.seen[id] || len(tf) == 0 {
+		return
+	}
+	c.seen[id] = true
+	c.ids = append(c.ids, id)
+	c.tfs = append(c.tfs, tf)
+	for term := range tf {
+		c.df[term]++
+	}
+}
+
+// Len 文档数
+func (c *Corpus) Len() int {
+	return len(c.ids)
+}
+
+// posting 倒排索引中的一项
+type posting struct {
+	doc    int
+	weight float64
+}
+
+// TopN 计算每篇文档最相似的n篇文档，只返回相似度不低于minScore的结果。
+// 只出现在一篇文档中或出现在一半以上文档中的词不参与计算
+func (c *Corpus) TopN(n int, minScore float64) map[int64][]Match {
+	total := len(c.ids)
+	result := make(map[int64][]Match)
+	if total < 2 || n <= 0 {
+		return result
+	}
+
+	maxDF := total / 2
+	if maxDF < 2 {
+		maxDF = 2
+	}
+
+	// 计算归一化的TF-IDF向量并建立倒排索引
+	index := make(map[string][]posting)
+	docs := make([][]string, total)
+	weights := make([]map[string]float64, total)
+	for i, tf := range c.tfs {
+		type termWeight struct {
+			term   string
+			weight float64
+		}
+		terms := make([]termWeight, 0, len(tf))
+		for term, freq := range tf {
+			df := c.df[term]
+			if df < 2 || df > maxDF || freq <= 0 {
+				continue
+			}
+			idf := math.Log(float64(total) / float64(df))
+			terms = append(terms, termWeight{term, (1 + math.Log(freq)) * idf})
+		}
+		sort.Slice(terms, func(a, b int) bool {
+			if terms[a].weight != terms[b].weight {
+				return terms[a].weight > terms[b].weight
+			}
+			return terms[a].term < terms[b].term
+		})
+		if len(terms) > maxDocTerms {
+			terms = terms[:maxDocTerms]
+		}
+
+		norm := 0.0
+		for _, t := range terms {
+			norm += t.weight * t.weight
+		}
+		if norm == 0 {
+			continue
+		}
+		norm = math.Sqrt(norm)
+
+		weights[i] = make(map[string]float64, len(terms))
+		for _, t := range terms {
+			w := t.weight / norm
+			weights[i][t.term] = w
+			docs[i] = append(docs[i], t.term)
+			index[t.term] = append(index[t.term], posting{doc: i, weight: w})
+		}
+	}
+
+	// 通过倒排索引累加共同词的权重乘积
+	scores := make(map[int]float64)
+	for i := range c.ids {
+		for k := range scores {
+			delete(scores, k)
+		}
+		for _, term := range docs[i] {
+			w := weights[i][term]
+			for _, p := range index[term] {
+				if p.doc != i {
+					scores[p.doc] += w * p.weight
+				}
+			}
+		}
+		if len(scores) == 0 {
+			continue
+		}
+
+		matches := make([]Match, 0, len(scores))
+		for doc, score := range scores {
+			if score >= minScore {
+				matches = append(matches, Match{ID: c.ids[doc], Score: math.Min(score, 1)})
+			}
+		}
+		sort.Slice(matches, func(a, b int) bool {
+			if matches[a].Score != matches[b].Score {
+				return matches[a].Score > matches[b].Score
+			}
+			return matches[a].ID > matches[b].ID
+		})
+		if len(matches) > n {
+			matches = matches[:n]
+		}
+		if len(matches) > 0 {
+			result[c.ids[i]] = matches
+		}
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+// Package migrations 内置的数据库迁移脚本，按数据库方言分目录存放
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed mysql/*.sql sqlite/*.sql postgres/*.sql
+var files embed.FS
+
+// For 返回指定方言的迁移脚本文件系统
+func For(dialect string) (fs.FS, error) {
+	if _, err := fs.Stat(files, dialect); err != nil {
+		return nil, err
+	}
+	return fs.Sub(files, dialect)
+}
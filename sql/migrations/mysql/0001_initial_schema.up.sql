@@ -0,0 +1,223 @@
+-- 初始表结构，与 sql/aq3cms.sql 保持一致
+-- 使用 IF NOT EXISTS，已有安装可以直接将其标记为已应用
+
+CREATE TABLE IF NOT EXISTS `#@__admin` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `username` varchar(50) NOT NULL DEFAULT '',
+  `password` varchar(255) NOT NULL DEFAULT '',
+  `rank` int(11) NOT NULL DEFAULT '10',
+  `email` varchar(100) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `last_login` datetime DEFAULT NULL,
+  `last_ip` varchar(45) DEFAULT '',
+  `login_count` int(11) NOT NULL DEFAULT '0',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `username` (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `#@__arctype` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `reid` int(11) NOT NULL DEFAULT '0',
+  `topid` int(11) NOT NULL DEFAULT '0',
+  `sortrank` int(11) NOT NULL DEFAULT '50',
+  `typename` varchar(60) NOT NULL DEFAULT '',
+  `typedir` varchar(60) NOT NULL DEFAULT '',
+  `isdefault` tinyint(1) NOT NULL DEFAULT '0',
+  `defaultname` varchar(60) NOT NULL DEFAULT 'index.html',
+  `issend` tinyint(1) NOT NULL DEFAULT '1',
+  `channeltype` int(11) NOT NULL DEFAULT '1',
+  `maxpage` int(11) NOT NULL DEFAULT '-1',
+  `ispart` tinyint(1) NOT NULL DEFAULT '0',
+  `corank` int(11) NOT NULL DEFAULT '0',
+  `tempindex` varchar(60) NOT NULL DEFAULT '',
+  `templist` varchar(60) NOT NULL DEFAULT '',
+  `temparticle` varchar(60) NOT NULL DEFAULT '',
+  `namerule` varchar(60) NOT NULL DEFAULT '',
+  `namerule2` varchar(60) NOT NULL DEFAULT '',
+  `modname` varchar(20) NOT NULL DEFAULT '',
+  `description` text,
+  `keywords` varchar(60) NOT NULL DEFAULT '',
+  `seotitle` varchar(80) NOT NULL DEFAULT '',
+  `moresite` tinyint(1) NOT NULL DEFAULT '0',
+  `sitepath` varchar(60) NOT NULL DEFAULT '',
+  `siteurl` varchar(200) NOT NULL DEFAULT '',
+  `ishidden` tinyint(1) NOT NULL DEFAULT '0',
+  `cross` tinyint(1) NOT NULL DEFAULT '0',
+  `crossid` text,
+  `content` text,
+  `smalltypes` text,
+  PRIMARY KEY (`id`),
+  KEY `reid` (`reid`),
+  KEY `topid` (`topid`),
+  KEY `sortrank` (`sortrank`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `#@__archives` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `typeid` int(11) NOT NULL DEFAULT '0',
+  `typeid2` varchar(90) NOT NULL DEFAULT '0',
+  `sortrank` int(11) NOT NULL DEFAULT '0',
+  `flag` set('c','h','p','f','s','j','a','b') DEFAULT NULL,
+  `ismake` tinyint(1) NOT NULL DEFAULT '0',
+  `channel` int(11) NOT NULL DEFAULT '1',
+  `arcrank` int(11) NOT NULL DEFAULT '0',
+  `click` int(11) NOT NULL DEFAULT '0',
+  `money` int(11) NOT NULL DEFAULT '0',
+  `title` varchar(60) NOT NULL DEFAULT '',
+  `shorttitle` varchar(36) NOT NULL DEFAULT '',
+  `color` varchar(7) NOT NULL DEFAULT '',
+  `writer` varchar(30) NOT NULL DEFAULT '',
+  `source` varchar(30) NOT NULL DEFAULT '',
+  `litpic` varchar(60) NOT NULL DEFAULT '',
+  `pubdate` int(11) NOT NULL DEFAULT '0',
+  `senddate` int(11) NOT NULL DEFAULT '0',
+  `mid` int(11) NOT NULL DEFAULT '0',
+  `keywords` varchar(60) NOT NULL DEFAULT '',
+  `lastpost` int(11) NOT NULL DEFAULT '0',
+  `scores` int(11) NOT NULL DEFAULT '0',
+  `goodpost` int(11) NOT NULL DEFAULT '0',
+  `badpost` int(11) NOT NULL DEFAULT '0',
+  `voteid` int(11) NOT NULL DEFAULT '0',
+  `notpost` tinyint(1) NOT NULL DEFAULT '0',
+  `description` varchar(250) NOT NULL DEFAULT '',
+  `filename` varchar(60) NOT NULL DEFAULT '',
+  `dutyadmin` int(11) NOT NULL DEFAULT '0',
+  `tackid` int(11) NOT NULL DEFAULT '0',
+  `mtype` int(11) NOT NULL DEFAULT '0',
+  `weight` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `typeid` (`typeid`),
+  KEY `sortrank` (`sortrank`),
+  KEY `pubdate` (`pubdate`),
+  KEY `click` (`click`),
+  KEY `arcrank` (`arcrank`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `#@__addonarticle` (
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `typeid` int(11) NOT NULL DEFAULT '0',
+  `body` longtext,
+  `redirecturl` varchar(255) NOT NULL DEFAULT '',
+  `templet` varchar(30) NOT NULL DEFAULT '',
+  `userip` varchar(15) NOT NULL DEFAULT '',
+  PRIMARY KEY (`aid`),
+  KEY `typeid` (`typeid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `#@__feedback` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `typeid` int(11) NOT NULL DEFAULT '0',
+  `username` varchar(50) NOT NULL DEFAULT '',
+  `mid` int(11) NOT NULL DEFAULT '0',
+  `ip` varchar(15) NOT NULL DEFAULT '',
+  `ischeck` tinyint(1) NOT NULL DEFAULT '0' COMMENT '0=待审核，1=已审核，-1=已拒绝',
+  `dtime` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `content` text NOT NULL,
+  `parentid` int(11) NOT NULL DEFAULT '0',
+  `score` int(11) NOT NULL DEFAULT '0',
+  `goodcount` int(11) NOT NULL DEFAULT '0',
+  `badcount` int(11) NOT NULL DEFAULT '0',
+  `userface` varchar(255) NOT NULL DEFAULT '',
+  `channeltype` tinyint(1) NOT NULL DEFAULT '1',
+  PRIMARY KEY (`id`),
+  KEY `idx_aid` (`aid`),
+  KEY `idx_typeid` (`typeid`),
+  KEY `idx_mid` (`mid`),
+  KEY `idx_ischeck` (`ischeck`),
+  KEY `idx_dtime` (`dtime`),
+  KEY `idx_parentid` (`parentid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='评论反馈表';
+
+CREATE TABLE IF NOT EXISTS `#@__flink` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `sortrank` smallint(6) NOT NULL DEFAULT '0',
+  `url` varchar(255) NOT NULL DEFAULT '',
+  `webname` varchar(60) NOT NULL DEFAULT '',
+  `msg` text,
+  `email` varchar(60) NOT NULL DEFAULT '',
+  `typeid` smallint(6) NOT NULL DEFAULT '0',
+  `logo` varchar(255) NOT NULL DEFAULT '',
+  `ischeck` tinyint(1) NOT NULL DEFAULT '0',
+  `dtime` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `sortrank` (`sortrank`),
+  KEY `typeid` (`typeid`),
+  KEY `ischeck` (`ischeck`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='友情链接表';
+
+CREATE TABLE IF NOT EXISTS `#@__member` (
+  `mid` int(11) NOT NULL AUTO_INCREMENT,
+  `mtype` varchar(20) NOT NULL DEFAULT '',
+  `userid` varchar(20) NOT NULL DEFAULT '',
+  `pwd` varchar(255) DEFAULT '',
+  `uname` varchar(36) NOT NULL DEFAULT '',
+  `sex` enum('男','女','保密') NOT NULL DEFAULT '保密',
+  `rank` int(11) NOT NULL DEFAULT '10',
+  `money` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `email` varchar(50) NOT NULL DEFAULT '',
+  `scores` int(11) NOT NULL DEFAULT '0',
+  `matt` tinyint(1) NOT NULL DEFAULT '0',
+  `spacesta` tinyint(1) NOT NULL DEFAULT '0',
+  `face` varchar(50) NOT NULL DEFAULT '',
+  `safequestion` tinyint(1) NOT NULL DEFAULT '0',
+  `safeanswer` varchar(30) NOT NULL DEFAULT '',
+  `jointime` int(11) NOT NULL DEFAULT '0',
+  `joinip` varchar(16) NOT NULL DEFAULT '',
+  `logintime` int(11) NOT NULL DEFAULT '0',
+  `loginip` varchar(16) NOT NULL DEFAULT '',
+  PRIMARY KEY (`mid`),
+  KEY `userid` (`userid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `#@__member_type` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `typename` varchar(50) NOT NULL DEFAULT '',
+  `description` text,
+  `rank` int(11) NOT NULL DEFAULT '10',
+  `money` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `scores` int(11) NOT NULL DEFAULT '0',
+  `purviews` text,
+  PRIMARY KEY (`id`),
+  KEY `idx_rank` (`rank`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='会员类型表';
+
+CREATE TABLE IF NOT EXISTS `#@__sysconfig` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `varname` varchar(20) NOT NULL DEFAULT '',
+  `info` varchar(100) NOT NULL DEFAULT '',
+  `groupid` tinyint(1) NOT NULL DEFAULT '1',
+  `type` varchar(10) NOT NULL DEFAULT 'string',
+  `value` text,
+  PRIMARY KEY (`id`),
+  KEY `varname` (`varname`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `#@__tagindex` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tag` varchar(30) NOT NULL DEFAULT '',
+  `count` int(11) NOT NULL DEFAULT '0',
+  `rank` int(11) NOT NULL DEFAULT '0',
+  `ishot` tinyint(1) NOT NULL DEFAULT '0',
+  `addtime` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `lastuse` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `tagpinyin` varchar(100) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `tag` (`tag`),
+  KEY `rank` (`rank`),
+  KEY `ishot` (`ishot`),
+  KEY `count` (`count`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `#@__taglist` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `tag` varchar(30) NOT NULL DEFAULT '',
+  `addtime` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `aid_tag` (`aid`,`tag`),
+  KEY `aid` (`aid`),
+  KEY `tag` (`tag`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS `#@__content_model`;
+DROP TABLE IF EXISTS `#@__ad`;
+DROP TABLE IF EXISTS `#@__ad_position`;
+DROP TABLE IF EXISTS `#@__vote_log`;
+DROP TABLE IF EXISTS `#@__vote_option`;
+DROP TABLE IF EXISTS `#@__vote`;
+DROP TABLE IF EXISTS `#@__payment_order`;
+DROP TABLE IF EXISTS `#@__payment_method`;
+DROP TABLE IF EXISTS `#@__collect_item`;
+DROP TABLE IF EXISTS `#@__collect_rule`;
@@ -0,0 +1,170 @@
+-- 采集、支付、投票、广告、内容模型所需的表
+
+CREATE TABLE IF NOT EXISTS `#@__collect_rule` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL DEFAULT '',
+  `sourcetype` tinyint(1) NOT NULL DEFAULT '0' COMMENT '0=列表，1=RSS',
+  `sourceurl` varchar(255) NOT NULL DEFAULT '',
+  `startpage` int(11) NOT NULL DEFAULT '1',
+  `endpage` int(11) NOT NULL DEFAULT '1',
+  `pagerule` varchar(255) NOT NULL DEFAULT '',
+  `listrule` text,
+  `titlerule` text,
+  `contentrule` text,
+  `typeid` int(11) NOT NULL DEFAULT '0',
+  `modelid` int(11) NOT NULL DEFAULT '0',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `lasttime` datetime DEFAULT NULL,
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  `fieldrules` text,
+  PRIMARY KEY (`id`),
+  KEY `typeid` (`typeid`),
+  KEY `status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='采集规则表';
+
+CREATE TABLE IF NOT EXISTS `#@__collect_item` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `ruleid` int(11) NOT NULL DEFAULT '0',
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `url` varchar(500) NOT NULL DEFAULT '',
+  `content` longtext,
+  `status` tinyint(1) NOT NULL DEFAULT '0' COMMENT '0=未处理，1=已处理，2=已发布',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  `fielddata` text,
+  PRIMARY KEY (`id`),
+  KEY `ruleid` (`ruleid`),
+  KEY `url` (`url`(191)),
+  KEY `status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='采集内容表';
+
+CREATE TABLE IF NOT EXISTS `#@__payment_method` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL DEFAULT '',
+  `code` varchar(30) NOT NULL DEFAULT '',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `config` text,
+  `icon` varchar(255) NOT NULL DEFAULT '',
+  `orderid` int(11) NOT NULL DEFAULT '0',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='支付方式表';
+
+CREATE TABLE IF NOT EXISTS `#@__payment_order` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `orderno` varchar(64) NOT NULL DEFAULT '',
+  `memberid` int(11) NOT NULL DEFAULT '0',
+  `amount` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `paymentmethod` varchar(30) NOT NULL DEFAULT '',
+  `paymentorderno` varchar(64) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '0' COMMENT '0=未支付，1=已支付，2=已取消，3=已退款',
+  `type` tinyint(1) NOT NULL DEFAULT '0' COMMENT '0=充值，1=购买，2=其他',
+  `relatedid` int(11) NOT NULL DEFAULT '0',
+  `relatedtype` varchar(30) NOT NULL DEFAULT '',
+  `remark` varchar(255) NOT NULL DEFAULT '',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  `paytime` datetime DEFAULT NULL,
+  `extradata` text,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `orderno` (`orderno`),
+  KEY `memberid` (`memberid`),
+  KEY `status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='支付订单表';
+
+CREATE TABLE IF NOT EXISTS `#@__vote` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `description` text,
+  `starttime` datetime DEFAULT NULL,
+  `endtime` datetime DEFAULT NULL,
+  `ismulti` tinyint(1) NOT NULL DEFAULT '0',
+  `maxchoices` int(11) NOT NULL DEFAULT '1',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `totalcount` int(11) NOT NULL DEFAULT '0',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票表';
+
+CREATE TABLE IF NOT EXISTS `#@__vote_option` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `voteid` int(11) NOT NULL DEFAULT '0',
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `count` int(11) NOT NULL DEFAULT '0',
+  `orderid` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `voteid` (`voteid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票选项表';
+
+CREATE TABLE IF NOT EXISTS `#@__vote_log` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `voteid` int(11) NOT NULL DEFAULT '0',
+  `optionid` int(11) NOT NULL DEFAULT '0',
+  `memberid` int(11) NOT NULL DEFAULT '0',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `createtime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `voteid` (`voteid`),
+  KEY `memberid` (`memberid`),
+  KEY `ip` (`ip`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票记录表';
+
+CREATE TABLE IF NOT EXISTS `#@__ad_position` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL DEFAULT '',
+  `code` varchar(50) NOT NULL DEFAULT '',
+  `width` int(11) NOT NULL DEFAULT '0',
+  `height` int(11) NOT NULL DEFAULT '0',
+  `template` text,
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='广告位表';
+
+CREATE TABLE IF NOT EXISTS `#@__ad` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `positionid` int(11) NOT NULL DEFAULT '0',
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `type` tinyint(1) NOT NULL DEFAULT '0' COMMENT '0=图片，1=Flash，2=代码，3=文字',
+  `image` varchar(255) NOT NULL DEFAULT '',
+  `flash` varchar(255) NOT NULL DEFAULT '',
+  `code` text,
+  `text` varchar(255) NOT NULL DEFAULT '',
+  `url` varchar(255) NOT NULL DEFAULT '',
+  `starttime` datetime DEFAULT NULL,
+  `endtime` datetime DEFAULT NULL,
+  `orderid` int(11) NOT NULL DEFAULT '0',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `target` varchar(10) NOT NULL DEFAULT '_blank',
+  `width` int(11) NOT NULL DEFAULT '0',
+  `height` int(11) NOT NULL DEFAULT '0',
+  `click` int(11) NOT NULL DEFAULT '0',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `positionid` (`positionid`),
+  KEY `status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='广告表';
+
+CREATE TABLE IF NOT EXISTS `#@__content_model` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL DEFAULT '',
+  `tablename` varchar(50) NOT NULL DEFAULT '',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `state` tinyint(1) NOT NULL DEFAULT '1',
+  `fields` text,
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `tablename` (`tablename`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='内容模型表';
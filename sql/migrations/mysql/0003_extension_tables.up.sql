@@ -0,0 +1,346 @@
+-- 专题、友情链接分类、积分、短消息、统计、SEO、插件、多语言、模板、表单、下载和产品模型所需的表
+
+CREATE TABLE IF NOT EXISTS `#@__special` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `typeid` int(11) NOT NULL DEFAULT '0',
+  `note` text,
+  `pic` varchar(255) NOT NULL DEFAULT '',
+  `pubdate` datetime DEFAULT NULL,
+  `lastupdate` datetime DEFAULT NULL,
+  `ishot` tinyint(1) NOT NULL DEFAULT '0',
+  `click` int(11) NOT NULL DEFAULT '0',
+  `template` varchar(100) NOT NULL DEFAULT '',
+  `templatelist` varchar(100) NOT NULL DEFAULT '',
+  `filename` varchar(100) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `keywords` varchar(255) NOT NULL DEFAULT '',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `content` longtext,
+  PRIMARY KEY (`id`),
+  KEY `filename` (`filename`),
+  KEY `status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='专题表';
+
+CREATE TABLE IF NOT EXISTS `#@__special_content` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `specialid` int(11) NOT NULL DEFAULT '0',
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `sortrank` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `specialid_aid` (`specialid`,`aid`),
+  KEY `aid` (`aid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='专题文章表';
+
+CREATE TABLE IF NOT EXISTS `#@__link_type` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL DEFAULT '',
+  `orderid` int(11) NOT NULL DEFAULT '0',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='友情链接分类表';
+
+CREATE TABLE IF NOT EXISTS `#@__score_rule` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL DEFAULT '',
+  `code` varchar(50) NOT NULL DEFAULT '',
+  `score` int(11) NOT NULL DEFAULT '0',
+  `maxtimes` int(11) NOT NULL DEFAULT '0',
+  `cycletype` tinyint(1) NOT NULL DEFAULT '0',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='积分规则表';
+
+CREATE TABLE IF NOT EXISTS `#@__score_log` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `memberid` int(11) NOT NULL DEFAULT '0',
+  `ruleid` int(11) NOT NULL DEFAULT '0',
+  `score` int(11) NOT NULL DEFAULT '0',
+  `remark` varchar(255) NOT NULL DEFAULT '',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `createtime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `memberid` (`memberid`),
+  KEY `ruleid` (`ruleid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='积分记录表';
+
+CREATE TABLE IF NOT EXISTS `#@__member_msg` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `fromid` int(11) NOT NULL DEFAULT '0',
+  `toid` int(11) NOT NULL DEFAULT '0',
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `content` text,
+  `sendtime` datetime DEFAULT NULL,
+  `readtime` datetime DEFAULT NULL,
+  `isread` tinyint(1) NOT NULL DEFAULT '0',
+  `fromdel` tinyint(1) NOT NULL DEFAULT '0',
+  `todel` tinyint(1) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `fromid` (`fromid`),
+  KEY `toid` (`toid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='会员短消息表';
+
+CREATE TABLE IF NOT EXISTS `#@__search_log` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `keyword` varchar(100) NOT NULL DEFAULT '',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `resultcount` int(11) NOT NULL DEFAULT '0',
+  `searchtime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `keyword` (`keyword`),
+  KEY `searchtime` (`searchtime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='搜索日志表';
+
+CREATE TABLE IF NOT EXISTS `#@__search_keyword` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `keyword` varchar(100) NOT NULL DEFAULT '',
+  `count` int(11) NOT NULL DEFAULT '0',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `keyword` (`keyword`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='搜索关键词表';
+
+CREATE TABLE IF NOT EXISTS `#@__visit` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `useragent` varchar(500) NOT NULL DEFAULT '',
+  `url` varchar(500) NOT NULL DEFAULT '',
+  `referer` varchar(500) NOT NULL DEFAULT '',
+  `memberid` int(11) NOT NULL DEFAULT '0',
+  `sessionid` varchar(64) NOT NULL DEFAULT '',
+  `createtime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `createtime` (`createtime`),
+  KEY `ip` (`ip`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问记录表';
+
+CREATE TABLE IF NOT EXISTS `#@__visit_log` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `memberid` int(11) NOT NULL DEFAULT '0',
+  `articleid` int(11) NOT NULL DEFAULT '0',
+  `categoryid` int(11) NOT NULL DEFAULT '0',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `useragent` varchar(500) NOT NULL DEFAULT '',
+  `referer` varchar(500) NOT NULL DEFAULT '',
+  `url` varchar(500) NOT NULL DEFAULT '',
+  `device` varchar(30) NOT NULL DEFAULT '',
+  `browser` varchar(30) NOT NULL DEFAULT '',
+  `os` varchar(30) NOT NULL DEFAULT '',
+  `region` varchar(50) NOT NULL DEFAULT '',
+  `visittime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `articleid` (`articleid`),
+  KEY `categoryid` (`categoryid`),
+  KEY `visittime` (`visittime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问日志表';
+
+CREATE TABLE IF NOT EXISTS `#@__seo_rule` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL DEFAULT '',
+  `type` tinyint(1) NOT NULL DEFAULT '0',
+  `pattern` varchar(255) NOT NULL DEFAULT '',
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `keywords` varchar(255) NOT NULL DEFAULT '',
+  `description` varchar(500) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `type` (`type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='SEO规则表';
+
+CREATE TABLE IF NOT EXISTS `#@__seo_keyword` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `keyword` varchar(100) NOT NULL DEFAULT '',
+  `url` varchar(255) NOT NULL DEFAULT '',
+  `weight` int(11) NOT NULL DEFAULT '0',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `keyword` (`keyword`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='SEO关键词表';
+
+CREATE TABLE IF NOT EXISTS `#@__plugin` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL DEFAULT '',
+  `code` varchar(50) NOT NULL DEFAULT '',
+  `version` varchar(20) NOT NULL DEFAULT '',
+  `author` varchar(50) NOT NULL DEFAULT '',
+  `description` varchar(500) NOT NULL DEFAULT '',
+  `config` text,
+  `status` tinyint(1) NOT NULL DEFAULT '0',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='插件表';
+
+CREATE TABLE IF NOT EXISTS `#@__plugin_hook` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `pluginid` int(11) NOT NULL DEFAULT '0',
+  `name` varchar(100) NOT NULL DEFAULT '',
+  `code` varchar(50) NOT NULL DEFAULT '',
+  `position` varchar(50) NOT NULL DEFAULT '',
+  `orderid` int(11) NOT NULL DEFAULT '0',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `pluginid` (`pluginid`),
+  KEY `position` (`position`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='插件钩子表';
+
+CREATE TABLE IF NOT EXISTS `#@__language` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL DEFAULT '',
+  `code` varchar(20) NOT NULL DEFAULT '',
+  `flag` varchar(255) NOT NULL DEFAULT '',
+  `isdefault` tinyint(1) NOT NULL DEFAULT '0',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `orderid` int(11) NOT NULL DEFAULT '0',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='语言表';
+
+CREATE TABLE IF NOT EXISTS `#@__language_text` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `languageid` int(11) NOT NULL DEFAULT '0',
+  `key` varchar(100) NOT NULL DEFAULT '',
+  `value` text,
+  `module` varchar(50) NOT NULL DEFAULT '',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `languageid_key` (`languageid`,`key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='语言文本表';
+
+CREATE TABLE IF NOT EXISTS `#@__template` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL DEFAULT '',
+  `path` varchar(255) NOT NULL DEFAULT '',
+  `type` tinyint(1) NOT NULL DEFAULT '0',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='模板表';
+
+CREATE TABLE IF NOT EXISTS `#@__form_data` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `formid` int(11) NOT NULL DEFAULT '0',
+  `data` text,
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '0',
+  `createtime` datetime DEFAULT NULL,
+  `updatetime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `formid` (`formid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='表单数据表';
+
+CREATE TABLE IF NOT EXISTS `#@__diyform` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `title` varchar(100) NOT NULL DEFAULT '',
+  `description` text,
+  `template` varchar(100) NOT NULL DEFAULT '',
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `addtime` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='自定义表单表';
+
+CREATE TABLE IF NOT EXISTS `#@__diyform_field` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `formid` int(11) NOT NULL DEFAULT '0',
+  `fieldname` varchar(50) NOT NULL DEFAULT '',
+  `fieldtype` varchar(20) NOT NULL DEFAULT '',
+  `fieldtitle` varchar(100) NOT NULL DEFAULT '',
+  `fieldvalue` text,
+  `isrequired` tinyint(1) NOT NULL DEFAULT '0',
+  `sortrank` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `formid` (`formid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='自定义表单字段表';
+
+-- 自定义表单字段以列的形式追加到该表
+CREATE TABLE IF NOT EXISTS `#@__diyform_data` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `formid` int(11) NOT NULL DEFAULT '0',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `addtime` datetime DEFAULT NULL,
+  `status` tinyint(1) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `formid` (`formid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='自定义表单数据表';
+
+CREATE TABLE IF NOT EXISTS `#@__addondownload` (
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `softname` varchar(100) NOT NULL DEFAULT '',
+  `softversion` varchar(30) NOT NULL DEFAULT '',
+  `softlanguage` varchar(30) NOT NULL DEFAULT '',
+  `softtype` varchar(30) NOT NULL DEFAULT '',
+  `softsize` varchar(30) NOT NULL DEFAULT '',
+  `softos` varchar(100) NOT NULL DEFAULT '',
+  `softdeveloper` varchar(100) NOT NULL DEFAULT '',
+  `softlicense` varchar(30) NOT NULL DEFAULT '',
+  `softscore` decimal(3,1) NOT NULL DEFAULT '0.0',
+  `softurl` varchar(255) NOT NULL DEFAULT '',
+  `softmirrurl` text,
+  `downcount` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`aid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='下载附加表';
+
+CREATE TABLE IF NOT EXISTS `#@__download_screenshots` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `screenshot` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `aid` (`aid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='下载截图表';
+
+CREATE TABLE IF NOT EXISTS `#@__addonproduct` (
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `productname` varchar(100) NOT NULL DEFAULT '',
+  `productsn` varchar(50) NOT NULL DEFAULT '',
+  `price` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `oldprice` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `units` varchar(20) NOT NULL DEFAULT '',
+  `weight` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `specification` text,
+  `features` text,
+  `parameters` text,
+  `stock` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`aid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='产品附加表';
+
+CREATE TABLE IF NOT EXISTS `#@__product_images` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `image` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `aid` (`aid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='产品图片表';
+
+CREATE TABLE IF NOT EXISTS `#@__myad` (
+  `aid` int(11) NOT NULL AUTO_INCREMENT,
+  `typeid` int(11) NOT NULL DEFAULT '0',
+  `tagname` varchar(30) NOT NULL DEFAULT '',
+  `adname` varchar(60) NOT NULL DEFAULT '',
+  `timeset` tinyint(1) NOT NULL DEFAULT '0',
+  `starttime` int(11) NOT NULL DEFAULT '0',
+  `endtime` int(11) NOT NULL DEFAULT '0',
+  `normbody` text,
+  `expbody` text,
+  `ischeck` tinyint(1) NOT NULL DEFAULT '1',
+  `hits` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`aid`),
+  KEY `tagname` (`tagname`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='自定义广告表';
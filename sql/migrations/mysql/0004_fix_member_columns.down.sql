@@ -0,0 +1 @@
+-- 字段修复不可逆：回滚时保持当前结构，避免截断已有的密码哈希
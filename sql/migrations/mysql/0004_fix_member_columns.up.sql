@@ -0,0 +1,3 @@
+-- 早期版本的会员表 sex 字段为 tinyint，pwd 长度不足以保存 bcrypt 哈希
+ALTER TABLE `#@__member` MODIFY COLUMN `sex` enum('男','女','保密') NOT NULL DEFAULT '保密';
+ALTER TABLE `#@__member` MODIFY COLUMN `pwd` varchar(255) DEFAULT '';
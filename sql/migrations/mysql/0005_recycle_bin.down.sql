@@ -0,0 +1,3 @@
+ALTER TABLE `#@__archives` DROP KEY `deleted_at`;
+ALTER TABLE `#@__archives` DROP COLUMN `deleted_arcrank`;
+ALTER TABLE `#@__archives` DROP COLUMN `deleted_at`;
@@ -0,0 +1,16 @@
+-- 文章修订历史：每次保存文章时记录一个版本
+
+CREATE TABLE IF NOT EXISTS `#@__arcrevision` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `body` longtext,
+  `keywords` varchar(255) NOT NULL DEFAULT '',
+  `flag` varchar(255) NOT NULL DEFAULT '',
+  `adminid` int(11) NOT NULL DEFAULT '0',
+  `mid` int(11) NOT NULL DEFAULT '0',
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `dateline` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `aid` (`aid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文章修订历史表';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `#@__arcworkflow`;
+ALTER TABLE `#@__archives` DROP KEY `state`;
+ALTER TABLE `#@__archives` DROP COLUMN `state`;
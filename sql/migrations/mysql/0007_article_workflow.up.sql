@@ -0,0 +1,18 @@
+-- 文章审核流程：state 0草稿 1待审核 2已审核 3已发布 4已归档
+-- 默认值为已发布，未接入流程的产品、下载等文档保持原有的可见性
+
+ALTER TABLE `#@__archives` ADD COLUMN `state` tinyint(1) NOT NULL DEFAULT '3';
+ALTER TABLE `#@__archives` ADD KEY `state` (`state`);
+UPDATE `#@__archives` SET `state` = 1 WHERE `arcrank` = -1 OR (`arcrank` = -2 AND `deleted_arcrank` = -1);
+
+CREATE TABLE IF NOT EXISTS `#@__arcworkflow` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `fromstate` tinyint(1) NOT NULL DEFAULT '0',
+  `tostate` tinyint(1) NOT NULL DEFAULT '0',
+  `adminid` int(11) NOT NULL DEFAULT '0',
+  `comment` varchar(500) NOT NULL DEFAULT '',
+  `dateline` int(11) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `aid` (`aid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文章审核记录表';
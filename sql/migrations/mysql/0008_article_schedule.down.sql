@@ -0,0 +1,3 @@
+UPDATE `#@__archives` SET `state` = 3 WHERE `state` = 5;
+ALTER TABLE `#@__archives` DROP KEY `expiredate`;
+ALTER TABLE `#@__archives` DROP COLUMN `expiredate`;
@@ -0,0 +1,10 @@
+-- 相关文章：后台根据标题、标签和正文计算的相似文章，sortrank为相似度排名
+
+CREATE TABLE IF NOT EXISTS `#@__arcrelated` (
+  `aid` int(11) NOT NULL DEFAULT '0',
+  `relid` int(11) NOT NULL DEFAULT '0',
+  `score` decimal(6,4) NOT NULL DEFAULT '0.0000',
+  `sortrank` smallint(6) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`aid`,`relid`),
+  KEY `aid_sortrank` (`aid`,`sortrank`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='相关文章表';
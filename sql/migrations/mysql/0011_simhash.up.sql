@@ -0,0 +1,6 @@
+-- 内容指纹：标题和正文的SimHash，用于发现不同来源的重复内容，0表示尚未计算
+-- 采集项目的dupaid为判定重复时对应的已有文档
+
+ALTER TABLE `#@__archives` ADD COLUMN `simhash` bigint(20) NOT NULL DEFAULT '0';
+ALTER TABLE `#@__collect_item` ADD COLUMN `simhash` bigint(20) NOT NULL DEFAULT '0';
+ALTER TABLE `#@__collect_item` ADD COLUMN `dupaid` int(11) NOT NULL DEFAULT '0';
@@ -0,0 +1,7 @@
+-- 标签使用次数不再包含回收站中的文章：移入回收站时减少，恢复时增加，这里按现有数据重新统计
+
+UPDATE `#@__tagindex` SET `count` = (
+  SELECT COUNT(*) FROM `#@__taglist` AS tl
+  INNER JOIN `#@__archives` AS a ON a.`id` = tl.`aid`
+  WHERE tl.`tag` = `#@__tagindex`.`tag` AND a.`arcrank` <> -2
+);
@@ -0,0 +1,213 @@
+-- 初始表结构，与 sql/aq3cms.sql 保持一致
+-- 使用 IF NOT EXISTS，已有安装可以直接将其标记为已应用
+
+CREATE TABLE IF NOT EXISTS "#@__admin" (
+  "id" SERIAL PRIMARY KEY,
+  "username" VARCHAR(50) NOT NULL DEFAULT '',
+  "password" VARCHAR(255) NOT NULL DEFAULT '',
+  "rank" INTEGER NOT NULL DEFAULT 10,
+  "email" VARCHAR(100) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "last_login" TIMESTAMP DEFAULT NULL,
+  "last_ip" VARCHAR(45) DEFAULT '',
+  "login_count" INTEGER NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__admin_username" ON "#@__admin" ("username");
+
+CREATE TABLE IF NOT EXISTS "#@__arctype" (
+  "id" SERIAL PRIMARY KEY,
+  "reid" INTEGER NOT NULL DEFAULT 0,
+  "topid" INTEGER NOT NULL DEFAULT 0,
+  "sortrank" INTEGER NOT NULL DEFAULT 50,
+  "typename" VARCHAR(60) NOT NULL DEFAULT '',
+  "typedir" VARCHAR(60) NOT NULL DEFAULT '',
+  "isdefault" SMALLINT NOT NULL DEFAULT 0,
+  "defaultname" VARCHAR(60) NOT NULL DEFAULT 'index.html',
+  "issend" SMALLINT NOT NULL DEFAULT 1,
+  "channeltype" INTEGER NOT NULL DEFAULT 1,
+  "maxpage" INTEGER NOT NULL DEFAULT -1,
+  "ispart" SMALLINT NOT NULL DEFAULT 0,
+  "corank" INTEGER NOT NULL DEFAULT 0,
+  "tempindex" VARCHAR(60) NOT NULL DEFAULT '',
+  "templist" VARCHAR(60) NOT NULL DEFAULT '',
+  "temparticle" VARCHAR(60) NOT NULL DEFAULT '',
+  "namerule" VARCHAR(60) NOT NULL DEFAULT '',
+  "namerule2" VARCHAR(60) NOT NULL DEFAULT '',
+  "modname" VARCHAR(20) NOT NULL DEFAULT '',
+  "description" TEXT,
+  "keywords" VARCHAR(60) NOT NULL DEFAULT '',
+  "seotitle" VARCHAR(80) NOT NULL DEFAULT '',
+  "moresite" SMALLINT NOT NULL DEFAULT 0,
+  "sitepath" VARCHAR(60) NOT NULL DEFAULT '',
+  "siteurl" VARCHAR(200) NOT NULL DEFAULT '',
+  "ishidden" SMALLINT NOT NULL DEFAULT 0,
+  "cross" SMALLINT NOT NULL DEFAULT 0,
+  "crossid" TEXT,
+  "content" TEXT,
+  "smalltypes" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__arctype_reid" ON "#@__arctype" ("reid");
+CREATE INDEX IF NOT EXISTS "#@__arctype_topid" ON "#@__arctype" ("topid");
+CREATE INDEX IF NOT EXISTS "#@__arctype_sortrank" ON "#@__arctype" ("sortrank");
+
+CREATE TABLE IF NOT EXISTS "#@__archives" (
+  "id" SERIAL PRIMARY KEY,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "typeid2" VARCHAR(90) NOT NULL DEFAULT '0',
+  "sortrank" INTEGER NOT NULL DEFAULT 0,
+  "flag" VARCHAR(30) DEFAULT NULL,
+  "ismake" SMALLINT NOT NULL DEFAULT 0,
+  "channel" INTEGER NOT NULL DEFAULT 1,
+  "arcrank" INTEGER NOT NULL DEFAULT 0,
+  "click" INTEGER NOT NULL DEFAULT 0,
+  "money" INTEGER NOT NULL DEFAULT 0,
+  "title" VARCHAR(60) NOT NULL DEFAULT '',
+  "shorttitle" VARCHAR(36) NOT NULL DEFAULT '',
+  "color" VARCHAR(7) NOT NULL DEFAULT '',
+  "writer" VARCHAR(30) NOT NULL DEFAULT '',
+  "source" VARCHAR(30) NOT NULL DEFAULT '',
+  "litpic" VARCHAR(60) NOT NULL DEFAULT '',
+  "pubdate" INTEGER NOT NULL DEFAULT 0,
+  "senddate" INTEGER NOT NULL DEFAULT 0,
+  "mid" INTEGER NOT NULL DEFAULT 0,
+  "keywords" VARCHAR(60) NOT NULL DEFAULT '',
+  "lastpost" INTEGER NOT NULL DEFAULT 0,
+  "scores" INTEGER NOT NULL DEFAULT 0,
+  "goodpost" INTEGER NOT NULL DEFAULT 0,
+  "badpost" INTEGER NOT NULL DEFAULT 0,
+  "voteid" INTEGER NOT NULL DEFAULT 0,
+  "notpost" SMALLINT NOT NULL DEFAULT 0,
+  "description" VARCHAR(250) NOT NULL DEFAULT '',
+  "filename" VARCHAR(60) NOT NULL DEFAULT '',
+  "dutyadmin" INTEGER NOT NULL DEFAULT 0,
+  "tackid" INTEGER NOT NULL DEFAULT 0,
+  "mtype" INTEGER NOT NULL DEFAULT 0,
+  "weight" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__archives_typeid" ON "#@__archives" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__archives_sortrank" ON "#@__archives" ("sortrank");
+CREATE INDEX IF NOT EXISTS "#@__archives_pubdate" ON "#@__archives" ("pubdate");
+CREATE INDEX IF NOT EXISTS "#@__archives_click" ON "#@__archives" ("click");
+CREATE INDEX IF NOT EXISTS "#@__archives_arcrank" ON "#@__archives" ("arcrank");
+
+CREATE TABLE IF NOT EXISTS "#@__addonarticle" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "body" TEXT,
+  "redirecturl" VARCHAR(255) NOT NULL DEFAULT '',
+  "templet" VARCHAR(30) NOT NULL DEFAULT '',
+  "userip" VARCHAR(15) NOT NULL DEFAULT '',
+  PRIMARY KEY ("aid")
+);
+CREATE INDEX IF NOT EXISTS "#@__addonarticle_typeid" ON "#@__addonarticle" ("typeid");
+
+CREATE TABLE IF NOT EXISTS "#@__feedback" (
+  "id" SERIAL PRIMARY KEY,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "username" VARCHAR(50) NOT NULL DEFAULT '',
+  "mid" INTEGER NOT NULL DEFAULT 0,
+  "ip" VARCHAR(15) NOT NULL DEFAULT '',
+  "ischeck" SMALLINT NOT NULL DEFAULT 0,
+  "dtime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "content" TEXT NOT NULL,
+  "parentid" INTEGER NOT NULL DEFAULT 0,
+  "score" INTEGER NOT NULL DEFAULT 0,
+  "goodcount" INTEGER NOT NULL DEFAULT 0,
+  "badcount" INTEGER NOT NULL DEFAULT 0,
+  "userface" VARCHAR(255) NOT NULL DEFAULT '',
+  "channeltype" SMALLINT NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_aid" ON "#@__feedback" ("aid");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_typeid" ON "#@__feedback" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_mid" ON "#@__feedback" ("mid");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_ischeck" ON "#@__feedback" ("ischeck");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_dtime" ON "#@__feedback" ("dtime");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_parentid" ON "#@__feedback" ("parentid");
+
+CREATE TABLE IF NOT EXISTS "#@__flink" (
+  "id" SERIAL PRIMARY KEY,
+  "sortrank" SMALLINT NOT NULL DEFAULT 0,
+  "url" VARCHAR(255) NOT NULL DEFAULT '',
+  "webname" VARCHAR(60) NOT NULL DEFAULT '',
+  "msg" TEXT,
+  "email" VARCHAR(60) NOT NULL DEFAULT '',
+  "typeid" SMALLINT NOT NULL DEFAULT 0,
+  "logo" VARCHAR(255) NOT NULL DEFAULT '',
+  "ischeck" SMALLINT NOT NULL DEFAULT 0,
+  "dtime" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__flink_sortrank" ON "#@__flink" ("sortrank");
+CREATE INDEX IF NOT EXISTS "#@__flink_typeid" ON "#@__flink" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__flink_ischeck" ON "#@__flink" ("ischeck");
+
+CREATE TABLE IF NOT EXISTS "#@__member" (
+  "mid" SERIAL PRIMARY KEY,
+  "mtype" VARCHAR(20) NOT NULL DEFAULT '',
+  "userid" VARCHAR(20) NOT NULL DEFAULT '',
+  "pwd" VARCHAR(255) DEFAULT '',
+  "uname" VARCHAR(36) NOT NULL DEFAULT '',
+  "sex" VARCHAR(10) NOT NULL DEFAULT '保密',
+  "rank" INTEGER NOT NULL DEFAULT 10,
+  "money" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "email" VARCHAR(50) NOT NULL DEFAULT '',
+  "scores" INTEGER NOT NULL DEFAULT 0,
+  "matt" SMALLINT NOT NULL DEFAULT 0,
+  "spacesta" SMALLINT NOT NULL DEFAULT 0,
+  "face" VARCHAR(50) NOT NULL DEFAULT '',
+  "safequestion" SMALLINT NOT NULL DEFAULT 0,
+  "safeanswer" VARCHAR(30) NOT NULL DEFAULT '',
+  "jointime" INTEGER NOT NULL DEFAULT 0,
+  "joinip" VARCHAR(16) NOT NULL DEFAULT '',
+  "logintime" INTEGER NOT NULL DEFAULT 0,
+  "loginip" VARCHAR(16) NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS "#@__member_userid" ON "#@__member" ("userid");
+
+CREATE TABLE IF NOT EXISTS "#@__member_type" (
+  "id" SERIAL PRIMARY KEY,
+  "typename" VARCHAR(50) NOT NULL DEFAULT '',
+  "description" TEXT,
+  "rank" INTEGER NOT NULL DEFAULT 10,
+  "money" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "scores" INTEGER NOT NULL DEFAULT 0,
+  "purviews" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__member_type_idx_rank" ON "#@__member_type" ("rank");
+
+CREATE TABLE IF NOT EXISTS "#@__sysconfig" (
+  "id" SERIAL PRIMARY KEY,
+  "varname" VARCHAR(20) NOT NULL DEFAULT '',
+  "info" VARCHAR(100) NOT NULL DEFAULT '',
+  "groupid" SMALLINT NOT NULL DEFAULT 1,
+  "type" VARCHAR(10) NOT NULL DEFAULT 'string',
+  "value" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__sysconfig_varname" ON "#@__sysconfig" ("varname");
+
+CREATE TABLE IF NOT EXISTS "#@__tagindex" (
+  "id" SERIAL PRIMARY KEY,
+  "tag" VARCHAR(30) NOT NULL DEFAULT '',
+  "count" INTEGER NOT NULL DEFAULT 0,
+  "rank" INTEGER NOT NULL DEFAULT 0,
+  "ishot" SMALLINT NOT NULL DEFAULT 0,
+  "addtime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "lastuse" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "tagpinyin" VARCHAR(100) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__tagindex_tag" ON "#@__tagindex" ("tag");
+CREATE INDEX IF NOT EXISTS "#@__tagindex_rank" ON "#@__tagindex" ("rank");
+CREATE INDEX IF NOT EXISTS "#@__tagindex_ishot" ON "#@__tagindex" ("ishot");
+CREATE INDEX IF NOT EXISTS "#@__tagindex_count" ON "#@__tagindex" ("count");
+
+CREATE TABLE IF NOT EXISTS "#@__taglist" (
+  "id" SERIAL PRIMARY KEY,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "tag" VARCHAR(30) NOT NULL DEFAULT '',
+  "addtime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__taglist_aid_tag" ON "#@__taglist" ("aid","tag");
+CREATE INDEX IF NOT EXISTS "#@__taglist_aid" ON "#@__taglist" ("aid");
+CREATE INDEX IF NOT EXISTS "#@__taglist_tag" ON "#@__taglist" ("tag");
@@ -0,0 +1,160 @@
+-- 采集、支付、投票、广告、内容模型所需的表
+
+CREATE TABLE IF NOT EXISTS "#@__collect_rule" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(100) NOT NULL DEFAULT '',
+  "sourcetype" SMALLINT NOT NULL DEFAULT 0,
+  "sourceurl" VARCHAR(255) NOT NULL DEFAULT '',
+  "startpage" INTEGER NOT NULL DEFAULT 1,
+  "endpage" INTEGER NOT NULL DEFAULT 1,
+  "pagerule" VARCHAR(255) NOT NULL DEFAULT '',
+  "listrule" TEXT,
+  "titlerule" TEXT,
+  "contentrule" TEXT,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "modelid" INTEGER NOT NULL DEFAULT 0,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "lasttime" TIMESTAMP DEFAULT NULL,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL,
+  "fieldrules" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__collect_rule_typeid" ON "#@__collect_rule" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__collect_rule_status" ON "#@__collect_rule" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__collect_item" (
+  "id" SERIAL PRIMARY KEY,
+  "ruleid" INTEGER NOT NULL DEFAULT 0,
+  "title" VARCHAR(255) NOT NULL DEFAULT '',
+  "url" VARCHAR(500) NOT NULL DEFAULT '',
+  "content" TEXT,
+  "status" SMALLINT NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL,
+  "fielddata" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__collect_item_ruleid" ON "#@__collect_item" ("ruleid");
+CREATE INDEX IF NOT EXISTS "#@__collect_item_url" ON "#@__collect_item" ("url");
+CREATE INDEX IF NOT EXISTS "#@__collect_item_status" ON "#@__collect_item" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__payment_method" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(50) NOT NULL DEFAULT '',
+  "code" VARCHAR(30) NOT NULL DEFAULT '',
+  "description" VARCHAR(255) NOT NULL DEFAULT '',
+  "config" TEXT,
+  "icon" VARCHAR(255) NOT NULL DEFAULT '',
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__payment_method_code" ON "#@__payment_method" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__payment_order" (
+  "id" SERIAL PRIMARY KEY,
+  "orderno" VARCHAR(64) NOT NULL DEFAULT '',
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "amount" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "paymentmethod" VARCHAR(30) NOT NULL DEFAULT '',
+  "paymentorderno" VARCHAR(64) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 0,
+  "type" SMALLINT NOT NULL DEFAULT 0,
+  "relatedid" INTEGER NOT NULL DEFAULT 0,
+  "relatedtype" VARCHAR(30) NOT NULL DEFAULT '',
+  "remark" VARCHAR(255) NOT NULL DEFAULT '',
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL,
+  "paytime" TIMESTAMP DEFAULT NULL,
+  "extradata" TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__payment_order_orderno" ON "#@__payment_order" ("orderno");
+CREATE INDEX IF NOT EXISTS "#@__payment_order_memberid" ON "#@__payment_order" ("memberid");
+CREATE INDEX IF NOT EXISTS "#@__payment_order_status" ON "#@__payment_order" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__vote" (
+  "id" SERIAL PRIMARY KEY,
+  "title" VARCHAR(255) NOT NULL DEFAULT '',
+  "description" TEXT,
+  "starttime" TIMESTAMP DEFAULT NULL,
+  "endtime" TIMESTAMP DEFAULT NULL,
+  "ismulti" SMALLINT NOT NULL DEFAULT 0,
+  "maxchoices" INTEGER NOT NULL DEFAULT 1,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "totalcount" INTEGER NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__vote_status" ON "#@__vote" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__vote_option" (
+  "id" SERIAL PRIMARY KEY,
+  "voteid" INTEGER NOT NULL DEFAULT 0,
+  "title" VARCHAR(255) NOT NULL DEFAULT '',
+  "count" INTEGER NOT NULL DEFAULT 0,
+  "orderid" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__vote_option_voteid" ON "#@__vote_option" ("voteid");
+
+CREATE TABLE IF NOT EXISTS "#@__vote_log" (
+  "id" SERIAL PRIMARY KEY,
+  "voteid" INTEGER NOT NULL DEFAULT 0,
+  "optionid" INTEGER NOT NULL DEFAULT 0,
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "createtime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__vote_log_voteid" ON "#@__vote_log" ("voteid");
+CREATE INDEX IF NOT EXISTS "#@__vote_log_memberid" ON "#@__vote_log" ("memberid");
+CREATE INDEX IF NOT EXISTS "#@__vote_log_ip" ON "#@__vote_log" ("ip");
+
+CREATE TABLE IF NOT EXISTS "#@__ad_position" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(100) NOT NULL DEFAULT '',
+  "code" VARCHAR(50) NOT NULL DEFAULT '',
+  "width" INTEGER NOT NULL DEFAULT 0,
+  "height" INTEGER NOT NULL DEFAULT 0,
+  "template" TEXT,
+  "description" VARCHAR(255) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__ad_position_code" ON "#@__ad_position" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__ad" (
+  "id" SERIAL PRIMARY KEY,
+  "positionid" INTEGER NOT NULL DEFAULT 0,
+  "title" VARCHAR(255) NOT NULL DEFAULT '',
+  "type" SMALLINT NOT NULL DEFAULT 0,
+  "image" VARCHAR(255) NOT NULL DEFAULT '',
+  "flash" VARCHAR(255) NOT NULL DEFAULT '',
+  "code" TEXT,
+  "text" VARCHAR(255) NOT NULL DEFAULT '',
+  "url" VARCHAR(255) NOT NULL DEFAULT '',
+  "starttime" TIMESTAMP DEFAULT NULL,
+  "endtime" TIMESTAMP DEFAULT NULL,
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "target" VARCHAR(10) NOT NULL DEFAULT '_blank',
+  "width" INTEGER NOT NULL DEFAULT 0,
+  "height" INTEGER NOT NULL DEFAULT 0,
+  "click" INTEGER NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__ad_positionid" ON "#@__ad" ("positionid");
+CREATE INDEX IF NOT EXISTS "#@__ad_status" ON "#@__ad" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__content_model" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(50) NOT NULL DEFAULT '',
+  "tablename" VARCHAR(50) NOT NULL DEFAULT '',
+  "description" VARCHAR(255) NOT NULL DEFAULT '',
+  "state" SMALLINT NOT NULL DEFAULT 1,
+  "fields" TEXT,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__content_model_tablename" ON "#@__content_model" ("tablename");
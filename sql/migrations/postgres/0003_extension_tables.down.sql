@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS "#@__myad";
+DROP TABLE IF EXISTS "#@__product_images";
+DROP TABLE IF EXISTS "#@__addonproduct";
+DROP TABLE IF EXISTS "#@__download_screenshots";
+DROP TABLE IF EXISTS "#@__addondownload";
+DROP TABLE IF EXISTS "#@__diyform_data";
+DROP TABLE IF EXISTS "#@__diyform_field";
+DROP TABLE IF EXISTS "#@__diyform";
+DROP TABLE IF EXISTS "#@__form_data";
+DROP TABLE IF EXISTS "#@__template";
+DROP TABLE IF EXISTS "#@__language_text";
+DROP TABLE IF EXISTS "#@__language";
+DROP TABLE IF EXISTS "#@__plugin_hook";
+DROP TABLE IF EXISTS "#@__plugin";
+DROP TABLE IF EXISTS "#@__seo_keyword";
+DROP TABLE IF EXISTS "#@__seo_rule";
+DROP TABLE IF EXISTS "#@__visit_log";
+DROP TABLE IF EXISTS "#@__visit";
+DROP TABLE IF EXISTS "#@__search_keyword";
+DROP TABLE IF EXISTS "#@__search_log";
+DROP TABLE IF EXISTS "#@__member_msg";
+DROP TABLE IF EXISTS "#@__score_log";
+DROP TABLE IF EXISTS "#@__score_rule";
+DROP TABLE IF EXISTS "#@__link_type";
+DROP TABLE IF EXISTS "#@__special_content";
+DROP TABLE IF EXISTS "#@__special";
@@ -0,0 +1,323 @@
+-- 专题、友情链接分类、积分、短消息、统计、SEO、插件、多语言、模板、表单、下载和产品模型所需的表
+
+CREATE TABLE IF NOT EXISTS "#@__special" (
+  "id" SERIAL PRIMARY KEY,
+  "title" VARCHAR(255) NOT NULL DEFAULT '',
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "note" TEXT,
+  "pic" VARCHAR(255) NOT NULL DEFAULT '',
+  "pubdate" TIMESTAMP DEFAULT NULL,
+  "lastupdate" TIMESTAMP DEFAULT NULL,
+  "ishot" SMALLINT NOT NULL DEFAULT 0,
+  "click" INTEGER NOT NULL DEFAULT 0,
+  "template" VARCHAR(100) NOT NULL DEFAULT '',
+  "templatelist" VARCHAR(100) NOT NULL DEFAULT '',
+  "filename" VARCHAR(100) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "keywords" VARCHAR(255) NOT NULL DEFAULT '',
+  "description" VARCHAR(255) NOT NULL DEFAULT '',
+  "content" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__special_filename" ON "#@__special" ("filename");
+CREATE INDEX IF NOT EXISTS "#@__special_status" ON "#@__special" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__special_content" (
+  "id" SERIAL PRIMARY KEY,
+  "specialid" INTEGER NOT NULL DEFAULT 0,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "sortrank" INTEGER NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__special_content_specialid_aid" ON "#@__special_content" ("specialid","aid");
+CREATE INDEX IF NOT EXISTS "#@__special_content_aid" ON "#@__special_content" ("aid");
+
+CREATE TABLE IF NOT EXISTS "#@__link_type" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(50) NOT NULL DEFAULT '',
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "#@__score_rule" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(50) NOT NULL DEFAULT '',
+  "code" VARCHAR(50) NOT NULL DEFAULT '',
+  "score" INTEGER NOT NULL DEFAULT 0,
+  "maxtimes" INTEGER NOT NULL DEFAULT 0,
+  "cycletype" SMALLINT NOT NULL DEFAULT 0,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "description" VARCHAR(255) NOT NULL DEFAULT '',
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__score_rule_code" ON "#@__score_rule" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__score_log" (
+  "id" SERIAL PRIMARY KEY,
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "ruleid" INTEGER NOT NULL DEFAULT 0,
+  "score" INTEGER NOT NULL DEFAULT 0,
+  "remark" VARCHAR(255) NOT NULL DEFAULT '',
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "createtime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__score_log_memberid" ON "#@__score_log" ("memberid");
+CREATE INDEX IF NOT EXISTS "#@__score_log_ruleid" ON "#@__score_log" ("ruleid");
+
+CREATE TABLE IF NOT EXISTS "#@__member_msg" (
+  "id" SERIAL PRIMARY KEY,
+  "fromid" INTEGER NOT NULL DEFAULT 0,
+  "toid" INTEGER NOT NULL DEFAULT 0,
+  "title" VARCHAR(255) NOT NULL DEFAULT '',
+  "content" TEXT,
+  "sendtime" TIMESTAMP DEFAULT NULL,
+  "readtime" TIMESTAMP DEFAULT NULL,
+  "isread" SMALLINT NOT NULL DEFAULT 0,
+  "fromdel" SMALLINT NOT NULL DEFAULT 0,
+  "todel" SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__member_msg_fromid" ON "#@__member_msg" ("fromid");
+CREATE INDEX IF NOT EXISTS "#@__member_msg_toid" ON "#@__member_msg" ("toid");
+
+CREATE TABLE IF NOT EXISTS "#@__search_log" (
+  "id" SERIAL PRIMARY KEY,
+  "keyword" VARCHAR(100) NOT NULL DEFAULT '',
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "resultcount" INTEGER NOT NULL DEFAULT 0,
+  "searchtime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__search_log_keyword" ON "#@__search_log" ("keyword");
+CREATE INDEX IF NOT EXISTS "#@__search_log_searchtime" ON "#@__search_log" ("searchtime");
+
+CREATE TABLE IF NOT EXISTS "#@__search_keyword" (
+  "id" SERIAL PRIMARY KEY,
+  "keyword" VARCHAR(100) NOT NULL DEFAULT '',
+  "count" INTEGER NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__search_keyword_keyword" ON "#@__search_keyword" ("keyword");
+
+CREATE TABLE IF NOT EXISTS "#@__visit" (
+  "id" SERIAL PRIMARY KEY,
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "useragent" VARCHAR(500) NOT NULL DEFAULT '',
+  "url" VARCHAR(500) NOT NULL DEFAULT '',
+  "referer" VARCHAR(500) NOT NULL DEFAULT '',
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "sessionid" VARCHAR(64) NOT NULL DEFAULT '',
+  "createtime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__visit_createtime" ON "#@__visit" ("createtime");
+CREATE INDEX IF NOT EXISTS "#@__visit_ip" ON "#@__visit" ("ip");
+
+CREATE TABLE IF NOT EXISTS "#@__visit_log" (
+  "id" SERIAL PRIMARY KEY,
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "articleid" INTEGER NOT NULL DEFAULT 0,
+  "categoryid" INTEGER NOT NULL DEFAULT 0,
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "useragent" VARCHAR(500) NOT NULL DEFAULT '',
+  "referer" VARCHAR(500) NOT NULL DEFAULT '',
+  "url" VARCHAR(500) NOT NULL DEFAULT '',
+  "device" VARCHAR(30) NOT NULL DEFAULT '',
+  "browser" VARCHAR(30) NOT NULL DEFAULT '',
+  "os" VARCHAR(30) NOT NULL DEFAULT '',
+  "region" VARCHAR(50) NOT NULL DEFAULT '',
+  "visittime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__visit_log_articleid" ON "#@__visit_log" ("articleid");
+CREATE INDEX IF NOT EXISTS "#@__visit_log_categoryid" ON "#@__visit_log" ("categoryid");
+CREATE INDEX IF NOT EXISTS "#@__visit_log_visittime" ON "#@__visit_log" ("visittime");
+
+CREATE TABLE IF NOT EXISTS "#@__seo_rule" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(50) NOT NULL DEFAULT '',
+  "type" SMALLINT NOT NULL DEFAULT 0,
+  "pattern" VARCHAR(255) NOT NULL DEFAULT '',
+  "title" VARCHAR(255) NOT NULL DEFAULT '',
+  "keywords" VARCHAR(255) NOT NULL DEFAULT '',
+  "description" VARCHAR(500) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__seo_rule_type" ON "#@__seo_rule" ("type");
+
+CREATE TABLE IF NOT EXISTS "#@__seo_keyword" (
+  "id" SERIAL PRIMARY KEY,
+  "keyword" VARCHAR(100) NOT NULL DEFAULT '',
+  "url" VARCHAR(255) NOT NULL DEFAULT '',
+  "weight" INTEGER NOT NULL DEFAULT 0,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__seo_keyword_keyword" ON "#@__seo_keyword" ("keyword");
+
+CREATE TABLE IF NOT EXISTS "#@__plugin" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(100) NOT NULL DEFAULT '',
+  "code" VARCHAR(50) NOT NULL DEFAULT '',
+  "version" VARCHAR(20) NOT NULL DEFAULT '',
+  "author" VARCHAR(50) NOT NULL DEFAULT '',
+  "description" VARCHAR(500) NOT NULL DEFAULT '',
+  "config" TEXT,
+  "status" SMALLINT NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__plugin_code" ON "#@__plugin" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__plugin_hook" (
+  "id" SERIAL PRIMARY KEY,
+  "pluginid" INTEGER NOT NULL DEFAULT 0,
+  "name" VARCHAR(100) NOT NULL DEFAULT '',
+  "code" VARCHAR(50) NOT NULL DEFAULT '',
+  "position" VARCHAR(50) NOT NULL DEFAULT '',
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__plugin_hook_pluginid" ON "#@__plugin_hook" ("pluginid");
+CREATE INDEX IF NOT EXISTS "#@__plugin_hook_position" ON "#@__plugin_hook" ("position");
+
+CREATE TABLE IF NOT EXISTS "#@__language" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(50) NOT NULL DEFAULT '',
+  "code" VARCHAR(20) NOT NULL DEFAULT '',
+  "flag" VARCHAR(255) NOT NULL DEFAULT '',
+  "isdefault" SMALLINT NOT NULL DEFAULT 0,
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__language_code" ON "#@__language" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__language_text" (
+  "id" SERIAL PRIMARY KEY,
+  "languageid" INTEGER NOT NULL DEFAULT 0,
+  "key" VARCHAR(100) NOT NULL DEFAULT '',
+  "value" TEXT,
+  "module" VARCHAR(50) NOT NULL DEFAULT '',
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__language_text_languageid_key" ON "#@__language_text" ("languageid","key");
+
+CREATE TABLE IF NOT EXISTS "#@__template" (
+  "id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(100) NOT NULL DEFAULT '',
+  "path" VARCHAR(255) NOT NULL DEFAULT '',
+  "type" SMALLINT NOT NULL DEFAULT 0,
+  "description" VARCHAR(255) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "#@__form_data" (
+  "id" SERIAL PRIMARY KEY,
+  "formid" INTEGER NOT NULL DEFAULT 0,
+  "data" TEXT,
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 0,
+  "createtime" TIMESTAMP DEFAULT NULL,
+  "updatetime" TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__form_data_formid" ON "#@__form_data" ("formid");
+
+CREATE TABLE IF NOT EXISTS "#@__diyform" (
+  "id" SERIAL PRIMARY KEY,
+  "title" VARCHAR(100) NOT NULL DEFAULT '',
+  "description" TEXT,
+  "template" VARCHAR(100) NOT NULL DEFAULT '',
+  "status" SMALLINT NOT NULL DEFAULT 1,
+  "addtime" TIMESTAMP DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "#@__diyform_field" (
+  "id" SERIAL PRIMARY KEY,
+  "formid" INTEGER NOT NULL DEFAULT 0,
+  "fieldname" VARCHAR(50) NOT NULL DEFAULT '',
+  "fieldtype" VARCHAR(20) NOT NULL DEFAULT '',
+  "fieldtitle" VARCHAR(100) NOT NULL DEFAULT '',
+  "fieldvalue" TEXT,
+  "isrequired" SMALLINT NOT NULL DEFAULT 0,
+  "sortrank" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__diyform_field_formid" ON "#@__diyform_field" ("formid");
+
+-- 自定义表单字段以列的形式追加到该表
+
+CREATE TABLE IF NOT EXISTS "#@__diyform_data" (
+  "id" SERIAL PRIMARY KEY,
+  "formid" INTEGER NOT NULL DEFAULT 0,
+  "ip" VARCHAR(45) NOT NULL DEFAULT '',
+  "addtime" TIMESTAMP DEFAULT NULL,
+  "status" SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__diyform_data_formid" ON "#@__diyform_data" ("formid");
+
+CREATE TABLE IF NOT EXISTS "#@__addondownload" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "softname" VARCHAR(100) NOT NULL DEFAULT '',
+  "softversion" VARCHAR(30) NOT NULL DEFAULT '',
+  "softlanguage" VARCHAR(30) NOT NULL DEFAULT '',
+  "softtype" VARCHAR(30) NOT NULL DEFAULT '',
+  "softsize" VARCHAR(30) NOT NULL DEFAULT '',
+  "softos" VARCHAR(100) NOT NULL DEFAULT '',
+  "softdeveloper" VARCHAR(100) NOT NULL DEFAULT '',
+  "softlicense" VARCHAR(30) NOT NULL DEFAULT '',
+  "softscore" DECIMAL(3,1) NOT NULL DEFAULT '0.0',
+  "softurl" VARCHAR(255) NOT NULL DEFAULT '',
+  "softmirrurl" TEXT,
+  "downcount" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("aid")
+);
+
+CREATE TABLE IF NOT EXISTS "#@__download_screenshots" (
+  "id" SERIAL PRIMARY KEY,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "screenshot" VARCHAR(255) NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS "#@__download_screenshots_aid" ON "#@__download_screenshots" ("aid");
+
+CREATE TABLE IF NOT EXISTS "#@__addonproduct" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "productname" VARCHAR(100) NOT NULL DEFAULT '',
+  "productsn" VARCHAR(50) NOT NULL DEFAULT '',
+  "price" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "oldprice" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "units" VARCHAR(20) NOT NULL DEFAULT '',
+  "weight" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "specification" TEXT,
+  "features" TEXT,
+  "parameters" TEXT,
+  "stock" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("aid")
+);
+
+CREATE TABLE IF NOT EXISTS "#@__product_images" (
+  "id" SERIAL PRIMARY KEY,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "image" VARCHAR(255) NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS "#@__product_images_aid" ON "#@__product_images" ("aid");
+
+CREATE TABLE IF NOT EXISTS "#@__myad" (
+  "aid" SERIAL PRIMARY KEY,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "tagname" VARCHAR(30) NOT NULL DEFAULT '',
+  "adname" VARCHAR(60) NOT NULL DEFAULT '',
+  "timeset" SMALLINT NOT NULL DEFAULT 0,
+  "starttime" INTEGER NOT NULL DEFAULT 0,
+  "endtime" INTEGER NOT NULL DEFAULT 0,
+  "normbody" TEXT,
+  "expbody" TEXT,
+  "ischeck" SMALLINT NOT NULL DEFAULT 1,
+  "hits" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__myad_tagname" ON "#@__myad" ("tagname");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "#@__archives_deleted_at";
+ALTER TABLE "#@__archives" DROP COLUMN "deleted_arcrank";
+ALTER TABLE "#@__archives" DROP COLUMN "deleted_at";
@@ -0,0 +1,17 @@
+-- 文章审核流程：state 0草稿 1待审核 2已审核 3已发布 4已归档
+-- 默认值为已发布，未接入流程的产品、下载等文档保持原有的可见性
+
+ALTER TABLE "#@__archives" ADD COLUMN "state" SMALLINT NOT NULL DEFAULT 3;
+CREATE INDEX IF NOT EXISTS "#@__archives_state" ON "#@__archives" ("state");
+UPDATE "#@__archives" SET "state" = 1 WHERE "arcrank" = -1 OR ("arcrank" = -2 AND "deleted_arcrank" = -1);
+
+CREATE TABLE IF NOT EXISTS "#@__arcworkflow" (
+  "id" SERIAL PRIMARY KEY,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "fromstate" SMALLINT NOT NULL DEFAULT 0,
+  "tostate" SMALLINT NOT NULL DEFAULT 0,
+  "adminid" INTEGER NOT NULL DEFAULT 0,
+  "comment" VARCHAR(500) NOT NULL DEFAULT '',
+  "dateline" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__arcworkflow_aid" ON "#@__arcworkflow" ("aid");
@@ -0,0 +1,5 @@
+-- 定时发布和到期下线：state 5为定时发布，pubdate到达后自动发布；expiredate为下线时间，0表示不下线
+
+ALTER TABLE "#@__archives" ADD COLUMN "expiredate" INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS "#@__archives_expiredate" ON "#@__archives" ("expiredate");
+UPDATE "#@__archives" SET "state" = 5 WHERE "state" = 3 AND "pubdate" > EXTRACT(EPOCH FROM NOW());
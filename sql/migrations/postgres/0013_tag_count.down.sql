@@ -0,0 +1 @@
+-- 重新统计的使用次数与原有数据一致，回滚时不需要修改
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "#@__taglist";
+DROP TABLE IF EXISTS "#@__tagindex";
+DROP TABLE IF EXISTS "#@__sysconfig";
+DROP TABLE IF EXISTS "#@__member_type";
+DROP TABLE IF EXISTS "#@__member";
+DROP TABLE IF EXISTS "#@__flink";
+DROP TABLE IF EXISTS "#@__feedback";
+DROP TABLE IF EXISTS "#@__addonarticle";
+DROP TABLE IF EXISTS "#@__archives";
+DROP TABLE IF EXISTS "#@__arctype";
+DROP TABLE IF EXISTS "#@__admin";
@@ -0,0 +1,213 @@
+-- 初始表结构，与 sql/aq3cms.sql 保持一致
+-- 使用 IF NOT EXISTS，已有安装可以直接将其标记为已应用
+
+CREATE TABLE IF NOT EXISTS "#@__admin" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "username" TEXT NOT NULL DEFAULT '',
+  "password" TEXT NOT NULL DEFAULT '',
+  "rank" INTEGER NOT NULL DEFAULT 10,
+  "email" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "last_login" DATETIME DEFAULT NULL,
+  "last_ip" TEXT DEFAULT '',
+  "login_count" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__admin_username" ON "#@__admin" ("username");
+
+CREATE TABLE IF NOT EXISTS "#@__arctype" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "reid" INTEGER NOT NULL DEFAULT 0,
+  "topid" INTEGER NOT NULL DEFAULT 0,
+  "sortrank" INTEGER NOT NULL DEFAULT 50,
+  "typename" TEXT NOT NULL DEFAULT '',
+  "typedir" TEXT NOT NULL DEFAULT '',
+  "isdefault" INTEGER NOT NULL DEFAULT 0,
+  "defaultname" TEXT NOT NULL DEFAULT 'index.html',
+  "issend" INTEGER NOT NULL DEFAULT 1,
+  "channeltype" INTEGER NOT NULL DEFAULT 1,
+  "maxpage" INTEGER NOT NULL DEFAULT -1,
+  "ispart" INTEGER NOT NULL DEFAULT 0,
+  "corank" INTEGER NOT NULL DEFAULT 0,
+  "tempindex" TEXT NOT NULL DEFAULT '',
+  "templist" TEXT NOT NULL DEFAULT '',
+  "temparticle" TEXT NOT NULL DEFAULT '',
+  "namerule" TEXT NOT NULL DEFAULT '',
+  "namerule2" TEXT NOT NULL DEFAULT '',
+  "modname" TEXT NOT NULL DEFAULT '',
+  "description" TEXT,
+  "keywords" TEXT NOT NULL DEFAULT '',
+  "seotitle" TEXT NOT NULL DEFAULT '',
+  "moresite" INTEGER NOT NULL DEFAULT 0,
+  "sitepath" TEXT NOT NULL DEFAULT '',
+  "siteurl" TEXT NOT NULL DEFAULT '',
+  "ishidden" INTEGER NOT NULL DEFAULT 0,
+  "cross" INTEGER NOT NULL DEFAULT 0,
+  "crossid" TEXT,
+  "content" TEXT,
+  "smalltypes" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__arctype_reid" ON "#@__arctype" ("reid");
+CREATE INDEX IF NOT EXISTS "#@__arctype_topid" ON "#@__arctype" ("topid");
+CREATE INDEX IF NOT EXISTS "#@__arctype_sortrank" ON "#@__arctype" ("sortrank");
+
+CREATE TABLE IF NOT EXISTS "#@__archives" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "typeid2" TEXT NOT NULL DEFAULT '0',
+  "sortrank" INTEGER NOT NULL DEFAULT 0,
+  "flag" TEXT DEFAULT NULL,
+  "ismake" INTEGER NOT NULL DEFAULT 0,
+  "channel" INTEGER NOT NULL DEFAULT 1,
+  "arcrank" INTEGER NOT NULL DEFAULT 0,
+  "click" INTEGER NOT NULL DEFAULT 0,
+  "money" INTEGER NOT NULL DEFAULT 0,
+  "title" TEXT NOT NULL DEFAULT '',
+  "shorttitle" TEXT NOT NULL DEFAULT '',
+  "color" TEXT NOT NULL DEFAULT '',
+  "writer" TEXT NOT NULL DEFAULT '',
+  "source" TEXT NOT NULL DEFAULT '',
+  "litpic" TEXT NOT NULL DEFAULT '',
+  "pubdate" INTEGER NOT NULL DEFAULT 0,
+  "senddate" INTEGER NOT NULL DEFAULT 0,
+  "mid" INTEGER NOT NULL DEFAULT 0,
+  "keywords" TEXT NOT NULL DEFAULT '',
+  "lastpost" INTEGER NOT NULL DEFAULT 0,
+  "scores" INTEGER NOT NULL DEFAULT 0,
+  "goodpost" INTEGER NOT NULL DEFAULT 0,
+  "badpost" INTEGER NOT NULL DEFAULT 0,
+  "voteid" INTEGER NOT NULL DEFAULT 0,
+  "notpost" INTEGER NOT NULL DEFAULT 0,
+  "description" TEXT NOT NULL DEFAULT '',
+  "filename" TEXT NOT NULL DEFAULT '',
+  "dutyadmin" INTEGER NOT NULL DEFAULT 0,
+  "tackid" INTEGER NOT NULL DEFAULT 0,
+  "mtype" INTEGER NOT NULL DEFAULT 0,
+  "weight" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__archives_typeid" ON "#@__archives" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__archives_sortrank" ON "#@__archives" ("sortrank");
+CREATE INDEX IF NOT EXISTS "#@__archives_pubdate" ON "#@__archives" ("pubdate");
+CREATE INDEX IF NOT EXISTS "#@__archives_click" ON "#@__archives" ("click");
+CREATE INDEX IF NOT EXISTS "#@__archives_arcrank" ON "#@__archives" ("arcrank");
+
+CREATE TABLE IF NOT EXISTS "#@__addonarticle" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "body" TEXT,
+  "redirecturl" TEXT NOT NULL DEFAULT '',
+  "templet" TEXT NOT NULL DEFAULT '',
+  "userip" TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY ("aid")
+);
+CREATE INDEX IF NOT EXISTS "#@__addonarticle_typeid" ON "#@__addonarticle" ("typeid");
+
+CREATE TABLE IF NOT EXISTS "#@__feedback" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "username" TEXT NOT NULL DEFAULT '',
+  "mid" INTEGER NOT NULL DEFAULT 0,
+  "ip" TEXT NOT NULL DEFAULT '',
+  "ischeck" INTEGER NOT NULL DEFAULT 0,
+  "dtime" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "content" TEXT NOT NULL,
+  "parentid" INTEGER NOT NULL DEFAULT 0,
+  "score" INTEGER NOT NULL DEFAULT 0,
+  "goodcount" INTEGER NOT NULL DEFAULT 0,
+  "badcount" INTEGER NOT NULL DEFAULT 0,
+  "userface" TEXT NOT NULL DEFAULT '',
+  "channeltype" INTEGER NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_aid" ON "#@__feedback" ("aid");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_typeid" ON "#@__feedback" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_mid" ON "#@__feedback" ("mid");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_ischeck" ON "#@__feedback" ("ischeck");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_dtime" ON "#@__feedback" ("dtime");
+CREATE INDEX IF NOT EXISTS "#@__feedback_idx_parentid" ON "#@__feedback" ("parentid");
+
+CREATE TABLE IF NOT EXISTS "#@__flink" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "sortrank" INTEGER NOT NULL DEFAULT 0,
+  "url" TEXT NOT NULL DEFAULT '',
+  "webname" TEXT NOT NULL DEFAULT '',
+  "msg" TEXT,
+  "email" TEXT NOT NULL DEFAULT '',
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "logo" TEXT NOT NULL DEFAULT '',
+  "ischeck" INTEGER NOT NULL DEFAULT 0,
+  "dtime" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__flink_sortrank" ON "#@__flink" ("sortrank");
+CREATE INDEX IF NOT EXISTS "#@__flink_typeid" ON "#@__flink" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__flink_ischeck" ON "#@__flink" ("ischeck");
+
+CREATE TABLE IF NOT EXISTS "#@__member" (
+  "mid" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "mtype" TEXT NOT NULL DEFAULT '',
+  "userid" TEXT NOT NULL DEFAULT '',
+  "pwd" TEXT DEFAULT '',
+  "uname" TEXT NOT NULL DEFAULT '',
+  "sex" TEXT NOT NULL DEFAULT '保密',
+  "rank" INTEGER NOT NULL DEFAULT 10,
+  "money" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "email" TEXT NOT NULL DEFAULT '',
+  "scores" INTEGER NOT NULL DEFAULT 0,
+  "matt" INTEGER NOT NULL DEFAULT 0,
+  "spacesta" INTEGER NOT NULL DEFAULT 0,
+  "face" TEXT NOT NULL DEFAULT '',
+  "safequestion" INTEGER NOT NULL DEFAULT 0,
+  "safeanswer" TEXT NOT NULL DEFAULT '',
+  "jointime" INTEGER NOT NULL DEFAULT 0,
+  "joinip" TEXT NOT NULL DEFAULT '',
+  "logintime" INTEGER NOT NULL DEFAULT 0,
+  "loginip" TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS "#@__member_userid" ON "#@__member" ("userid");
+
+CREATE TABLE IF NOT EXISTS "#@__member_type" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "typename" TEXT NOT NULL DEFAULT '',
+  "description" TEXT,
+  "rank" INTEGER NOT NULL DEFAULT 10,
+  "money" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "scores" INTEGER NOT NULL DEFAULT 0,
+  "purviews" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__member_type_idx_rank" ON "#@__member_type" ("rank");
+
+CREATE TABLE IF NOT EXISTS "#@__sysconfig" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "varname" TEXT NOT NULL DEFAULT '',
+  "info" TEXT NOT NULL DEFAULT '',
+  "groupid" INTEGER NOT NULL DEFAULT 1,
+  "type" TEXT NOT NULL DEFAULT 'string',
+  "value" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__sysconfig_varname" ON "#@__sysconfig" ("varname");
+
+CREATE TABLE IF NOT EXISTS "#@__tagindex" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "tag" TEXT NOT NULL DEFAULT '',
+  "count" INTEGER NOT NULL DEFAULT 0,
+  "rank" INTEGER NOT NULL DEFAULT 0,
+  "ishot" INTEGER NOT NULL DEFAULT 0,
+  "addtime" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "lastuse" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "tagpinyin" TEXT NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__tagindex_tag" ON "#@__tagindex" ("tag");
+CREATE INDEX IF NOT EXISTS "#@__tagindex_rank" ON "#@__tagindex" ("rank");
+CREATE INDEX IF NOT EXISTS "#@__tagindex_ishot" ON "#@__tagindex" ("ishot");
+CREATE INDEX IF NOT EXISTS "#@__tagindex_count" ON "#@__tagindex" ("count");
+
+CREATE TABLE IF NOT EXISTS "#@__taglist" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "tag" TEXT NOT NULL DEFAULT '',
+  "addtime" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__taglist_aid_tag" ON "#@__taglist" ("aid","tag");
+CREATE INDEX IF NOT EXISTS "#@__taglist_aid" ON "#@__taglist" ("aid");
+CREATE INDEX IF NOT EXISTS "#@__taglist_tag" ON "#@__taglist" ("tag");
@@ -0,0 +1,160 @@
+-- 采集、支付、投票、广告、内容模型所需的表
+
+CREATE TABLE IF NOT EXISTS "#@__collect_rule" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "sourcetype" INTEGER NOT NULL DEFAULT 0,
+  "sourceurl" TEXT NOT NULL DEFAULT '',
+  "startpage" INTEGER NOT NULL DEFAULT 1,
+  "endpage" INTEGER NOT NULL DEFAULT 1,
+  "pagerule" TEXT NOT NULL DEFAULT '',
+  "listrule" TEXT,
+  "titlerule" TEXT,
+  "contentrule" TEXT,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "modelid" INTEGER NOT NULL DEFAULT 0,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "lasttime" DATETIME DEFAULT NULL,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL,
+  "fieldrules" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__collect_rule_typeid" ON "#@__collect_rule" ("typeid");
+CREATE INDEX IF NOT EXISTS "#@__collect_rule_status" ON "#@__collect_rule" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__collect_item" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "ruleid" INTEGER NOT NULL DEFAULT 0,
+  "title" TEXT NOT NULL DEFAULT '',
+  "url" TEXT NOT NULL DEFAULT '',
+  "content" TEXT,
+  "status" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL,
+  "fielddata" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__collect_item_ruleid" ON "#@__collect_item" ("ruleid");
+CREATE INDEX IF NOT EXISTS "#@__collect_item_url" ON "#@__collect_item" ("url");
+CREATE INDEX IF NOT EXISTS "#@__collect_item_status" ON "#@__collect_item" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__payment_method" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "code" TEXT NOT NULL DEFAULT '',
+  "description" TEXT NOT NULL DEFAULT '',
+  "config" TEXT,
+  "icon" TEXT NOT NULL DEFAULT '',
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__payment_method_code" ON "#@__payment_method" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__payment_order" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "orderno" TEXT NOT NULL DEFAULT '',
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "amount" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "paymentmethod" TEXT NOT NULL DEFAULT '',
+  "paymentorderno" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 0,
+  "type" INTEGER NOT NULL DEFAULT 0,
+  "relatedid" INTEGER NOT NULL DEFAULT 0,
+  "relatedtype" TEXT NOT NULL DEFAULT '',
+  "remark" TEXT NOT NULL DEFAULT '',
+  "ip" TEXT NOT NULL DEFAULT '',
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL,
+  "paytime" DATETIME DEFAULT NULL,
+  "extradata" TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__payment_order_orderno" ON "#@__payment_order" ("orderno");
+CREATE INDEX IF NOT EXISTS "#@__payment_order_memberid" ON "#@__payment_order" ("memberid");
+CREATE INDEX IF NOT EXISTS "#@__payment_order_status" ON "#@__payment_order" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__vote" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "title" TEXT NOT NULL DEFAULT '',
+  "description" TEXT,
+  "starttime" DATETIME DEFAULT NULL,
+  "endtime" DATETIME DEFAULT NULL,
+  "ismulti" INTEGER NOT NULL DEFAULT 0,
+  "maxchoices" INTEGER NOT NULL DEFAULT 1,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "totalcount" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__vote_status" ON "#@__vote" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__vote_option" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "voteid" INTEGER NOT NULL DEFAULT 0,
+  "title" TEXT NOT NULL DEFAULT '',
+  "count" INTEGER NOT NULL DEFAULT 0,
+  "orderid" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__vote_option_voteid" ON "#@__vote_option" ("voteid");
+
+CREATE TABLE IF NOT EXISTS "#@__vote_log" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "voteid" INTEGER NOT NULL DEFAULT 0,
+  "optionid" INTEGER NOT NULL DEFAULT 0,
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "ip" TEXT NOT NULL DEFAULT '',
+  "createtime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__vote_log_voteid" ON "#@__vote_log" ("voteid");
+CREATE INDEX IF NOT EXISTS "#@__vote_log_memberid" ON "#@__vote_log" ("memberid");
+CREATE INDEX IF NOT EXISTS "#@__vote_log_ip" ON "#@__vote_log" ("ip");
+
+CREATE TABLE IF NOT EXISTS "#@__ad_position" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "code" TEXT NOT NULL DEFAULT '',
+  "width" INTEGER NOT NULL DEFAULT 0,
+  "height" INTEGER NOT NULL DEFAULT 0,
+  "template" TEXT,
+  "description" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__ad_position_code" ON "#@__ad_position" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__ad" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "positionid" INTEGER NOT NULL DEFAULT 0,
+  "title" TEXT NOT NULL DEFAULT '',
+  "type" INTEGER NOT NULL DEFAULT 0,
+  "image" TEXT NOT NULL DEFAULT '',
+  "flash" TEXT NOT NULL DEFAULT '',
+  "code" TEXT,
+  "text" TEXT NOT NULL DEFAULT '',
+  "url" TEXT NOT NULL DEFAULT '',
+  "starttime" DATETIME DEFAULT NULL,
+  "endtime" DATETIME DEFAULT NULL,
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "target" TEXT NOT NULL DEFAULT '_blank',
+  "width" INTEGER NOT NULL DEFAULT 0,
+  "height" INTEGER NOT NULL DEFAULT 0,
+  "click" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__ad_positionid" ON "#@__ad" ("positionid");
+CREATE INDEX IF NOT EXISTS "#@__ad_status" ON "#@__ad" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__content_model" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "tablename" TEXT NOT NULL DEFAULT '',
+  "description" TEXT NOT NULL DEFAULT '',
+  "state" INTEGER NOT NULL DEFAULT 1,
+  "fields" TEXT,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__content_model_tablename" ON "#@__content_model" ("tablename");
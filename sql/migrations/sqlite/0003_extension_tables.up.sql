@@ -0,0 +1,322 @@
+-- 专题、友情链接分类、积分、短消息、统计、SEO、插件、多语言、模板、表单、下载和产品模型所需的表
+
+CREATE TABLE IF NOT EXISTS "#@__special" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "title" TEXT NOT NULL DEFAULT '',
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "note" TEXT,
+  "pic" TEXT NOT NULL DEFAULT '',
+  "pubdate" DATETIME DEFAULT NULL,
+  "lastupdate" DATETIME DEFAULT NULL,
+  "ishot" INTEGER NOT NULL DEFAULT 0,
+  "click" INTEGER NOT NULL DEFAULT 0,
+  "template" TEXT NOT NULL DEFAULT '',
+  "templatelist" TEXT NOT NULL DEFAULT '',
+  "filename" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "keywords" TEXT NOT NULL DEFAULT '',
+  "description" TEXT NOT NULL DEFAULT '',
+  "content" TEXT
+);
+CREATE INDEX IF NOT EXISTS "#@__special_filename" ON "#@__special" ("filename");
+CREATE INDEX IF NOT EXISTS "#@__special_status" ON "#@__special" ("status");
+
+CREATE TABLE IF NOT EXISTS "#@__special_content" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "specialid" INTEGER NOT NULL DEFAULT 0,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "sortrank" INTEGER NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__special_content_specialid_aid" ON "#@__special_content" ("specialid","aid");
+CREATE INDEX IF NOT EXISTS "#@__special_content_aid" ON "#@__special_content" ("aid");
+
+CREATE TABLE IF NOT EXISTS "#@__link_type" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "#@__score_rule" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "code" TEXT NOT NULL DEFAULT '',
+  "score" INTEGER NOT NULL DEFAULT 0,
+  "maxtimes" INTEGER NOT NULL DEFAULT 0,
+  "cycletype" INTEGER NOT NULL DEFAULT 0,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "description" TEXT NOT NULL DEFAULT '',
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__score_rule_code" ON "#@__score_rule" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__score_log" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "ruleid" INTEGER NOT NULL DEFAULT 0,
+  "score" INTEGER NOT NULL DEFAULT 0,
+  "remark" TEXT NOT NULL DEFAULT '',
+  "ip" TEXT NOT NULL DEFAULT '',
+  "createtime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__score_log_memberid" ON "#@__score_log" ("memberid");
+CREATE INDEX IF NOT EXISTS "#@__score_log_ruleid" ON "#@__score_log" ("ruleid");
+
+CREATE TABLE IF NOT EXISTS "#@__member_msg" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "fromid" INTEGER NOT NULL DEFAULT 0,
+  "toid" INTEGER NOT NULL DEFAULT 0,
+  "title" TEXT NOT NULL DEFAULT '',
+  "content" TEXT,
+  "sendtime" DATETIME DEFAULT NULL,
+  "readtime" DATETIME DEFAULT NULL,
+  "isread" INTEGER NOT NULL DEFAULT 0,
+  "fromdel" INTEGER NOT NULL DEFAULT 0,
+  "todel" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__member_msg_fromid" ON "#@__member_msg" ("fromid");
+CREATE INDEX IF NOT EXISTS "#@__member_msg_toid" ON "#@__member_msg" ("toid");
+
+CREATE TABLE IF NOT EXISTS "#@__search_log" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "keyword" TEXT NOT NULL DEFAULT '',
+  "ip" TEXT NOT NULL DEFAULT '',
+  "resultcount" INTEGER NOT NULL DEFAULT 0,
+  "searchtime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__search_log_keyword" ON "#@__search_log" ("keyword");
+CREATE INDEX IF NOT EXISTS "#@__search_log_searchtime" ON "#@__search_log" ("searchtime");
+
+CREATE TABLE IF NOT EXISTS "#@__search_keyword" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "keyword" TEXT NOT NULL DEFAULT '',
+  "count" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__search_keyword_keyword" ON "#@__search_keyword" ("keyword");
+
+CREATE TABLE IF NOT EXISTS "#@__visit" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "ip" TEXT NOT NULL DEFAULT '',
+  "useragent" TEXT NOT NULL DEFAULT '',
+  "url" TEXT NOT NULL DEFAULT '',
+  "referer" TEXT NOT NULL DEFAULT '',
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "sessionid" TEXT NOT NULL DEFAULT '',
+  "createtime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__visit_createtime" ON "#@__visit" ("createtime");
+CREATE INDEX IF NOT EXISTS "#@__visit_ip" ON "#@__visit" ("ip");
+
+CREATE TABLE IF NOT EXISTS "#@__visit_log" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "memberid" INTEGER NOT NULL DEFAULT 0,
+  "articleid" INTEGER NOT NULL DEFAULT 0,
+  "categoryid" INTEGER NOT NULL DEFAULT 0,
+  "ip" TEXT NOT NULL DEFAULT '',
+  "useragent" TEXT NOT NULL DEFAULT '',
+  "referer" TEXT NOT NULL DEFAULT '',
+  "url" TEXT NOT NULL DEFAULT '',
+  "device" TEXT NOT NULL DEFAULT '',
+  "browser" TEXT NOT NULL DEFAULT '',
+  "os" TEXT NOT NULL DEFAULT '',
+  "region" TEXT NOT NULL DEFAULT '',
+  "visittime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__visit_log_articleid" ON "#@__visit_log" ("articleid");
+CREATE INDEX IF NOT EXISTS "#@__visit_log_categoryid" ON "#@__visit_log" ("categoryid");
+CREATE INDEX IF NOT EXISTS "#@__visit_log_visittime" ON "#@__visit_log" ("visittime");
+
+CREATE TABLE IF NOT EXISTS "#@__seo_rule" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "type" INTEGER NOT NULL DEFAULT 0,
+  "pattern" TEXT NOT NULL DEFAULT '',
+  "title" TEXT NOT NULL DEFAULT '',
+  "keywords" TEXT NOT NULL DEFAULT '',
+  "description" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__seo_rule_type" ON "#@__seo_rule" ("type");
+
+CREATE TABLE IF NOT EXISTS "#@__seo_keyword" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "keyword" TEXT NOT NULL DEFAULT '',
+  "url" TEXT NOT NULL DEFAULT '',
+  "weight" INTEGER NOT NULL DEFAULT 0,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__seo_keyword_keyword" ON "#@__seo_keyword" ("keyword");
+
+CREATE TABLE IF NOT EXISTS "#@__plugin" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "code" TEXT NOT NULL DEFAULT '',
+  "version" TEXT NOT NULL DEFAULT '',
+  "author" TEXT NOT NULL DEFAULT '',
+  "description" TEXT NOT NULL DEFAULT '',
+  "config" TEXT,
+  "status" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__plugin_code" ON "#@__plugin" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__plugin_hook" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "pluginid" INTEGER NOT NULL DEFAULT 0,
+  "name" TEXT NOT NULL DEFAULT '',
+  "code" TEXT NOT NULL DEFAULT '',
+  "position" TEXT NOT NULL DEFAULT '',
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__plugin_hook_pluginid" ON "#@__plugin_hook" ("pluginid");
+CREATE INDEX IF NOT EXISTS "#@__plugin_hook_position" ON "#@__plugin_hook" ("position");
+
+CREATE TABLE IF NOT EXISTS "#@__language" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "code" TEXT NOT NULL DEFAULT '',
+  "flag" TEXT NOT NULL DEFAULT '',
+  "isdefault" INTEGER NOT NULL DEFAULT 0,
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "orderid" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__language_code" ON "#@__language" ("code");
+
+CREATE TABLE IF NOT EXISTS "#@__language_text" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "languageid" INTEGER NOT NULL DEFAULT 0,
+  "key" TEXT NOT NULL DEFAULT '',
+  "value" TEXT,
+  "module" TEXT NOT NULL DEFAULT '',
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "#@__language_text_languageid_key" ON "#@__language_text" ("languageid","key");
+
+CREATE TABLE IF NOT EXISTS "#@__template" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" TEXT NOT NULL DEFAULT '',
+  "path" TEXT NOT NULL DEFAULT '',
+  "type" INTEGER NOT NULL DEFAULT 0,
+  "description" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "#@__form_data" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "formid" INTEGER NOT NULL DEFAULT 0,
+  "data" TEXT,
+  "ip" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 0,
+  "createtime" DATETIME DEFAULT NULL,
+  "updatetime" DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "#@__form_data_formid" ON "#@__form_data" ("formid");
+
+CREATE TABLE IF NOT EXISTS "#@__diyform" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "title" TEXT NOT NULL DEFAULT '',
+  "description" TEXT,
+  "template" TEXT NOT NULL DEFAULT '',
+  "status" INTEGER NOT NULL DEFAULT 1,
+  "addtime" DATETIME DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "#@__diyform_field" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "formid" INTEGER NOT NULL DEFAULT 0,
+  "fieldname" TEXT NOT NULL DEFAULT '',
+  "fieldtype" TEXT NOT NULL DEFAULT '',
+  "fieldtitle" TEXT NOT NULL DEFAULT '',
+  "fieldvalue" TEXT,
+  "isrequired" INTEGER NOT NULL DEFAULT 0,
+  "sortrank" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__diyform_field_formid" ON "#@__diyform_field" ("formid");
+
+-- 自定义表单字段以列的形式追加到该表
+CREATE TABLE IF NOT EXISTS "#@__diyform_data" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "formid" INTEGER NOT NULL DEFAULT 0,
+  "ip" TEXT NOT NULL DEFAULT '',
+  "addtime" DATETIME DEFAULT NULL,
+  "status" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__diyform_data_formid" ON "#@__diyform_data" ("formid");
+
+CREATE TABLE IF NOT EXISTS "#@__addondownload" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "softname" TEXT NOT NULL DEFAULT '',
+  "softversion" TEXT NOT NULL DEFAULT '',
+  "softlanguage" TEXT NOT NULL DEFAULT '',
+  "softtype" TEXT NOT NULL DEFAULT '',
+  "softsize" TEXT NOT NULL DEFAULT '',
+  "softos" TEXT NOT NULL DEFAULT '',
+  "softdeveloper" TEXT NOT NULL DEFAULT '',
+  "softlicense" TEXT NOT NULL DEFAULT '',
+  "softscore" DECIMAL(3,1) NOT NULL DEFAULT '0.0',
+  "softurl" TEXT NOT NULL DEFAULT '',
+  "softmirrurl" TEXT,
+  "downcount" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("aid")
+);
+
+CREATE TABLE IF NOT EXISTS "#@__download_screenshots" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "screenshot" TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS "#@__download_screenshots_aid" ON "#@__download_screenshots" ("aid");
+
+CREATE TABLE IF NOT EXISTS "#@__addonproduct" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "productname" TEXT NOT NULL DEFAULT '',
+  "productsn" TEXT NOT NULL DEFAULT '',
+  "price" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "oldprice" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "units" TEXT NOT NULL DEFAULT '',
+  "weight" DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+  "specification" TEXT,
+  "features" TEXT,
+  "parameters" TEXT,
+  "stock" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("aid")
+);
+
+CREATE TABLE IF NOT EXISTS "#@__product_images" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "image" TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS "#@__product_images_aid" ON "#@__product_images" ("aid");
+
+CREATE TABLE IF NOT EXISTS "#@__myad" (
+  "aid" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  "tagname" TEXT NOT NULL DEFAULT '',
+  "adname" TEXT NOT NULL DEFAULT '',
+  "timeset" INTEGER NOT NULL DEFAULT 0,
+  "starttime" INTEGER NOT NULL DEFAULT 0,
+  "endtime" INTEGER NOT NULL DEFAULT 0,
+  "normbody" TEXT,
+  "expbody" TEXT,
+  "ischeck" INTEGER NOT NULL DEFAULT 1,
+  "hits" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__myad_tagname" ON "#@__myad" ("tagname");
@@ -0,0 +1 @@
+-- SQLite 的初始表结构已经使用正确的字段类型，无需修复
@@ -0,0 +1,5 @@
+-- 文档回收站：arcrank 为 -2 的文档在回收站中，deleted_arcrank 保存删除前的 arcrank 用于恢复
+
+ALTER TABLE "#@__archives" ADD COLUMN "deleted_at" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "#@__archives" ADD COLUMN "deleted_arcrank" INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS "#@__archives_deleted_at" ON "#@__archives" ("deleted_at");
@@ -0,0 +1,15 @@
+-- 文章修订历史：每次保存文章时记录一个版本
+
+CREATE TABLE IF NOT EXISTS "#@__arcrevision" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "title" TEXT NOT NULL DEFAULT '',
+  "body" TEXT,
+  "keywords" TEXT NOT NULL DEFAULT '',
+  "flag" TEXT NOT NULL DEFAULT '',
+  "adminid" INTEGER NOT NULL DEFAULT 0,
+  "mid" INTEGER NOT NULL DEFAULT 0,
+  "note" TEXT NOT NULL DEFAULT '',
+  "dateline" INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "#@__arcrevision_aid" ON "#@__arcrevision" ("aid");
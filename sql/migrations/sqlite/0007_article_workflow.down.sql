@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "#@__arcworkflow";
+DROP INDEX IF EXISTS "#@__archives_state";
+ALTER TABLE "#@__archives" DROP COLUMN "state";
@@ -0,0 +1,3 @@
+ALTER TABLE "#@__arcrevision" DROP COLUMN "format";
+ALTER TABLE "#@__addonarticle" DROP COLUMN "markdown";
+ALTER TABLE "#@__addonarticle" DROP COLUMN "format";
@@ -0,0 +1,6 @@
+-- 文章内容格式：html或markdown，markdown格式的文章在markdown字段保存原文，body保存转换后的HTML
+-- 修订版本的format为body的格式，markdown格式的版本body中保存的是原文
+
+ALTER TABLE "#@__addonarticle" ADD COLUMN "format" VARCHAR(10) NOT NULL DEFAULT 'html';
+ALTER TABLE "#@__addonarticle" ADD COLUMN "markdown" TEXT;
+ALTER TABLE "#@__arcrevision" ADD COLUMN "format" VARCHAR(10) NOT NULL DEFAULT 'html';
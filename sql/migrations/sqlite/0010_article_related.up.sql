@@ -0,0 +1,10 @@
+-- 相关文章：后台根据标题、标签和正文计算的相似文章，sortrank为相似度排名
+
+CREATE TABLE IF NOT EXISTS "#@__arcrelated" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "relid" INTEGER NOT NULL DEFAULT 0,
+  "score" REAL NOT NULL DEFAULT 0,
+  "sortrank" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("aid", "relid")
+);
+CREATE INDEX IF NOT EXISTS "#@__arcrelated_aid_sortrank" ON "#@__arcrelated" ("aid", "sortrank");
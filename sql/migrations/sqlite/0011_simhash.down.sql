@@ -0,0 +1,3 @@
+ALTER TABLE "#@__collect_item" DROP COLUMN "dupaid";
+ALTER TABLE "#@__collect_item" DROP COLUMN "simhash";
+ALTER TABLE "#@__archives" DROP COLUMN "simhash";
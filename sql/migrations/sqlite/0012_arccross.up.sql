@@ -0,0 +1,11 @@
+-- 交叉栏目：文章除主栏目typeid外还可以发布到多个副栏目，副栏目的列表页、RSS和arclist标签也包含该文章
+-- 栏目的crossid为逗号分隔的栏目ID，这些栏目的文章同时出现在该栏目中
+
+CREATE TABLE IF NOT EXISTS "#@__arccross" (
+  "aid" INTEGER NOT NULL DEFAULT 0,
+  "typeid" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("aid", "typeid")
+);
+CREATE INDEX IF NOT EXISTS "#@__arccross_typeid" ON "#@__arccross" ("typeid");
+
+UPDATE "#@__arctype" SET "crossid" = '' WHERE "crossid" IS NULL OR "crossid" = '0';